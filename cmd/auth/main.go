@@ -31,7 +31,10 @@ import (
 	"github.com/PivotLLM/MCPFusion/cmd/auth/debug"
 	"github.com/PivotLLM/MCPFusion/cmd/auth/mcp"
 	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/github"
 	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/google"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/microsoft"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/oidc"
 )
 
 // authCodeBlob represents the JSON payload inside a base64url-encoded auth code blob
@@ -44,6 +47,12 @@ type authCodeBlob struct {
 const (
 	defaultTimeout = 10 * time.Minute
 	version        = "1.0.0"
+
+	// tenantPlaceholder is replaced in Microsoft endpoints with the tenant ID
+	tenantPlaceholder = "${MS365_TENANT_ID}"
+
+	// deviceCodeGrantType is the grant type of device flow token requests (RFC 8628)
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
 )
 
 type cliFlags struct {
@@ -170,9 +179,20 @@ func registerProviders(registry *providers.ProviderRegistry) error {
 		return fmt.Errorf("failed to register Google provider: %w", err)
 	}
 
-	// Additional providers can be registered here
-	// dropboxProvider := dropbox.NewProvider()
-	// registry.Register(dropboxProvider)
+	// Register Microsoft 365 OAuth provider
+	microsoftProvider := microsoft.NewProvider()
+	if err := registry.Register(microsoftProvider); err != nil {
+		return fmt.Errorf("failed to register Microsoft 365 provider: %w", err)
+	}
+
+	// Register GitHub OAuth provider
+	githubProvider := github.NewProvider()
+	if err := registry.Register(githubProvider); err != nil {
+		return fmt.Errorf("failed to register GitHub provider: %w", err)
+	}
+
+	// Any other service is handled by a generic OIDC provider created from the
+	// issuer in the server's service configuration (see executeOAuthFlow)
 
 	return nil
 }
@@ -190,6 +210,9 @@ func listProviders(registry *providers.ProviderRegistry) {
 		fmt.Printf("  Auth Code Flow: %v\n", info.SupportsAuthCode)
 		fmt.Printf("  Default Scopes: %v\n", info.DefaultScopes)
 	}
+
+	fmt.Println("\nOther services are supported through OpenID Connect discovery when the")
+	fmt.Println("MCPFusion service configuration includes an issuer.")
 }
 
 func validateFlags(flags *cliFlags, _ *providers.ProviderRegistry) error {
@@ -262,20 +285,7 @@ func executeOAuthFlow(ctx context.Context, cfg *config.Config, flags *cliFlags,
 		}
 
 		// Override local config with server-provided OAuth values
-		localConfig := cfg.GetServiceConfig(cfg.Service)
-		if localConfig == nil {
-			localConfig = &config.ServiceConfig{}
-			cfg.SetServiceConfig(cfg.Service, localConfig)
-		}
-		if serverConfig.Config.ClientID != "" {
-			localConfig.ClientID = serverConfig.Config.ClientID
-		}
-		if serverConfig.Config.ClientSecret != "" {
-			localConfig.ClientSecret = serverConfig.Config.ClientSecret
-		}
-		if serverConfig.Config.Scopes != "" {
-			localConfig.Scope = serverConfig.Config.Scopes
-		}
+		applyServerConfig(cfg, serverConfig.Config)
 		if flags.verbose {
 			log.Printf("Applied OAuth configuration from server for service: %s", cfg.Service)
 		}
@@ -283,9 +293,15 @@ func executeOAuthFlow(ctx context.Context, cfg *config.Config, flags *cliFlags,
 
 	// Fall back to standard OAuth provider flow
 	provider, err := registry.GetProvider(cfg.Service)
+	if err != nil && serverConfig != nil && serverConfig.Config != nil && serverConfig.Config.Issuer != "" {
+		provider, err = registerOIDCProvider(ctx, registry, cfg.Service, serverConfig.Config, flags.verbose)
+		if err != nil {
+			return err
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("no local OAuth provider registered for service '%s', "+
-			"and the server did not return a user_credentials auth config for it either: %w", cfg.Service, err)
+			"and the server returned neither an OIDC issuer nor a user_credentials auth config for it: %w", cfg.Service, err)
 	}
 
 	// Validate the merged configuration (server config + local defaults)
@@ -298,7 +314,7 @@ func executeOAuthFlow(ctx context.Context, cfg *config.Config, flags *cliFlags,
 		Scopes:       strings.Join(provider.GetRequiredScopes(), " "),
 	}
 	if err := provider.ValidateConfiguration(serviceConfig); err != nil {
-		return fmt.Errorf("configuration validation failed: %w\n\nThe server may not have OAuth credentials configured.\nCheck GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET environment variables on the server.", err)
+		return fmt.Errorf("configuration validation failed: %w\n\nThe server may not have OAuth credentials configured.\nCheck the clientId and clientSecret in the auth config of service '%s' on the server (e.g. GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET).", err, cfg.Service)
 	}
 
 	if flags.verbose {
@@ -324,6 +340,53 @@ func executeOAuthFlow(ctx context.Context, cfg *config.Config, flags *cliFlags,
 	}
 }
 
+// applyServerConfig overrides the local defaults of the service with the
+// OAuth values returned by the MCPFusion server
+func applyServerConfig(cfg *config.Config, configData *mcp.ServiceConfigData) {
+	localConfig := cfg.GetServiceConfig(cfg.Service)
+	if localConfig == nil {
+		localConfig = &config.ServiceConfig{}
+		cfg.SetServiceConfig(cfg.Service, localConfig)
+	}
+	if configData.ClientID != "" {
+		localConfig.ClientID = configData.ClientID
+	}
+	if configData.ClientSecret != "" {
+		localConfig.ClientSecret = configData.ClientSecret
+	}
+	if configData.Scopes != "" {
+		localConfig.Scope = configData.Scopes
+	}
+	if configData.TenantID != "" {
+		localConfig.TenantID = configData.TenantID
+	}
+}
+
+// registerOIDCProvider creates a generic provider for a service from the OIDC
+// issuer in its server configuration and adds it to the registry
+func registerOIDCProvider(ctx context.Context, registry *providers.ProviderRegistry, serviceName string, configData *mcp.ServiceConfigData, verbose bool) (providers.OAuthProvider, error) {
+	if verbose {
+		log.Printf("Discovering OAuth endpoints of service '%s' from issuer %s", serviceName, configData.Issuer)
+	}
+
+	var options []oidc.Option
+	if configData.AuthURL != "" {
+		options = append(options, oidc.WithAuthorizationURL(configData.AuthURL))
+	}
+	if configData.TokenURL != "" {
+		options = append(options, oidc.WithTokenURL(configData.TokenURL))
+	}
+
+	provider, err := oidc.NewProvider(ctx, serviceName, configData.Issuer, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC provider for service '%s': %w", serviceName, err)
+	}
+	if err := registry.Register(provider); err != nil {
+		return nil, fmt.Errorf("failed to register OIDC provider: %w", err)
+	}
+	return provider, nil
+}
+
 // executeUserCredentialsFlow handles the user_credentials authentication flow
 // by prompting the user for each field defined in the auth config
 func executeUserCredentialsFlow(ctx context.Context, cfg *config.Config, mcpClient *mcp.Client, configData *mcp.ServiceConfigData, verbose bool) error {
@@ -385,15 +448,133 @@ type OAuthFlowExecutor struct {
 	Verbose   bool
 }
 
-// ExecuteDeviceFlow implements the OAuth device flow
-func (e *OAuthFlowExecutor) ExecuteDeviceFlow(_ context.Context) error {
+// ExecuteDeviceFlow implements the OAuth device flow (RFC 8628)
+func (e *OAuthFlowExecutor) ExecuteDeviceFlow(ctx context.Context) error {
 	if e.Verbose {
 		log.Println("Initiating OAuth device flow...")
 	}
 
-	// This would implement the device flow logic
-	// For now, returning a placeholder
-	return fmt.Errorf("device flow implementation pending")
+	providerConfig := e.providerConfig()
+
+	// Request a device code
+	params := make(map[string]string)
+	if err := e.Provider.CustomizeDeviceRequest(params, providerConfig); err != nil {
+		return fmt.Errorf("failed to customize device code request: %w", err)
+	}
+
+	deviceURL := resolveEndpoint(e.Provider.GetDeviceCodeEndpoint(), providerConfig.TenantID)
+	status, body, err := postForm(ctx, deviceURL, params)
+	if err != nil {
+		return fmt.Errorf("device code request failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("device code request failed with status %d: %s", status, string(body))
+	}
+
+	var deviceResp struct {
+		providers.DeviceCodeResponse
+		VerificationURL string `json:"verification_url"` // Used by Google instead of verification_uri
+	}
+	if err := json.Unmarshal(body, &deviceResp); err != nil {
+		return fmt.Errorf("failed to parse device code response: %w", err)
+	}
+	if deviceResp.VerificationURI == "" {
+		deviceResp.VerificationURI = deviceResp.VerificationURL
+	}
+	if deviceResp.DeviceCode == "" || deviceResp.UserCode == "" || deviceResp.VerificationURI == "" {
+		return fmt.Errorf("incomplete device code response")
+	}
+
+	// Show the user where to sign in
+	fmt.Printf("To authorize MCPFusion, visit:\n%s\n\nand enter the code: %s\n\n", deviceResp.VerificationURI, deviceResp.UserCode)
+	browserURL := deviceResp.VerificationURIComplete
+	if browserURL == "" {
+		browserURL = deviceResp.VerificationURI
+	}
+	if err := openBrowser(browserURL); err != nil && e.Verbose {
+		log.Printf("Failed to open browser automatically: %v", err)
+	}
+	fmt.Printf("Waiting for authorization...\n")
+
+	tokenInfo, err := e.pollDeviceToken(ctx, &deviceResp.DeviceCodeResponse, providerConfig)
+	if err != nil {
+		return err
+	}
+
+	return e.completeAuthentication(ctx, tokenInfo)
+}
+
+// pollDeviceToken polls the token endpoint until the user completes or
+// declines the authorization, or the device code expires
+func (e *OAuthFlowExecutor) pollDeviceToken(ctx context.Context, deviceResp *providers.DeviceCodeResponse, config *providers.ServiceConfig) (*providers.TokenInfo, error) {
+	interval := time.Duration(deviceResp.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(deviceResp.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 15 * time.Minute
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	params := map[string]string{
+		"grant_type":  deviceCodeGrantType,
+		"device_code": deviceResp.DeviceCode,
+		"client_id":   config.ClientID,
+	}
+	if err := e.Provider.CustomizeTokenRequest(params, config); err != nil {
+		return nil, fmt.Errorf("failed to customize token request: %w", err)
+	}
+	tokenURL := resolveEndpoint(e.Provider.GetTokenEndpoint(), config.TenantID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("OAuth flow timed out")
+		case <-time.After(interval):
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("device code expired before authorization was completed")
+		}
+
+		_, body, err := postForm(ctx, tokenURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("token request failed: %w", err)
+		}
+
+		var tokenResponse map[string]interface{}
+		if err := json.Unmarshal(body, &tokenResponse); err != nil {
+			return nil, fmt.Errorf("failed to parse token response: %w", err)
+		}
+
+		// Errors are returned with status 400, except by GitHub which uses 200
+		switch errorCode, _ := tokenResponse["error"].(string); errorCode {
+		case "":
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			if seconds, ok := tokenResponse["interval"].(float64); ok && seconds > 0 {
+				interval = time.Duration(seconds) * time.Second
+			}
+			continue
+		case "access_denied", "authorization_declined":
+			return nil, fmt.Errorf("authorization was declined")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired before authorization was completed")
+		}
+
+		tokenInfo, err := e.Provider.ProcessTokenResponse(tokenResponse)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process token response: %w", err)
+		}
+
+		if e.Verbose {
+			log.Printf("Successfully obtained tokens (expires in %d seconds)", tokenInfo.ExpiresIn)
+		}
+		return tokenInfo, nil
+	}
 }
 
 // ExecuteAuthCodeFlow implements the OAuth authorization code flow
@@ -402,15 +583,7 @@ func (e *OAuthFlowExecutor) ExecuteAuthCodeFlow(ctx context.Context) error {
 		log.Println("Initiating OAuth authorization code flow...")
 	}
 
-	// Merge service configuration
-	serviceConfig := e.Config.MergeServiceConfig(e.Config.Service)
-	providerConfig := &providers.ServiceConfig{
-		ServiceName:  e.Config.Service,
-		ClientID:     serviceConfig.ClientID,
-		ClientSecret: serviceConfig.ClientSecret,
-		TenantID:     serviceConfig.TenantID,
-		Scopes:       serviceConfig.Scope, // Use scopes from configuration
-	}
+	providerConfig := e.providerConfig()
 
 	// Generate secure state parameter for CSRF protection
 	state, err := generateSecureState()
@@ -474,57 +647,129 @@ func (e *OAuthFlowExecutor) ExecuteAuthCodeFlow(ctx context.Context) error {
 	select {
 	case result := <-resultChan:
 		// Shutdown server
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		_ = server.Shutdown(ctx)
+		_ = server.Shutdown(shutdownCtx)
 
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Exchange authorization code for tokens
-		tokenInfo, err := e.exchangeCodeForTokens(result.Code, redirectURI, codeVerifier, providerConfig)
+		tokenInfo, err := e.exchangeCodeForTokens(ctx, result.Code, redirectURI, codeVerifier, providerConfig)
 		if err != nil {
 			return fmt.Errorf("failed to exchange code for tokens: %w", err)
 		}
 
-		// Verify tokens by getting user info
-		userInfo, err := e.Provider.GetUserInfo(ctx, tokenInfo)
-		if err != nil {
-			return fmt.Errorf("failed to get user info: %w", err)
-		}
+		return e.completeAuthentication(ctx, tokenInfo)
 
+	case <-ctx.Done():
+		// Shutdown server
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+
+		return fmt.Errorf("OAuth flow timed out")
+	}
+}
+
+// providerConfig merges the service configuration into the form used by providers.
+// The provider's default scopes apply when neither the server nor the local
+// defaults specify any.
+func (e *OAuthFlowExecutor) providerConfig() *providers.ServiceConfig {
+	serviceConfig := e.Config.MergeServiceConfig(e.Config.Service)
+	scopes := serviceConfig.Scope
+	if scopes == "" {
+		scopes = strings.Join(e.Provider.GetRequiredScopes(), " ")
+	}
+	return &providers.ServiceConfig{
+		ServiceName:  e.Config.Service,
+		ClientID:     serviceConfig.ClientID,
+		ClientSecret: serviceConfig.ClientSecret,
+		TenantID:     serviceConfig.TenantID,
+		Scopes:       scopes,
+	}
+}
+
+// completeAuthentication verifies the tokens by getting user info and stores them in MCPFusion
+func (e *OAuthFlowExecutor) completeAuthentication(ctx context.Context, tokenInfo *providers.TokenInfo) error {
+	// Verify tokens by getting user info
+	userInfo, err := e.Provider.GetUserInfo(ctx, tokenInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	if e.Verbose {
+		log.Printf("Successfully authenticated user: %s (%s)", userInfo.Name, userInfo.Email)
+	}
+
+	// Store tokens in MCPFusion
+	_, err = e.MCPClient.StoreTokens(ctx, e.Config.Service, tokenInfo.AccessToken, tokenInfo.RefreshToken, tokenInfo.ExpiresIn, nil)
+	if err != nil {
+		return fmt.Errorf("failed to store tokens in MCPFusion: %w", err)
+	}
+
+	// Send success notification
+	if err := e.MCPClient.NotifySuccess(ctx, e.Config.Service, userInfo); err != nil {
 		if e.Verbose {
-			log.Printf("Successfully authenticated user: %s (%s)", userInfo.Name, userInfo.Email)
+			log.Printf("Warning: failed to send success notification: %v", err)
 		}
+	}
 
-		// Store tokens in MCPFusion
-		_, err = e.MCPClient.StoreTokens(ctx, e.Config.Service, tokenInfo.AccessToken, tokenInfo.RefreshToken, tokenInfo.ExpiresIn, nil)
-		if err != nil {
-			return fmt.Errorf("failed to store tokens in MCPFusion: %w", err)
-		}
+	fmt.Printf("\n✓ OAuth authentication successful!\n")
+	fmt.Printf("✓ Tokens stored in MCPFusion\n")
+	fmt.Printf("✓ Authenticated as: %s (%s)\n", userInfo.Name, userInfo.Email)
 
-		// Send success notification
-		if err := e.MCPClient.NotifySuccess(ctx, e.Config.Service, userInfo); err != nil {
-			if e.Verbose {
-				log.Printf("Warning: failed to send success notification: %v", err)
-			}
-		}
+	return nil
+}
 
-		fmt.Printf("\n✓ OAuth authentication successful!\n")
-		fmt.Printf("✓ Tokens stored in MCPFusion\n")
-		fmt.Printf("✓ Authenticated as: %s (%s)\n", userInfo.Name, userInfo.Email)
+// resolveEndpoint substitutes the tenant ID into Microsoft tenant-specific
+// endpoints, using the multi-tenant "common" endpoint when none is configured
+func resolveEndpoint(endpoint, tenantID string) string {
+	if !strings.Contains(endpoint, tenantPlaceholder) {
+		return endpoint
+	}
+	if tenantID == "" {
+		tenantID = "common"
+	}
+	return strings.ReplaceAll(endpoint, tenantPlaceholder, tenantID)
+}
 
-		return nil
+// postForm sends a form-encoded POST request and returns the status and body of the response
+func postForm(ctx context.Context, endpoint string, params map[string]string) (int, []byte, error) {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
 
-	case <-ctx.Done():
-		// Shutdown server
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		_ = server.Shutdown(shutdownCtx)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub returns form-encoded responses unless JSON is requested
+	req.Header.Set("Accept", "application/json")
 
-		return fmt.Errorf("OAuth flow timed out")
+	// Log the request if debug is enabled
+	debug.LogHTTPRequest(req)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
 	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Log the response if debug is enabled
+	debug.LogHTTPResponse(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, body, nil
 }
 
 // authResult holds the result of OAuth callback
@@ -559,12 +804,8 @@ func generateCodeChallenge(verifier string) string {
 
 // buildAuthorizationURL constructs the OAuth authorization URL
 func (e *OAuthFlowExecutor) buildAuthorizationURL(config *providers.ServiceConfig, redirectURI, state, codeChallenge string) (string, error) {
-	baseURL := e.Provider.GetAuthorizationEndpoint()
-
 	// Handle Microsoft 365 tenant-specific endpoints
-	if config.TenantID != "" && strings.Contains(baseURL, "${MS365_TENANT_ID}") {
-		baseURL = strings.ReplaceAll(baseURL, "${MS365_TENANT_ID}", config.TenantID)
-	}
+	baseURL := resolveEndpoint(e.Provider.GetAuthorizationEndpoint(), config.TenantID)
 
 	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
@@ -643,74 +884,37 @@ func (e *OAuthFlowExecutor) createCallbackHandler(expectedState, _ string, _ *pr
 }
 
 // exchangeCodeForTokens exchanges the authorization code for access tokens
-func (e *OAuthFlowExecutor) exchangeCodeForTokens(code, redirectURI, codeVerifier string, config *providers.ServiceConfig) (*providers.TokenInfo, error) {
-	tokenURL := e.Provider.GetTokenEndpoint()
-
+func (e *OAuthFlowExecutor) exchangeCodeForTokens(ctx context.Context, code, redirectURI, codeVerifier string, config *providers.ServiceConfig) (*providers.TokenInfo, error) {
 	// Handle Microsoft 365 tenant-specific endpoints
-	if config.TenantID != "" && strings.Contains(tokenURL, "${MS365_TENANT_ID}") {
-		tokenURL = strings.ReplaceAll(tokenURL, "${MS365_TENANT_ID}", config.TenantID)
-	}
+	tokenURL := resolveEndpoint(e.Provider.GetTokenEndpoint(), config.TenantID)
 
 	// Prepare token request parameters
-	params := url.Values{}
-	params.Set("grant_type", "authorization_code")
-	params.Set("client_id", config.ClientID)
-	params.Set("code", code)
-	params.Set("redirect_uri", redirectURI)
-	params.Set("code_verifier", codeVerifier)
+	params := map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     config.ClientID,
+		"code":          code,
+		"redirect_uri":  redirectURI,
+		"code_verifier": codeVerifier,
+	}
 
 	// Add client secret if available and required
 	if config.ClientSecret != "" {
-		params.Set("client_secret", config.ClientSecret)
+		params["client_secret"] = config.ClientSecret
 	}
 
 	// Allow provider-specific customization
-	paramMap := make(map[string]string)
-	for key, values := range params {
-		if len(values) > 0 {
-			paramMap[key] = values[0]
-		}
-	}
-
-	if err := e.Provider.CustomizeTokenRequest(paramMap, config); err != nil {
+	if err := e.Provider.CustomizeTokenRequest(params, config); err != nil {
 		return nil, fmt.Errorf("failed to customize token request: %w", err)
 	}
 
-	// Update params with provider customizations
-	params = url.Values{}
-	for key, value := range paramMap {
-		params.Set(key, value)
-	}
-
 	// Make token request
-	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(params.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create token request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// Log the request if debug is enabled
-	debug.LogHTTPRequest(req)
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	status, body, err := postForm(ctx, tokenURL, params)
 	if err != nil {
 		return nil, fmt.Errorf("token request failed: %w", err)
 	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
-
-	// Log the response if debug is enabled
-	debug.LogHTTPResponse(resp)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read token response: %w", err)
-	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("token request failed with status %d: %s", status, string(body))
 	}
 
 	// Parse token response
@@ -0,0 +1,231 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/config"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/mcp"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/github"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/microsoft"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers/oidc"
+)
+
+// tokenServer is an OAuth token endpoint that records the last request and
+// answers with a fixed status and body
+type tokenServer struct {
+	*httptest.Server
+	path   string
+	form   url.Values
+	status int
+	body   string
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	t.Helper()
+	ts := &tokenServer{status: http.StatusOK}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 ts.URL,
+				"authorization_endpoint": ts.URL + "/authorize",
+				"token_endpoint":         ts.URL + "/token",
+			})
+			return
+		}
+		_ = r.ParseForm()
+		ts.path, ts.form = r.URL.Path, r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(ts.status)
+		_, _ = w.Write([]byte(ts.body))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// tokenEndpointProvider sends token requests of a provider to a test server
+type tokenEndpointProvider struct {
+	providers.OAuthProvider
+	endpoint string
+}
+
+func (p tokenEndpointProvider) GetTokenEndpoint() string {
+	return p.endpoint
+}
+
+func TestExchangeCodeForTokens(t *testing.T) {
+	ts := newTokenServer(t)
+	oidcProvider, err := oidc.NewProvider(context.Background(), "acme", ts.URL)
+	if err != nil {
+		t.Fatalf("oidc.NewProvider() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		provider   providers.OAuthProvider
+		config     *providers.ServiceConfig
+		body       string
+		wantPath   string
+		wantSecret string
+		wantScopes int
+	}{
+		{
+			name:       "github",
+			provider:   tokenEndpointProvider{github.NewProvider(), ts.URL + "/login/oauth/access_token"},
+			config:     &providers.ServiceConfig{ClientID: "gh-client", ClientSecret: "gh-secret"},
+			body:       `{"access_token":"gho_1","token_type":"bearer","scope":"repo,read:user"}`,
+			wantPath:   "/login/oauth/access_token",
+			wantSecret: "gh-secret",
+			wantScopes: 2,
+		},
+		{
+			name:       "microsoft365",
+			provider:   tokenEndpointProvider{microsoft.NewProvider(), ts.URL + "/" + tenantPlaceholder + "/oauth2/v2.0/token"},
+			config:     &providers.ServiceConfig{ClientID: "ms-client", TenantID: "contoso"},
+			body:       `{"access_token":"eyJ0","token_type":"Bearer","expires_in":3599,"refresh_token":"0.AX","scope":"User.Read"}`,
+			wantPath:   "/contoso/oauth2/v2.0/token",
+			wantScopes: 1,
+		},
+		{
+			name:       "oidc",
+			provider:   oidcProvider,
+			config:     &providers.ServiceConfig{ClientID: "acme-client", ClientSecret: "acme-secret"},
+			body:       `{"access_token":"at","token_type":"Bearer","expires_in":600,"refresh_token":"rt","id_token":"a.b.c"}`,
+			wantPath:   "/token",
+			wantSecret: "acme-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &OAuthFlowExecutor{Provider: tt.provider}
+			ts.status, ts.body = http.StatusOK, tt.body
+
+			token, err := executor.exchangeCodeForTokens(context.Background(), "code-1", "http://127.0.0.1:8085/callback", "verifier-1", tt.config)
+			if err != nil {
+				t.Fatalf("exchangeCodeForTokens() error = %v", err)
+			}
+			if ts.path != tt.wantPath {
+				t.Errorf("token request sent to %s, want %s", ts.path, tt.wantPath)
+			}
+			if ts.form.Get("grant_type") != "authorization_code" || ts.form.Get("code") != "code-1" ||
+				ts.form.Get("code_verifier") != "verifier-1" || ts.form.Get("client_id") != tt.config.ClientID {
+				t.Errorf("unexpected token request %v", ts.form)
+			}
+			if ts.form.Get("client_secret") != tt.wantSecret {
+				t.Errorf("client_secret = %q, want %q", ts.form.Get("client_secret"), tt.wantSecret)
+			}
+			if token.TokenType != "Bearer" || token.AccessToken == "" || len(token.Scope) != tt.wantScopes {
+				t.Errorf("unexpected token %+v", token)
+			}
+
+			// Rejected exchanges, with an error status or (GitHub) an error body
+			for _, response := range []struct {
+				status  int
+				body    string
+				wantErr string
+			}{
+				{http.StatusBadRequest, `{"error":"invalid_grant"}`, "status 400"},
+				{http.StatusOK, `{"error":"bad_verification_code","error_description":"The code is incorrect"}`, "bad_verification_code"},
+				{http.StatusOK, `access_token=gho_1`, "parse token response"},
+			} {
+				ts.status, ts.body = response.status, response.body
+				_, err := executor.exchangeCodeForTokens(context.Background(), "code-1", "http://127.0.0.1:8085/callback", "verifier-1", tt.config)
+				if err == nil || !strings.Contains(err.Error(), response.wantErr) {
+					t.Errorf("response %d %s: error = %v, want %q", response.status, response.body, err, response.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceConfig_OIDCIssuerAndScopes(t *testing.T) {
+	issuer := newTokenServer(t)
+	fusion := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer api-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"success":false,"message":"invalid API token"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/services/acme/config":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": true,
+				"config": map[string]string{
+					"issuer":    issuer.URL,
+					"client_id": "acme-client",
+					"scopes":    "openid acme.read",
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"message":"service not found"}`))
+		}
+	}))
+	defer fusion.Close()
+
+	cfg := &config.Config{Service: "acme", FusionURL: fusion.URL, APIToken: "api-token"}
+	cfg.LoadServiceDefaults()
+	client := mcp.NewClient(cfg.FusionURL, cfg.APIToken)
+
+	response, err := client.GetServiceConfig(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("GetServiceConfig() error = %v", err)
+	}
+	if response.Config.Issuer != issuer.URL || response.Config.Scopes != "openid acme.read" {
+		t.Fatalf("unexpected service config %+v", response.Config)
+	}
+	applyServerConfig(cfg, response.Config)
+
+	// A service without a built-in provider is discovered from its issuer
+	registry := providers.NewProviderRegistry()
+	if err := registerProviders(registry); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.GetProvider("acme"); err == nil {
+		t.Fatal("acme must not have a built-in provider")
+	}
+	provider, err := registerOIDCProvider(context.Background(), registry, "acme", response.Config, false)
+	if err != nil {
+		t.Fatalf("registerOIDCProvider() error = %v", err)
+	}
+	if provider.GetTokenEndpoint() != issuer.URL+"/token" || provider.GetAuthorizationEndpoint() != issuer.URL+"/authorize" {
+		t.Errorf("unexpected endpoints %s, %s", provider.GetAuthorizationEndpoint(), provider.GetTokenEndpoint())
+	}
+	if registered, err := registry.GetProvider("acme"); err != nil || registered != provider {
+		t.Errorf("provider not registered: %v", err)
+	}
+
+	// The server's scopes replace the provider defaults
+	executor := &OAuthFlowExecutor{Provider: provider, Config: cfg}
+	serviceConfig := executor.providerConfig()
+	if serviceConfig.ClientID != "acme-client" || serviceConfig.Scopes != "openid acme.read" {
+		t.Errorf("unexpected provider config %+v", serviceConfig)
+	}
+
+	// Error responses carry the server's message
+	if _, err := client.GetServiceConfig(context.Background(), "unknown"); err == nil || !strings.Contains(err.Error(), "service not found") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+	if _, err := mcp.NewClient(fusion.URL, "wrong").GetServiceConfig(context.Background(), "acme"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+
+	// An issuer that cannot be discovered is reported
+	broken := &mcp.ServiceConfigData{Issuer: fusion.URL}
+	if _, err := registerOIDCProvider(context.Background(), providers.NewProviderRegistry(), "broken", broken, false); err == nil {
+		t.Error("expected an error for an issuer without a discovery document")
+	}
+}
@@ -293,6 +293,10 @@ type ServiceConfigData struct {
 	ClientID       string                 `json:"client_id,omitempty"`
 	ClientSecret   string                 `json:"client_secret,omitempty"`
 	Scopes         string                 `json:"scopes,omitempty"`
+	TenantID       string                 `json:"tenant_id,omitempty"`
+	Issuer         string                 `json:"issuer,omitempty"`
+	TokenURL       string                 `json:"token_url,omitempty"`
+	AuthURL        string                 `json:"authorization_url,omitempty"`
 	Instructions   string                 `json:"instructions,omitempty"`
 	Fields         []CredentialField      `json:"fields,omitempty"`
 	Endpoints      map[string]string      `json:"endpoints,omitempty"`
@@ -0,0 +1,160 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/debug"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// Provider implements OAuth for GitHub OAuth apps
+type Provider struct {
+	httpClient *http.Client
+}
+
+// NewProvider creates a new GitHub OAuth provider
+func NewProvider() *Provider {
+	return &Provider{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (p *Provider) GetServiceName() string {
+	return "github"
+}
+
+func (p *Provider) GetDisplayName() string {
+	return "GitHub"
+}
+
+func (p *Provider) GetRequiredScopes() []string {
+	return []string{
+		"read:user",
+		"user:email",
+		"repo",
+	}
+}
+
+func (p *Provider) GetAuthorizationEndpoint() string {
+	return "https://github.com/login/oauth/authorize"
+}
+
+func (p *Provider) GetTokenEndpoint() string {
+	return "https://github.com/login/oauth/access_token"
+}
+
+func (p *Provider) GetDeviceCodeEndpoint() string {
+	return "https://github.com/login/device/code"
+}
+
+// SupportsDeviceFlow returns false because the device flow must be enabled
+// separately on each GitHub OAuth app; the browser flow works with any app
+func (p *Provider) SupportsDeviceFlow() bool {
+	return false
+}
+
+func (p *Provider) SupportsAuthorizationCode() bool {
+	return true
+}
+
+func (p *Provider) ValidateConfiguration(config *providers.ServiceConfig) error {
+	if config.ClientID == "" {
+		return fmt.Errorf("client_id is required for GitHub OAuth")
+	}
+
+	// GitHub OAuth apps always require the client secret to exchange the code
+	if config.ClientSecret == "" {
+		return fmt.Errorf("client_secret is required for GitHub OAuth")
+	}
+
+	return nil
+}
+
+func (p *Provider) CustomizeDeviceRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+
+	scopes := config.Scopes
+	if scopes == "" {
+		scopes = strings.Join(p.GetRequiredScopes(), " ")
+	}
+	params["scope"] = scopes
+
+	return nil
+}
+
+func (p *Provider) CustomizeTokenRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+	params["client_secret"] = config.ClientSecret
+	return nil
+}
+
+func (p *Provider) ProcessTokenResponse(response map[string]interface{}) (*providers.TokenInfo, error) {
+	return providers.ParseTokenResponse(response)
+}
+
+func (p *Provider) GetUserInfo(ctx context.Context, token *providers.TokenInfo) (*providers.UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user info request: %w", err)
+	}
+
+	req.Header.Set("Authorization", token.GetAuthorizationHeader())
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	// Log the request if debug is enabled
+	debug.LogHTTPRequest(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Log the response if debug is enabled
+	debug.LogHTTPResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user info request failed with status: %d", resp.StatusCode)
+	}
+
+	var userInfoResp struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&userInfoResp); err != nil {
+		return nil, fmt.Errorf("failed to decode user info response: %w", err)
+	}
+
+	// The name is optional on GitHub; the login always exists
+	name := userInfoResp.Name
+	if name == "" {
+		name = userInfoResp.Login
+	}
+
+	return &providers.UserInfo{
+		ID:          strconv.FormatInt(userInfoResp.ID, 10),
+		Email:       userInfoResp.Email,
+		Name:        name,
+		DisplayName: userInfoResp.Login,
+	}, nil
+}
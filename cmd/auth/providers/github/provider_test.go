@@ -0,0 +1,114 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// redirectTransport sends every request to a test server, keeping the path
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = rt.target.Scheme, rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestProvider returns a provider whose API requests go to handler
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+
+	p := NewProvider()
+	p.httpClient = &http.Client{Transport: redirectTransport{target: target}}
+	return p
+}
+
+func TestProvider_Configuration(t *testing.T) {
+	p := NewProvider()
+
+	if err := p.ValidateConfiguration(&providers.ServiceConfig{ClientID: "client-1"}); err == nil {
+		t.Error("expected an error without a client secret")
+	}
+	config := &providers.ServiceConfig{ClientID: "client-1", ClientSecret: "secret-1"}
+	if err := p.ValidateConfiguration(config); err != nil {
+		t.Errorf("ValidateConfiguration() error = %v", err)
+	}
+
+	params := map[string]string{}
+	if err := p.CustomizeTokenRequest(params, config); err != nil {
+		t.Fatal(err)
+	}
+	if params["client_id"] != "client-1" || params["client_secret"] != "secret-1" {
+		t.Errorf("unexpected token request parameters %v", params)
+	}
+
+	if p.SupportsDeviceFlow() || !p.SupportsAuthorizationCode() {
+		t.Error("expected the browser flow")
+	}
+}
+
+func TestProvider_ProcessTokenResponse(t *testing.T) {
+	p := NewProvider()
+
+	// GitHub returns lower-case token types and comma-separated scopes
+	token, err := p.ProcessTokenResponse(map[string]interface{}{
+		"access_token": "gho_1", "token_type": "bearer", "scope": "repo,read:user",
+	})
+	if err != nil {
+		t.Fatalf("ProcessTokenResponse() error = %v", err)
+	}
+	if token.GetAuthorizationHeader() != "Bearer gho_1" || !slices.Equal(token.Scope, []string{"repo", "read:user"}) {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if token.ExpiresAt != nil {
+		t.Error("OAuth app tokens do not expire")
+	}
+
+	// Errors come back with status 200
+	_, err = p.ProcessTokenResponse(map[string]interface{}{
+		"error": "bad_verification_code", "error_description": "The code passed is incorrect or expired.",
+	})
+	if err == nil || !strings.Contains(err.Error(), "bad_verification_code") {
+		t.Errorf("expected the token error to be returned, got %v", err)
+	}
+}
+
+func TestProvider_GetUserInfo(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.Header.Get("Authorization") != "Bearer gho_1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"login":"octocat","name":"","email":"octo@example.com"}`))
+	})
+
+	user, err := p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "gho_1", TokenType: "Bearer"})
+	if err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+	if user.ID != "42" || user.Name != "octocat" || user.DisplayName != "octocat" || user.Email != "octo@example.com" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	_, err = p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "revoked", TokenType: "Bearer"})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+}
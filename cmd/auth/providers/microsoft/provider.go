@@ -0,0 +1,168 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package microsoft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/debug"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// Endpoints of the Microsoft identity platform (v2.0). The tenant placeholder
+// is replaced with the tenant ID from the service configuration, or "common"
+// when none is configured.
+const (
+	authorizationEndpoint = "https://login.microsoftonline.com/${MS365_TENANT_ID}/oauth2/v2.0/authorize"
+	tokenEndpoint         = "https://login.microsoftonline.com/${MS365_TENANT_ID}/oauth2/v2.0/token"
+	deviceCodeEndpoint    = "https://login.microsoftonline.com/${MS365_TENANT_ID}/oauth2/v2.0/devicecode"
+	userInfoEndpoint      = "https://graph.microsoft.com/v1.0/me"
+)
+
+// Provider implements OAuth for Microsoft 365 through the Microsoft identity platform
+type Provider struct {
+	httpClient *http.Client
+}
+
+// NewProvider creates a new Microsoft 365 OAuth provider
+func NewProvider() *Provider {
+	return &Provider{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (p *Provider) GetServiceName() string {
+	return "microsoft365"
+}
+
+func (p *Provider) GetDisplayName() string {
+	return "Microsoft 365"
+}
+
+func (p *Provider) GetRequiredScopes() []string {
+	return []string{
+		"openid",
+		"profile",
+		"offline_access",
+		"User.Read",
+	}
+}
+
+func (p *Provider) GetAuthorizationEndpoint() string {
+	return authorizationEndpoint
+}
+
+func (p *Provider) GetTokenEndpoint() string {
+	return tokenEndpoint
+}
+
+func (p *Provider) GetDeviceCodeEndpoint() string {
+	return deviceCodeEndpoint
+}
+
+// SupportsDeviceFlow returns true: app registrations used by MCPFusion are
+// public clients with device code flow enabled, and the device flow works on
+// machines without a local browser
+func (p *Provider) SupportsDeviceFlow() bool {
+	return true
+}
+
+func (p *Provider) SupportsAuthorizationCode() bool {
+	return true
+}
+
+func (p *Provider) ValidateConfiguration(config *providers.ServiceConfig) error {
+	if config.ClientID == "" {
+		return fmt.Errorf("client_id is required for Microsoft 365 OAuth")
+	}
+	return nil
+}
+
+func (p *Provider) CustomizeDeviceRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+
+	scopes := config.Scopes
+	if scopes == "" {
+		scopes = strings.Join(p.GetRequiredScopes(), " ")
+	}
+	params["scope"] = scopes
+
+	return nil
+}
+
+func (p *Provider) CustomizeTokenRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+
+	// Confidential clients authenticate with a secret; public clients must not send one
+	if config.ClientSecret != "" {
+		params["client_secret"] = config.ClientSecret
+	}
+
+	return nil
+}
+
+func (p *Provider) ProcessTokenResponse(response map[string]interface{}) (*providers.TokenInfo, error) {
+	return providers.ParseTokenResponse(response)
+}
+
+func (p *Provider) GetUserInfo(ctx context.Context, token *providers.TokenInfo) (*providers.UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", userInfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user info request: %w", err)
+	}
+
+	req.Header.Set("Authorization", token.GetAuthorizationHeader())
+
+	// Log the request if debug is enabled
+	debug.LogHTTPRequest(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Log the response if debug is enabled
+	debug.LogHTTPResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user info request failed with status: %d", resp.StatusCode)
+	}
+
+	var userInfoResp struct {
+		ID                string `json:"id"`
+		DisplayName       string `json:"displayName"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&userInfoResp); err != nil {
+		return nil, fmt.Errorf("failed to decode user info response: %w", err)
+	}
+
+	// Accounts without a mailbox have no mail address; the UPN is the sign-in name
+	email := userInfoResp.Mail
+	if email == "" {
+		email = userInfoResp.UserPrincipalName
+	}
+
+	return &providers.UserInfo{
+		ID:          userInfoResp.ID,
+		Email:       email,
+		Name:        userInfoResp.DisplayName,
+		DisplayName: userInfoResp.DisplayName,
+	}, nil
+}
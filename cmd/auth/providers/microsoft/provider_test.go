@@ -0,0 +1,120 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package microsoft
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// redirectTransport sends every request to a test server, keeping the path
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = rt.target.Scheme, rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestProvider returns a provider whose Graph requests go to handler
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+
+	p := NewProvider()
+	p.httpClient = &http.Client{Transport: redirectTransport{target: target}}
+	return p
+}
+
+func TestProvider_Configuration(t *testing.T) {
+	p := NewProvider()
+
+	if err := p.ValidateConfiguration(&providers.ServiceConfig{}); err == nil {
+		t.Error("expected an error without a client ID")
+	}
+
+	// Public clients must not send a secret
+	params := map[string]string{}
+	if err := p.CustomizeTokenRequest(params, &providers.ServiceConfig{ClientID: "app-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["client_secret"]; ok || params["client_id"] != "app-1" {
+		t.Errorf("unexpected public client parameters %v", params)
+	}
+
+	params = map[string]string{}
+	if err := p.CustomizeDeviceRequest(params, &providers.ServiceConfig{ClientID: "app-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if params["scope"] != "openid profile offline_access User.Read" {
+		t.Errorf("unexpected default scopes %q", params["scope"])
+	}
+
+	for _, endpoint := range []string{p.GetAuthorizationEndpoint(), p.GetTokenEndpoint(), p.GetDeviceCodeEndpoint()} {
+		if !strings.Contains(endpoint, "${MS365_TENANT_ID}") {
+			t.Errorf("endpoint %s has no tenant placeholder", endpoint)
+		}
+	}
+}
+
+func TestProvider_ProcessTokenResponse(t *testing.T) {
+	p := NewProvider()
+
+	token, err := p.ProcessTokenResponse(map[string]interface{}{
+		"access_token": "eyJ0", "token_type": "Bearer", "expires_in": float64(3599),
+		"refresh_token": "0.AX", "scope": "User.Read Mail.Read",
+	})
+	if err != nil {
+		t.Fatalf("ProcessTokenResponse() error = %v", err)
+	}
+	if token.ExpiresIn != 3599 || token.ExpiresAt == nil || token.RefreshToken != "0.AX" || len(token.Scope) != 2 {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	_, err = p.ProcessTokenResponse(map[string]interface{}{
+		"error": "authorization_pending", "error_description": "AADSTS70016: pending",
+	})
+	if err == nil || !strings.Contains(err.Error(), "authorization_pending") {
+		t.Errorf("expected the token error to be returned, got %v", err)
+	}
+
+	if _, err := p.ProcessTokenResponse(map[string]interface{}{"token_type": "Bearer"}); err == nil {
+		t.Error("expected an error without an access token")
+	}
+}
+
+func TestProvider_GetUserInfo(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/me" || r.Header.Get("Authorization") != "Bearer eyJ0" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"u-1","displayName":"Adele Vance","mail":null,"userPrincipalName":"adele@contoso.com"}`))
+	})
+
+	user, err := p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "eyJ0", TokenType: "Bearer"})
+	if err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+	if user.ID != "u-1" || user.Name != "Adele Vance" || user.Email != "adele@contoso.com" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	_, err = p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "expired", TokenType: "Bearer"})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+}
@@ -0,0 +1,317 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package oidc implements a generic OAuth provider for any OpenID Connect
+// issuer. Endpoints are read from the issuer's discovery document, so a new
+// OAuth API only needs an issuer in its MCPFusion service configuration.
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/debug"
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// DiscoveryPath is appended to the issuer to locate the discovery document
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Discovery holds the fields of an OpenID Provider discovery document used by fusion-auth
+type Discovery struct {
+	Issuer                      string   `json:"issuer"`
+	AuthorizationEndpoint       string   `json:"authorization_endpoint"`
+	TokenEndpoint               string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string   `json:"device_authorization_endpoint,omitempty"`
+	UserInfoEndpoint            string   `json:"userinfo_endpoint,omitempty"`
+	ScopesSupported             []string `json:"scopes_supported,omitempty"`
+}
+
+// Provider implements OAuth for a service whose endpoints are discovered from an OIDC issuer
+type Provider struct {
+	serviceName      string
+	displayName      string
+	issuer           string
+	authorizationURL string
+	tokenURL         string
+	httpClient       *http.Client
+	discovery        *Discovery
+}
+
+// Option configures a Provider
+type Option func(*Provider)
+
+// WithDisplayName sets the display name shown to the user (default: the service name)
+func WithDisplayName(name string) Option {
+	return func(p *Provider) {
+		p.displayName = name
+	}
+}
+
+// WithAuthorizationURL overrides the discovered authorization endpoint
+func WithAuthorizationURL(authorizationURL string) Option {
+	return func(p *Provider) {
+		p.authorizationURL = authorizationURL
+	}
+}
+
+// WithTokenURL overrides the discovered token endpoint
+func WithTokenURL(tokenURL string) Option {
+	return func(p *Provider) {
+		p.tokenURL = tokenURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for discovery and user info requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// NewProvider creates a provider for the named service by fetching the
+// discovery document of the issuer
+func NewProvider(ctx context.Context, serviceName, issuer string, options ...Option) (*Provider, error) {
+	if serviceName == "" {
+		return nil, fmt.Errorf("service name is required")
+	}
+	if issuer == "" {
+		return nil, fmt.Errorf("issuer is required for OIDC discovery")
+	}
+
+	p := &Provider{
+		serviceName: serviceName,
+		displayName: serviceName,
+		issuer:      strings.TrimSuffix(issuer, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, option := range options {
+		option(p)
+	}
+
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.discovery = discovery
+
+	if p.authorizationURL == "" {
+		p.authorizationURL = discovery.AuthorizationEndpoint
+	}
+	if p.tokenURL == "" {
+		p.tokenURL = discovery.TokenEndpoint
+	}
+	if p.tokenURL == "" {
+		return nil, fmt.Errorf("issuer %s does not advertise a token endpoint", p.issuer)
+	}
+
+	return p, nil
+}
+
+// discover fetches and checks the discovery document of the issuer
+func (p *Provider) discover(ctx context.Context) (*Discovery, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.issuer+DiscoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Log the request if debug is enabled
+	debug.LogHTTPRequest(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Log the response if debug is enabled
+	debug.LogHTTPResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery at %s failed with status: %d", p.issuer+DiscoveryPath, resp.StatusCode)
+	}
+
+	var discovery Discovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+
+	// The issuer in the document must match the configured issuer (OIDC Discovery 1.0, section 4.3)
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("discovery document issuer %q does not match configured issuer %q", discovery.Issuer, p.issuer)
+	}
+
+	return &discovery, nil
+}
+
+// Discovery returns the discovery document of the issuer
+func (p *Provider) Discovery() *Discovery {
+	return p.discovery
+}
+
+func (p *Provider) GetServiceName() string {
+	return p.serviceName
+}
+
+func (p *Provider) GetDisplayName() string {
+	return p.displayName
+}
+
+// GetRequiredScopes returns the standard OIDC scopes, with offline_access
+// when the issuer supports it so that a refresh token is issued
+func (p *Provider) GetRequiredScopes() []string {
+	scopes := []string{"openid", "profile", "email"}
+	if slices.Contains(p.discovery.ScopesSupported, "offline_access") {
+		scopes = append(scopes, "offline_access")
+	}
+	return scopes
+}
+
+func (p *Provider) GetAuthorizationEndpoint() string {
+	return p.authorizationURL
+}
+
+func (p *Provider) GetTokenEndpoint() string {
+	return p.tokenURL
+}
+
+func (p *Provider) GetDeviceCodeEndpoint() string {
+	return p.discovery.DeviceAuthorizationEndpoint
+}
+
+// SupportsDeviceFlow returns true only for issuers without an authorization
+// endpoint. The browser flow is preferred because clients must usually be
+// enabled for the device flow explicitly.
+func (p *Provider) SupportsDeviceFlow() bool {
+	return p.authorizationURL == "" && p.discovery.DeviceAuthorizationEndpoint != ""
+}
+
+func (p *Provider) SupportsAuthorizationCode() bool {
+	return p.authorizationURL != ""
+}
+
+func (p *Provider) ValidateConfiguration(config *providers.ServiceConfig) error {
+	if config.ClientID == "" {
+		return fmt.Errorf("client_id is required for %s OAuth", p.displayName)
+	}
+	return nil
+}
+
+func (p *Provider) CustomizeDeviceRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+
+	scopes := config.Scopes
+	if scopes == "" {
+		scopes = strings.Join(p.GetRequiredScopes(), " ")
+	}
+	params["scope"] = scopes
+
+	return nil
+}
+
+func (p *Provider) CustomizeTokenRequest(params map[string]string, config *providers.ServiceConfig) error {
+	params["client_id"] = config.ClientID
+
+	if config.ClientSecret != "" {
+		params["client_secret"] = config.ClientSecret
+	}
+
+	return nil
+}
+
+func (p *Provider) ProcessTokenResponse(response map[string]interface{}) (*providers.TokenInfo, error) {
+	return providers.ParseTokenResponse(response)
+}
+
+// GetUserInfo queries the userinfo endpoint. Issuers without one fall back to
+// the claims of the ID token, which are used for display only and not verified.
+func (p *Provider) GetUserInfo(ctx context.Context, token *providers.TokenInfo) (*providers.UserInfo, error) {
+	if p.discovery.UserInfoEndpoint == "" {
+		return userInfoFromIDToken(token.IDToken), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.discovery.UserInfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user info request: %w", err)
+	}
+
+	req.Header.Set("Authorization", token.GetAuthorizationHeader())
+	req.Header.Set("Accept", "application/json")
+
+	// Log the request if debug is enabled
+	debug.LogHTTPRequest(req)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	// Log the response if debug is enabled
+	debug.LogHTTPResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user info request failed with status: %d", resp.StatusCode)
+	}
+
+	var claims userClaims
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode user info response: %w", err)
+	}
+
+	return claims.userInfo(), nil
+}
+
+// userClaims holds the standard OIDC claims describing the user
+type userClaims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+func (c *userClaims) userInfo() *providers.UserInfo {
+	name := c.Name
+	if name == "" {
+		name = c.PreferredUsername
+	}
+	return &providers.UserInfo{
+		ID:          c.Subject,
+		Email:       c.Email,
+		Name:        name,
+		DisplayName: name,
+	}
+}
+
+// userInfoFromIDToken decodes the claims of an ID token without verifying it
+func userInfoFromIDToken(idToken string) *providers.UserInfo {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return &providers.UserInfo{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return &providers.UserInfo{}
+	}
+	var claims userClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return &providers.UserInfo{}
+	}
+	return claims.userInfo()
+}
@@ -0,0 +1,209 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/cmd/auth/providers"
+)
+
+// newIssuer starts an OIDC issuer whose discovery document is produced by
+// document, which receives the issuer URL
+func newIssuer(t *testing.T, document func(issuer string) map[string]interface{}) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DiscoveryPath:
+			if document == nil {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(document(server.URL))
+		case "/userinfo":
+			if r.Header.Get("Authorization") != "Bearer access-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"sub":"user-1","email":"jane@example.com","preferred_username":"jane"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fullDocument advertises every endpoint and offline_access
+func fullDocument(issuer string) map[string]interface{} {
+	return map[string]interface{}{
+		"issuer":                        issuer + "/",
+		"authorization_endpoint":        issuer + "/authorize",
+		"token_endpoint":                issuer + "/token",
+		"device_authorization_endpoint": issuer + "/device",
+		"userinfo_endpoint":             issuer + "/userinfo",
+		"scopes_supported":              []string{"openid", "profile", "email", "offline_access"},
+	}
+}
+
+func TestNewProvider_Discovery(t *testing.T) {
+	server := newIssuer(t, fullDocument)
+
+	p, err := NewProvider(context.Background(), "acme", server.URL+"/", WithDisplayName("Acme"))
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	if p.GetServiceName() != "acme" || p.GetDisplayName() != "Acme" {
+		t.Errorf("unexpected names %q, %q", p.GetServiceName(), p.GetDisplayName())
+	}
+	if p.GetAuthorizationEndpoint() != server.URL+"/authorize" || p.GetTokenEndpoint() != server.URL+"/token" {
+		t.Errorf("unexpected endpoints %q, %q", p.GetAuthorizationEndpoint(), p.GetTokenEndpoint())
+	}
+	if p.GetDeviceCodeEndpoint() != server.URL+"/device" {
+		t.Errorf("unexpected device endpoint %q", p.GetDeviceCodeEndpoint())
+	}
+	if !p.SupportsAuthorizationCode() || p.SupportsDeviceFlow() {
+		t.Error("expected the browser flow to be preferred when an authorization endpoint exists")
+	}
+	if scopes := p.GetRequiredScopes(); !slices.Equal(scopes, []string{"openid", "profile", "email", "offline_access"}) {
+		t.Errorf("unexpected scopes %v", scopes)
+	}
+}
+
+func TestNewProvider_Overrides(t *testing.T) {
+	server := newIssuer(t, func(issuer string) map[string]interface{} {
+		return map[string]interface{}{
+			"issuer":                        issuer,
+			"token_endpoint":                issuer + "/token",
+			"device_authorization_endpoint": issuer + "/device",
+		}
+	})
+
+	p, err := NewProvider(context.Background(), "acme", server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if !p.SupportsDeviceFlow() || p.SupportsAuthorizationCode() {
+		t.Error("expected the device flow for an issuer without an authorization endpoint")
+	}
+	if scopes := p.GetRequiredScopes(); slices.Contains(scopes, "offline_access") {
+		t.Errorf("offline_access requested from an issuer that does not support it: %v", scopes)
+	}
+
+	p, err = NewProvider(context.Background(), "acme", server.URL,
+		WithAuthorizationURL("https://login.example.com/authorize"), WithTokenURL("https://login.example.com/token"))
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if p.GetAuthorizationEndpoint() != "https://login.example.com/authorize" || p.GetTokenEndpoint() != "https://login.example.com/token" {
+		t.Errorf("overrides not applied: %q, %q", p.GetAuthorizationEndpoint(), p.GetTokenEndpoint())
+	}
+}
+
+func TestNewProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document func(issuer string) map[string]interface{}
+		wantErr  string
+	}{
+		{"no discovery document", nil, "status: 404"},
+		{"issuer mismatch", func(string) map[string]interface{} {
+			return map[string]interface{}{"issuer": "https://evil.example.com", "token_endpoint": "https://evil.example.com/token"}
+		}, "does not match"},
+		{"no token endpoint", func(issuer string) map[string]interface{} {
+			return map[string]interface{}{"issuer": issuer, "authorization_endpoint": issuer + "/authorize"}
+		}, "token endpoint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newIssuer(t, tt.document)
+			_, err := NewProvider(context.Background(), "acme", server.URL)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewProvider() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewProvider(context.Background(), "acme", ""); err == nil {
+		t.Error("expected an error without an issuer")
+	}
+}
+
+func TestProvider_TokenRequestAndResponse(t *testing.T) {
+	server := newIssuer(t, fullDocument)
+	p, err := NewProvider(context.Background(), "acme", server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	if err := p.ValidateConfiguration(&providers.ServiceConfig{}); err == nil {
+		t.Error("expected an error without a client ID")
+	}
+
+	params := map[string]string{}
+	if err := p.CustomizeTokenRequest(params, &providers.ServiceConfig{ClientID: "client-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["client_secret"]; ok || params["client_id"] != "client-1" {
+		t.Errorf("unexpected public client parameters %v", params)
+	}
+
+	token, err := p.ProcessTokenResponse(map[string]interface{}{
+		"access_token": "access-1", "token_type": "bearer", "expires_in": float64(3600),
+		"refresh_token": "refresh-1", "scope": "openid email",
+	})
+	if err != nil {
+		t.Fatalf("ProcessTokenResponse() error = %v", err)
+	}
+	if token.GetAuthorizationHeader() != "Bearer access-1" || token.RefreshToken != "refresh-1" ||
+		token.ExpiresAt == nil || !slices.Equal(token.Scope, []string{"openid", "email"}) {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	_, err = p.ProcessTokenResponse(map[string]interface{}{"error": "invalid_grant", "error_description": "code expired"})
+	if err == nil || !strings.Contains(err.Error(), "code expired") {
+		t.Errorf("expected the token error to be returned, got %v", err)
+	}
+}
+
+func TestProvider_GetUserInfo(t *testing.T) {
+	server := newIssuer(t, fullDocument)
+	p, err := NewProvider(context.Background(), "acme", server.URL)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	user, err := p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "access-1", TokenType: "Bearer"})
+	if err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+	if user.ID != "user-1" || user.Email != "jane@example.com" || user.Name != "jane" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if _, err := p.GetUserInfo(context.Background(), &providers.TokenInfo{AccessToken: "expired"}); err == nil {
+		t.Error("expected an error for a rejected access token")
+	}
+
+	// Without a userinfo endpoint the claims of the ID token are used
+	p.discovery.UserInfoEndpoint = ""
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-2","name":"Jo"}`))
+	user, err = p.GetUserInfo(context.Background(), &providers.TokenInfo{IDToken: "e30." + payload + ".sig"})
+	if err != nil || user.ID != "user-2" || user.Name != "Jo" {
+		t.Errorf("unexpected ID token user %+v, %v", user, err)
+	}
+}
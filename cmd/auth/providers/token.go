@@ -0,0 +1,60 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package providers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseTokenResponse extracts the standard OAuth 2.0 token response fields.
+// Scopes are split on spaces and commas, since some providers (GitHub)
+// return a comma-separated list.
+func ParseTokenResponse(response map[string]interface{}) (*TokenInfo, error) {
+	if errorCode, ok := response["error"].(string); ok && errorCode != "" {
+		if description, ok := response["error_description"].(string); ok && description != "" {
+			return nil, fmt.Errorf("%s: %s", errorCode, description)
+		}
+		return nil, fmt.Errorf("%s", errorCode)
+	}
+
+	tokenInfo := &TokenInfo{TokenType: "Bearer"}
+
+	accessToken, ok := response["access_token"].(string)
+	if !ok || accessToken == "" {
+		return nil, fmt.Errorf("access_token not found in response")
+	}
+	tokenInfo.AccessToken = accessToken
+
+	if tokenType, ok := response["token_type"].(string); ok && tokenType != "" {
+		// Some providers return "bearer"; normalise it for the Authorization header
+		if strings.EqualFold(tokenType, "bearer") {
+			tokenType = "Bearer"
+		}
+		tokenInfo.TokenType = tokenType
+	}
+
+	if refreshToken, ok := response["refresh_token"].(string); ok {
+		tokenInfo.RefreshToken = refreshToken
+	}
+
+	if expiresIn, ok := response["expires_in"].(float64); ok && expiresIn > 0 {
+		tokenInfo.ExpiresIn = int(expiresIn)
+		expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
+		tokenInfo.ExpiresAt = &expiresAt
+	}
+
+	if scope, ok := response["scope"].(string); ok && scope != "" {
+		tokenInfo.Scope = strings.FieldsFunc(scope, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+
+	if idToken, ok := response["id_token"].(string); ok {
+		tokenInfo.IDToken = idToken
+	}
+
+	return tokenInfo, nil
+}
@@ -19,6 +19,8 @@ type Manager struct {
 	services       map[string]*fusion.ServiceConfig      // Merged services from all files
 	commands       map[string]*fusion.CommandGroupConfig  // Merged commands from all files
 	nativePrefixes map[string]bool                       // Prefixes for native (non-config) tools
+	policy         *fusion.PolicyConfig                  // Merged tool access policy (nil if none)
+	rateLimits     *fusion.RateLimitConfig               // Merged rate limits (nil if none)
 	logger         global.Logger
 	mu             sync.RWMutex
 }
@@ -57,6 +59,22 @@ func New(options ...Option) *Manager {
 	return m
 }
 
+// configSet holds the merged result of loading every configuration file
+type configSet struct {
+	services   map[string]*fusion.ServiceConfig
+	commands   map[string]*fusion.CommandGroupConfig
+	policy     *fusion.PolicyConfig
+	rateLimits *fusion.RateLimitConfig
+}
+
+// newConfigSet creates an empty configSet
+func newConfigSet() *configSet {
+	return &configSet{
+		services: make(map[string]*fusion.ServiceConfig),
+		commands: make(map[string]*fusion.CommandGroupConfig),
+	}
+}
+
 // LoadConfigs loads all configured configuration files and merges them
 func (m *Manager) LoadConfigs() error {
 	if len(m.configFiles) == 0 {
@@ -66,13 +84,14 @@ func (m *Manager) LoadConfigs() error {
 		return nil // Not an error, just no configs
 	}
 
+	set := newConfigSet()
 	successCount := 0
 	for _, configFile := range m.configFiles {
 		if m.logger != nil {
 			m.logger.Infof("Loading configuration file: %s", configFile)
 		}
 
-		if err := m.loadAndMergeConfig(configFile); err != nil {
+		if err := m.loadAndMergeConfig(set, configFile); err != nil {
 			if m.logger != nil {
 				m.logger.Errorf("Failed to load config %s: %v", configFile, err)
 			}
@@ -89,36 +108,69 @@ func (m *Manager) LoadConfigs() error {
 		return fmt.Errorf("failed to load any configuration files from %d specified", len(m.configFiles))
 	}
 
+	m.swap(set)
+
 	if m.logger != nil {
 		m.logger.Infof("Loaded %d services and %d command groups from %d config files",
-			len(m.services), len(m.commands), successCount)
+			len(set.services), len(set.commands), successCount)
+	}
+
+	return nil
+}
+
+// Reload re-reads every configuration file and replaces the loaded services,
+// commands and policy. Unlike LoadConfigs, all files must load and validate;
+// if any fails, the error is returned and the current configuration is kept.
+func (m *Manager) Reload() error {
+	set := newConfigSet()
+	for _, configFile := range m.configFiles {
+		if err := m.loadAndMergeConfig(set, configFile); err != nil {
+			if m.logger != nil {
+				m.logger.Errorf("Reload aborted, keeping current configuration: %v", err)
+			}
+			return err
+		}
+	}
+
+	m.swap(set)
+
+	if m.logger != nil {
+		m.logger.Infof("Reloaded %d services and %d command groups from %d config files",
+			len(set.services), len(set.commands), len(m.configFiles))
 	}
 
 	return nil
 }
 
-// loadAndMergeConfig loads a single config file and merges its services and commands
-func (m *Manager) loadAndMergeConfig(configFile string) error {
+// swap replaces the active configuration with set
+func (m *Manager) swap(set *configSet) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.services = set.services
+	m.commands = set.commands
+	m.policy = set.policy
+	m.rateLimits = set.rateLimits
+}
+
+// loadAndMergeConfig loads a single config file and merges its services and commands into set
+func (m *Manager) loadAndMergeConfig(set *configSet, configFile string) error {
 	// Load the Config from file using fusion's existing loader
 	config, err := fusion.LoadConfigFromFile(configFile)
 	if err != nil {
 		return fmt.Errorf("failed to load config from %s: %w", configFile, err)
 	}
 
-	// Merge services and commands into our maps
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	// Merge services
 	serviceCount := 0
 	for serviceName, service := range config.Services {
-		if _, exists := m.services[serviceName]; exists {
+		if _, exists := set.services[serviceName]; exists {
 			if m.logger != nil {
 				m.logger.Warningf("Service '%s' from %s overwrites previous definition",
 					serviceName, configFile)
 			}
 		}
-		m.services[serviceName] = service
+		set.services[serviceName] = service
 		serviceCount++
 		if m.logger != nil {
 			m.logger.Debugf("Loaded service '%s' from %s", serviceName, configFile)
@@ -128,13 +180,13 @@ func (m *Manager) loadAndMergeConfig(configFile string) error {
 	// Merge commands
 	commandCount := 0
 	for commandGroupName, commandGroup := range config.Commands {
-		if _, exists := m.commands[commandGroupName]; exists {
+		if _, exists := set.commands[commandGroupName]; exists {
 			if m.logger != nil {
 				m.logger.Warningf("Command group '%s' from %s overwrites previous definition",
 					commandGroupName, configFile)
 			}
 		}
-		m.commands[commandGroupName] = commandGroup
+		set.commands[commandGroupName] = commandGroup
 		commandCount++
 		if m.logger != nil {
 			m.logger.Debugf("Loaded command group '%s' with %d commands from %s",
@@ -142,6 +194,37 @@ func (m *Manager) loadAndMergeConfig(configFile string) error {
 		}
 	}
 
+	// Merge policy: rules are appended in file order, so earlier files take precedence
+	if config.Policy != nil {
+		if set.policy == nil {
+			set.policy = &fusion.PolicyConfig{}
+		}
+		if config.Policy.DefaultEffect != "" {
+			if set.policy.DefaultEffect != "" && set.policy.DefaultEffect != config.Policy.DefaultEffect {
+				if m.logger != nil {
+					m.logger.Warningf("Policy defaultEffect '%s' from %s overwrites previous value '%s'",
+						config.Policy.DefaultEffect, configFile, set.policy.DefaultEffect)
+				}
+			}
+			set.policy.DefaultEffect = config.Policy.DefaultEffect
+		}
+		set.policy.Rules = append(set.policy.Rules, config.Policy.Rules...)
+		if m.logger != nil {
+			m.logger.Debugf("Loaded %d policy rules from %s", len(config.Policy.Rules), configFile)
+		}
+	}
+
+	// Merge rate limits: every matching limit applies, so they are simply collected
+	if config.RateLimits != nil {
+		if set.rateLimits == nil {
+			set.rateLimits = &fusion.RateLimitConfig{}
+		}
+		set.rateLimits.Limits = append(set.rateLimits.Limits, config.RateLimits.Limits...)
+		if m.logger != nil {
+			m.logger.Debugf("Loaded %d rate limits from %s", len(config.RateLimits.Limits), configFile)
+		}
+	}
+
 	if m.logger != nil {
 		m.logger.Debugf("Merged %d services and %d command groups from %s",
 			serviceCount, commandCount, configFile)
@@ -150,6 +233,34 @@ func (m *Manager) loadAndMergeConfig(configFile string) error {
 	return nil
 }
 
+// GetPolicy returns the merged tool access policy, or nil if no configuration
+// file defines one
+func (m *Manager) GetPolicy() *fusion.PolicyConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.policy == nil {
+		return nil
+	}
+	policy := *m.policy
+	policy.Rules = append([]fusion.PolicyRule(nil), m.policy.Rules...)
+	return &policy
+}
+
+// GetRateLimits returns the merged rate limits, or nil if no configuration
+// file defines any
+func (m *Manager) GetRateLimits() *fusion.RateLimitConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.rateLimits == nil {
+		return nil
+	}
+	limits := *m.rateLimits
+	limits.Limits = append([]fusion.RateLimitRule(nil), m.rateLimits.Limits...)
+	return &limits
+}
+
 // GetService returns a specific service configuration by name
 func (m *Manager) GetService(name string) (*fusion.ServiceConfig, error) {
 	m.mu.RLock()
@@ -254,8 +365,10 @@ func (m *Manager) GetConfig() *fusion.Config {
 	defer m.mu.RUnlock()
 
 	return &fusion.Config{
-		Services: m.services,
-		Commands: m.commands,
+		Services:   m.services,
+		Commands:   m.commands,
+		Policy:     m.policy,
+		RateLimits: m.rateLimits,
 	}
 }
 
@@ -0,0 +1,65 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const reloadTestConfig = `{
+	"services": {
+		"%s": {
+			"name": "Test",
+			"baseURL": "https://api.example.com",
+			"auth": {"type": "bearer", "config": {"token": "x"}},
+			"endpoints": [{"id": "get", "name": "Get", "description": "Get", "method": "GET", "path": "/", "response": {"type": "json"}}]
+		}
+	}
+}`
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+}
+
+func TestManager_Reload(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	writeConfig(t, first, fmt.Sprintf(reloadTestConfig, "alpha"))
+	writeConfig(t, second, fmt.Sprintf(reloadTestConfig, "beta"))
+
+	m := New(WithConfigFiles(first, second))
+	if err := m.LoadConfigs(); err != nil {
+		t.Fatalf("LoadConfigs failed: %v", err)
+	}
+	if !m.HasService("alpha") || !m.HasService("beta") {
+		t.Fatalf("expected alpha and beta, got %v", m.GetServiceNames())
+	}
+
+	// A valid change replaces the configuration
+	writeConfig(t, second, fmt.Sprintf(reloadTestConfig, "gamma"))
+	if err := m.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if m.HasService("beta") || !m.HasService("gamma") || !m.HasService("alpha") {
+		t.Errorf("expected alpha and gamma after reload, got %v", m.GetServiceNames())
+	}
+
+	// An invalid file aborts the reload and keeps the current configuration
+	writeConfig(t, first, `{"services": {`)
+	if err := m.Reload(); err == nil {
+		t.Fatal("expected Reload to fail for invalid JSON")
+	}
+	if !m.HasService("alpha") || !m.HasService("gamma") || m.ServiceCount() != 2 {
+		t.Errorf("expected configuration to be unchanged, got %v", m.GetServiceNames())
+	}
+}
@@ -0,0 +1,73 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// fileStamp identifies a version of a configuration file on disk
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// equal reports whether two stamps describe the same file version
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.exists == other.exists && s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// Watch polls the configuration files every interval and calls onChange when
+// any of them is modified, created or removed. It blocks until ctx is done.
+//
+// Polling is used instead of filesystem notifications because editors and
+// configuration management tools commonly replace files by rename, which
+// inotify-style watchers on the file itself do not survive.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration, onChange func()) {
+	stamps := m.statConfigFiles()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := m.statConfigFiles()
+			changed := false
+			for file, stamp := range current {
+				if !stamps[file].equal(stamp) {
+					changed = true
+					if m.logger != nil {
+						m.logger.Infof("Configuration file changed: %s", file)
+					}
+				}
+			}
+			stamps = current
+			if changed {
+				onChange()
+			}
+		}
+	}
+}
+
+// statConfigFiles returns the current stamp of every configuration file
+func (m *Manager) statConfigFiles() map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(m.configFiles))
+	for _, file := range m.configFiles {
+		info, err := os.Stat(file)
+		if err != nil {
+			stamps[file] = fileStamp{}
+			continue
+		}
+		stamps[file] = fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+	}
+	return stamps
+}
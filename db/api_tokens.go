@@ -340,6 +340,61 @@ func (d *DB) GetAPITokenMetadata(hash string) (*APITokenMetadata, error) {
 	return metadata, nil
 }
 
+// SetAPITokenRole sets the role of an API token, APITokenRoleUser or APITokenRoleAdmin
+func (d *DB) SetAPITokenRole(hash, role string) error {
+	if err := d.checkClosed(); err != nil {
+		return err
+	}
+
+	// Validate hash format and role
+	if err := internal.ValidateHash(hash); err != nil {
+		return NewValidationError("hash", hash, err.Error())
+	}
+	if role != APITokenRoleUser && role != APITokenRoleAdmin {
+		return NewValidationError("role", role, "role must be user or admin")
+	}
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		tokensBucket := tx.Bucket([]byte(internal.BucketAPITokens))
+		if tokensBucket == nil {
+			return NewDatabaseError("set_api_token_role", fmt.Errorf("tokens bucket not found"))
+		}
+
+		metadataBytes := tokensBucket.Get([]byte(hash))
+		if metadataBytes == nil {
+			return NewTokenError("api", hash, ErrTokenNotFound)
+		}
+
+		var metadata APITokenMetadata
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return NewDatabaseError("set_api_token_role", fmt.Errorf("failed to unmarshal metadata: %w", err))
+		}
+
+		// Regular tokens are stored without a role
+		metadata.Role = role
+		if role == APITokenRoleUser {
+			metadata.Role = ""
+		}
+
+		updatedBytes, err := json.Marshal(&metadata)
+		if err != nil {
+			return NewDatabaseError("set_api_token_role", fmt.Errorf("failed to marshal metadata: %w", err))
+		}
+		if err := tokensBucket.Put([]byte(hash), updatedBytes); err != nil {
+			return NewDatabaseError("set_api_token_role", fmt.Errorf("failed to store metadata: %w", err))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d.logger.Infof("Set role of API token %s to %s", safeHashPrefix(hash), role)
+	return nil
+}
+
 // ResolveAPIToken resolves a token identifier (hash or prefix) to its full hash
 func (d *DB) ResolveAPIToken(identifier string) (string, error) {
 	if err := d.checkClosed(); err != nil {
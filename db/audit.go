@@ -0,0 +1,330 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db/internal"
+	"go.etcd.io/bbolt"
+)
+
+// RecordAuditEntry queues a tool call for the audit log. Entries are written
+// in batches by a background worker; when its buffer is full the entry is
+// written immediately instead, so entries are never dropped.
+func (d *DB) RecordAuditEntry(entry *AuditEntry) error {
+	if entry == nil {
+		return NewValidationError("entry", nil, "audit entry cannot be nil")
+	}
+
+	if strings.TrimSpace(entry.Tool) == "" {
+		return NewValidationError("tool", entry.Tool, "tool cannot be empty")
+	}
+
+	record := *entry
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+
+	// Hold the read lock while queuing so that Close cannot stop the worker
+	// between the closed check and the send
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.closed {
+		return ErrDatabaseClosed
+	}
+
+	select {
+	case d.auditCh <- record:
+		return nil
+	default:
+		return d.writeAuditEntries([]AuditEntry{record})
+	}
+}
+
+// QueryAuditLog returns the audit entries matching the filter, newest first
+func (d *DB) QueryAuditLog(filter AuditFilter) ([]AuditEntry, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit < 0 || filter.Limit > internal.AuditMaxQueryLimit {
+		return nil, NewValidationError("limit", filter.Limit,
+			fmt.Sprintf("limit must be between 0 and %d", internal.AuditMaxQueryLimit))
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return nil, NewValidationError("until", filter.Until, "until must be after since")
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = internal.AuditDefaultQueryLimit
+	}
+
+	entries := []AuditEntry{}
+
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		auditBucket := tx.Bucket([]byte(internal.BucketAuditLog))
+		if auditBucket == nil {
+			return nil
+		}
+
+		// Start at the newest entry before Until and walk back in time
+		c := auditBucket.Cursor()
+		var k, v []byte
+		if filter.Until.IsZero() {
+			k, v = c.Last()
+		} else if k, _ = c.Seek(auditKey(filter.Until, 0)); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+
+		for ; k != nil && len(entries) < limit; k, v = c.Prev() {
+			if !filter.Since.IsZero() && auditKeyTime(k).Before(filter.Since) {
+				break
+			}
+
+			var entry AuditEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				d.logger.Warningf("Failed to unmarshal audit entry %x: %v", k, err)
+				continue
+			}
+			if (filter.UserID != "" && entry.UserID != filter.UserID) ||
+				(filter.TenantHash != "" && entry.TenantHash != filter.TenantHash) ||
+				(filter.Tool != "" && entry.Tool != filter.Tool) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, NewDatabaseError("query_audit_log", err)
+	}
+
+	d.logger.Debugf("Audit log query returned %d entries", len(entries))
+	return entries, nil
+}
+
+// PruneAuditLog deletes audit entries older than before and then the oldest
+// entries beyond maxEntries. A zero before or maxEntries disables that limit.
+// It returns the number of entries deleted.
+func (d *DB) PruneAuditLog(before time.Time, maxEntries int) (int, error) {
+	if err := d.checkClosed(); err != nil {
+		return 0, err
+	}
+
+	if maxEntries < 0 {
+		return 0, NewValidationError("max_entries", maxEntries, "max entries cannot be negative")
+	}
+
+	return d.pruneAuditLog(before, maxEntries)
+}
+
+// pruneAuditLog implements PruneAuditLog without the closed check, for the audit worker
+func (d *DB) pruneAuditLog(before time.Time, maxEntries int) (int, error) {
+	deleted := 0
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		auditBucket := tx.Bucket([]byte(internal.BucketAuditLog))
+		if auditBucket == nil {
+			return nil
+		}
+
+		// Keys sort by time, so expired entries are at the start of the bucket
+		total := auditBucket.Stats().KeyN
+		excess := 0
+		if maxEntries > 0 && total > maxEntries {
+			excess = total - maxEntries
+		}
+
+		var keys [][]byte
+		c := auditBucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if len(keys) >= excess && (before.IsZero() || !auditKeyTime(k).Before(before)) {
+				break
+			}
+			keys = append(keys, append([]byte(nil), k...))
+		}
+
+		for _, k := range keys {
+			if err := auditBucket.Delete(k); err != nil {
+				return fmt.Errorf("failed to delete audit entry: %w", err)
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+
+	if err != nil {
+		return 0, NewDatabaseError("prune_audit_log", err)
+	}
+
+	if deleted > 0 {
+		d.logger.Infof("Pruned %d audit log entries", deleted)
+	}
+	return deleted, nil
+}
+
+// writeAuditEntries stores audit entries in a single transaction
+func (d *DB) writeAuditEntries(entries []AuditEntry) error {
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		auditBucket, err := tx.CreateBucketIfNotExists([]byte(internal.BucketAuditLog))
+		if err != nil {
+			return fmt.Errorf("failed to create audit log bucket: %w", err)
+		}
+
+		for i := range entries {
+			seq, err := auditBucket.NextSequence()
+			if err != nil {
+				return fmt.Errorf("failed to allocate audit sequence: %w", err)
+			}
+			key := auditKey(entries[i].Timestamp, seq)
+			entries[i].ID = hex.EncodeToString(key)
+
+			entryBytes, err := json.Marshal(&entries[i])
+			if err != nil {
+				return fmt.Errorf("failed to marshal audit entry: %w", err)
+			}
+			if err := auditBucket.Put(key, entryBytes); err != nil {
+				return fmt.Errorf("failed to store audit entry: %w", err)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return NewDatabaseError("record_audit_entry", err)
+	}
+	return nil
+}
+
+// auditWorker is the single background goroutine that writes queued audit
+// entries, either when AuditMaxBatch entries have accumulated or every
+// AuditFlushInterval, and prunes entries beyond the retention every
+// AuditPruneInterval.
+//
+// On shutdown (stopAudit closed) it drains the channel and performs a final
+// flush before returning.
+func (d *DB) auditWorker() {
+	defer d.auditWg.Done()
+
+	flushTicker := time.NewTicker(internal.AuditFlushInterval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(internal.AuditPruneInterval)
+	defer pruneTicker.Stop()
+
+	pending := make([]AuditEntry, 0, internal.AuditMaxBatch)
+
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if err := d.writeAuditEntries(pending); err != nil {
+			d.logger.Errorf("Failed to write %d audit log entries: %v", len(pending), err)
+		}
+		pending = pending[:0]
+	}
+
+	prune := func() {
+		if d.auditMaxAge == 0 && d.auditMaxKeep == 0 {
+			return
+		}
+		var before time.Time
+		if d.auditMaxAge > 0 {
+			before = time.Now().Add(-d.auditMaxAge)
+		}
+		if _, err := d.pruneAuditLog(before, d.auditMaxKeep); err != nil {
+			d.logger.Warningf("Failed to prune audit log: %v", err)
+		}
+	}
+
+	prune()
+
+	for {
+		select {
+		case entry := <-d.auditCh:
+			pending = append(pending, entry)
+			if len(pending) >= internal.AuditMaxBatch {
+				flush()
+			}
+
+		case <-flushTicker.C:
+			flush()
+
+		case <-pruneTicker.C:
+			flush()
+			prune()
+
+		case <-d.stopAudit:
+			// Drain any entries already in the channel buffer before the final flush.
+			for {
+				select {
+				case entry := <-d.auditCh:
+					pending = append(pending, entry)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// auditKey returns the key of an audit entry: the timestamp in nanoseconds
+// followed by a sequence number, both big-endian so that keys sort by time
+func auditKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[:8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+// auditKeyTime returns the timestamp encoded in an audit key
+func auditKeyTime(key []byte) time.Time {
+	if len(key) < 8 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+// ParseAuditTime parses a time bound for an audit log query: an RFC 3339
+// timestamp, a date (YYYY-MM-DD, UTC), or an age relative to now such as
+// "90m", "24h" or "7d". An empty value returns the zero time.
+func ParseAuditTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, NewValidationError("time", value,
+		"expected an RFC 3339 timestamp, a date (YYYY-MM-DD) or an age such as 24h or 7d")
+}
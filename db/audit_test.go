@@ -0,0 +1,95 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tenebris-tech/mlogger"
+)
+
+func TestAuditLog(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer func() { cleanupTestDB(database, tempDir) }()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []AuditEntry{
+		{Timestamp: base, UserID: "alice", TenantHash: "tenant-a", Tool: "github_list_repos", Status: "ok"},
+		{Timestamp: base.Add(time.Hour), UserID: "bob", TenantHash: "tenant-b", Tool: "github_list_repos", Status: "error"},
+		{Timestamp: base.Add(2 * time.Hour), UserID: "alice", TenantHash: "tenant-a", Tool: "jira_search", Status: "ok"},
+		{Timestamp: base.Add(2 * time.Hour), UserID: "alice", TenantHash: "tenant-a", Tool: "jira_search", Status: "ok"},
+	}
+	for i := range entries {
+		require.NoError(t, database.RecordAuditEntry(&entries[i]))
+	}
+	assert.Error(t, database.RecordAuditEntry(&AuditEntry{TenantHash: "tenant-a"}), "tool is required")
+
+	// Closing the database writes the queued entries
+	require.NoError(t, database.Close())
+	database, err := New(WithLogger(mlogger.NewMemoryLogger()), WithDataDir(tempDir))
+	require.NoError(t, err)
+
+	all, err := database.QueryAuditLog(AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "jira_search", all[0].Tool, "entries are returned newest first")
+	assert.NotEqual(t, all[0].ID, all[1].ID, "entries with the same timestamp are kept apart")
+
+	byUser, err := database.QueryAuditLog(AuditFilter{UserID: "alice", Tool: "github_list_repos"})
+	require.NoError(t, err)
+	require.Len(t, byUser, 1)
+	assert.Equal(t, base, byUser[0].Timestamp.UTC())
+
+	window, err := database.QueryAuditLog(AuditFilter{Since: base.Add(30 * time.Minute), Until: base.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, window, 1)
+	assert.Equal(t, "bob", window[0].UserID)
+
+	limited, err := database.QueryAuditLog(AuditFilter{TenantHash: "tenant-a", Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	_, err = database.QueryAuditLog(AuditFilter{Since: base, Until: base})
+	assert.True(t, IsValidationError(err))
+
+	// Retention by age, then by count
+	deleted, err := database.PruneAuditLog(base.Add(30*time.Minute), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	deleted, err = database.PruneAuditLog(time.Time{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	remaining, err := database.QueryAuditLog(AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, "jira_search", remaining[1].Tool)
+}
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		"":                     {},
+		"2026-03-01T08:30:00Z": time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC),
+		"2026-03-01":           time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		"24h":                  now.Add(-24 * time.Hour),
+		"7d":                   now.Add(-7 * 24 * time.Hour),
+	}
+	for value, expected := range tests {
+		got, err := ParseAuditTime(value, now)
+		require.NoError(t, err, value)
+		assert.True(t, expected.Equal(got), "%s: expected %v, got %v", value, expected, got)
+	}
+
+	for _, value := range []string{"yesterday", "-1h", "3w"} {
+		_, err := ParseAuditTime(value, now)
+		assert.True(t, IsValidationError(err), value)
+	}
+}
@@ -0,0 +1,169 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/MCPFusion/db/internal"
+	"go.etcd.io/bbolt"
+)
+
+// SaveCommandJob creates or replaces an asynchronous command job record
+func (d *DB) SaveCommandJob(job *CommandJob) error {
+	if err := d.checkClosed(); err != nil {
+		return err
+	}
+
+	if job == nil {
+		return NewValidationError("job", nil, "command job cannot be nil")
+	}
+
+	if strings.TrimSpace(job.ID) == "" {
+		return NewValidationError("id", job.ID, "job ID cannot be empty")
+	}
+
+	if strings.TrimSpace(job.TenantHash) == "" {
+		return NewValidationError("tenant_hash", job.TenantHash, "tenant hash cannot be empty")
+	}
+
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return NewDatabaseError("save_command_job", fmt.Errorf("failed to marshal command job: %w", err))
+	}
+
+	err = d.db.Update(func(tx *bbolt.Tx) error {
+		jobsBucket, err := tx.CreateBucketIfNotExists([]byte(internal.BucketCommandJobs))
+		if err != nil {
+			return NewDatabaseError("save_command_job", fmt.Errorf("failed to create command jobs bucket: %w", err))
+		}
+
+		if err := jobsBucket.Put([]byte(job.ID), jobBytes); err != nil {
+			return NewDatabaseError("save_command_job", fmt.Errorf("failed to store command job: %w", err))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d.logger.Debugf("Saved command job %s (status: %s)", job.ID, job.Status)
+	return nil
+}
+
+// GetCommandJob retrieves an asynchronous command job by ID
+func (d *DB) GetCommandJob(jobID string) (*CommandJob, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(jobID) == "" {
+		return nil, NewValidationError("id", jobID, "job ID cannot be empty")
+	}
+
+	var job *CommandJob
+
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		jobsBucket := tx.Bucket([]byte(internal.BucketCommandJobs))
+		if jobsBucket == nil {
+			return NewDatabaseError("get_command_job", ErrCommandJobNotFound)
+		}
+
+		jobBytes := jobsBucket.Get([]byte(jobID))
+		if jobBytes == nil {
+			return NewDatabaseError("get_command_job", ErrCommandJobNotFound)
+		}
+
+		job = &CommandJob{}
+		if err := json.Unmarshal(jobBytes, job); err != nil {
+			return NewDatabaseError("get_command_job", fmt.Errorf("failed to unmarshal command job: %w", err))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// DeleteCommandJob removes an asynchronous command job record
+func (d *DB) DeleteCommandJob(jobID string) error {
+	if err := d.checkClosed(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(jobID) == "" {
+		return NewValidationError("id", jobID, "job ID cannot be empty")
+	}
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		jobsBucket := tx.Bucket([]byte(internal.BucketCommandJobs))
+		if jobsBucket == nil || jobsBucket.Get([]byte(jobID)) == nil {
+			return NewDatabaseError("delete_command_job", ErrCommandJobNotFound)
+		}
+
+		if err := jobsBucket.Delete([]byte(jobID)); err != nil {
+			return NewDatabaseError("delete_command_job", fmt.Errorf("failed to delete command job: %w", err))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d.logger.Debugf("Deleted command job %s", jobID)
+	return nil
+}
+
+// ListCommandJobs returns the command jobs of a tenant, newest first. An empty
+// tenant hash returns the jobs of every tenant.
+func (d *DB) ListCommandJobs(tenantHash string) ([]CommandJob, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	jobs := []CommandJob{}
+
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		jobsBucket := tx.Bucket([]byte(internal.BucketCommandJobs))
+		if jobsBucket == nil {
+			return nil
+		}
+
+		return jobsBucket.ForEach(func(k, v []byte) error {
+			var job CommandJob
+			if err := json.Unmarshal(v, &job); err != nil {
+				d.logger.Warningf("Failed to unmarshal command job %s: %v", string(k), err)
+				return nil // Continue iteration
+			}
+			if tenantHash == "" || job.TenantHash == tenantHash {
+				jobs = append(jobs, job)
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	d.logger.Debugf("Listed %d command jobs (tenant: %q)", len(jobs), tenantHash)
+	return jobs, nil
+}
@@ -0,0 +1,66 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandJobs(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer cleanupTestDB(database, tempDir)
+
+	now := time.Now()
+	jobs := []*CommandJob{
+		{ID: "job1", TenantHash: "tenant-a", Tool: "command_nmap", Status: "completed", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "job2", TenantHash: "tenant-a", Tool: "command_gobuster", Status: "running", CreatedAt: now.Add(-time.Minute)},
+		{ID: "job3", TenantHash: "tenant-b", Tool: "command_nmap", Status: "running", CreatedAt: now},
+	}
+	for _, job := range jobs {
+		require.NoError(t, database.SaveCommandJob(job))
+	}
+
+	job, err := database.GetCommandJob("job2")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", job.TenantHash)
+	assert.Equal(t, "command_gobuster", job.Tool)
+
+	// Saving again replaces the record
+	exitCode := 0
+	job.Status = "completed"
+	job.ExitCode = &exitCode
+	require.NoError(t, database.SaveCommandJob(job))
+	job, err = database.GetCommandJob("job2")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", job.Status)
+	require.NotNil(t, job.ExitCode)
+
+	list, err := database.ListCommandJobs("tenant-a")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "job2", list[0].ID, "jobs should be listed newest first")
+	assert.Equal(t, "job1", list[1].ID)
+
+	list, err = database.ListCommandJobs("")
+	require.NoError(t, err)
+	assert.Len(t, list, 3)
+
+	_, err = database.GetCommandJob("missing")
+	assert.True(t, errors.Is(err, ErrCommandJobNotFound), "expected ErrCommandJobNotFound, got %v", err)
+
+	assert.Error(t, database.SaveCommandJob(&CommandJob{ID: "job4"}), "tenant hash is required")
+
+	require.NoError(t, database.DeleteCommandJob("job1"))
+	_, err = database.GetCommandJob("job1")
+	assert.True(t, errors.Is(err, ErrCommandJobNotFound), "expected the job to be deleted, got %v", err)
+	err = database.DeleteCommandJob("job1")
+	assert.True(t, errors.Is(err, ErrCommandJobNotFound), "expected ErrCommandJobNotFound, got %v", err)
+}
@@ -6,7 +6,6 @@
 package db
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -78,10 +77,10 @@ func (d *DB) StoreCredentials(tenantHash, serviceName string, credentials *Servi
 			return NewDatabaseErrorWithContext("store_credentials", fmt.Errorf("failed to create credentials bucket: %w", err), tenantHash, serviceName)
 		}
 
-		// Marshal credentials data
-		credentialsBytes, err := json.Marshal(credentials)
+		// Encode (and encrypt, if enabled) credentials data
+		credentialsBytes, err := d.encodeSecret(internal.BucketServiceCredentials, tenantHash, serviceName, credentials)
 		if err != nil {
-			return NewDatabaseErrorWithContext("store_credentials", fmt.Errorf("failed to marshal credentials: %w", err), tenantHash, serviceName)
+			return NewDatabaseErrorWithContext("store_credentials", fmt.Errorf("failed to encode credentials: %w", err), tenantHash, serviceName)
 		}
 
 		// Store credentials data
@@ -141,10 +140,10 @@ func (d *DB) GetCredentials(tenantHash, serviceName string) (*ServiceCredentials
 			return NewDatabaseErrorWithContext("get_credentials", ErrServiceNotFound, tenantHash, serviceName)
 		}
 
-		// Unmarshal credentials data
+		// Decode credentials data
 		credentials = &ServiceCredentials{}
-		if err := json.Unmarshal(credentialsBytes, credentials); err != nil {
-			return NewDatabaseErrorWithContext("get_credentials", fmt.Errorf("failed to unmarshal credentials: %w", err), tenantHash, serviceName)
+		if err := d.decodeSecret(internal.BucketServiceCredentials, tenantHash, serviceName, credentialsBytes, credentials); err != nil {
+			return NewDatabaseErrorWithContext("get_credentials", fmt.Errorf("failed to decode credentials: %w", err), tenantHash, serviceName)
 		}
 
 		return nil
@@ -250,8 +249,8 @@ func (d *DB) ListCredentials(tenantHash string) (map[string]*ServiceCredentials,
 			serviceName := string(k)
 
 			var serviceCredentials ServiceCredentials
-			if err := json.Unmarshal(v, &serviceCredentials); err != nil {
-				d.logger.Warningf("Failed to unmarshal credentials for service %s: %v", serviceName, err)
+			if err := d.decodeSecret(internal.BucketServiceCredentials, tenantHash, serviceName, v, &serviceCredentials); err != nil {
+				d.logger.Warningf("Failed to decode credentials for service %s: %v", serviceName, err)
 				return nil // Continue iteration
 			}
 
@@ -313,8 +312,8 @@ func (d *DB) UpdateCredentials(tenantHash, serviceName string, credentials *Serv
 		}
 
 		var existing ServiceCredentials
-		if err := json.Unmarshal(existingBytes, &existing); err != nil {
-			return NewDatabaseErrorWithContext("update_credentials", fmt.Errorf("failed to unmarshal existing credentials: %w", err), tenantHash, serviceName)
+		if err := d.decodeSecret(internal.BucketServiceCredentials, tenantHash, serviceName, existingBytes, &existing); err != nil {
+			return NewDatabaseErrorWithContext("update_credentials", fmt.Errorf("failed to decode existing credentials: %w", err), tenantHash, serviceName)
 		}
 
 		// Update credentials while preserving creation time
@@ -322,9 +321,9 @@ func (d *DB) UpdateCredentials(tenantHash, serviceName string, credentials *Serv
 		credentials.UpdatedAt = time.Now()
 
 		// Marshal and store updated credentials
-		updatedBytes, err := json.Marshal(credentials)
+		updatedBytes, err := d.encodeSecret(internal.BucketServiceCredentials, tenantHash, serviceName, credentials)
 		if err != nil {
-			return NewDatabaseErrorWithContext("update_credentials", fmt.Errorf("failed to marshal updated credentials: %w", err), tenantHash, serviceName)
+			return NewDatabaseErrorWithContext("update_credentials", fmt.Errorf("failed to encode updated credentials: %w", err), tenantHash, serviceName)
 		}
 
 		if err := credentialsBucket.Put([]byte(serviceName), updatedBytes); err != nil {
@@ -31,6 +31,7 @@ type Database interface {
 	ListAPITokens() ([]APITokenMetadata, error)
 	GetAPITokenMetadata(hash string) (*APITokenMetadata, error)
 	ResolveAPIToken(identifier string) (string, error)
+	SetAPITokenRole(hash, role string) error
 
 	// OAuth Token Management
 	StoreOAuthToken(tenantHash, serviceName string, tokenData *OAuthTokenData) error
@@ -61,6 +62,7 @@ type Database interface {
 	LinkAPIKey(userID, keyHash string) error
 	UnlinkAPIKey(keyHash string) error
 	GetUserByAPIKey(keyHash string) (string, error)
+	ListUserAPIKeys(userID string) ([]string, error)
 	AutoMigrateKeys() error
 
 	// Knowledge Management
@@ -71,7 +73,31 @@ type Database interface {
 	RenameKnowledge(userID, domain, oldKey, newKey string) error
 	SearchKnowledge(userID, query string) ([]KnowledgeEntry, error)
 
+	// Command Job Management
+	SaveCommandJob(job *CommandJob) error
+	GetCommandJob(jobID string) (*CommandJob, error)
+	ListCommandJobs(tenantHash string) ([]CommandJob, error)
+	DeleteCommandJob(jobID string) error
+
+	// OAuth Authorization Server
+	CreateOAuthClient(client *OAuthClientData) (string, error)
+	GetOAuthClient(clientID string) (*OAuthClientData, error)
+	CreateOAuthGrant(grant *OAuthGrantData) (string, error)
+	ConsumeOAuthGrant(code string) (*OAuthGrantData, error)
+	CreateOAuthAccessToken(data *OAuthIssuedTokenData) (string, error)
+	ValidateOAuthAccessToken(token string) (*OAuthIssuedTokenData, error)
+	CreateOAuthRefreshToken(data *OAuthIssuedTokenData) (string, error)
+	ConsumeOAuthRefreshToken(token string) (*OAuthIssuedTokenData, error)
+	RevokeOAuthToken(token string) error
+	CleanupExpiredOAuthServerData() error
+
+	// Audit Log
+	RecordAuditEntry(entry *AuditEntry) error
+	QueryAuditLog(filter AuditFilter) ([]AuditEntry, error)
+	PruneAuditLog(before time.Time, maxEntries int) (int, error)
+
 	// Database Management
+	DataDir() string
 	Close() error
 	Backup(path string) error
 }
@@ -86,12 +112,25 @@ type DB struct {
 	lastUsedCh   chan string     // buffered channel; token hashes queued for last-used update
 	stopLastUsed chan struct{}   // closed by Close() to signal the worker to flush and exit
 	lastUsedWg   sync.WaitGroup // tracks the single lastUsedWorker goroutine
+	keyMutex     sync.RWMutex   // guards masterKey and retiredKey
+	masterKey    *masterKey     // encrypts secrets at rest; nil when encryption is disabled
+	retiredKey   *masterKey     // previous master key after a rotation, used only for decryption
+
+	auditCh      chan AuditEntry // buffered channel; audit entries queued for the audit worker
+	stopAudit    chan struct{}   // closed by Close() to signal the audit worker to flush and exit
+	auditWg      sync.WaitGroup  // tracks the single auditWorker goroutine
+	auditMaxAge  time.Duration   // audit entries older than this are pruned; 0 keeps them
+	auditMaxKeep int             // the oldest audit entries beyond this count are pruned; 0 keeps them
 }
 
 // Config holds configuration options for the database
 type Config struct {
-	DataDir string
-	Logger  global.Logger
+	DataDir   string
+	Logger    global.Logger
+	MasterKey []byte
+
+	AuditRetention  time.Duration
+	AuditMaxEntries int
 }
 
 // Option defines a configuration option for the database
@@ -111,6 +150,23 @@ func WithLogger(logger global.Logger) Option {
 	}
 }
 
+// WithMasterKey enables encryption at rest of OAuth tokens and service
+// credentials using the given 32-byte master key
+func WithMasterKey(key []byte) Option {
+	return func(c *Config) {
+		c.MasterKey = key
+	}
+}
+
+// WithAuditRetention sets how long audit log entries are kept and the maximum
+// number kept. Zero disables the respective limit.
+func WithAuditRetention(maxAge time.Duration, maxEntries int) Option {
+	return func(c *Config) {
+		c.AuditRetention = maxAge
+		c.AuditMaxEntries = maxEntries
+	}
+}
+
 // New creates a new database instance with functional options
 func New(opts ...Option) (Database, error) {
 	config := &Config{}
@@ -126,7 +182,17 @@ func New(opts ...Option) (Database, error) {
 	}
 
 	d := &DB{
-		logger: config.Logger,
+		logger:       config.Logger,
+		auditMaxAge:  config.AuditRetention,
+		auditMaxKeep: config.AuditMaxEntries,
+	}
+
+	if config.MasterKey != nil {
+		key, err := newMasterKey(config.MasterKey)
+		if err != nil {
+			return nil, NewValidationError("master_key", nil, err.Error())
+		}
+		d.masterKey = key
 	}
 
 	// Determine data directory
@@ -153,6 +219,12 @@ func New(opts ...Option) (Database, error) {
 	}
 
 	d.logger.Infof("Database initialized at %s", filepath.Join(d.dataDir, "mcpfusion.db"))
+	if d.masterKey != nil {
+		d.logger.Infof("Encryption at rest enabled for OAuth tokens and credentials (master key %s)", d.masterKey.id)
+	} else {
+		d.logger.Warningf("No master key configured (%s or %s): OAuth tokens and credentials are stored unencrypted",
+			MasterKeyEnvVar, MasterKeyFileEnvVar)
+	}
 
 	// Start background worker that batches token last-used timestamp writes.
 	d.lastUsedCh = make(chan string, internal.LastUsedChannelSize)
@@ -160,6 +232,12 @@ func New(opts ...Option) (Database, error) {
 	d.lastUsedWg.Add(1)
 	go d.lastUsedWorker()
 
+	// Start background worker that batches audit log writes and applies retention.
+	d.auditCh = make(chan AuditEntry, internal.AuditChannelSize)
+	d.stopAudit = make(chan struct{})
+	d.auditWg.Add(1)
+	go d.auditWorker()
+
 	return d, nil
 }
 
@@ -228,6 +306,12 @@ func (d *DB) initializeSchema() error {
 			internal.BucketAuthCodes,
 			internal.BucketUsers,
 			internal.BucketKeyToUser,
+			internal.BucketCommandJobs,
+			internal.BucketOAuthClients,
+			internal.BucketOAuthGrants,
+			internal.BucketOAuthAccessTokens,
+			internal.BucketOAuthRefreshTokens,
+			internal.BucketAuditLog,
 		}
 
 		for _, bucketName := range rootBuckets {
@@ -281,6 +365,10 @@ func (d *DB) Close() error {
 	close(d.stopLastUsed)
 	d.lastUsedWg.Wait()
 
+	// Stop the audit worker after it has written all queued entries
+	close(d.stopAudit)
+	d.auditWg.Wait()
+
 	err := d.db.Close()
 	d.closed = true
 
@@ -292,6 +380,11 @@ func (d *DB) Close() error {
 	return nil
 }
 
+// DataDir returns the directory holding the database file
+func (d *DB) DataDir() string {
+	return d.dataDir
+}
+
 // Backup creates a backup of the database to the specified path
 func (d *DB) Backup(path string) error {
 	d.mutex.RLock()
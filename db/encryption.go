@@ -0,0 +1,469 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PivotLLM/MCPFusion/db/internal"
+	"go.etcd.io/bbolt"
+)
+
+// Environment variables used to supply the master key for encryption at rest
+const (
+	MasterKeyEnvVar     = "MCP_FUSION_MASTER_KEY"      // Base64 or hex encoded 32-byte key
+	MasterKeyFileEnvVar = "MCP_FUSION_MASTER_KEY_FILE" // Path to a file holding the key
+)
+
+// MasterKeySize is the required master key length in bytes (AES-256)
+const MasterKeySize = 32
+
+// encryptedRecordPrefix marks a stored value as an encrypted envelope. Plaintext
+// records are JSON objects and therefore always start with '{'.
+const encryptedRecordPrefix = "$mcpfenc1$"
+
+// Encryption errors
+var (
+	ErrMasterKeyRequired = errors.New("record is encrypted but no master key is configured")
+	ErrMasterKeyMismatch = errors.New("record was encrypted with a different master key")
+	ErrInvalidMasterKey  = errors.New("invalid master key")
+)
+
+// encryptedRecord is the envelope stored in place of a plaintext secret record.
+// The record is sealed with a random data key, and the data key is sealed with
+// the master key. Both ciphertexts carry their nonce as a prefix.
+type encryptedRecord struct {
+	KeyID      string `json:"kid"` // Identifies the master key that wrapped the data key
+	WrappedKey []byte `json:"wk"`  // Data key sealed with the master key
+	Ciphertext []byte `json:"ct"`  // Record sealed with the data key
+}
+
+// masterKey holds the key-encryption key used to wrap per-record data keys
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// newMasterKey validates key and prepares it for use
+func newMasterKey(key []byte) (*masterKey, error) {
+	if len(key) != MasterKeySize {
+		return nil, fmt.Errorf("%w: must be %d bytes, got %d", ErrInvalidMasterKey, MasterKeySize, len(key))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &masterKey{id: MasterKeyID(key), aead: aead}, nil
+}
+
+// MasterKeyID returns a short, non-secret identifier for a master key
+func MasterKeyID(key []byte) string {
+	sum := sha256.Sum256(append([]byte("mcpfusion-master-key-id:"), key...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParseMasterKey decodes a base64 or hex encoded master key
+func ParseMasterKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, fmt.Errorf("%w: key is empty", ErrInvalidMasterKey)
+	}
+
+	if len(encoded) == hex.EncodedLen(MasterKeySize) {
+		if key, err := hex.DecodeString(encoded); err == nil {
+			return key, nil
+		}
+	}
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := encoding.DecodeString(encoded); err == nil && len(key) == MasterKeySize {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: expected %d bytes encoded as base64 or hex", ErrInvalidMasterKey, MasterKeySize)
+}
+
+// ReadMasterKeyFile reads a master key from a file. The file may hold the key
+// base64 or hex encoded, or as exactly MasterKeySize raw bytes.
+func ReadMasterKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key file %s: %w", path, err)
+	}
+	if len(data) == MasterKeySize {
+		return data, nil
+	}
+	key, err := ParseMasterKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("master key file %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// LoadMasterKey returns the master key configured through MCP_FUSION_MASTER_KEY
+// or MCP_FUSION_MASTER_KEY_FILE. The environment variable takes precedence.
+// Returns nil without error when neither is set.
+func LoadMasterKey() ([]byte, error) {
+	if encoded := os.Getenv(MasterKeyEnvVar); encoded != "" {
+		key, err := ParseMasterKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", MasterKeyEnvVar, err)
+		}
+		return key, nil
+	}
+	if path := os.Getenv(MasterKeyFileEnvVar); path != "" {
+		return ReadMasterKeyFile(path)
+	}
+	return nil, nil
+}
+
+// newAEAD returns an AES-GCM cipher for key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with aead, returning the nonce followed by the ciphertext
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts a nonce-prefixed ciphertext produced by seal
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrCorruptedData
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decryption failed", ErrCorruptedData)
+	}
+	return plaintext, nil
+}
+
+// recordAAD binds an encrypted record to its location so that a ciphertext
+// copied to another tenant or service fails to decrypt
+func recordAAD(bucket, tenantHash, serviceName string) []byte {
+	return []byte(bucket + "/" + tenantHash + "/" + serviceName)
+}
+
+// isEncryptedRecord reports whether a stored value is an encrypted envelope
+func isEncryptedRecord(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedRecordPrefix))
+}
+
+// currentMasterKey returns the master key in use, or nil when encryption is disabled
+func (d *DB) currentMasterKey() *masterKey {
+	d.keyMutex.RLock()
+	defer d.keyMutex.RUnlock()
+	return d.masterKey
+}
+
+// decryptionKeys returns the keys that may have wrapped a record visible to a
+// transaction: the current master key and, after a rotation, the retired key.
+func (d *DB) decryptionKeys() []*masterKey {
+	d.keyMutex.RLock()
+	defer d.keyMutex.RUnlock()
+	var keys []*masterKey
+	if d.masterKey != nil {
+		keys = append(keys, d.masterKey)
+	}
+	if d.retiredKey != nil {
+		keys = append(keys, d.retiredKey)
+	}
+	return keys
+}
+
+// EncryptionEnabled reports whether secrets are encrypted when written
+func (d *DB) EncryptionEnabled() bool {
+	return d.currentMasterKey() != nil
+}
+
+// sealRecord encrypts a marshalled record under a fresh data key
+func sealRecord(key *masterKey, aad, plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, MasterKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(dataAEAD, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := seal(key.aead, dataKey, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := json.Marshal(&encryptedRecord{KeyID: key.id, WrappedKey: wrappedKey, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(encryptedRecordPrefix), envelope...), nil
+}
+
+// parseEncryptedRecord decodes the envelope of an encrypted record
+func parseEncryptedRecord(data []byte) (*encryptedRecord, error) {
+	var record encryptedRecord
+	if err := json.Unmarshal(data[len(encryptedRecordPrefix):], &record); err != nil {
+		return nil, fmt.Errorf("%w: invalid encryption envelope: %v", ErrCorruptedData, err)
+	}
+	return &record, nil
+}
+
+// unwrapDataKey recovers the data key of an encrypted record using whichever of
+// keys wrapped it
+func unwrapDataKey(keys []*masterKey, record *encryptedRecord, aad []byte) ([]byte, error) {
+	if len(keys) == 0 {
+		return nil, ErrMasterKeyRequired
+	}
+	for _, key := range keys {
+		if record.KeyID == key.id {
+			return open(key.aead, record.WrappedKey, aad)
+		}
+	}
+	return nil, fmt.Errorf("%w (record key %s, configured key %s)", ErrMasterKeyMismatch, record.KeyID, keys[0].id)
+}
+
+// openRecord returns the plaintext of a stored record, decrypting it if needed.
+// Plaintext records written before encryption was enabled are returned as is.
+func openRecord(keys []*masterKey, aad, data []byte) ([]byte, error) {
+	if !isEncryptedRecord(data) {
+		return data, nil
+	}
+
+	record, err := parseEncryptedRecord(data)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := unwrapDataKey(keys, record, aad)
+	if err != nil {
+		return nil, err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataAEAD, record.Ciphertext, aad)
+}
+
+// encodeSecret marshals a secret record and encrypts it when a master key is configured
+func (d *DB) encodeSecret(bucket, tenantHash, serviceName string, value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	key := d.currentMasterKey()
+	if key == nil {
+		return data, nil
+	}
+	return sealRecord(key, recordAAD(bucket, tenantHash, serviceName), data)
+}
+
+// decodeSecret decrypts a stored secret record if needed and unmarshals it into value
+func (d *DB) decodeSecret(bucket, tenantHash, serviceName string, data []byte, value interface{}) error {
+	plaintext, err := openRecord(d.decryptionKeys(), recordAAD(bucket, tenantHash, serviceName), data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, value)
+}
+
+// secretBuckets lists the per-tenant buckets whose records are encrypted at rest
+var secretBuckets = []string{
+	internal.BucketOAuthTokens,
+	internal.BucketServiceCredentials,
+}
+
+// forEachSecretRecord calls fn for every record in the secret buckets of every
+// tenant. The returned value, if non-nil, replaces the stored record. Updates are
+// collected and applied after iteration, as bbolt does not allow modifying a
+// bucket while iterating over it.
+func forEachSecretRecord(tx *bbolt.Tx, fn func(bucket, tenantHash, serviceName string, value []byte) ([]byte, error)) error {
+	tenantsBucket := tx.Bucket([]byte(internal.BucketTenants))
+	if tenantsBucket == nil {
+		return fmt.Errorf("tenants bucket not found")
+	}
+
+	var tenantHashes []string
+	if err := tenantsBucket.ForEach(func(k, v []byte) error {
+		if v == nil { // Only nested buckets are tenants
+			tenantHashes = append(tenantHashes, string(k))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, tenantHash := range tenantHashes {
+		tenantBucket := tenantsBucket.Bucket([]byte(tenantHash))
+		for _, bucketName := range secretBuckets {
+			bucket := tenantBucket.Bucket([]byte(bucketName))
+			if bucket == nil {
+				continue
+			}
+
+			updates := make(map[string][]byte)
+			if err := bucket.ForEach(func(k, v []byte) error {
+				updated, err := fn(bucketName, tenantHash, string(k), v)
+				if err != nil {
+					return fmt.Errorf("%s/%s/%s: %w", bucketName, tenantHash[:min(len(tenantHash), 12)], string(k), err)
+				}
+				if updated != nil {
+					updates[string(k)] = updated
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			for serviceName, value := range updates {
+				if err := bucket.Put([]byte(serviceName), value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// EncryptExistingSecrets encrypts every plaintext OAuth token and service
+// credential record with the configured master key. Records that are already
+// encrypted are left unchanged, so the migration can safely be run again.
+// All records are converted in a single transaction. Returns the number of
+// records encrypted.
+func (d *DB) EncryptExistingSecrets() (int, error) {
+	if err := d.checkClosed(); err != nil {
+		return 0, err
+	}
+
+	key := d.currentMasterKey()
+	if key == nil {
+		return 0, NewDatabaseError("encrypt_secrets", fmt.Errorf("no master key configured (set %s or %s)", MasterKeyEnvVar, MasterKeyFileEnvVar))
+	}
+
+	count := 0
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		return forEachSecretRecord(tx, func(bucket, tenantHash, serviceName string, value []byte) ([]byte, error) {
+			if isEncryptedRecord(value) {
+				return nil, nil
+			}
+			sealed, err := sealRecord(key, recordAAD(bucket, tenantHash, serviceName), value)
+			if err != nil {
+				return nil, err
+			}
+			count++
+			return sealed, nil
+		})
+	})
+	if err != nil {
+		return 0, NewDatabaseError("encrypt_secrets", err)
+	}
+
+	d.logger.Infof("Encrypted %d secret records with master key %s", count, key.id)
+	return count, nil
+}
+
+// RotateMasterKey re-wraps the data key of every encrypted record with newKey
+// and makes newKey the active master key. Record ciphertexts are not changed.
+// Plaintext records are encrypted under newKey at the same time. All records
+// are converted in a single transaction, so a failure leaves the database
+// unchanged. Returns the number of records rewritten.
+func (d *DB) RotateMasterKey(newKey []byte) (int, error) {
+	if err := d.checkClosed(); err != nil {
+		return 0, err
+	}
+
+	next, err := newMasterKey(newKey)
+	if err != nil {
+		return 0, NewValidationError("master_key", nil, err.Error())
+	}
+
+	current := d.currentMasterKey()
+	if current == nil {
+		return 0, NewDatabaseError("rotate_master_key", fmt.Errorf("no current master key configured (set %s or %s)", MasterKeyEnvVar, MasterKeyFileEnvVar))
+	}
+	if current.id == next.id {
+		return 0, NewValidationError("master_key", nil, "new master key is the same as the current key")
+	}
+
+	// Writers look up the master key inside their own write transaction and
+	// bbolt serialises write transactions, so switching keys inside this
+	// transaction guarantees no record is sealed with the old key afterwards.
+	// The old key is kept as the retired key so that read transactions still
+	// holding a pre-rotation snapshot can decrypt it.
+	count := 0
+	err = d.db.Update(func(tx *bbolt.Tx) error {
+		if err := forEachSecretRecord(tx, func(bucket, tenantHash, serviceName string, value []byte) ([]byte, error) {
+			aad := recordAAD(bucket, tenantHash, serviceName)
+			count++
+
+			if !isEncryptedRecord(value) {
+				return sealRecord(next, aad, value)
+			}
+
+			record, err := parseEncryptedRecord(value)
+			if err != nil {
+				return nil, err
+			}
+			dataKey, err := unwrapDataKey([]*masterKey{current}, record, aad)
+			if err != nil {
+				return nil, err
+			}
+			record.WrappedKey, err = seal(next.aead, dataKey, aad)
+			if err != nil {
+				return nil, err
+			}
+			record.KeyID = next.id
+
+			envelope, err := json.Marshal(record)
+			if err != nil {
+				return nil, err
+			}
+			return append([]byte(encryptedRecordPrefix), envelope...), nil
+		}); err != nil {
+			return err
+		}
+
+		d.keyMutex.Lock()
+		d.masterKey, d.retiredKey = next, current
+		d.keyMutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		// Restore the previous key if the commit failed after the switch
+		d.keyMutex.Lock()
+		if d.masterKey == next {
+			d.masterKey, d.retiredKey = current, nil
+		}
+		d.keyMutex.Unlock()
+		return 0, NewDatabaseError("rotate_master_key", err)
+	}
+
+	d.logger.Infof("Rotated master key %s -> %s (%d records re-wrapped)", current.id, next.id, count)
+	return count, nil
+}
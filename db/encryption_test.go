@@ -0,0 +1,256 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/db/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tenebris-tech/mlogger"
+	"go.etcd.io/bbolt"
+)
+
+// newTestMasterKey returns a random master key
+func newTestMasterKey(t *testing.T) []byte {
+	key := make([]byte, MasterKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+// openTestDB opens (or reopens) a database in dir with an optional master key
+func openTestDB(t *testing.T, dir string, key []byte) *DB {
+	opts := []Option{WithLogger(mlogger.NewMemoryLogger()), WithDataDir(dir)}
+	if key != nil {
+		opts = append(opts, WithMasterKey(key))
+	}
+	database, err := New(opts...)
+	require.NoError(t, err)
+	return database.(*DB)
+}
+
+// rawSecret returns the stored bytes of a secret record
+func rawSecret(t *testing.T, d *DB, bucket, tenantHash, serviceName string) []byte {
+	var value []byte
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(internal.BucketTenants)).Bucket([]byte(tenantHash)).Bucket([]byte(bucket))
+		value = append([]byte(nil), b.Get([]byte(serviceName))...)
+		return nil
+	})
+	require.NoError(t, err)
+	return value
+}
+
+func TestEncryption_RoundTripAndNoPlaintextOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	key := newTestMasterKey(t)
+	d := openTestDB(t, dir, key)
+	defer func() { _ = d.Close() }()
+
+	tenant := createTestTenant(t, d, "encrypted tenant")
+	require.NoError(t, d.StoreOAuthToken(tenant, "microsoft365", &OAuthTokenData{
+		AccessToken: "secret-access-token", RefreshToken: "secret-refresh-token", TokenType: "Bearer",
+	}))
+	require.NoError(t, d.StoreCredentials(tenant, "weather", &ServiceCredentials{
+		Type: CredentialTypeAPIKey, Data: map[string]interface{}{"api_key": "secret-api-key"},
+	}))
+
+	token, err := d.GetOAuthToken(tenant, "microsoft365")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-refresh-token", token.RefreshToken)
+
+	creds, err := d.GetCredentials(tenant, "weather")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-api-key", creds.Data["api_key"])
+
+	require.NoError(t, d.RefreshOAuthToken(tenant, "microsoft365", "new-access-token", nil))
+	tokens, err := d.ListOAuthTokens(tenant)
+	require.NoError(t, err)
+	assert.Equal(t, "new-access-token", tokens["microsoft365"].AccessToken)
+
+	assert.True(t, isEncryptedRecord(rawSecret(t, d, internal.BucketOAuthTokens, tenant, "microsoft365")))
+
+	// Neither the database file nor a backup may contain the secrets in the clear
+	backupPath := filepath.Join(dir, "backup.db")
+	require.NoError(t, d.Backup(backupPath))
+	require.NoError(t, d.Close())
+	for _, path := range []string{filepath.Join(dir, "mcpfusion.db"), backupPath} {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		for _, secret := range []string{"secret-refresh-token", "new-access-token", "secret-api-key"} {
+			assert.False(t, bytes.Contains(data, []byte(secret)), "%s contains %q", path, secret)
+		}
+	}
+}
+
+func TestEncryption_WrongOrMissingKey(t *testing.T) {
+	dir := t.TempDir()
+	d := openTestDB(t, dir, newTestMasterKey(t))
+	tenant := createTestTenant(t, d, "tenant")
+	require.NoError(t, d.StoreOAuthToken(tenant, "google", &OAuthTokenData{AccessToken: "at", TokenType: "Bearer"}))
+	require.NoError(t, d.Close())
+
+	d = openTestDB(t, dir, nil)
+	_, err := d.GetOAuthToken(tenant, "google")
+	assert.ErrorIs(t, err, ErrMasterKeyRequired)
+	require.NoError(t, d.Close())
+
+	d = openTestDB(t, dir, newTestMasterKey(t))
+	defer func() { _ = d.Close() }()
+	_, err = d.GetOAuthToken(tenant, "google")
+	assert.ErrorIs(t, err, ErrMasterKeyMismatch)
+}
+
+func TestEncryption_RecordBoundToLocation(t *testing.T) {
+	d := openTestDB(t, t.TempDir(), newTestMasterKey(t))
+	defer func() { _ = d.Close() }()
+
+	tenantA := createTestTenant(t, d, "a")
+	tenantB := createTestTenant(t, d, "b")
+	require.NoError(t, d.StoreOAuthToken(tenantA, "google", &OAuthTokenData{AccessToken: "a-token", TokenType: "Bearer"}))
+	require.NoError(t, d.StoreOAuthToken(tenantB, "google", &OAuthTokenData{AccessToken: "b-token", TokenType: "Bearer"}))
+
+	// Copy tenant A's ciphertext over tenant B's record
+	stolen := rawSecret(t, d, internal.BucketOAuthTokens, tenantA, "google")
+	require.NoError(t, d.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(internal.BucketTenants)).Bucket([]byte(tenantB)).Bucket([]byte(internal.BucketOAuthTokens))
+		return b.Put([]byte("google"), stolen)
+	}))
+
+	_, err := d.GetOAuthToken(tenantB, "google")
+	assert.ErrorIs(t, err, ErrCorruptedData)
+}
+
+func TestEncryption_MigrateExistingRecords(t *testing.T) {
+	dir := t.TempDir()
+	d := openTestDB(t, dir, nil)
+	tenant := createTestTenant(t, d, "legacy tenant")
+	require.NoError(t, d.StoreOAuthToken(tenant, "google", &OAuthTokenData{AccessToken: "legacy", TokenType: "Bearer"}))
+	require.NoError(t, d.StoreCredentials(tenant, "weather", &ServiceCredentials{
+		Type: CredentialTypeAPIKey, Data: map[string]interface{}{"api_key": "k"},
+	}))
+	_, err := d.EncryptExistingSecrets()
+	assert.Error(t, err, "migration requires a master key")
+	require.NoError(t, d.Close())
+
+	d = openTestDB(t, dir, newTestMasterKey(t))
+	defer func() { _ = d.Close() }()
+
+	// Plaintext records remain readable before migration
+	token, err := d.GetOAuthToken(tenant, "google")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", token.AccessToken)
+
+	count, err := d.EncryptExistingSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.True(t, isEncryptedRecord(rawSecret(t, d, internal.BucketServiceCredentials, tenant, "weather")))
+
+	count, err = d.EncryptExistingSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "migration should be idempotent")
+
+	creds, err := d.GetCredentials(tenant, "weather")
+	require.NoError(t, err)
+	assert.Equal(t, "k", creds.Data["api_key"])
+}
+
+func TestEncryption_RotateMasterKey(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := newTestMasterKey(t)
+	newKey := newTestMasterKey(t)
+
+	d := openTestDB(t, dir, oldKey)
+	tenant := createTestTenant(t, d, "tenant")
+	require.NoError(t, d.StoreOAuthToken(tenant, "google", &OAuthTokenData{AccessToken: "at", RefreshToken: "rt", TokenType: "Bearer"}))
+	before := rawSecret(t, d, internal.BucketOAuthTokens, tenant, "google")
+
+	_, err := d.RotateMasterKey(oldKey)
+	assert.Error(t, err, "rotating to the same key should fail")
+
+	count, err := d.RotateMasterKey(newKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	after := rawSecret(t, d, internal.BucketOAuthTokens, tenant, "google")
+	beforeRecord, err := parseEncryptedRecord(before)
+	require.NoError(t, err)
+	afterRecord, err := parseEncryptedRecord(after)
+	require.NoError(t, err)
+	assert.Equal(t, beforeRecord.Ciphertext, afterRecord.Ciphertext, "rotation should only re-wrap the data key")
+	assert.Equal(t, MasterKeyID(newKey), afterRecord.KeyID)
+
+	// New writes use the new key immediately
+	require.NoError(t, d.StoreCredentials(tenant, "weather", &ServiceCredentials{
+		Type: CredentialTypeBearer, Data: map[string]interface{}{"token": "t"},
+	}))
+	require.NoError(t, d.Close())
+
+	d = openTestDB(t, dir, newKey)
+	defer func() { _ = d.Close() }()
+	token, err := d.GetOAuthToken(tenant, "google")
+	require.NoError(t, err)
+	assert.Equal(t, "rt", token.RefreshToken)
+	_, err = d.GetCredentials(tenant, "weather")
+	require.NoError(t, err)
+}
+
+func TestParseMasterKey(t *testing.T) {
+	key := make([]byte, MasterKeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	for name, encoded := range map[string]string{
+		"base64":     base64.StdEncoding.EncodeToString(key),
+		"raw base64": base64.RawURLEncoding.EncodeToString(key),
+		"hex":        hex.EncodeToString(key),
+		"whitespace": "  " + base64.StdEncoding.EncodeToString(key) + "\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := ParseMasterKey(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, key, parsed)
+		})
+	}
+
+	_, err := ParseMasterKey(base64.StdEncoding.EncodeToString(key[:16]))
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+	_, err = ParseMasterKey("")
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+
+	_, err = New(WithLogger(mlogger.NewMemoryLogger()), WithDataDir(t.TempDir()), WithMasterKey(key[:16]))
+	assert.Error(t, err)
+}
+
+func TestLoadMasterKey(t *testing.T) {
+	key := newTestMasterKey(t)
+
+	t.Setenv(MasterKeyEnvVar, "")
+	t.Setenv(MasterKeyFileEnvVar, "")
+	loaded, err := LoadMasterKey()
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	path := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600))
+	t.Setenv(MasterKeyFileEnvVar, path)
+	loaded, err = LoadMasterKey()
+	require.NoError(t, err)
+	assert.Equal(t, key, loaded)
+
+	t.Setenv(MasterKeyEnvVar, "not-a-key")
+	_, err = LoadMasterKey()
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+}
@@ -28,6 +28,8 @@ var (
 	ErrUserExists       = errors.New("user already exists")
 	ErrKeyAlreadyLinked = errors.New("API key already linked to a user")
 	ErrKnowledgeNotFound = errors.New("knowledge entry not found")
+	ErrCommandJobNotFound = errors.New("command job not found")
+	ErrOAuthClientNotFound = errors.New("OAuth client not found")
 )
 
 // DatabaseError represents a database-specific error with context
@@ -33,6 +33,19 @@ const (
 	BucketUserAPIKeys   = "api_keys"
 	BucketUserKnowledge = "knowledge"
 
+	// Root bucket for asynchronous command jobs, keyed by job ID
+	BucketCommandJobs = "command_jobs"
+
+	// Root buckets of the built-in OAuth authorization server. Clients are keyed
+	// by client ID; codes and tokens by the SHA-256 hash of their value.
+	BucketOAuthClients       = "oauth_clients"
+	BucketOAuthGrants        = "oauth_grants"
+	BucketOAuthAccessTokens  = "oauth_access_tokens"
+	BucketOAuthRefreshTokens = "oauth_refresh_tokens"
+
+	// Root bucket for the audit log of tool calls, keyed by timestamp and sequence
+	BucketAuditLog = "audit_log"
+
 	// System keys
 	KeySchemaVersion = "schema_version"
 	KeyMetadata      = "metadata"
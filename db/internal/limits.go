@@ -24,3 +24,24 @@ const LastUsedMaxBatch = 64
 // LastUsedFlushInterval is how often the worker flushes pending token
 // last-used timestamps to BoltDB in a single batched write transaction.
 const LastUsedFlushInterval = 30 * time.Second
+
+// AuditChannelSize is the number of audit entries buffered for the audit
+// worker. When the buffer is full, entries are written synchronously so that
+// none are lost.
+const AuditChannelSize = 1024
+
+// AuditMaxBatch is the maximum number of audit entries written in one transaction.
+const AuditMaxBatch = 256
+
+// AuditFlushInterval is how often the audit worker writes buffered entries.
+const AuditFlushInterval = time.Second
+
+// AuditPruneInterval is how often entries beyond the audit retention are deleted.
+const AuditPruneInterval = time.Hour
+
+// AuditDefaultQueryLimit is the number of audit entries returned when a query
+// does not set a limit, and AuditMaxQueryLimit the most that may be requested.
+const (
+	AuditDefaultQueryLimit = 100
+	AuditMaxQueryLimit     = 10000
+)
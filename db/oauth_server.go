@@ -0,0 +1,358 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db/internal"
+	"go.etcd.io/bbolt"
+)
+
+// Prefixes of the tokens issued by the built-in OAuth authorization server. They
+// tell these tokens apart from API tokens, which are plain hex strings.
+const (
+	OAuthAccessTokenPrefix  = "mcpfat_"
+	OAuthRefreshTokenPrefix = "mcpfrt_"
+)
+
+// CreateOAuthClient registers an OAuth client, assigning its client ID and
+// creation time. Confidential clients (any token endpoint auth method other
+// than "none") are also given a secret, which is returned once and stored
+// only as a hash.
+func (d *DB) CreateOAuthClient(client *OAuthClientData) (string, error) {
+	if err := d.checkClosed(); err != nil {
+		return "", err
+	}
+
+	if client == nil {
+		return "", NewValidationError("client", nil, "OAuth client cannot be nil")
+	}
+	if len(client.RedirectURIs) == 0 {
+		return "", NewValidationError("redirect_uris", client.RedirectURIs, "at least one redirect URI is required")
+	}
+
+	clientID, err := randomHex(16)
+	if err != nil {
+		return "", NewDatabaseError("create_oauth_client", err)
+	}
+
+	var secret string
+	if client.TokenEndpointAuthMethod != "none" {
+		if secret, err = randomHex(32); err != nil {
+			return "", NewDatabaseError("create_oauth_client", err)
+		}
+		client.ClientSecretHash = hashSecret(secret)
+	}
+	client.ClientID = clientID
+	client.CreatedAt = time.Now()
+
+	if err := d.putOAuthRecord("create_oauth_client", internal.BucketOAuthClients, clientID, client); err != nil {
+		return "", err
+	}
+
+	d.logger.Infof("Registered OAuth client %s (%s)", clientID, client.ClientName)
+	return secret, nil
+}
+
+// GetOAuthClient returns a registered OAuth client
+func (d *DB) GetOAuthClient(clientID string) (*OAuthClientData, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if clientID == "" {
+		return nil, NewValidationError("client_id", clientID, "client ID cannot be empty")
+	}
+
+	var client OAuthClientData
+	found, err := d.getOAuthRecord("get_oauth_client", internal.BucketOAuthClients, clientID, &client, false)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewDatabaseError("get_oauth_client", ErrOAuthClientNotFound)
+	}
+	return &client, nil
+}
+
+// VerifySecret returns true if secret is the client's secret
+func (client *OAuthClientData) VerifySecret(secret string) bool {
+	if client.ClientSecretHash == "" || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(client.ClientSecretHash), []byte(hashSecret(secret))) == 1
+}
+
+// CreateOAuthGrant stores an authorization grant and returns its code
+func (d *DB) CreateOAuthGrant(grant *OAuthGrantData) (string, error) {
+	if err := d.checkClosed(); err != nil {
+		return "", err
+	}
+
+	if grant == nil || grant.TenantHash == "" || grant.ClientID == "" {
+		return "", NewValidationError("grant", grant, "grant requires a tenant hash and client ID")
+	}
+
+	code, err := randomHex(32)
+	if err != nil {
+		return "", NewDatabaseError("create_oauth_grant", err)
+	}
+	grant.CreatedAt = time.Now()
+
+	if err := d.putOAuthRecord("create_oauth_grant", internal.BucketOAuthGrants, hashSecret(code), grant); err != nil {
+		return "", err
+	}
+
+	d.logger.Debugf("Created OAuth grant for client %s (tenant: %s)", grant.ClientID, safeHashPrefix(grant.TenantHash))
+	return code, nil
+}
+
+// ConsumeOAuthGrant returns the grant of an authorization code and deletes it,
+// so that each code can be exchanged only once
+func (d *DB) ConsumeOAuthGrant(code string) (*OAuthGrantData, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	var grant OAuthGrantData
+	found, err := d.getOAuthRecord("consume_oauth_grant", internal.BucketOAuthGrants, hashSecret(code), &grant, true)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewTokenError("oauth_grant", "", ErrTokenNotFound)
+	}
+	if time.Now().After(grant.ExpiresAt) {
+		return nil, NewTokenError("oauth_grant", "", fmt.Errorf("authorization code expired"))
+	}
+	return &grant, nil
+}
+
+// CreateOAuthAccessToken stores an access token and returns its value
+func (d *DB) CreateOAuthAccessToken(data *OAuthIssuedTokenData) (string, error) {
+	return d.createOAuthIssuedToken("create_oauth_access_token", internal.BucketOAuthAccessTokens, OAuthAccessTokenPrefix, data)
+}
+
+// ValidateOAuthAccessToken returns the data of an unexpired access token
+func (d *DB) ValidateOAuthAccessToken(token string) (*OAuthIssuedTokenData, error) {
+	return d.lookupOAuthIssuedToken("validate_oauth_access_token", internal.BucketOAuthAccessTokens, OAuthAccessTokenPrefix, token, false)
+}
+
+// CreateOAuthRefreshToken stores a refresh token and returns its value
+func (d *DB) CreateOAuthRefreshToken(data *OAuthIssuedTokenData) (string, error) {
+	return d.createOAuthIssuedToken("create_oauth_refresh_token", internal.BucketOAuthRefreshTokens, OAuthRefreshTokenPrefix, data)
+}
+
+// ConsumeOAuthRefreshToken returns the data of an unexpired refresh token and
+// deletes it. Refresh tokens are rotated: each use issues a new one.
+func (d *DB) ConsumeOAuthRefreshToken(token string) (*OAuthIssuedTokenData, error) {
+	return d.lookupOAuthIssuedToken("consume_oauth_refresh_token", internal.BucketOAuthRefreshTokens, OAuthRefreshTokenPrefix, token, true)
+}
+
+// RevokeOAuthToken deletes an access or refresh token. Unknown tokens are
+// ignored, as required for token revocation (RFC 7009).
+func (d *DB) RevokeOAuthToken(token string) error {
+	if err := d.checkClosed(); err != nil {
+		return err
+	}
+
+	var bucketName string
+	switch {
+	case strings.HasPrefix(token, OAuthAccessTokenPrefix):
+		bucketName = internal.BucketOAuthAccessTokens
+	case strings.HasPrefix(token, OAuthRefreshTokenPrefix):
+		bucketName = internal.BucketOAuthRefreshTokens
+	default:
+		return nil
+	}
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.Delete([]byte(hashSecret(token)))
+	})
+	if err != nil {
+		return NewDatabaseError("revoke_oauth_token", err)
+	}
+
+	d.logger.Debugf("Revoked OAuth token")
+	return nil
+}
+
+// CleanupExpiredOAuthServerData deletes expired authorization codes, access
+// tokens and refresh tokens
+func (d *DB) CleanupExpiredOAuthServerData() error {
+	if err := d.checkClosed(); err != nil {
+		return err
+	}
+
+	var deletedCount int
+	now := time.Now()
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		for _, bucketName := range []string{internal.BucketOAuthGrants, internal.BucketOAuthAccessTokens, internal.BucketOAuthRefreshTokens} {
+			bucket := tx.Bucket([]byte(bucketName))
+			if bucket == nil {
+				continue
+			}
+
+			// Collect expired keys first to avoid modifying the bucket during iteration
+			var expiredKeys [][]byte
+			err := bucket.ForEach(func(k, v []byte) error {
+				var record struct {
+					ExpiresAt time.Time `json:"expires_at"`
+				}
+				if err := json.Unmarshal(v, &record); err != nil || now.After(record.ExpiresAt) {
+					expiredKeys = append(expiredKeys, append([]byte{}, k...))
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to iterate %s: %w", bucketName, err)
+			}
+
+			for _, key := range expiredKeys {
+				if err := bucket.Delete(key); err != nil {
+					return fmt.Errorf("failed to delete expired entry from %s: %w", bucketName, err)
+				}
+				deletedCount++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return NewDatabaseError("cleanup_oauth_server_data", err)
+	}
+
+	if deletedCount > 0 {
+		d.logger.Debugf("Cleaned up %d expired OAuth codes and tokens", deletedCount)
+	}
+	return nil
+}
+
+// createOAuthIssuedToken generates a token with the given prefix and stores its data
+func (d *DB) createOAuthIssuedToken(op, bucketName, prefix string, data *OAuthIssuedTokenData) (string, error) {
+	if err := d.checkClosed(); err != nil {
+		return "", err
+	}
+
+	if data == nil || data.TenantHash == "" || data.ClientID == "" {
+		return "", NewValidationError("token", data, "token requires a tenant hash and client ID")
+	}
+
+	value, err := randomHex(32)
+	if err != nil {
+		return "", NewDatabaseError(op, err)
+	}
+	token := prefix + value
+	data.CreatedAt = time.Now()
+
+	if err := d.putOAuthRecord(op, bucketName, hashSecret(token), data); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// lookupOAuthIssuedToken returns the data of an unexpired token, deleting it when consume is set
+func (d *DB) lookupOAuthIssuedToken(op, bucketName, prefix, token string, consume bool) (*OAuthIssuedTokenData, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(token, prefix) {
+		return nil, NewTokenError("oauth_server", "", ErrInvalidToken)
+	}
+
+	var data OAuthIssuedTokenData
+	found, err := d.getOAuthRecord(op, bucketName, hashSecret(token), &data, consume)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, NewTokenError("oauth_server", "", ErrTokenNotFound)
+	}
+	if time.Now().After(data.ExpiresAt) {
+		return nil, NewTokenError("oauth_server", "", fmt.Errorf("token expired"))
+	}
+	return &data, nil
+}
+
+// putOAuthRecord stores a JSON record in one of the OAuth server buckets
+func (d *DB) putOAuthRecord(op, bucketName, key string, record interface{}) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return NewDatabaseError(op, fmt.Errorf("failed to marshal record: %w", err))
+	}
+
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketName))
+		if err != nil {
+			return NewDatabaseError(op, fmt.Errorf("failed to create %s bucket: %w", bucketName, err))
+		}
+		if err := bucket.Put([]byte(key), recordBytes); err != nil {
+			return NewDatabaseError(op, fmt.Errorf("failed to store record: %w", err))
+		}
+		return nil
+	})
+}
+
+// getOAuthRecord reads a JSON record from one of the OAuth server buckets,
+// deleting it in the same transaction when consume is set
+func (d *DB) getOAuthRecord(op, bucketName, key string, record interface{}, consume bool) (bool, error) {
+	var found bool
+
+	lookup := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+		recordBytes := bucket.Get([]byte(key))
+		if recordBytes == nil {
+			return nil
+		}
+		if err := json.Unmarshal(recordBytes, record); err != nil {
+			return NewDatabaseError(op, fmt.Errorf("failed to unmarshal record: %w", err))
+		}
+		found = true
+		if consume {
+			return bucket.Delete([]byte(key))
+		}
+		return nil
+	}
+
+	var err error
+	if consume {
+		err = d.db.Update(lookup)
+	} else {
+		err = d.db.View(lookup)
+	}
+	return found, err
+}
+
+// randomHex returns n cryptographically random bytes as a hex string
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashSecret returns the SHA-256 hash of a secret, under which it is stored
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,116 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package db
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthClient(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer os.RemoveAll(tempDir)
+	defer database.Close()
+
+	public := &OAuthClientData{ClientName: "desktop", RedirectURIs: []string{"http://127.0.0.1/callback"}, TokenEndpointAuthMethod: "none"}
+	secret, err := database.CreateOAuthClient(public)
+	require.NoError(t, err)
+	assert.Empty(t, secret, "public clients have no secret")
+	assert.NotEmpty(t, public.ClientID)
+
+	confidential := &OAuthClientData{RedirectURIs: []string{"https://app.example.com/cb"}, TokenEndpointAuthMethod: "client_secret_post"}
+	secret, err = database.CreateOAuthClient(confidential)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+
+	stored, err := database.GetOAuthClient(confidential.ClientID)
+	require.NoError(t, err)
+	assert.Equal(t, confidential.RedirectURIs, stored.RedirectURIs)
+	assert.NotContains(t, stored.ClientSecretHash, secret, "the secret is stored only as a hash")
+	assert.True(t, stored.VerifySecret(secret))
+	assert.False(t, stored.VerifySecret("wrong"))
+
+	_, err = database.GetOAuthClient("unknown")
+	assert.ErrorIs(t, err, ErrOAuthClientNotFound)
+
+	_, err = database.CreateOAuthClient(&OAuthClientData{TokenEndpointAuthMethod: "none"})
+	assert.Error(t, err, "a client needs a redirect URI")
+}
+
+func TestOAuthGrant_SingleUse(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer os.RemoveAll(tempDir)
+	defer database.Close()
+
+	tenantHash := createTestTenant(t, database, "oauth grant tenant")
+	code, err := database.CreateOAuthGrant(&OAuthGrantData{
+		TenantHash: tenantHash, ClientID: "client", CodeChallenge: "challenge", ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	grant, err := database.ConsumeOAuthGrant(code)
+	require.NoError(t, err)
+	assert.Equal(t, tenantHash, grant.TenantHash)
+	assert.Equal(t, "challenge", grant.CodeChallenge)
+
+	_, err = database.ConsumeOAuthGrant(code)
+	assert.ErrorIs(t, err, ErrTokenNotFound, "an authorization code can be used only once")
+
+	expired, err := database.CreateOAuthGrant(&OAuthGrantData{
+		TenantHash: tenantHash, ClientID: "client", ExpiresAt: time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+	_, err = database.ConsumeOAuthGrant(expired)
+	assert.Error(t, err)
+}
+
+func TestOAuthIssuedTokens(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer os.RemoveAll(tempDir)
+	defer database.Close()
+
+	tenantHash := createTestTenant(t, database, "oauth token tenant")
+	data := func(ttl time.Duration) *OAuthIssuedTokenData {
+		return &OAuthIssuedTokenData{TenantHash: tenantHash, ClientID: "client", ExpiresAt: time.Now().Add(ttl)}
+	}
+
+	access, err := database.CreateOAuthAccessToken(data(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(access, OAuthAccessTokenPrefix))
+
+	validated, err := database.ValidateOAuthAccessToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, tenantHash, validated.TenantHash)
+
+	refresh, err := database.CreateOAuthRefreshToken(data(time.Hour))
+	require.NoError(t, err)
+	_, err = database.ValidateOAuthAccessToken(refresh)
+	assert.Error(t, err, "a refresh token is not an access token")
+
+	_, err = database.ConsumeOAuthRefreshToken(refresh)
+	require.NoError(t, err)
+	_, err = database.ConsumeOAuthRefreshToken(refresh)
+	assert.Error(t, err, "refresh tokens are rotated")
+
+	require.NoError(t, database.RevokeOAuthToken(access))
+	_, err = database.ValidateOAuthAccessToken(access)
+	assert.Error(t, err)
+	assert.NoError(t, database.RevokeOAuthToken("unknown"))
+
+	// Expired tokens are rejected and removed by cleanup
+	expired, err := database.CreateOAuthAccessToken(data(-time.Second))
+	require.NoError(t, err)
+	_, err = database.ValidateOAuthAccessToken(expired)
+	assert.Error(t, err)
+	require.NoError(t, database.CleanupExpiredOAuthServerData())
+	_, err = database.ValidateOAuthAccessToken(expired)
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
@@ -6,7 +6,6 @@
 package db
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -62,10 +61,10 @@ func (d *DB) StoreOAuthToken(tenantHash, serviceName string, tokenData *OAuthTok
 			return NewDatabaseErrorWithContext("store_oauth_token", fmt.Errorf("failed to create oauth bucket: %w", err), tenantHash, serviceName)
 		}
 
-		// Marshal token data
-		tokenBytes, err := json.Marshal(tokenData)
+		// Encode (and encrypt, if enabled) token data
+		tokenBytes, err := d.encodeSecret(internal.BucketOAuthTokens, tenantHash, serviceName, tokenData)
 		if err != nil {
-			return NewDatabaseErrorWithContext("store_oauth_token", fmt.Errorf("failed to marshal token data: %w", err), tenantHash, serviceName)
+			return NewDatabaseErrorWithContext("store_oauth_token", fmt.Errorf("failed to encode token data: %w", err), tenantHash, serviceName)
 		}
 
 		// Store token data
@@ -125,10 +124,10 @@ func (d *DB) GetOAuthToken(tenantHash, serviceName string) (*OAuthTokenData, err
 			return NewDatabaseErrorWithContext("get_oauth_token", ErrServiceNotFound, tenantHash, serviceName)
 		}
 
-		// Unmarshal token data
+		// Decode token data
 		tokenData = &OAuthTokenData{}
-		if err := json.Unmarshal(tokenBytes, tokenData); err != nil {
-			return NewDatabaseErrorWithContext("get_oauth_token", fmt.Errorf("failed to unmarshal token data: %w", err), tenantHash, serviceName)
+		if err := d.decodeSecret(internal.BucketOAuthTokens, tenantHash, serviceName, tokenBytes, tokenData); err != nil {
+			return NewDatabaseErrorWithContext("get_oauth_token", fmt.Errorf("failed to decode token data: %w", err), tenantHash, serviceName)
 		}
 
 		return nil
@@ -234,8 +233,8 @@ func (d *DB) ListOAuthTokens(tenantHash string) (map[string]*OAuthTokenData, err
 			serviceName := string(k)
 
 			var tokenData OAuthTokenData
-			if err := json.Unmarshal(v, &tokenData); err != nil {
-				d.logger.Warningf("Failed to unmarshal OAuth token for service %s: %v", serviceName, err)
+			if err := d.decodeSecret(internal.BucketOAuthTokens, tenantHash, serviceName, v, &tokenData); err != nil {
+				d.logger.Warningf("Failed to decode OAuth token for service %s: %v", serviceName, err)
 				return nil // Continue iteration
 			}
 
@@ -297,8 +296,8 @@ func (d *DB) RefreshOAuthToken(tenantHash, serviceName, newAccessToken string, e
 		}
 
 		var tokenData OAuthTokenData
-		if err := json.Unmarshal(tokenBytes, &tokenData); err != nil {
-			return NewDatabaseErrorWithContext("refresh_oauth_token", fmt.Errorf("failed to unmarshal existing token data: %w", err), tenantHash, serviceName)
+		if err := d.decodeSecret(internal.BucketOAuthTokens, tenantHash, serviceName, tokenBytes, &tokenData); err != nil {
+			return NewDatabaseErrorWithContext("refresh_oauth_token", fmt.Errorf("failed to decode existing token data: %w", err), tenantHash, serviceName)
 		}
 
 		// Update token data
@@ -307,9 +306,9 @@ func (d *DB) RefreshOAuthToken(tenantHash, serviceName, newAccessToken string, e
 		tokenData.UpdatedAt = time.Now()
 
 		// Marshal and store updated data
-		updatedBytes, err := json.Marshal(&tokenData)
+		updatedBytes, err := d.encodeSecret(internal.BucketOAuthTokens, tenantHash, serviceName, &tokenData)
 		if err != nil {
-			return NewDatabaseErrorWithContext("refresh_oauth_token", fmt.Errorf("failed to marshal updated token data: %w", err), tenantHash, serviceName)
+			return NewDatabaseErrorWithContext("refresh_oauth_token", fmt.Errorf("failed to encode updated token data: %w", err), tenantHash, serviceName)
 		}
 
 		if err := oauthBucket.Put([]byte(serviceName), updatedBytes); err != nil {
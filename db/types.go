@@ -17,13 +17,61 @@ type AuthCodeData struct {
 	ExpiresAt  time.Time `json:"expires_at"`
 }
 
+// OAuthClientData represents a client registered with the built-in OAuth
+// authorization server through dynamic client registration
+type OAuthClientData struct {
+	ClientID                string    `json:"client_id"`
+	ClientSecretHash        string    `json:"client_secret_hash,omitempty"` // SHA-256 of the secret; empty for public clients
+	ClientName              string    `json:"client_name,omitempty"`
+	RedirectURIs            []string  `json:"redirect_uris"`
+	GrantTypes              []string  `json:"grant_types"`
+	TokenEndpointAuthMethod string    `json:"token_endpoint_auth_method"`
+	CreatedAt               time.Time `json:"created_at"`
+}
+
+// OAuthGrantData represents an authorization code issued by the built-in OAuth
+// authorization server that has not yet been exchanged for tokens
+type OAuthGrantData struct {
+	TenantHash    string    `json:"tenant_hash"` // Hash of the API token the user signed in with
+	ClientID      string    `json:"client_id"`
+	RedirectURI   string    `json:"redirect_uri"`   // Empty if the authorization request omitted it
+	CodeChallenge string    `json:"code_challenge"` // PKCE S256 challenge
+	Scope         string    `json:"scope,omitempty"`
+	Resource      string    `json:"resource,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// OAuthIssuedTokenData represents an access or refresh token issued by the
+// built-in OAuth authorization server
+type OAuthIssuedTokenData struct {
+	TenantHash string    `json:"tenant_hash"` // Hash of the API token the grant was made for
+	ClientID   string    `json:"client_id"`
+	Scope      string    `json:"scope,omitempty"`
+	Resource   string    `json:"resource,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
 // APITokenMetadata represents metadata for an API token
 type APITokenMetadata struct {
-	Hash        string    `json:"hash"`        // SHA-256 hash of the original token
-	CreatedAt   time.Time `json:"created_at"`  // When the token was created
-	LastUsed    time.Time `json:"last_used"`   // When the token was last used
-	Description string    `json:"description"` // Optional description
-	Prefix      string    `json:"prefix"`      // First 8 chars for identification
+	Hash        string    `json:"hash"`           // SHA-256 hash of the original token
+	CreatedAt   time.Time `json:"created_at"`     // When the token was created
+	LastUsed    time.Time `json:"last_used"`      // When the token was last used
+	Description string    `json:"description"`    // Optional description
+	Prefix      string    `json:"prefix"`         // First 8 chars for identification
+	Role        string    `json:"role,omitempty"` // APITokenRoleAdmin for admin API access; empty for regular tokens
+}
+
+// API token roles
+const (
+	APITokenRoleUser  = "user"
+	APITokenRoleAdmin = "admin"
+)
+
+// IsAdmin returns true if the token may use the admin API
+func (m *APITokenMetadata) IsAdmin() bool {
+	return m.Role == APITokenRoleAdmin
 }
 
 // OAuthTokenData represents stored OAuth token information
@@ -95,6 +143,23 @@ type UserMetadata struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// CommandJob is the persistent record of an asynchronous command execution.
+// Output is kept in files on disk; the record only holds their paths.
+type CommandJob struct {
+	ID         string     `json:"id"`
+	TenantHash string     `json:"tenant_hash"`
+	UserID     string     `json:"user_id,omitempty"`
+	Tool       string     `json:"tool"`    // MCP tool that started the job, e.g. "command_nmap"
+	Command    string     `json:"command"` // Executable and arguments, for display
+	Status     string     `json:"status"`  // running, completed, failed, timeout, cancelled, limit or interrupted
+	ExitCode   *int       `json:"exit_code,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	StdoutPath string     `json:"stdout_path"`
+	StderrPath string     `json:"stderr_path"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
 // KnowledgeEntry represents a piece of knowledge stored for a user
 type KnowledgeEntry struct {
 	Domain    string    `json:"domain"`     // e.g., "email", "calendar", "contacts", "general"
@@ -103,3 +168,34 @@ type KnowledgeEntry struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// AuditEntry records one tool call in the audit log. Arguments are not stored;
+// ArgumentsDigest identifies them without revealing their values.
+type AuditEntry struct {
+	ID              string    `json:"id"` // Assigned when the entry is stored; sorts by time
+	Timestamp       time.Time `json:"timestamp"`
+	UserID          string    `json:"user_id,omitempty"`
+	TenantHash      string    `json:"tenant_hash"`
+	Service         string    `json:"service,omitempty"`
+	Tool            string    `json:"tool"`
+	ArgumentKeys    []string  `json:"argument_keys,omitempty"`    // Names of the arguments, sorted
+	ArgumentsDigest string    `json:"arguments_digest,omitempty"` // SHA-256 of the redacted arguments
+	Status          string    `json:"status"`                     // ok or error
+	Error           string    `json:"error,omitempty"`
+	DurationMs      int64     `json:"duration_ms"`
+	Bytes           int       `json:"bytes"`
+	ClientIP        string    `json:"client_ip,omitempty"`
+	RequestID       string    `json:"request_id,omitempty"`
+	TraceID         string    `json:"trace_id,omitempty"`
+}
+
+// AuditFilter selects audit log entries. Empty fields match every entry;
+// Since is inclusive and Until exclusive.
+type AuditFilter struct {
+	UserID     string
+	TenantHash string
+	Tool       string
+	Since      time.Time
+	Until      time.Time
+	Limit      int // Defaults to 100
+}
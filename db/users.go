@@ -431,6 +431,47 @@ func (d *DB) GetUserByAPIKey(keyHash string) (string, error) {
 	return userID, nil
 }
 
+// ListUserAPIKeys returns the hashes of the API keys linked to a user
+func (d *DB) ListUserAPIKeys(userID string) ([]string, error) {
+	if err := d.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		return nil, NewValidationError("user_id", userID, "user ID cannot be empty")
+	}
+
+	keys := []string{}
+
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		usersBucket := tx.Bucket([]byte(internal.BucketUsers))
+		if usersBucket == nil {
+			return NewDatabaseError("list_user_api_keys", fmt.Errorf("users bucket not found"))
+		}
+
+		userBucket := usersBucket.Bucket([]byte(userID))
+		if userBucket == nil {
+			return ErrUserNotFound
+		}
+
+		apiKeysBucket := userBucket.Bucket([]byte(internal.BucketUserAPIKeys))
+		if apiKeysBucket == nil {
+			return nil
+		}
+
+		return apiKeysBucket.ForEach(func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
 // AutoMigrateKeys iterates all API tokens and creates users for any that are
 // not yet linked via key_to_user. This should be called on server startup to
 // ensure backward compatibility with tokens created before user management.
@@ -363,6 +363,63 @@ func TestGetUserByAPIKey_NotLinked(t *testing.T) {
 	assert.ErrorIs(t, err, ErrUserNotFound)
 }
 
+// TestListUserAPIKeys verifies that ListUserAPIKeys returns the keys linked to
+// a user and an empty list for a user without keys.
+func TestListUserAPIKeys(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer os.RemoveAll(tempDir)
+	defer database.Close()
+
+	user, err := database.CreateUser("Key owner")
+	require.NoError(t, err)
+
+	keys, err := database.ListUserAPIKeys(user.UserID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, hash1, err := database.AddAPIToken("first token")
+	require.NoError(t, err)
+	_, hash2, err := database.AddAPIToken("second token")
+	require.NoError(t, err)
+	require.NoError(t, database.LinkAPIKey(user.UserID, hash1))
+	require.NoError(t, database.LinkAPIKey(user.UserID, hash2))
+
+	keys, err = database.ListUserAPIKeys(user.UserID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{hash1, hash2}, keys)
+
+	_, err = database.ListUserAPIKeys("00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+// TestSetAPITokenRole verifies that SetAPITokenRole grants and removes the
+// admin role and rejects unknown roles and tokens.
+func TestSetAPITokenRole(t *testing.T) {
+	database, tempDir, _ := setupTestDB(t)
+	defer os.RemoveAll(tempDir)
+	defer database.Close()
+
+	_, hash, err := database.AddAPIToken("role token")
+	require.NoError(t, err)
+
+	metadata, err := database.GetAPITokenMetadata(hash)
+	require.NoError(t, err)
+	assert.False(t, metadata.IsAdmin(), "new tokens are not admins")
+
+	require.NoError(t, database.SetAPITokenRole(hash, APITokenRoleAdmin))
+	metadata, err = database.GetAPITokenMetadata(hash)
+	require.NoError(t, err)
+	assert.True(t, metadata.IsAdmin())
+
+	require.NoError(t, database.SetAPITokenRole(hash, APITokenRoleUser))
+	metadata, err = database.GetAPITokenMetadata(hash)
+	require.NoError(t, err)
+	assert.False(t, metadata.IsAdmin())
+
+	assert.True(t, IsValidationError(database.SetAPITokenRole(hash, "owner")))
+	assert.True(t, IsNotFound(database.SetAPITokenRole(strings.Repeat("0", 64), APITokenRoleAdmin)))
+}
+
 // TestAutoMigrateKeys creates API tokens, calls AutoMigrateKeys, and verifies
 // that users were created and keys were linked.
 func TestAutoMigrateKeys(t *testing.T) {
@@ -92,7 +92,7 @@ func (f *Fusion) createAuthSetupHandler(serviceName string, authType AuthType) g
 		}
 
 		// Look up the service config for the display name
-		service := f.config.Services[serviceName]
+		service := f.GetConfig().Services[serviceName]
 		if service == nil {
 			return "", fmt.Errorf("service %s not found in configuration", serviceName)
 		}
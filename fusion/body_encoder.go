@@ -17,12 +17,21 @@ type BodyEncoder interface {
 	Encode(params map[string]interface{}) (string, error)
 }
 
-// bodyEncoders is the registry of available body encoders. Read-only after init.
+// BodyEncoderFunc adapts an ordinary function to the BodyEncoder interface.
+type BodyEncoderFunc func(params map[string]interface{}) (string, error)
+
+// Encode calls f(params).
+func (f BodyEncoderFunc) Encode(params map[string]interface{}) (string, error) {
+	return f(params)
+}
+
+// bodyEncoders holds the built-in body encoders. Read-only after init; each
+// Fusion instance starts from a copy and adds encoders passed to WithBodyEncoder.
 var bodyEncoders = map[string]BodyEncoder{
 	"rfc2822_base64url": &RFC2822Base64URLEncoder{},
 }
 
-// GetBodyEncoder returns the encoder registered under the given name.
+// GetBodyEncoder returns the built-in encoder registered under the given name.
 func GetBodyEncoder(name string) (BodyEncoder, bool) {
 	enc, ok := bodyEncoders[name]
 	return enc, ok
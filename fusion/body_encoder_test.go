@@ -7,11 +7,15 @@ package fusion
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tenebris-tech/mlogger"
 )
 
 func TestGetBodyEncoder_Known(t *testing.T) {
@@ -26,6 +30,54 @@ func TestGetBodyEncoder_Unknown(t *testing.T) {
 	assert.Nil(t, enc)
 }
 
+func TestWithBodyEncoder_Invalid(t *testing.T) {
+	upper := BodyEncoderFunc(func(params map[string]interface{}) (string, error) {
+		return strings.ToUpper(params["text"].(string)), nil
+	})
+	assert.Panics(t, func() { WithBodyEncoder("", upper) })
+	assert.Panics(t, func() { WithBodyEncoder("test_nil", nil) })
+}
+
+func TestWithBodyEncoder_EndpointEncoding(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	reverse := BodyEncoderFunc(func(params map[string]interface{}) (string, error) {
+		runes := []rune(params["text"].(string))
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	})
+	endpoint := EndpointConfig{
+		ID: "send", Name: "Send", Method: http.MethodPost, Path: "/send",
+		Parameters:  []ParameterConfig{{Name: "text", Type: ParameterTypeString, Required: true, Location: ParameterLocationBody}},
+		RequestBody: &RequestBodyConfig{Encoding: "test_reverse", WrapperPath: "payload.data"},
+		Response:    ResponseConfig{Type: ResponseTypeJSON},
+	}
+	config := &Config{Services: map[string]*ServiceConfig{
+		"svc": {ServiceKey: "svc", Name: "svc", BaseURL: server.URL, Auth: AuthConfig{Type: AuthTypeNone}, Endpoints: []EndpointConfig{endpoint}},
+	}}
+
+	f := New(WithBodyEncoder("test_reverse", reverse), WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+	require.NoError(t, endpoint.Validate())
+
+	_, err := findTool(t, f.RegisterTools(), "svc_send").Handler(withTenant("tenant-a", map[string]interface{}{"text": "abc"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"payload": map[string]interface{}{"data": "cba"}}, received)
+
+	// Encoders belong to the instance that added them
+	_, ok := GetBodyEncoder("test_reverse")
+	assert.False(t, ok)
+	other := New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+	assert.Empty(t, other.RegisterTools())
+}
+
 func TestRFC2822Base64URLEncoder_BasicMessage(t *testing.T) {
 	enc := &RFC2822Base64URLEncoder{}
 	params := map[string]interface{}{
@@ -6,9 +6,11 @@
 package fusion
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -16,6 +18,10 @@ import (
 	"github.com/PivotLLM/MCPFusion/global"
 )
 
+// outputWaitDelay is how long output is read after a command exits, on top of
+// its kill grace period
+const outputWaitDelay = 3 * time.Second
+
 // CommandExecutor handles low-level command execution
 type CommandExecutor struct {
 	logger global.Logger
@@ -41,19 +47,44 @@ type ExecutionConfig struct {
 	CaptureStderr    bool
 	UseShell         bool
 	ShellInterpreter string
+	Sandbox          *CommandSandboxConfig // Resource limits and privileges (nil = unconfined)
+	Stdout           io.Writer             // Receives stdout as it is produced, in addition to any capture
+	Stderr           io.Writer             // Receives stderr as it is produced, in addition to any capture
+	MaxCaptureBytes  int                   // Keep only the last N bytes of each captured stream (0 = unlimited)
 }
 
+// TerminationReason describes why a command was stopped before it exited on its own
+type TerminationReason string
+
+const (
+	TerminationNone      TerminationReason = ""
+	TerminationTimeout   TerminationReason = "timeout"
+	TerminationCancelled TerminationReason = "cancelled"
+	TerminationLimit     TerminationReason = "limit"
+)
+
 // ExecutionResult holds the command execution result
 type ExecutionResult struct {
-	ExitCode int
-	Stdout   string
-	Stderr   string
-	Duration time.Duration
-	TimedOut bool
-	Error    error
+	ExitCode          int
+	Stdout            string
+	Stderr            string
+	Duration          time.Duration
+	TimedOut          bool
+	TerminationReason TerminationReason // Why the command was stopped, if it was
+	ForceKilled       bool              // SIGKILL was needed after the grace period
+	Signal            string            // Signal that terminated the process, if any
+	LimitExceeded     string            // Sandbox limit that stopped the command (LimitCPU, LimitMemory, LimitOutput)
+	StdoutOmitted     int64             // Bytes dropped from the start of Stdout by MaxCaptureBytes
+	StderrOmitted     int64             // Bytes dropped from the start of Stderr by MaxCaptureBytes
+	Error             error
 }
 
-// Execute runs a command with the given configuration
+// Execute runs a command with the given configuration.
+//
+// The command runs in its own process group. On timeout or cancellation the
+// whole group is sent SIGTERM, and SIGKILL if it is still running after
+// config.KillGracePeriod seconds, so that grandchildren (shell pipelines,
+// scripts started by the command) do not outlive it.
 func (e *CommandExecutor) Execute(ctx context.Context, config ExecutionConfig) ExecutionResult {
 	startTime := time.Now()
 	result := ExecutionResult{}
@@ -66,10 +97,10 @@ func (e *CommandExecutor) Execute(ctx context.Context, config ExecutionConfig) E
 	var cancel context.CancelFunc
 	if config.Timeout > 0 {
 		cmdCtx, cancel = context.WithTimeout(context.Background(), time.Duration(config.Timeout)*time.Second)
-		defer cancel()
 	} else {
-		cmdCtx = context.Background()
+		cmdCtx, cancel = context.WithCancel(context.Background())
 	}
+	defer cancel()
 
 	// Monitor parent context cancellation in background (for server shutdown)
 	// This ensures we respect graceful shutdown even though command uses independent context
@@ -79,9 +110,7 @@ func (e *CommandExecutor) Execute(ctx context.Context, config ExecutionConfig) E
 		case <-ctx.Done():
 			// Parent context cancelled (server shutdown or client disconnect)
 			// Cancel command context to stop execution
-			if cancel != nil {
-				cancel()
-			}
+			cancel()
 		case <-parentDone:
 			// Command completed normally
 			return
@@ -108,54 +137,113 @@ func (e *CommandExecutor) Execute(ctx context.Context, config ExecutionConfig) E
 			fullCommand = config.Executable
 		}
 
-		cmd = exec.CommandContext(cmdCtx, shellInterpreter, "-c", fullCommand)
+		cmd = exec.Command(shellInterpreter, "-c", fullCommand)
 	} else {
 		// Direct execution
-		cmd = exec.CommandContext(cmdCtx, config.Executable, config.Args...)
+		cmd = exec.Command(config.Executable, config.Args...)
 	}
 
+	// Start the command in its own process group so it can be terminated as a whole
+	setProcessGroup(cmd)
+
+	// Stop reading output shortly after the command exits. A background process
+	// that left the process group (e.g. through setsid) and kept stdout or
+	// stderr open would otherwise block Wait until it exits.
+	cmd.WaitDelay = time.Duration(max(config.KillGracePeriod, 0))*time.Second + outputWaitDelay
+
 	// Set working directory if specified
 	if config.Cwd != "" {
 		cmd.Dir = config.Cwd
 	}
 
-	// Set environment variables
-	if len(config.Env) > 0 {
+	// Set environment variables before the sandbox adds its own. A sandboxed
+	// command only ever receives the filtered environment, even when it is empty.
+	if config.Sandbox != nil {
+		env := config.Env
+		if env == nil {
+			env = config.Sandbox.filterEnv(os.Environ(), nil)
+		}
+		cmd.Env = append([]string{}, env...)
+	} else if len(config.Env) > 0 {
 		cmd.Env = config.Env
 	}
 
+	// Apply the command group's sandbox before anything is started
+	if config.Sandbox != nil {
+		dir, err := config.Sandbox.resolveWorkingDir(config.Cwd)
+		if err == nil {
+			cmd.Dir = dir
+			err = applySandbox(cmd, config.Sandbox)
+		}
+		if err != nil {
+			result.Error = fmt.Errorf("sandbox: %w", err)
+			result.ExitCode = -1
+			result.Duration = time.Since(startTime)
+			return result
+		}
+	}
+
 	// Set up stdin if provided
 	if config.Stdin != "" {
 		cmd.Stdin = strings.NewReader(config.Stdin)
 	}
 
-	// Set up stdout/stderr capture
-	var stdoutBuf, stderrBuf bytes.Buffer
+	// Set up stdout/stderr capture, sharing the sandbox output limit if any
+	stdoutBuf := &tailBuffer{max: config.MaxCaptureBytes}
+	stderrBuf := &tailBuffer{max: config.MaxCaptureBytes}
+	var limiter *outputLimiter
+	if config.Sandbox != nil && config.Sandbox.MaxOutputBytes > 0 {
+		limiter = newOutputLimiter(config.Sandbox.MaxOutputBytes)
+	}
 	if config.CaptureStdout {
-		cmd.Stdout = &stdoutBuf
+		cmd.Stdout = outputWriter(limiter, stdoutBuf, config.Stdout)
+	} else if config.Stdout != nil {
+		cmd.Stdout = outputWriter(limiter, config.Stdout)
 	}
 	if config.CaptureStderr {
-		cmd.Stderr = &stderrBuf
+		cmd.Stderr = outputWriter(limiter, stderrBuf, config.Stderr)
+	} else if config.Stderr != nil {
+		cmd.Stderr = outputWriter(limiter, config.Stderr)
 	}
 
 	// Execute command
-	err := cmd.Run()
+	err := e.run(cmdCtx, cmd, config, limiter, &result)
 	result.Duration = time.Since(startTime)
+	result.Signal = exitSignal(cmd.ProcessState)
+	if config.Sandbox != nil && result.TerminationReason == TerminationNone {
+		result.LimitExceeded = exceededLimit(cmd.ProcessState, config.Sandbox)
+	}
 
 	// Capture output
 	if config.CaptureStdout {
 		result.Stdout = stdoutBuf.String()
+		result.StdoutOmitted = stdoutBuf.dropped
 	}
 	if config.CaptureStderr {
 		result.Stderr = stderrBuf.String()
+		result.StderrOmitted = stderrBuf.dropped
 	}
 
-	// Check for timeout
-	if cmdCtx.Err() == context.DeadlineExceeded {
+	// Check for timeout or cancellation
+	switch result.TerminationReason {
+	case TerminationTimeout:
 		result.TimedOut = true
 		result.Error = fmt.Errorf("command timed out after %d seconds", config.Timeout)
 		result.ExitCode = -1
 		return result
+	case TerminationCancelled:
+		result.Error = fmt.Errorf("command cancelled")
+		result.ExitCode = -1
+		return result
+	case TerminationLimit:
+		result.Error = fmt.Errorf("command stopped: %s", config.Sandbox.describeLimit(result.LimitExceeded))
+		result.ExitCode = -1
+		return result
+	}
+	if result.LimitExceeded != "" {
+		result.Error = fmt.Errorf("command stopped: %s", config.Sandbox.describeLimit(result.LimitExceeded))
+		result.ExitCode = -1
+		return result
 	}
 
 	// Get exit code
@@ -173,6 +261,116 @@ func (e *CommandExecutor) Execute(ctx context.Context, config ExecutionConfig) E
 	return result
 }
 
+// tailBuffer is a capture buffer that keeps only the last max bytes written
+// to it. A max of 0 keeps everything.
+type tailBuffer struct {
+	buf     []byte
+	max     int
+	dropped int64 // Bytes discarded from the start
+}
+
+// Write appends p, discarding the oldest bytes beyond max
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.max; t.max > 0 && over > 0 {
+		t.dropped += int64(over)
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the retained output
+func (t *tailBuffer) String() string {
+	return string(t.buf)
+}
+
+// outputWriter combines the destinations of one output stream, sharing the
+// sandbox output limit if there is one. Nil writers are ignored.
+func outputWriter(limiter *outputLimiter, writers ...io.Writer) io.Writer {
+	var w io.Writer
+	var targets []io.Writer
+	for _, writer := range writers {
+		if writer != nil {
+			targets = append(targets, writer)
+		}
+	}
+	if len(targets) == 1 {
+		w = targets[0]
+	} else {
+		w = io.MultiWriter(targets...)
+	}
+	if limiter != nil {
+		return limiter.wrap(w)
+	}
+	return w
+}
+
+// run starts cmd and waits for it to exit. If ctx is done or the output limit
+// is exceeded first, the process group is terminated and the reason is
+// recorded in result.
+func (e *CommandExecutor) run(ctx context.Context, cmd *exec.Cmd, config ExecutionConfig, limiter *outputLimiter, result *ExecutionResult) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			// The command succeeded but something it started still holds its output
+			if e.logger != nil {
+				e.logger.Warningf("%s exited but its output was held open by another process; stopped reading after %v",
+					config.Executable, cmd.WaitDelay)
+			}
+			err = nil
+		}
+		done <- err
+	}()
+
+	var outputExceeded <-chan struct{}
+	if limiter != nil {
+		outputExceeded = limiter.exceeded
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		result.TerminationReason = TerminationCancelled
+		if ctx.Err() == context.DeadlineExceeded {
+			result.TerminationReason = TerminationTimeout
+		}
+	case <-outputExceeded:
+		result.TerminationReason = TerminationLimit
+		result.LimitExceeded = LimitOutput
+	}
+
+	gracePeriod := time.Duration(max(config.KillGracePeriod, 0)) * time.Second
+	if e.logger != nil {
+		e.logger.Infof("Terminating %s (pid %d, %s), grace period %v",
+			config.Executable, cmd.Process.Pid, result.TerminationReason, gracePeriod)
+	}
+
+	if gracePeriod > 0 {
+		if err := terminateProcessGroup(cmd); err != nil && e.logger != nil {
+			e.logger.Warningf("Failed to send SIGTERM to process group %d: %v", cmd.Process.Pid, err)
+		}
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case err := <-done:
+			return err
+		case <-timer.C:
+		}
+	}
+
+	result.ForceKilled = true
+	if err := killProcessGroup(cmd); err != nil && e.logger != nil {
+		e.logger.Warningf("Failed to send SIGKILL to process group %d: %v", cmd.Process.Pid, err)
+	}
+	return <-done
+}
+
 // FormatResponse formats the execution result as text
 func (e *CommandExecutor) FormatResponse(result ExecutionResult) string {
 	var sb strings.Builder
@@ -184,19 +382,36 @@ func (e *CommandExecutor) FormatResponse(result ExecutionResult) string {
 	// Status
 	if result.TimedOut {
 		sb.WriteString("Status: Timed Out\n")
+	} else if result.TerminationReason == TerminationCancelled {
+		sb.WriteString("Status: Cancelled\n")
+	} else if result.LimitExceeded != "" {
+		sb.WriteString("Status: Limit Exceeded\n")
 	} else if result.ExitCode == 0 {
 		sb.WriteString("Status: Success\n")
 	} else {
 		sb.WriteString("Status: Failed\n")
 	}
 
+	// How the command was terminated, if it was
+	if result.TerminationReason != TerminationNone {
+		if result.ForceKilled {
+			sb.WriteString("Terminated: SIGKILL\n")
+		} else {
+			sb.WriteString("Terminated: SIGTERM\n")
+		}
+	}
+
+	if result.Signal != "" && result.TerminationReason == TerminationNone {
+		sb.WriteString(fmt.Sprintf("Signal: %s\n", result.Signal))
+	}
+
 	// Error if present
-	if result.Error != nil && !result.TimedOut {
+	if result.Error != nil && !result.TimedOut && result.TerminationReason != TerminationCancelled {
 		sb.WriteString(fmt.Sprintf("Error: %v\n", result.Error))
 	}
 
 	// Always show stdout section
-	sb.WriteString("\n--- stdout ---\n")
+	sb.WriteString(outputHeader("stdout", result.Stdout, result.StdoutOmitted))
 	if result.Stdout != "" {
 		sb.WriteString(result.Stdout)
 		if !strings.HasSuffix(result.Stdout, "\n") {
@@ -207,7 +422,7 @@ func (e *CommandExecutor) FormatResponse(result ExecutionResult) string {
 	}
 
 	// Always show stderr section
-	sb.WriteString("\n--- stderr ---\n")
+	sb.WriteString(outputHeader("stderr", result.Stderr, result.StderrOmitted))
 	if result.Stderr != "" {
 		sb.WriteString(result.Stderr)
 		if !strings.HasSuffix(result.Stderr, "\n") {
@@ -219,3 +434,12 @@ func (e *CommandExecutor) FormatResponse(result ExecutionResult) string {
 
 	return sb.String()
 }
+
+// outputHeader returns the section header for a captured stream, noting any
+// output dropped by ExecutionConfig.MaxCaptureBytes
+func outputHeader(name, output string, omitted int64) string {
+	if omitted > 0 {
+		return fmt.Sprintf("\n--- %s (last %d bytes, %d earlier bytes omitted) ---\n", name, len(output), omitted)
+	}
+	return fmt.Sprintf("\n--- %s ---\n", name)
+}
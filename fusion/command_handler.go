@@ -11,6 +11,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/PivotLLM/MCPFusion/global"
 )
 
 // CommandHandler handles command execution for a specific command configuration
@@ -36,25 +38,48 @@ func (h *CommandHandler) Handle(ctx context.Context, args map[string]interface{}
 	// Extract control parameters
 	execConfig := h.buildExecutionConfig(args)
 
+	if h.command.Async {
+		return h.startJob(ctx, execConfig)
+	}
+
 	// Log execution
 	if h.fusion.logger != nil {
 		h.fusion.logger.Infof("Executing command %s_%s: %s %v",
 			h.commandGroup.Name, h.command.ID, execConfig.Executable, execConfig.Args)
 	}
 
+	// Stream output to the client while the command runs if it asked for
+	// progress, keeping only a tail of each stream for the final response
+	var streamer *progressStreamer
+	if notify, ok := ctx.Value(global.ProgressNotifierKey).(global.ProgressNotifier); ok && notify != nil {
+		streamer = newProgressStreamer(notify, h.fusion.logger)
+		if execConfig.CaptureStdout {
+			execConfig.Stdout = streamer.writer("")
+		}
+		if execConfig.CaptureStderr {
+			execConfig.Stderr = streamer.writer("[stderr] ")
+		}
+		execConfig.MaxCaptureBytes = progressTailBytes
+	}
+
 	// Execute command
 	result := h.executor.Execute(ctx, execConfig)
+	if streamer != nil {
+		streamer.Close()
+	}
 
 	// Log execution result
 	if h.fusion.logger != nil {
 		status := "success"
-		if result.TimedOut {
-			status = "timeout"
+		if result.LimitExceeded != "" {
+			status = "limit_" + result.LimitExceeded
+		} else if result.TerminationReason != TerminationNone {
+			status = string(result.TerminationReason)
 		} else if result.ExitCode != 0 {
 			status = "failed"
 		}
-		h.fusion.logger.Infof("Command %s_%s completed: status=%s exit_code=%d duration=%.2fs",
-			h.commandGroup.Name, h.command.ID, status, result.ExitCode, result.Duration.Seconds())
+		h.fusion.logger.Infof("Command %s_%s completed: status=%s exit_code=%d duration=%.2fs force_killed=%v",
+			h.commandGroup.Name, h.command.ID, status, result.ExitCode, result.Duration.Seconds(), result.ForceKilled)
 	}
 
 	// Return error on timeout so MCP client knows it's not a normal success
@@ -63,10 +88,40 @@ func (h *CommandHandler) Handle(ctx context.Context, args map[string]interface{}
 			fmt.Errorf("command timed out after %d seconds (configured timeout limit reached)", execConfig.Timeout)
 	}
 
+	if result.TerminationReason == TerminationCancelled {
+		return h.executor.FormatResponse(result), fmt.Errorf("command cancelled before completion")
+	}
+
+	// Report exceeded sandbox limits and sandbox setup failures as errors
+	if result.LimitExceeded != "" || (execConfig.Sandbox != nil && result.Error != nil) {
+		return h.executor.FormatResponse(result), result.Error
+	}
+
 	// Format and return response
 	return h.executor.FormatResponse(result), nil
 }
 
+// startJob runs the command as an asynchronous job owned by the calling tenant
+func (h *CommandHandler) startJob(ctx context.Context, execConfig ExecutionConfig) (string, error) {
+	if h.fusion.jobs == nil {
+		return "", fmt.Errorf("asynchronous commands require a database")
+	}
+	tenant, ok := ctx.Value(global.TenantContextKey).(*TenantContext)
+	if !ok || tenant == nil {
+		return "", fmt.Errorf("no tenant context found - authentication required")
+	}
+
+	job, err := h.fusion.jobs.Start(tenant, "command_"+h.command.ID, execConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to start job: %w", err)
+	}
+
+	return fmt.Sprintf("Job ID: %s\nStatus: %s\nCommand: %s\n\n"+
+		"The command is running in the background. Use job_status to check on it, "+
+		"job_output to read its output and job_cancel to stop it.\n",
+		job.ID, job.Status, job.Command), nil
+}
+
 // buildExecutionConfig constructs execution configuration from parameters
 func (h *CommandHandler) buildExecutionConfig(args map[string]interface{}) ExecutionConfig {
 	config := ExecutionConfig{
@@ -116,6 +171,19 @@ func (h *CommandHandler) buildExecutionConfig(args map[string]interface{}) Execu
 		config.Env = os.Environ()
 	}
 
+	// Apply the command group's sandbox. Named environment parameters are always
+	// passed; object parameters supplying arbitrary variables must match the allowlist.
+	if sandbox := h.commandGroup.Sandbox; sandbox != nil {
+		config.Sandbox = sandbox
+		var declared []string
+		for _, param := range h.command.Parameters {
+			if param.Location == ParameterLocationEnvironment && param.Type != ParameterTypeObject {
+				declared = append(declared, param.Name)
+			}
+		}
+		config.Env = sandbox.filterEnv(config.Env, declared)
+	}
+
 	return config
 }
 
@@ -7,8 +7,10 @@ package fusion
 
 import (
 	"context"
+	"os/exec"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCommandExecutor_SimpleCommand(t *testing.T) {
@@ -123,3 +125,102 @@ func TestCommandExecutor_Timeout(t *testing.T) {
 		t.Errorf("Expected exit code -1 for timeout, got %d", result.ExitCode)
 	}
 }
+
+func TestCommandExecutor_TimeoutKillsProcessGroup(t *testing.T) {
+	executor := NewCommandExecutor(nil)
+
+	// The backgrounded sleep inherits stdout, so Execute cannot return until
+	// the grandchild is gone as well as the shell
+	config := ExecutionConfig{
+		Executable:      "/bin/sh",
+		Args:            []string{"-c", "sleep 30 & wait"},
+		Timeout:         1,
+		KillGracePeriod: 5,
+		CaptureStdout:   true,
+	}
+
+	result := executor.Execute(context.Background(), config)
+
+	if result.TerminationReason != TerminationTimeout {
+		t.Errorf("Expected termination reason %q, got %q", TerminationTimeout, result.TerminationReason)
+	}
+	if result.ForceKilled {
+		t.Error("Expected SIGTERM to be sufficient")
+	}
+	if result.Duration > 5*time.Second {
+		t.Errorf("Expected grandchild to be terminated with the group, took %v", result.Duration)
+	}
+}
+
+func TestCommandExecutor_DetachedGrandchild(t *testing.T) {
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("setsid not available")
+	}
+	executor := NewCommandExecutor(nil)
+
+	// The grandchild leaves the process group but keeps stdout open
+	config := ExecutionConfig{
+		Executable:    "/bin/sh",
+		Args:          []string{"-c", "setsid sleep 30 & echo started"},
+		Timeout:       60,
+		CaptureStdout: true,
+	}
+
+	result := executor.Execute(context.Background(), config)
+
+	if result.Error != nil || result.ExitCode != 0 {
+		t.Errorf("Expected success, got exit code %d, error %v", result.ExitCode, result.Error)
+	}
+	if strings.TrimSpace(result.Stdout) != "started" {
+		t.Errorf("Expected output before the command exited, got %q", result.Stdout)
+	}
+	if result.Duration > outputWaitDelay+5*time.Second {
+		t.Errorf("Expected Execute to stop waiting for the grandchild, took %v", result.Duration)
+	}
+}
+
+func TestCommandExecutor_KillAfterGracePeriod(t *testing.T) {
+	executor := NewCommandExecutor(nil)
+
+	config := ExecutionConfig{
+		Executable:      "/bin/sh",
+		Args:            []string{"-c", "trap '' TERM; sleep 30"},
+		Timeout:         1,
+		KillGracePeriod: 1,
+	}
+
+	result := executor.Execute(context.Background(), config)
+
+	if !result.TimedOut || !result.ForceKilled {
+		t.Errorf("Expected timeout escalated to SIGKILL, got timedOut=%v forceKilled=%v", result.TimedOut, result.ForceKilled)
+	}
+	if result.Duration > 10*time.Second {
+		t.Errorf("Expected SIGKILL after the grace period, took %v", result.Duration)
+	}
+	if !strings.Contains(executor.FormatResponse(result), "Terminated: SIGKILL") {
+		t.Error("Expected response to report SIGKILL termination")
+	}
+}
+
+func TestCommandExecutor_Cancelled(t *testing.T) {
+	executor := NewCommandExecutor(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	result := executor.Execute(ctx, ExecutionConfig{
+		Executable:      "/bin/sleep",
+		Args:            []string{"30"},
+		KillGracePeriod: 2,
+	})
+
+	if result.TerminationReason != TerminationCancelled {
+		t.Errorf("Expected termination reason %q, got %q", TerminationCancelled, result.TerminationReason)
+	}
+	if result.TimedOut {
+		t.Error("Cancellation should not be reported as a timeout")
+	}
+	if result.ExitCode != -1 {
+		t.Errorf("Expected exit code -1 for cancellation, got %d", result.ExitCode)
+	}
+}
@@ -0,0 +1,268 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// defaultJobOutputBytes is the default size of a job_output window
+const defaultJobOutputBytes = 64 * 1024
+
+// createJobToolDefinitions returns the tools used to follow asynchronous command jobs
+func (f *Fusion) createJobToolDefinitions() []global.ToolDefinition {
+	jobIDParam := global.Parameter{
+		Name:        "job_id",
+		Description: "Job ID returned when the command was started",
+		Required:    true,
+		Type:        "string",
+	}
+	readOnly := &global.ToolHints{
+		ReadOnly:    global.BoolPtr(true),
+		Destructive: global.BoolPtr(false),
+		Idempotent:  global.BoolPtr(true),
+		OpenWorld:   global.BoolPtr(false),
+	}
+
+	return []global.ToolDefinition{
+		{
+			Name:        "job_status",
+			Description: "Get the status of an asynchronous command job, including its exit code once finished and the size of its output.",
+			Parameters:  []global.Parameter{jobIDParam},
+			Handler:     f.jobToolHandler(f.handleJobStatus),
+			Hints:       readOnly,
+		},
+		{
+			Name: "job_output",
+			Description: "Read the output of an asynchronous command job. Use offset to page through the output " +
+				"(pass the returned next offset to continue) or tail to get the last lines.",
+			Parameters: []global.Parameter{
+				jobIDParam,
+				{
+					Name:        "stream",
+					Description: "Output stream to read",
+					Type:        "string",
+					Default:     JobStreamStdout,
+					Enum:        []interface{}{JobStreamStdout, JobStreamStderr},
+				},
+				{
+					Name:        "offset",
+					Description: "Byte offset to start reading from",
+					Type:        "number",
+					Default:     0,
+				},
+				{
+					Name:        "tail",
+					Description: "Return only the last N lines instead of reading from offset",
+					Type:        "number",
+				},
+				{
+					Name:        "max_bytes",
+					Description: "Maximum number of bytes to return",
+					Type:        "number",
+					Default:     defaultJobOutputBytes,
+				},
+			},
+			Handler: f.jobToolHandler(f.handleJobOutput),
+			Hints:   readOnly,
+		},
+		{
+			Name:        "job_cancel",
+			Description: "Cancel a running asynchronous command job. Its processes are sent SIGTERM, then SIGKILL after the command's grace period.",
+			Parameters:  []global.Parameter{jobIDParam},
+			Handler:     f.jobToolHandler(f.handleJobCancel),
+			Hints: &global.ToolHints{
+				ReadOnly:    global.BoolPtr(false),
+				Destructive: global.BoolPtr(false),
+				Idempotent:  global.BoolPtr(true),
+				OpenWorld:   global.BoolPtr(false),
+			},
+		},
+		{
+			Name:        "job_list",
+			Description: "List your asynchronous command jobs, newest first.",
+			Parameters: []global.Parameter{
+				{
+					Name:        "status",
+					Description: "Only list jobs with this status",
+					Type:        "string",
+					Enum: []interface{}{JobStatusRunning, JobStatusCompleted, JobStatusFailed, JobStatusTimeout,
+						JobStatusCancelled, JobStatusLimit, JobStatusInterrupted},
+				},
+			},
+			Handler: f.jobToolHandler(f.handleJobList),
+			Hints:   readOnly,
+		},
+	}
+}
+
+// jobToolHandler wraps a job tool with tenant resolution. Jobs are only
+// visible to the tenant that started them.
+func (f *Fusion) jobToolHandler(handle func(tenant *TenantContext, args map[string]any) (string, error)) global.ToolHandler {
+	return func(options map[string]any) (string, error) {
+		if f.jobs == nil {
+			return "", fmt.Errorf("asynchronous command jobs are not available - no database configured")
+		}
+		tenant, err := tenantFromOptions(options)
+		if err != nil {
+			return "", err
+		}
+		return handle(tenant, options)
+	}
+}
+
+// tenantFromOptions extracts the tenant context from the MCP context passed in options
+func tenantFromOptions(options map[string]any) (*TenantContext, error) {
+	ctx, ok := options["__mcp_context"].(context.Context)
+	if !ok {
+		return nil, fmt.Errorf("no tenant context found - authentication required")
+	}
+	tenant, ok := ctx.Value(global.TenantContextKey).(*TenantContext)
+	if !ok || tenant == nil {
+		return nil, fmt.Errorf("no tenant context found - authentication required")
+	}
+	return tenant, nil
+}
+
+// handleJobStatus implements job_status
+func (f *Fusion) handleJobStatus(tenant *TenantContext, args map[string]any) (string, error) {
+	job, err := f.getJob(tenant, args)
+	if err != nil {
+		return "", err
+	}
+	return formatJobStatus(job), nil
+}
+
+// handleJobOutput implements job_output
+func (f *Fusion) handleJobOutput(tenant *TenantContext, args map[string]any) (string, error) {
+	job, err := f.getJob(tenant, args)
+	if err != nil {
+		return "", err
+	}
+
+	stream := JobStreamStdout
+	if s, ok := args["stream"].(string); ok && s != "" {
+		if s != JobStreamStdout && s != JobStreamStderr {
+			return "", fmt.Errorf("stream must be %q or %q", JobStreamStdout, JobStreamStderr)
+		}
+		stream = s
+	}
+	offset, _ := args["offset"].(float64)
+	tail, _ := args["tail"].(float64)
+	maxBytes := int64(defaultJobOutputBytes)
+	if n, ok := args["max_bytes"].(float64); ok && n > 0 {
+		maxBytes = int64(n)
+	}
+
+	output, err := f.jobs.ReadOutput(job, stream, int64(offset), int(tail), maxBytes)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Job: %s (%s)\n", job.ID, job.Status))
+	sb.WriteString(fmt.Sprintf("Stream: %s\n", stream))
+	sb.WriteString(fmt.Sprintf("Bytes: %d-%d of %d\n", output.Start, output.End, output.Size))
+	if output.End < output.Size || job.Status == JobStatusRunning {
+		sb.WriteString(fmt.Sprintf("Next Offset: %d\n", output.End))
+	}
+	sb.WriteString("\n")
+	if output.Data == "" {
+		sb.WriteString("(no output)\n")
+	} else {
+		sb.WriteString(output.Data)
+	}
+	return sb.String(), nil
+}
+
+// handleJobCancel implements job_cancel
+func (f *Fusion) handleJobCancel(tenant *TenantContext, args map[string]any) (string, error) {
+	jobID, _ := args["job_id"].(string)
+	job, err := f.jobs.Cancel(tenant.TenantHash, jobID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Cancellation requested for job %s (%s). Use job_status to confirm it has stopped.", job.ID, job.Tool), nil
+}
+
+// handleJobList implements job_list
+func (f *Fusion) handleJobList(tenant *TenantContext, args map[string]any) (string, error) {
+	jobs, err := f.jobs.List(tenant.TenantHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to list jobs: %w", err)
+	}
+	status, _ := args["status"].(string)
+
+	var sb strings.Builder
+	count := 0
+	for i := range jobs {
+		job := &jobs[i]
+		if status != "" && job.Status != status {
+			continue
+		}
+		count++
+		sb.WriteString(fmt.Sprintf("%s  %-11s  %s  %s  %s\n",
+			job.ID, job.Status, job.CreatedAt.Format(time.RFC3339), job.Tool, job.Command))
+	}
+	if count == 0 {
+		return "No jobs found", nil
+	}
+	return fmt.Sprintf("%d job(s):\n%s", count, sb.String()), nil
+}
+
+// getJob returns the job named by the job_id argument
+func (f *Fusion) getJob(tenant *TenantContext, args map[string]any) (*db.CommandJob, error) {
+	jobID, _ := args["job_id"].(string)
+	if jobID == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+	job, err := f.jobs.Get(tenant.TenantHash, jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, fmt.Errorf("job %s not found", jobID)
+	}
+	return job, err
+}
+
+// formatJobStatus formats a job record as text
+func formatJobStatus(job *db.CommandJob) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Job ID: %s\n", job.ID))
+	sb.WriteString(fmt.Sprintf("Tool: %s\n", job.Tool))
+	sb.WriteString(fmt.Sprintf("Command: %s\n", job.Command))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", job.Status))
+	sb.WriteString(fmt.Sprintf("Started: %s\n", job.CreatedAt.Format(time.RFC3339)))
+	if job.FinishedAt != nil {
+		sb.WriteString(fmt.Sprintf("Finished: %s\n", job.FinishedAt.Format(time.RFC3339)))
+		sb.WriteString(fmt.Sprintf("Duration: %v\n", job.FinishedAt.Sub(job.CreatedAt).Round(time.Millisecond)))
+	} else {
+		sb.WriteString(fmt.Sprintf("Running For: %v\n", time.Since(job.CreatedAt).Round(time.Second)))
+	}
+	if job.ExitCode != nil {
+		sb.WriteString(fmt.Sprintf("Exit Code: %d\n", *job.ExitCode))
+	}
+	if job.Error != "" {
+		sb.WriteString(fmt.Sprintf("Error: %s\n", job.Error))
+	}
+	sb.WriteString(fmt.Sprintf("Output: stdout %d bytes, stderr %d bytes\n", fileSize(job.StdoutPath), fileSize(job.StderrPath)))
+	return sb.String()
+}
+
+// fileSize returns the size of a file, or 0 if it cannot be read
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,471 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// Job statuses recorded in db.CommandJob.Status
+const (
+	JobStatusRunning     = "running"
+	JobStatusCompleted   = "completed"
+	JobStatusFailed      = "failed"
+	JobStatusTimeout     = "timeout"
+	JobStatusCancelled   = "cancelled"
+	JobStatusLimit       = "limit"
+	JobStatusInterrupted = "interrupted" // The server stopped while the job was running
+)
+
+// Output streams of a job
+const (
+	JobStreamStdout = "stdout"
+	JobStreamStderr = "stderr"
+)
+
+// ErrJobNotFound is returned when a job does not exist or belongs to another tenant
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrTooManyJobs is returned by Start when the tenant already runs the maximum
+// number of jobs
+var ErrTooManyJobs = errors.New("too many running jobs")
+
+// jobPruneInterval is how often finished jobs are checked against the retention
+const jobPruneInterval = time.Hour
+
+// JobLimits bounds the jobs a JobManager keeps and runs. Zero values mean no limit.
+type JobLimits struct {
+	Retention           time.Duration // Finished jobs older than this are deleted
+	MaxJobs             int           // Finished jobs kept per tenant; the oldest are deleted first
+	MaxRunningPerTenant int           // Jobs a tenant may run at the same time
+}
+
+// JobManager runs asynchronous command jobs. Job records are stored in the
+// database and output is written to files under dir, so both survive a
+// server restart. Jobs that were running when the server stopped are marked
+// interrupted when the manager is created. Finished jobs are deleted, record
+// and output, once they fall outside the limits.
+type JobManager struct {
+	database      db.Database
+	dir           string
+	limits        JobLimits
+	executor      *CommandExecutor
+	logger        global.Logger
+	mu            sync.Mutex
+	running       map[string]context.CancelFunc // Cancel functions of running jobs by ID
+	tenantRunning map[string]int                // Running jobs by tenant hash
+	stopping      bool                          // Set by Shutdown; running jobs end as interrupted
+	stop          chan struct{}                 // Closed by Shutdown to stop pruning
+	wg            sync.WaitGroup
+}
+
+// NewJobManager creates a job manager storing output under dir
+func NewJobManager(database db.Database, dir string, limits JobLimits, logger global.Logger) *JobManager {
+	m := &JobManager{
+		database:      database,
+		dir:           dir,
+		limits:        limits,
+		executor:      NewCommandExecutor(logger),
+		logger:        logger,
+		running:       make(map[string]context.CancelFunc),
+		tenantRunning: make(map[string]int),
+		stop:          make(chan struct{}),
+	}
+	m.recover()
+
+	if limits.Retention > 0 || limits.MaxJobs > 0 {
+		m.wg.Add(1)
+		go m.pruneLoop()
+	}
+	return m
+}
+
+// recover marks jobs left running by a previous server process as interrupted
+// and deletes finished jobs outside the limits
+func (m *JobManager) recover() {
+	jobs, err := m.database.ListCommandJobs("")
+	if err != nil {
+		if m.logger != nil {
+			m.logger.Warningf("Failed to list command jobs for recovery: %v", err)
+		}
+		return
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status != JobStatusRunning {
+			continue
+		}
+		now := time.Now()
+		job.Status = JobStatusInterrupted
+		job.Error = "server stopped while the job was running"
+		job.FinishedAt = &now
+		if err := m.database.SaveCommandJob(job); err != nil && m.logger != nil {
+			m.logger.Warningf("Failed to mark command job %s as interrupted: %v", job.ID, err)
+		}
+	}
+
+	m.prune()
+}
+
+// pruneLoop deletes finished jobs outside the limits until Shutdown
+func (m *JobManager) pruneLoop() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(jobPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.prune()
+		}
+	}
+}
+
+// prune deletes finished jobs older than the retention and, per tenant, the
+// oldest finished jobs beyond MaxJobs. Running jobs are never deleted.
+func (m *JobManager) prune() {
+	if m.limits.Retention <= 0 && m.limits.MaxJobs <= 0 {
+		return
+	}
+
+	jobs, err := m.database.ListCommandJobs("")
+	if err != nil {
+		if m.logger != nil {
+			m.logger.Warningf("Failed to list command jobs for pruning: %v", err)
+		}
+		return
+	}
+
+	cutoff := time.Now().Add(-m.limits.Retention)
+	kept := make(map[string]int)
+	deleted := 0
+	// Jobs are listed newest first, so the oldest exceed MaxJobs
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status == JobStatusRunning {
+			continue
+		}
+		kept[job.TenantHash]++
+		finished := job.CreatedAt
+		if job.FinishedAt != nil {
+			finished = *job.FinishedAt
+		}
+		expired := m.limits.Retention > 0 && finished.Before(cutoff)
+		excess := m.limits.MaxJobs > 0 && kept[job.TenantHash] > m.limits.MaxJobs
+		if !expired && !excess {
+			continue
+		}
+		if err := m.delete(job); err != nil {
+			if m.logger != nil {
+				m.logger.Warningf("Failed to delete command job %s: %v", job.ID, err)
+			}
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 && m.logger != nil {
+		m.logger.Infof("Deleted %d finished command jobs", deleted)
+	}
+}
+
+// delete removes a job's output directory and record
+func (m *JobManager) delete(job *db.CommandJob) error {
+	// The output directory is named after the job; never remove anything else
+	if job.StdoutPath != "" {
+		jobDir := filepath.Dir(job.StdoutPath)
+		if filepath.Base(jobDir) != job.ID {
+			return fmt.Errorf("unexpected output path %s", job.StdoutPath)
+		}
+		if err := os.RemoveAll(jobDir); err != nil {
+			return fmt.Errorf("failed to delete job output: %w", err)
+		}
+	}
+	if err := m.database.DeleteCommandJob(job.ID); err != nil && !errors.Is(err, db.ErrCommandJobNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Start launches config in the background for tenant and returns the new job.
+// It returns ErrTooManyJobs if the tenant already runs MaxRunningPerTenant jobs.
+func (m *JobManager) Start(tenant *TenantContext, tool string, config ExecutionConfig) (*db.CommandJob, error) {
+	// Reserve a slot for the job; it is released when the job ends or fails to start
+	m.mu.Lock()
+	if limit := m.limits.MaxRunningPerTenant; limit > 0 && m.tenantRunning[tenant.TenantHash] >= limit {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w: at most %d jobs may run at the same time", ErrTooManyJobs, limit)
+	}
+	m.tenantRunning[tenant.TenantHash]++
+	m.mu.Unlock()
+
+	started := false
+	defer func() {
+		if !started {
+			m.release(tenant.TenantHash)
+		}
+	}()
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	jobDir := filepath.Join(m.dir, id)
+	if err := os.MkdirAll(jobDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create job directory: %w", err)
+	}
+
+	job := &db.CommandJob{
+		ID:         id,
+		TenantHash: tenant.TenantHash,
+		UserID:     tenant.UserID,
+		Tool:       tool,
+		Command:    strings.TrimSpace(config.Executable + " " + strings.Join(config.Args, " ")),
+		Status:     JobStatusRunning,
+		StdoutPath: filepath.Join(jobDir, JobStreamStdout+".log"),
+		StderrPath: filepath.Join(jobDir, JobStreamStderr+".log"),
+		CreatedAt:  time.Now(),
+	}
+
+	stdout, err := os.OpenFile(job.StdoutPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job output: %w", err)
+	}
+	stderr, err := os.OpenFile(job.StderrPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		_ = stdout.Close()
+		return nil, fmt.Errorf("failed to create job output: %w", err)
+	}
+
+	// Output goes to the job files instead of memory
+	if config.CaptureStdout {
+		config.Stdout = stdout
+	}
+	if config.CaptureStderr {
+		config.Stderr = stderr
+	}
+	config.CaptureStdout = false
+	config.CaptureStderr = false
+
+	if err := m.database.SaveCommandJob(job); err != nil {
+		_ = stdout.Close()
+		_ = stderr.Close()
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.running[id] = cancel
+	m.mu.Unlock()
+	started = true
+
+	// The goroutine updates its own copy of the record
+	record := *job
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer cancel()
+
+		result := m.executor.Execute(ctx, config)
+		_ = stdout.Close()
+		_ = stderr.Close()
+
+		m.mu.Lock()
+		delete(m.running, id)
+		stopping := m.stopping
+		m.mu.Unlock()
+		m.release(record.TenantHash)
+
+		m.finish(&record, result, stopping)
+	}()
+
+	if m.logger != nil {
+		m.logger.Infof("Started command job %s for tenant %s: %s", id, tenant.ShortHash(), job.Command)
+	}
+	return job, nil
+}
+
+// release frees a running job slot of the tenant
+func (m *JobManager) release(tenantHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tenantRunning[tenantHash]--; m.tenantRunning[tenantHash] <= 0 {
+		delete(m.tenantRunning, tenantHash)
+	}
+}
+
+// finish records the outcome of a job
+func (m *JobManager) finish(job *db.CommandJob, result ExecutionResult, stopping bool) {
+	now := time.Now()
+	job.FinishedAt = &now
+	exitCode := result.ExitCode
+	job.ExitCode = &exitCode
+	if result.Error != nil {
+		job.Error = result.Error.Error()
+	}
+
+	switch {
+	case result.TerminationReason == TerminationCancelled && stopping:
+		job.Status = JobStatusInterrupted
+		job.Error = "server stopped while the job was running"
+	case result.TerminationReason == TerminationCancelled:
+		job.Status = JobStatusCancelled
+	case result.TimedOut:
+		job.Status = JobStatusTimeout
+	case result.LimitExceeded != "":
+		job.Status = JobStatusLimit
+	case result.Error != nil || result.ExitCode != 0:
+		job.Status = JobStatusFailed
+	default:
+		job.Status = JobStatusCompleted
+	}
+
+	if err := m.database.SaveCommandJob(job); err != nil && m.logger != nil {
+		m.logger.Errorf("Failed to save command job %s: %v", job.ID, err)
+	}
+	if m.logger != nil {
+		m.logger.Infof("Command job %s finished: status=%s exit_code=%d duration=%.2fs",
+			job.ID, job.Status, result.ExitCode, result.Duration.Seconds())
+	}
+}
+
+// Get returns a job of the tenant
+func (m *JobManager) Get(tenantHash, id string) (*db.CommandJob, error) {
+	job, err := m.database.GetCommandJob(id)
+	if err != nil {
+		if errors.Is(err, db.ErrCommandJobNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	if job.TenantHash != tenantHash {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// List returns the tenant's jobs, newest first
+func (m *JobManager) List(tenantHash string) ([]db.CommandJob, error) {
+	return m.database.ListCommandJobs(tenantHash)
+}
+
+// Cancel stops a running job of the tenant. The job's process group is
+// terminated as for a timeout and the job ends with status cancelled.
+func (m *JobManager) Cancel(tenantHash, id string) (*db.CommandJob, error) {
+	job, err := m.Get(tenantHash, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	cancel, ok := m.running[id]
+	m.mu.Unlock()
+	if !ok {
+		return job, fmt.Errorf("job %s is not running (status: %s)", id, job.Status)
+	}
+
+	cancel()
+	if m.logger != nil {
+		m.logger.Infof("Cancelled command job %s", id)
+	}
+	return job, nil
+}
+
+// JobOutput is a window of a job's output stream
+type JobOutput struct {
+	Data  string
+	Start int64 // Offset of the first byte of Data
+	End   int64 // Offset after the last byte of Data
+	Size  int64 // Current size of the stream
+}
+
+// ReadOutput returns up to limit bytes of a job's output stream starting at
+// offset. If tail is positive, the last tail lines (within the last limit
+// bytes) are returned instead.
+func (m *JobManager) ReadOutput(job *db.CommandJob, stream string, offset int64, tail int, limit int64) (*JobOutput, error) {
+	path := job.StdoutPath
+	if stream == JobStreamStderr {
+		path = job.StderrPath
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("job output is no longer available: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+
+	start := min(max(offset, 0), size)
+	if tail > 0 {
+		start = max(size-limit, 0)
+	}
+	buf := make([]byte, min(limit, size-start))
+	n, err := file.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	buf = buf[:n]
+
+	if tail > 0 {
+		lines := bytes.SplitAfter(buf, []byte("\n"))
+		if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > tail {
+			skipped := len(bytes.Join(lines[:len(lines)-tail], nil))
+			start += int64(skipped)
+			buf = buf[skipped:]
+		}
+	}
+
+	return &JobOutput{Data: string(buf), Start: start, End: start + int64(len(buf)), Size: size}, nil
+}
+
+// Shutdown cancels running jobs and waits for them to stop. Their records are
+// marked interrupted.
+func (m *JobManager) Shutdown() {
+	m.mu.Lock()
+	if !m.stopping {
+		m.stopping = true
+		close(m.stop)
+	}
+	for _, cancel := range m.running {
+		cancel()
+	}
+	m.mu.Unlock()
+	m.wg.Wait()
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
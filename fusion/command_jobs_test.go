@@ -0,0 +1,295 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tenebris-tech/mlogger/testlogger"
+)
+
+// newJobTestDatabase creates a temporary database for job tests
+func newJobTestDatabase(t *testing.T) db.Database {
+	t.Helper()
+	database, err := db.New(db.WithLogger(testlogger.New(t)), db.WithDataDir(t.TempDir()))
+	require.NoError(t, err, "failed to create database")
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+// waitForJob polls until the job is no longer running
+func waitForJob(t *testing.T, jobs *JobManager, tenantHash, id string) *db.CommandJob {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Get(tenantHash, id)
+		require.NoError(t, err)
+		if job.Status != JobStatusRunning {
+			return job
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestJobManager_RunAndReadOutput(t *testing.T) {
+	jobs := NewJobManager(newJobTestDatabase(t), t.TempDir(), JobLimits{}, nil)
+	t.Cleanup(jobs.Shutdown)
+	tenant := &TenantContext{TenantHash: "tenant-a"}
+
+	job, err := jobs.Start(tenant, "command_test", ExecutionConfig{
+		Executable:    "/bin/sh",
+		Args:          []string{"-c", "echo one; echo two; echo three; echo oops >&2"},
+		Timeout:       10,
+		CaptureStdout: true,
+		CaptureStderr: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, job.Status)
+
+	job = waitForJob(t, jobs, "tenant-a", job.ID)
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	require.NotNil(t, job.ExitCode)
+	assert.Equal(t, 0, *job.ExitCode)
+	assert.NotNil(t, job.FinishedAt)
+
+	output, err := jobs.ReadOutput(job, JobStreamStdout, 4, 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "two\n", output.Data)
+	assert.Equal(t, int64(4), output.Start)
+	assert.Equal(t, int64(8), output.End)
+	assert.Equal(t, int64(14), output.Size)
+
+	output, err = jobs.ReadOutput(job, JobStreamStdout, 0, 2, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "two\nthree\n", output.Data)
+	assert.Equal(t, int64(4), output.Start)
+
+	output, err = jobs.ReadOutput(job, JobStreamStderr, 0, 0, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "oops\n", output.Data)
+}
+
+func TestJobManager_TenantScoping(t *testing.T) {
+	jobs := NewJobManager(newJobTestDatabase(t), t.TempDir(), JobLimits{}, nil)
+	t.Cleanup(jobs.Shutdown)
+
+	job, err := jobs.Start(&TenantContext{TenantHash: "tenant-a"}, "command_test", ExecutionConfig{
+		Executable: "/bin/true",
+		Timeout:    10,
+	})
+	require.NoError(t, err)
+	waitForJob(t, jobs, "tenant-a", job.ID)
+
+	_, err = jobs.Get("tenant-b", job.ID)
+	assert.True(t, errors.Is(err, ErrJobNotFound), "another tenant must not see the job, got %v", err)
+
+	_, err = jobs.Cancel("tenant-b", job.ID)
+	assert.True(t, errors.Is(err, ErrJobNotFound), "another tenant must not cancel the job, got %v", err)
+
+	list, err := jobs.List("tenant-b")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	list, err = jobs.List("tenant-a")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestJobManager_Cancel(t *testing.T) {
+	jobs := NewJobManager(newJobTestDatabase(t), t.TempDir(), JobLimits{}, nil)
+	t.Cleanup(jobs.Shutdown)
+
+	job, err := jobs.Start(&TenantContext{TenantHash: "tenant-a"}, "command_test", ExecutionConfig{
+		Executable:      "/bin/sleep",
+		Args:            []string{"30"},
+		Timeout:         60,
+		KillGracePeriod: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = jobs.Cancel("tenant-a", job.ID)
+	require.NoError(t, err)
+
+	job = waitForJob(t, jobs, "tenant-a", job.ID)
+	assert.Equal(t, JobStatusCancelled, job.Status)
+
+	_, err = jobs.Cancel("tenant-a", job.ID)
+	assert.Error(t, err, "cancelling a finished job should fail")
+}
+
+func TestJobManager_RecoverInterrupted(t *testing.T) {
+	database := newJobTestDatabase(t)
+	require.NoError(t, database.SaveCommandJob(&db.CommandJob{
+		ID:         "abc123",
+		TenantHash: "tenant-a",
+		Tool:       "command_test",
+		Status:     JobStatusRunning,
+		CreatedAt:  time.Now(),
+	}))
+
+	jobs := NewJobManager(database, t.TempDir(), JobLimits{}, nil)
+	job, err := jobs.Get("tenant-a", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusInterrupted, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+}
+
+func TestJobManager_Prune(t *testing.T) {
+	database := newJobTestDatabase(t)
+	dir := t.TempDir()
+	now := time.Now()
+
+	// saveJob records a job with an output directory that finished age ago
+	saveJob := func(id, tenant, status string, age time.Duration) string {
+		jobDir := filepath.Join(dir, id)
+		require.NoError(t, os.MkdirAll(jobDir, 0700))
+		finished := now.Add(-age)
+		require.NoError(t, database.SaveCommandJob(&db.CommandJob{
+			ID:         id,
+			TenantHash: tenant,
+			Status:     status,
+			StdoutPath: filepath.Join(jobDir, JobStreamStdout+".log"),
+			StderrPath: filepath.Join(jobDir, JobStreamStderr+".log"),
+			CreatedAt:  finished.Add(-time.Minute),
+			FinishedAt: &finished,
+		}))
+		return jobDir
+	}
+
+	expired := saveJob("expired", "tenant-a", JobStatusCompleted, 10*24*time.Hour)
+	oldest := saveJob("oldest", "tenant-a", JobStatusFailed, 3*time.Hour)
+	saveJob("older", "tenant-a", JobStatusCompleted, 2*time.Hour)
+	saveJob("newest", "tenant-a", JobStatusCompleted, time.Hour)
+	saveJob("other", "tenant-b", JobStatusCompleted, 4*time.Hour)
+	// A job left running is marked interrupted on recovery and kept as the newest
+	running := saveJob("running", "tenant-b", JobStatusRunning, 30*24*time.Hour)
+
+	jobs := NewJobManager(database, dir, JobLimits{Retention: 7 * 24 * time.Hour, MaxJobs: 2}, nil)
+	t.Cleanup(jobs.Shutdown)
+
+	for id, tenant := range map[string]string{"expired": "tenant-a", "oldest": "tenant-a"} {
+		_, err := jobs.Get(tenant, id)
+		assert.True(t, errors.Is(err, ErrJobNotFound), "job %s should be deleted, got %v", id, err)
+	}
+	for _, jobDir := range []string{expired, oldest} {
+		_, err := os.Stat(jobDir)
+		assert.True(t, os.IsNotExist(err), "output of %s should be deleted", jobDir)
+	}
+
+	list, err := jobs.List("tenant-a")
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = jobs.List("tenant-b")
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.DirExists(t, running)
+}
+
+func TestJobManager_MaxRunningPerTenant(t *testing.T) {
+	jobs := NewJobManager(newJobTestDatabase(t), t.TempDir(), JobLimits{MaxRunningPerTenant: 1}, nil)
+	t.Cleanup(jobs.Shutdown)
+	tenant := &TenantContext{TenantHash: "tenant-a"}
+	sleep := ExecutionConfig{Executable: "/bin/sleep", Args: []string{"30"}, Timeout: 60, KillGracePeriod: 1}
+
+	job, err := jobs.Start(tenant, "command_test", sleep)
+	require.NoError(t, err)
+
+	_, err = jobs.Start(tenant, "command_test", sleep)
+	assert.True(t, errors.Is(err, ErrTooManyJobs), "expected ErrTooManyJobs, got %v", err)
+
+	// Other tenants have their own limit
+	other, err := jobs.Start(&TenantContext{TenantHash: "tenant-b"}, "command_test", sleep)
+	require.NoError(t, err)
+	_, err = jobs.Cancel("tenant-b", other.ID)
+	require.NoError(t, err)
+
+	// A finished job frees its slot
+	_, err = jobs.Cancel("tenant-a", job.ID)
+	require.NoError(t, err)
+	waitForJob(t, jobs, "tenant-a", job.ID)
+	job, err = jobs.Start(tenant, "command_test", ExecutionConfig{Executable: "/bin/true", Timeout: 10})
+	require.NoError(t, err)
+	waitForJob(t, jobs, "tenant-a", job.ID)
+}
+
+func TestAsyncCommandTools(t *testing.T) {
+	config := &Config{
+		Commands: map[string]*CommandGroupConfig{
+			"test": {
+				Name: "Test",
+				Commands: []CommandConfig{{
+					ID:          "greet",
+					Name:        "Greet",
+					Description: "Print a greeting",
+					Async:       true,
+					Parameters: []ParameterConfig{{
+						Name:     "executable",
+						Type:     ParameterTypeString,
+						Location: ParameterLocationControl,
+						Default:  "/bin/echo",
+						Static:   true,
+					}, {
+						Name:     "name",
+						Type:     ParameterTypeString,
+						Location: ParameterLocationArgument,
+					}},
+				}},
+			},
+		},
+	}
+	f := New(WithConfig(config), WithDatabase(newJobTestDatabase(t)), WithJobsDir(t.TempDir()))
+	t.Cleanup(f.Shutdown)
+
+	tools := make(map[string]global.ToolDefinition)
+	for _, tool := range f.RegisterTools() {
+		tools[tool.Name] = tool
+	}
+	for _, name := range []string{"command_greet", "job_status", "job_output", "job_cancel", "job_list"} {
+		require.Contains(t, tools, name)
+	}
+
+	ctx := context.WithValue(context.Background(), global.TenantContextKey, &TenantContext{TenantHash: "tenant-a"})
+	result, err := tools["command_greet"].Handler(map[string]any{"name": "world", "__mcp_context": ctx})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(result, "Job ID: "), result)
+	jobID := strings.TrimPrefix(strings.SplitN(result, "\n", 2)[0], "Job ID: ")
+
+	waitForJob(t, f.jobs, "tenant-a", jobID)
+
+	status, err := tools["job_status"].Handler(map[string]any{"job_id": jobID, "__mcp_context": ctx})
+	require.NoError(t, err)
+	assert.Contains(t, status, "Status: completed")
+	assert.Contains(t, status, "Exit Code: 0")
+
+	output, err := tools["job_output"].Handler(map[string]any{"job_id": jobID, "__mcp_context": ctx})
+	require.NoError(t, err)
+	assert.Contains(t, output, "world\n")
+
+	list, err := tools["job_list"].Handler(map[string]any{"__mcp_context": ctx})
+	require.NoError(t, err)
+	assert.Contains(t, list, jobID)
+
+	// Other tenants cannot see the job
+	other := context.WithValue(context.Background(), global.TenantContextKey, &TenantContext{TenantHash: "tenant-b"})
+	_, err = tools["job_status"].Handler(map[string]any{"job_id": jobID, "__mcp_context": other})
+	assert.Error(t, err)
+
+	// Without a tenant context the command is refused
+	_, err = tools["command_greet"].Handler(map[string]any{"name": "world"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on platforms without POSIX process groups
+func setProcessGroup(_ *exec.Cmd) {}
+
+// terminateProcessGroup kills the direct child; there is no portable SIGTERM
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// killProcessGroup kills the direct child
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
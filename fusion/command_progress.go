@@ -0,0 +1,155 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+const (
+	progressInterval        = 500 * time.Millisecond // Minimum time between notifications
+	progressMaxMessageBytes = 4096                   // Output carried by one notification
+	progressTailBytes       = 64 * 1024              // Output of each stream kept for the final response
+)
+
+// progressStreamer relays command output lines to the MCP client while the
+// command runs. Lines are batched and sent at most once per progressInterval.
+// Lines that do not fit in a notification are dropped from the stream and
+// counted; they still reach the command's captured output.
+type progressStreamer struct {
+	notify   global.ProgressNotifier
+	logger   global.Logger
+	sendMu   sync.Mutex // Serialises notifications so progress stays increasing
+	mu       sync.Mutex
+	partial  map[string][]byte // Incomplete trailing line per stream prefix
+	pending  strings.Builder
+	dropped  int
+	lines    int // Lines seen so far, reported as progress
+	lastSent time.Time
+	timer    *time.Timer
+	closed   bool
+}
+
+// newProgressStreamer creates a streamer sending to notify
+func newProgressStreamer(notify global.ProgressNotifier, logger global.Logger) *progressStreamer {
+	return &progressStreamer{
+		notify:  notify,
+		logger:  logger,
+		partial: make(map[string][]byte),
+	}
+}
+
+// writer returns a writer for one output stream. Each line is sent with prefix.
+func (s *progressStreamer) writer(prefix string) io.Writer {
+	return &progressWriter{streamer: s, prefix: prefix}
+}
+
+// progressWriter is one output stream feeding a progressStreamer
+type progressWriter struct {
+	streamer *progressStreamer
+	prefix   string
+}
+
+// Write splits p into lines and queues them. It never fails, so a slow or
+// disconnected client cannot stop the command.
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.streamer.write(w.prefix, p)
+	return len(p), nil
+}
+
+// write queues the complete lines in p
+func (s *progressStreamer) write(prefix string, p []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	data := append(s.partial[prefix], p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		s.addLine(prefix, data[:i])
+		data = data[i+1:]
+	}
+
+	// Send very long lines in pieces rather than buffering them
+	if len(data) >= progressMaxMessageBytes {
+		s.addLine(prefix, data)
+		data = nil
+	}
+	s.partial[prefix] = append([]byte(nil), data...)
+}
+
+// addLine queues one line and schedules a flush. Must be called with mu held.
+func (s *progressStreamer) addLine(prefix string, line []byte) {
+	s.lines++
+	text := prefix + strings.TrimSuffix(string(line), "\r")
+	if s.pending.Len()+len(text)+1 > progressMaxMessageBytes {
+		s.dropped++
+	} else {
+		if s.pending.Len() > 0 {
+			s.pending.WriteByte('\n')
+		}
+		s.pending.WriteString(text)
+	}
+
+	if s.timer == nil && !s.closed {
+		s.timer = time.AfterFunc(time.Until(s.lastSent.Add(progressInterval)), s.flush)
+	}
+}
+
+// flush sends the queued lines
+func (s *progressStreamer) flush() {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+	s.timer = nil
+	message := s.pending.String()
+	s.pending.Reset()
+	if s.dropped > 0 {
+		message += fmt.Sprintf("\n... %d lines omitted", s.dropped)
+		s.dropped = 0
+	}
+	progress := float64(s.lines)
+	s.lastSent = time.Now()
+	s.mu.Unlock()
+
+	if message == "" {
+		return
+	}
+	if err := s.notify(progress, message); err != nil && s.logger != nil {
+		s.logger.Debugf("Failed to send command progress: %v", err)
+	}
+}
+
+// Close sends any remaining output, including incomplete final lines
+func (s *progressStreamer) Close() {
+	s.mu.Lock()
+	for prefix, data := range s.partial {
+		if len(data) > 0 {
+			s.addLine(prefix, data)
+		}
+	}
+	s.partial = nil
+	s.closed = true
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+
+	s.flush()
+}
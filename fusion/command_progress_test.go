@@ -0,0 +1,148 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// progressRecorder collects notifications sent by a progressStreamer
+type progressRecorder struct {
+	mu       sync.Mutex
+	progress []float64
+	messages []string
+}
+
+func (r *progressRecorder) notify(progress float64, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress = append(r.progress, progress)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *progressRecorder) text() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.messages, "\n")
+}
+
+func TestProgressStreamer_BatchesLines(t *testing.T) {
+	recorder := &progressRecorder{}
+	streamer := newProgressStreamer(recorder.notify, nil)
+	stdout := streamer.writer("")
+	stderr := streamer.writer("[stderr] ")
+
+	_, _ = stdout.Write([]byte("line 1\nline"))
+	_, _ = stdout.Write([]byte(" 2\r\nline 3\n"))
+	_, _ = stderr.Write([]byte("warning\n"))
+	_, _ = stdout.Write([]byte("no newline"))
+	streamer.Close()
+
+	if got, want := recorder.text(), "line 1\nline 2\nline 3\n[stderr] warning\nno newline"; got != want {
+		t.Errorf("expected streamed output %q, got %q", want, got)
+	}
+	// Output written within one interval is batched
+	if len(recorder.messages) > 2 {
+		t.Errorf("expected at most 2 notifications, got %d", len(recorder.messages))
+	}
+	for i := 1; i < len(recorder.progress); i++ {
+		if recorder.progress[i] <= recorder.progress[i-1] {
+			t.Errorf("progress must increase: %v", recorder.progress)
+		}
+	}
+}
+
+func TestProgressStreamer_DropsExcessLines(t *testing.T) {
+	recorder := &progressRecorder{}
+	streamer := newProgressStreamer(recorder.notify, nil)
+	w := streamer.writer("")
+
+	// Hold the first flush back so every line lands in the same batch
+	streamer.sendMu.Lock()
+	line := strings.Repeat("x", 99) + "\n"
+	for i := 0; i < 200; i++ {
+		_, _ = w.Write([]byte(line))
+	}
+	streamer.sendMu.Unlock()
+	streamer.Close()
+
+	text := recorder.text()
+	if !strings.Contains(text, "lines omitted") {
+		t.Errorf("expected omitted lines to be reported")
+	}
+	if len(text) > 2*progressMaxMessageBytes {
+		t.Errorf("expected streamed output to be capped, got %d bytes", len(text))
+	}
+}
+
+func TestCommandExecutor_MaxCaptureBytes(t *testing.T) {
+	executor := NewCommandExecutor(nil)
+
+	result := executor.Execute(context.Background(), ExecutionConfig{
+		Executable:      "/bin/sh",
+		Args:            []string{"-c", "echo first; echo second; echo last"},
+		Timeout:         10,
+		CaptureStdout:   true,
+		MaxCaptureBytes: 5,
+	})
+
+	if result.Stdout != "last\n" {
+		t.Errorf("expected only the tail to be kept, got %q", result.Stdout)
+	}
+	if result.StdoutOmitted != 13 {
+		t.Errorf("expected 13 omitted bytes, got %d", result.StdoutOmitted)
+	}
+	if !strings.Contains(executor.FormatResponse(result), "13 earlier bytes omitted") {
+		t.Errorf("expected the response to note the omitted output")
+	}
+}
+
+func TestCommandHandler_StreamsProgress(t *testing.T) {
+	f := &Fusion{}
+	group := &CommandGroupConfig{Name: "test"}
+	command := &CommandConfig{
+		ID: "lines",
+		Parameters: []ParameterConfig{{
+			Name:     "executable",
+			Location: ParameterLocationControl,
+			Default:  "/bin/sh",
+			Static:   true,
+		}, {
+			Name:     "script",
+			Location: ParameterLocationArgument,
+			Default:  "-c",
+			Static:   true,
+		}, {
+			Name:     "body",
+			Location: ParameterLocationArgument,
+			Default:  "echo one; echo two >&2; echo three",
+			Static:   true,
+		}},
+	}
+
+	recorder := &progressRecorder{}
+	ctx := context.WithValue(context.Background(), global.ProgressNotifierKey, global.ProgressNotifier(recorder.notify))
+	response, err := NewCommandHandler(f, group, command).Handle(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	text := recorder.text()
+	for _, want := range []string{"one", "[stderr] two", "three"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected streamed output to contain %q, got %q", want, text)
+		}
+	}
+	if !strings.Contains(response, "one\nthree\n") {
+		t.Errorf("expected the final response to include the output, got %q", response)
+	}
+}
@@ -0,0 +1,203 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// Sandbox limits that can stop a command, reported in ExecutionResult.LimitExceeded
+const (
+	LimitCPU    = "cpu"
+	LimitMemory = "memory"
+	LimitOutput = "output"
+)
+
+// CommandSandboxConfig restricts the resources and privileges of every command
+// in a command group. Limits are enforced on Linux only; on other platforms a
+// group with a sandbox refuses to run rather than run unconfined.
+type CommandSandboxConfig struct {
+	CPUSeconds               int      `json:"cpuSeconds,omitempty"`               // RLIMIT_CPU, in seconds of CPU time
+	MemoryMB                 int      `json:"memoryMB,omitempty"`                 // RLIMIT_AS, in MiB of address space
+	OpenFiles                int      `json:"openFiles,omitempty"`                // RLIMIT_NOFILE
+	MaxOutputBytes           int      `json:"maxOutputBytes,omitempty"`           // Combined stdout and stderr; the command is stopped when exceeded
+	RunAsUID                 *uint32  `json:"runAsUid,omitempty"`                 // Requires the server to run as root
+	RunAsGID                 *uint32  `json:"runAsGid,omitempty"`                 // Required together with runAsUid
+	WorkingDir               string   `json:"workingDir,omitempty"`               // Commands start here and cwd may not leave it
+	RejectWritableWorkingDir bool     `json:"rejectWritableWorkingDir,omitempty"` // Pre-flight check only; see checkWritable
+	EnvAllowlist             []string `json:"envAllowlist,omitempty"`             // path.Match patterns of environment variables passed to commands
+}
+
+// ValidateWithLogger validates the sandbox configuration with logging support
+func (s *CommandSandboxConfig) ValidateWithLogger(groupName string, logger global.Logger) error {
+	err := s.validate()
+	if err != nil && logger != nil {
+		logger.Errorf("Command group %s: invalid sandbox: %v", groupName, err)
+	}
+	return err
+}
+
+// validate checks the sandbox settings for consistency
+func (s *CommandSandboxConfig) validate() error {
+	if s.CPUSeconds < 0 || s.MemoryMB < 0 || s.OpenFiles < 0 || s.MaxOutputBytes < 0 {
+		return fmt.Errorf("cpuSeconds, memoryMB, openFiles and maxOutputBytes must not be negative")
+	}
+	if (s.RunAsUID == nil) != (s.RunAsGID == nil) {
+		return fmt.Errorf("runAsUid and runAsGid must be set together")
+	}
+	if s.WorkingDir != "" && !filepath.IsAbs(s.WorkingDir) {
+		return fmt.Errorf("workingDir must be an absolute path: %s", s.WorkingDir)
+	}
+	if s.RejectWritableWorkingDir && s.WorkingDir == "" {
+		return fmt.Errorf("rejectWritableWorkingDir requires workingDir")
+	}
+	for _, pattern := range s.EnvAllowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid envAllowlist pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// canWrite applies the permission bits of a file owned by ownerUID and
+// ownerGID to a process running as uid with gids
+func canWrite(mode os.FileMode, ownerUID, ownerGID, uid uint32, gids []uint32) bool {
+	switch {
+	case uid == 0:
+		return true
+	case ownerUID == uid:
+		return mode&0200 != 0
+	case slices.Contains(gids, ownerGID):
+		return mode&0020 != 0
+	default:
+		return mode&0002 != 0
+	}
+}
+
+// defaultSandboxEnv is the allowlist of a sandbox without envAllowlist, so that
+// server secrets such as MCP_FUSION_* and provider credentials are not passed on
+var defaultSandboxEnv = []string{"PATH", "HOME", "TMPDIR", "TZ", "LANG", "LC_*"}
+
+// filterEnv returns the entries of env whose names match the allowlist (or the
+// default allowlist when none is configured) or are listed in declared
+// (environment parameters defined by the command itself).
+func (s *CommandSandboxConfig) filterEnv(env []string, declared []string) []string {
+	allowlist := s.EnvAllowlist
+	if allowlist == nil {
+		allowlist = defaultSandboxEnv
+	}
+
+	filtered := make([]string, 0, len(env))
+	for _, entry := range env {
+		name, _, _ := strings.Cut(entry, "=")
+		if matchesAny(allowlist, name) || slices.Contains(declared, name) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// resolveWorkingDir returns the directory a command should start in. A
+// requested cwd is resolved relative to WorkingDir and must stay inside it.
+func (s *CommandSandboxConfig) resolveWorkingDir(cwd string) (string, error) {
+	if s.WorkingDir == "" {
+		return cwd, nil
+	}
+	if cwd == "" {
+		return s.WorkingDir, nil
+	}
+
+	if !filepath.IsAbs(cwd) {
+		cwd = filepath.Join(s.WorkingDir, cwd)
+	}
+
+	// Resolve symlinks so a link inside the directory cannot point outside it
+	root, err := filepath.EvalSymlinks(s.WorkingDir)
+	if err != nil {
+		return "", fmt.Errorf("sandbox working directory: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(cwd)
+	if err != nil {
+		return "", fmt.Errorf("working directory: %w", err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("working directory %s is outside the sandbox directory %s", cwd, s.WorkingDir)
+	}
+	return resolved, nil
+}
+
+// describeLimit returns a human-readable description of the configured limit
+func (s *CommandSandboxConfig) describeLimit(limit string) string {
+	switch limit {
+	case LimitCPU:
+		return fmt.Sprintf("CPU time limit of %ds exceeded", s.CPUSeconds)
+	case LimitMemory:
+		return fmt.Sprintf("memory limit of %d MiB likely exceeded", s.MemoryMB)
+	case LimitOutput:
+		return fmt.Sprintf("output limit of %d bytes exceeded", s.MaxOutputBytes)
+	}
+	return limit
+}
+
+// outputLimiter caps the combined bytes written to a command's stdout and
+// stderr buffers and signals once the cap is reached
+type outputLimiter struct {
+	mu       sync.Mutex
+	limit    int
+	written  int
+	tripped  bool
+	exceeded chan struct{} // Closed when output beyond the limit is discarded
+}
+
+// newOutputLimiter creates a limiter for limit bytes
+func newOutputLimiter(limit int) *outputLimiter {
+	return &outputLimiter{limit: limit, exceeded: make(chan struct{})}
+}
+
+// wrap returns a writer that writes to w while the limit allows
+func (l *outputLimiter) wrap(w io.Writer) *limitedWriter {
+	return &limitedWriter{limiter: l, w: w}
+}
+
+// limitedWriter is one stream sharing an outputLimiter
+type limitedWriter struct {
+	limiter *outputLimiter
+	w       io.Writer
+}
+
+// Write stores as much of p as the limit allows and discards the rest. It
+// never fails, so the command is stopped by the executor rather than by a
+// broken pipe.
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	l := lw.limiter
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	chunk := p
+	if remaining := l.limit - l.written; len(chunk) > remaining {
+		chunk = chunk[:max(remaining, 0)]
+		if !l.tripped {
+			l.tripped = true
+			close(l.exceeded)
+		}
+	}
+	if len(chunk) == 0 {
+		return len(p), nil
+	}
+	n, err := lw.w.Write(chunk)
+	l.written += n
+	return len(p), err
+}
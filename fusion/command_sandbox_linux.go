@@ -0,0 +1,193 @@
+//go:build linux
+
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Resource limits cannot be set on a child before it execs, and setting them
+// after Start races with the command. Instead the server binary re-executes
+// itself under sandboxExecArg0, applies the limits passed in sandboxLimitsEnv
+// to its own process and then execs the real command, which inherits them.
+const (
+	sandboxExecArg0  = "mcpfusion-sandbox-exec"
+	sandboxLimitsEnv = "MCP_FUSION_SANDBOX_RLIMITS"
+)
+
+func init() {
+	if len(os.Args) > 1 && os.Args[0] == sandboxExecArg0 {
+		sandboxExec(os.Args[1:])
+	}
+}
+
+// sandboxExec applies the resource limits from the environment and replaces
+// the process with args. It never returns.
+func sandboxExec(args []string) {
+	var env []string
+	for _, entry := range os.Environ() {
+		if value, ok := strings.CutPrefix(entry, sandboxLimitsEnv+"="); ok {
+			if err := setResourceLimits(value); err != nil {
+				fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+				os.Exit(126)
+			}
+			continue
+		}
+		env = append(env, entry)
+	}
+
+	err := syscall.Exec(args[0], args, env)
+	fmt.Fprintf(os.Stderr, "sandbox: exec %s: %v\n", args[0], err)
+	os.Exit(127)
+}
+
+// setResourceLimits applies limits encoded by encodeResourceLimits to the current process
+func setResourceLimits(encoded string) error {
+	for _, item := range strings.Split(encoded, ",") {
+		var resource int
+		var soft, hard uint64
+		if _, err := fmt.Sscanf(item, "%d=%d:%d", &resource, &soft, &hard); err != nil {
+			return fmt.Errorf("invalid limit %q", item)
+		}
+		if err := syscall.Setrlimit(resource, &syscall.Rlimit{Cur: soft, Max: hard}); err != nil {
+			return fmt.Errorf("setrlimit %d: %w", resource, err)
+		}
+	}
+	return nil
+}
+
+// encodeResourceLimits returns the sandbox's rlimits in the form read by
+// setResourceLimits, or "" if none are configured. The CPU hard limit is one
+// second above the soft limit so the process receives SIGXCPU before SIGKILL.
+func encodeResourceLimits(sandbox *CommandSandboxConfig) string {
+	var limits []string
+	add := func(resource int, soft, hard uint64) {
+		if soft > 0 {
+			limits = append(limits, strconv.Itoa(resource)+"="+strconv.FormatUint(soft, 10)+":"+strconv.FormatUint(hard, 10))
+		}
+	}
+	add(syscall.RLIMIT_CPU, uint64(sandbox.CPUSeconds), uint64(sandbox.CPUSeconds)+1)
+	add(syscall.RLIMIT_AS, uint64(sandbox.MemoryMB)<<20, uint64(sandbox.MemoryMB)<<20)
+	add(syscall.RLIMIT_NOFILE, uint64(sandbox.OpenFiles), uint64(sandbox.OpenFiles))
+	return strings.Join(limits, ",")
+}
+
+// applySandbox prepares cmd to run under the sandbox: it sets the credentials,
+// checks the working directory and routes the command through the limit helper
+func applySandbox(cmd *exec.Cmd, sandbox *CommandSandboxConfig) error {
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+
+	if sandbox.RunAsUID != nil {
+		if cmd.SysProcAttr == nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{}
+		}
+		cmd.SysProcAttr.Credential = &syscall.Credential{Uid: *sandbox.RunAsUID, Gid: *sandbox.RunAsGID}
+	}
+
+	if sandbox.RejectWritableWorkingDir {
+		uid, gids, err := sandboxCredentials(sandbox)
+		if err != nil {
+			return err
+		}
+		if err := checkWritable(cmd.Dir, uid, gids); err != nil {
+			return err
+		}
+	}
+
+	if limits := encodeResourceLimits(sandbox); limits != "" {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, sandboxLimitsEnv+"="+limits)
+		cmd.Args = append([]string{sandboxExecArg0, cmd.Path}, cmd.Args[1:]...)
+		cmd.Path = "/proc/self/exe"
+	}
+	return nil
+}
+
+// sandboxCredentials returns the user and groups commands run with. A run-as
+// user gets only its primary group (Credential without Groups clears the
+// supplementary groups); otherwise commands inherit the server's groups.
+func sandboxCredentials(sandbox *CommandSandboxConfig) (uint32, []uint32, error) {
+	if sandbox.RunAsUID != nil {
+		return *sandbox.RunAsUID, []uint32{*sandbox.RunAsGID}, nil
+	}
+	groups, err := os.Getgroups()
+	if err != nil {
+		return 0, nil, fmt.Errorf("supplementary groups: %w", err)
+	}
+	gids := []uint32{uint32(os.Getegid())}
+	for _, group := range groups {
+		gids = append(gids, uint32(group))
+	}
+	return uint32(os.Geteuid()), gids, nil
+}
+
+// checkWritable returns an error if a process running as uid with gids could
+// write to dir according to its permission bits.
+//
+// This is a pre-flight check, not enforcement: it looks at dir itself only,
+// not at its subdirectories, ACLs or capabilities, and the permissions can
+// change after the command starts.
+func checkWritable(dir string, uid uint32, gids []uint32) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("working directory: %w", err)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("working directory %s: cannot determine ownership", dir)
+	}
+	if canWrite(info.Mode().Perm(), stat.Uid, stat.Gid, uid, gids) {
+		return fmt.Errorf("working directory %s must not be writable but is writable by uid %d", dir, uid)
+	}
+	return nil
+}
+
+// exitSignal returns the name of the signal that terminated the process, if any
+func exitSignal(state *os.ProcessState) string {
+	if state == nil {
+		return ""
+	}
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return ""
+	}
+	return unix.SignalName(status.Signal())
+}
+
+// exceededLimit infers which sandbox limit stopped the process from the signal
+// that terminated it and the CPU time it used
+func exceededLimit(state *os.ProcessState, sandbox *CommandSandboxConfig) string {
+	switch exitSignal(state) {
+	case "SIGXCPU":
+		if sandbox.CPUSeconds > 0 {
+			return LimitCPU
+		}
+	case "SIGKILL":
+		cpuLimit := time.Duration(sandbox.CPUSeconds) * time.Second
+		if sandbox.CPUSeconds > 0 && state.UserTime()+state.SystemTime() >= cpuLimit {
+			return LimitCPU
+		}
+	case "SIGSEGV", "SIGABRT", "SIGBUS":
+		if sandbox.MemoryMB > 0 {
+			return LimitMemory
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+//go:build !linux
+
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// applySandbox refuses to run sandboxed commands where limits cannot be enforced
+func applySandbox(_ *exec.Cmd, _ *CommandSandboxConfig) error {
+	return fmt.Errorf("command sandboxing is not supported on %s", runtime.GOOS)
+}
+
+// exitSignal is not reported on this platform
+func exitSignal(_ *os.ProcessState) string {
+	return ""
+}
+
+// exceededLimit is not reported on this platform
+func exceededLimit(_ *os.ProcessState, _ *CommandSandboxConfig) string {
+	return ""
+}
@@ -0,0 +1,220 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tenebris-tech/mlogger"
+)
+
+func skipUnlessLinux(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("command sandboxing is only enforced on Linux")
+	}
+}
+
+func TestCommandSandboxConfig_Validate(t *testing.T) {
+	uid := uint32(65534)
+	tests := []struct {
+		name    string
+		sandbox CommandSandboxConfig
+		wantErr bool
+	}{
+		{"empty", CommandSandboxConfig{}, false},
+		{"full", CommandSandboxConfig{CPUSeconds: 60, MemoryMB: 512, OpenFiles: 256, MaxOutputBytes: 1 << 20,
+			RunAsUID: &uid, RunAsGID: &uid, WorkingDir: "/tmp", RejectWritableWorkingDir: true, EnvAllowlist: []string{"PATH", "LC_*"}}, false},
+		{"negative limit", CommandSandboxConfig{CPUSeconds: -1}, true},
+		{"uid without gid", CommandSandboxConfig{RunAsUID: &uid}, true},
+		{"relative working dir", CommandSandboxConfig{WorkingDir: "work"}, true},
+		{"writable check without dir", CommandSandboxConfig{RejectWritableWorkingDir: true}, true},
+		{"bad env pattern", CommandSandboxConfig{EnvAllowlist: []string{"[PATH"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sandbox.ValidateWithLogger("test", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandSandboxConfig_FilterEnv(t *testing.T) {
+	sandbox := &CommandSandboxConfig{EnvAllowlist: []string{"PATH", "LC_*"}}
+	env := []string{"PATH=/usr/bin", "LC_ALL=C", "LD_PRELOAD=/tmp/evil.so", "API_KEY=secret", "TARGET=host"}
+
+	got := sandbox.filterEnv(env, []string{"TARGET"})
+	want := []string{"PATH=/usr/bin", "LC_ALL=C", "TARGET=host"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("filterEnv() = %v, want %v", got, want)
+	}
+
+	// Without an allowlist only a minimal default environment is passed
+	got = (&CommandSandboxConfig{}).filterEnv(append(env, "MCP_FUSION_DB_KEY=secret"), []string{"TARGET"})
+	want = []string{"PATH=/usr/bin", "LC_ALL=C", "TARGET=host"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("filterEnv() without allowlist = %v, want %v", got, want)
+	}
+}
+
+func TestCommandSandboxConfig_ResolveWorkingDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "scans"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(os.TempDir(), filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	sandbox := &CommandSandboxConfig{WorkingDir: root}
+
+	if dir, err := sandbox.resolveWorkingDir(""); err != nil || dir != root {
+		t.Errorf("expected default to working dir, got %q, %v", dir, err)
+	}
+	if _, err := sandbox.resolveWorkingDir("scans"); err != nil {
+		t.Errorf("expected relative subdirectory to be allowed, got %v", err)
+	}
+	for _, cwd := range []string{"..", "/etc", "escape"} {
+		if _, err := sandbox.resolveWorkingDir(cwd); err == nil {
+			t.Errorf("expected %q to be rejected", cwd)
+		}
+	}
+}
+
+func TestCommandExecutor_OutputLimit(t *testing.T) {
+	skipUnlessLinux(t)
+	executor := NewCommandExecutor(nil)
+
+	result := executor.Execute(context.Background(), ExecutionConfig{
+		Executable:      "/bin/sh",
+		Args:            []string{"-c", "while :; do echo output; done"},
+		Timeout:         10,
+		KillGracePeriod: 1,
+		CaptureStdout:   true,
+		CaptureStderr:   true,
+		Sandbox:         &CommandSandboxConfig{MaxOutputBytes: 1000},
+	})
+
+	if result.LimitExceeded != LimitOutput {
+		t.Fatalf("expected output limit to stop the command, got %q (%v)", result.LimitExceeded, result.Error)
+	}
+	if len(result.Stdout) > 1000 {
+		t.Errorf("expected at most 1000 bytes of output, got %d", len(result.Stdout))
+	}
+	if !strings.Contains(executor.FormatResponse(result), "Status: Limit Exceeded") {
+		t.Error("expected response to report the exceeded limit")
+	}
+}
+
+func TestCommandExecutor_CPULimit(t *testing.T) {
+	skipUnlessLinux(t)
+	executor := NewCommandExecutor(nil)
+
+	result := executor.Execute(context.Background(), ExecutionConfig{
+		Executable: "/bin/sh",
+		Args:       []string{"-c", "while :; do :; done"},
+		Timeout:    20,
+		Sandbox:    &CommandSandboxConfig{CPUSeconds: 1},
+	})
+
+	if result.LimitExceeded != LimitCPU {
+		t.Fatalf("expected CPU limit to stop the command, got %q (signal %q, %v)", result.LimitExceeded, result.Signal, result.Error)
+	}
+	if result.Duration > 10*time.Second {
+		t.Errorf("expected CPU limit well before the timeout, took %v", result.Duration)
+	}
+}
+
+func TestCommandExecutor_OpenFilesLimit(t *testing.T) {
+	skipUnlessLinux(t)
+	executor := NewCommandExecutor(nil)
+
+	// The limit must already be in place when the command starts
+	result := executor.Execute(context.Background(), ExecutionConfig{
+		Executable:    "/bin/sh",
+		Args:          []string{"-c", "ulimit -n"},
+		Timeout:       10,
+		CaptureStdout: true,
+		Sandbox:       &CommandSandboxConfig{OpenFiles: 8},
+	})
+
+	if strings.TrimSpace(result.Stdout) != "8" {
+		t.Errorf("expected an open files limit of 8, got %q (%v)", result.Stdout, result.Error)
+	}
+}
+
+func TestCommandExecutor_RejectWritableWorkingDir(t *testing.T) {
+	skipUnlessLinux(t)
+	executor := NewCommandExecutor(nil)
+
+	result := executor.Execute(context.Background(), ExecutionConfig{
+		Executable: "/bin/true",
+		Timeout:    10,
+		Sandbox:    &CommandSandboxConfig{WorkingDir: t.TempDir(), RejectWritableWorkingDir: true},
+	})
+
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "must not be writable") {
+		t.Errorf("expected a writable working directory to be refused, got %v", result.Error)
+	}
+}
+
+func TestCanWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		mode os.FileMode
+		uid  uint32
+		gids []uint32
+		want bool
+	}{
+		{"root", 0555, 0, []uint32{0}, true},
+		{"owner", 0755, 1000, []uint32{1000}, true},
+		{"read-only owner", 0575, 1000, []uint32{1000}, false},
+		{"primary group", 0570, 2000, []uint32{100}, true},
+		{"supplementary group", 0570, 2000, []uint32{2000, 27, 100}, true},
+		{"read-only group", 0755, 2000, []uint32{2000, 100}, false},
+		{"other", 0757, 2000, []uint32{2000}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The directory is owned by uid 1000 and gid 100
+			if got := canWrite(tt.mode, 1000, 100, tt.uid, tt.gids); got != tt.want {
+				t.Errorf("canWrite(%v) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandHandler_SandboxLimits(t *testing.T) {
+	skipUnlessLinux(t)
+	t.Setenv("MCP_FUSION_TEST_SECRET", "secret")
+	f := New(WithLogger(mlogger.NewMemoryLogger()))
+	group := &CommandGroupConfig{Name: "tools", Sandbox: &CommandSandboxConfig{OpenFiles: 8}}
+	command := &CommandConfig{ID: "limits", Parameters: []ParameterConfig{
+		{Name: "executable", Type: ParameterTypeString, Location: ParameterLocationControl, Static: true, Default: "/bin/sh"},
+		{Name: "script", Type: ParameterTypeString, Location: ParameterLocationArgument, Static: true, Default: "-c"},
+		{Name: "command", Type: ParameterTypeString, Location: ParameterLocationArgument, Static: true,
+			Default: `ulimit -n; echo "secret=${MCP_FUSION_TEST_SECRET:-unset}"`},
+	}}
+
+	// The handler always passes an environment, which must not displace the limits
+	result, err := NewCommandHandler(f, group, command).Handle(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result, "\n8\n") {
+		t.Errorf("expected an open files limit of 8, got:\n%s", result)
+	}
+	if !strings.Contains(result, "secret=unset") {
+		t.Errorf("expected server variables to be filtered without an allowlist, got:\n%s", result)
+	}
+}
@@ -84,13 +84,16 @@ const (
 	ResponseTypeJSON   ResponseType = "json"
 	ResponseTypeText   ResponseType = "text"
 	ResponseTypeBinary ResponseType = "binary"
+	ResponseTypeXML    ResponseType = "xml" // Converted to JSON before transforms are applied
 )
 
 // Config holds the main configuration for the fusion package
 type Config struct {
 	Logger     global.Logger                  `json:"-"`
 	Services   map[string]*ServiceConfig      `json:"services"`
-	Commands   map[string]*CommandGroupConfig `json:"commands"` // Command execution configs
+	Commands   map[string]*CommandGroupConfig `json:"commands"`             // Command execution configs
+	Policy     *PolicyConfig                  `json:"policy,omitempty"`     // Tool access policy
+	RateLimits *RateLimitConfig               `json:"rateLimits,omitempty"` // Tool request rate and concurrency limits
 	HTTPClient *http.Client                   `json:"-"`
 	Cache      Cache                          `json:"-"`
 	ConfigPath string                         `json:"-"`
@@ -109,10 +112,13 @@ type ServiceConfig struct {
 	ToolRefreshIntervalStr string                `json:"toolRefreshInterval,omitempty"`
 	CallTimeout            time.Duration         `json:"-"`
 	CallTimeoutSeconds     int                   `json:"callTimeout,omitempty"`
+	SessionIdleTimeout     time.Duration         `json:"-"`
+	SessionIdleTimeoutStr  string                `json:"sessionIdleTimeout,omitempty"`
 	Auth                   AuthConfig            `json:"auth"`
 	Endpoints              []EndpointConfig      `json:"endpoints,omitempty"`
 	Retry                  *RetryConfig          `json:"retry,omitempty"`
 	CircuitBreaker         *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+	OpenAPI                *OpenAPIConfig        `json:"openapi,omitempty"` // Import endpoints from an OpenAPI document
 }
 
 // IsHubService returns true if this service uses a hub transport (stdio or mcp_http)
@@ -120,6 +126,20 @@ func (s *ServiceConfig) IsHubService() bool {
 	return s.Transport == TransportTypeStdio || s.Transport == TransportTypeMCPHTTP || s.Transport == TransportTypeSSE
 }
 
+// UsesTenantCredentials returns true if this is a hub service that connects to
+// the downstream server with each tenant's own credentials rather than a shared
+// identity from the service config
+func (s *ServiceConfig) UsesTenantCredentials() bool {
+	if !s.IsHubService() {
+		return false
+	}
+	switch s.Auth.Type {
+	case AuthTypeUserCredentials, AuthTypeOAuth2External, AuthTypeSessionJWT:
+		return true
+	}
+	return false
+}
+
 // UnmarshalJSON implements custom JSON unmarshaling for ServiceConfig
 func (s *ServiceConfig) UnmarshalJSON(data []byte) error {
 	type Alias ServiceConfig
@@ -142,6 +162,15 @@ func (s *ServiceConfig) UnmarshalJSON(data []byte) error {
 		s.ToolRefreshInterval = duration
 	}
 
+	// Parse SessionIdleTimeout string to duration
+	if s.SessionIdleTimeoutStr != "" {
+		duration, err := time.ParseDuration(s.SessionIdleTimeoutStr)
+		if err != nil {
+			return fmt.Errorf("invalid sessionIdleTimeout duration '%s': %w", s.SessionIdleTimeoutStr, err)
+		}
+		s.SessionIdleTimeout = duration
+	}
+
 	// callTimeout is a plain number of seconds
 	if s.CallTimeoutSeconds < 0 {
 		return fmt.Errorf("invalid callTimeout '%d': must not be negative", s.CallTimeoutSeconds)
@@ -153,9 +182,10 @@ func (s *ServiceConfig) UnmarshalJSON(data []byte) error {
 
 // CommandGroupConfig represents a group of related commands
 type CommandGroupConfig struct {
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Commands    []CommandConfig `json:"commands"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Commands    []CommandConfig       `json:"commands"`
+	Sandbox     *CommandSandboxConfig `json:"sandbox,omitempty"` // Limits applied to every command in the group
 }
 
 // CommandConfig represents configuration for a single command
@@ -164,6 +194,7 @@ type CommandConfig struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description"`
 	Parameters  []ParameterConfig `json:"parameters"`
+	Async       bool              `json:"async,omitempty"` // Run as a background job and return a job ID
 }
 
 // TokenInvalidationConfig represents configuration for automatic token invalidation
@@ -222,13 +253,58 @@ type AuthConfig struct {
 	TokenInvalidation *TokenInvalidationConfig `json:"tokenInvalidation,omitempty"`
 }
 
-// RequestBodyConfig represents configuration for encoding request body parameters
-// before sending. When set, body parameters without a transform.targetName are
-// collected, passed through the named encoder, and the encoded result is placed
+// RequestBodyFormat represents how body parameters are serialized
+type RequestBodyFormat string
+
+const (
+	RequestBodyFormatJSON RequestBodyFormat = "json" // JSON object (default)
+	RequestBodyFormatForm RequestBodyFormat = "form" // application/x-www-form-urlencoded
+	RequestBodyFormatXML  RequestBodyFormat = "xml"  // XML document rendered from a template
+)
+
+// RequestBodyConfig represents configuration for shaping the request body.
+// For JSON bodies with an encoding, body parameters without a transform.targetName
+// are collected, passed through the named encoder, and the encoded result is placed
 // at wrapperPath in the JSON body. Parameters with targetName bypass encoding.
+// Form bodies send the body parameters URL-encoded; XML bodies render template
+// with the body parameters as data.
 type RequestBodyConfig struct {
-	Encoding    string `json:"encoding"`
-	WrapperPath string `json:"wrapperPath"`
+	Format      RequestBodyFormat `json:"format,omitempty"`      // Defaults to "json"
+	Encoding    string            `json:"encoding,omitempty"`    // Body encoder for JSON bodies
+	WrapperPath string            `json:"wrapperPath,omitempty"` // Where the encoded value is placed in JSON bodies
+	Template    string            `json:"template,omitempty"`    // text/template producing a JSON or XML body
+	JQ          string            `json:"jq,omitempty"`          // jq expression producing a JSON or form body
+}
+
+// GetFormat returns the effective request body format
+func (r *RequestBodyConfig) GetFormat() RequestBodyFormat {
+	if r.Format == "" {
+		return RequestBodyFormatJSON
+	}
+	return r.Format
+}
+
+// usesEncoder reports whether the body is a JSON object built from the body
+// parameters, optionally with an encoder, rather than by a template or jq
+func (r *RequestBodyConfig) usesEncoder() bool {
+	return r.GetFormat() == RequestBodyFormatJSON && r.Template == "" && r.JQ == ""
+}
+
+// GraphQLConfig turns an endpoint into a GraphQL operation. The document is
+// POSTed to the endpoint path and body parameters become its variables.
+type GraphQLConfig struct {
+	Query         string `json:"query"`                   // Query or mutation document
+	OperationName string `json:"operationName,omitempty"` // Operation to execute when the document defines several
+}
+
+// SOAPConfig turns an endpoint into a SOAP operation. The rendered body (and
+// optional header) is wrapped in a SOAP envelope, and faults in the response are
+// returned as errors.
+type SOAPConfig struct {
+	Version string `json:"version,omitempty"` // "1.1" (default) or "1.2"
+	Action  string `json:"action,omitempty"`  // SOAPAction of the operation
+	Header  string `json:"header,omitempty"`  // text/template producing the soap:Header content
+	Body    string `json:"body"`              // text/template producing the soap:Body content
 }
 
 // EndpointConfig represents configuration for a single API endpoint
@@ -241,6 +317,8 @@ type EndpointConfig struct {
 	BaseURL     string             `json:"baseURL,omitempty"` // Overrides service BaseURL when set
 	Parameters  []ParameterConfig  `json:"parameters"`
 	RequestBody *RequestBodyConfig `json:"requestBody,omitempty"`
+	GraphQL     *GraphQLConfig     `json:"graphql,omitempty"` // Sends a GraphQL operation instead of a REST request
+	SOAP        *SOAPConfig        `json:"soap,omitempty"`    // Sends a SOAP envelope instead of a REST request
 	Response    ResponseConfig     `json:"response"`
 	Retry       *RetryConfig       `json:"retry,omitempty"`
 	Connection  *ConnectionConfig  `json:"connection,omitempty"`
@@ -282,6 +360,7 @@ type ParameterConfig struct {
 	Quoted        bool              `json:"quoted,omitempty"` // Whether to quote the parameter value
 	Static        bool              `json:"static,omitempty"` // Whether this is a static parameter (not exposed to MCP, always uses default)
 	FileNameParam string            `json:"fileNameParam,omitempty"` // For file-location params: name of another param that provides the Content-Disposition filename
+	Schema        map[string]interface{} `json:"schema,omitempty"`     // JSON Schema fragment for the value (nested objects, typed arrays, ranges, formats)
 }
 
 // ValidationConfig represents validation rules for a parameter
@@ -462,6 +541,7 @@ type ConnectionConfig struct {
 // ResponseConfig represents configuration for response handling
 type ResponseConfig struct {
 	Type             ResponseType      `json:"type"`
+	Decoder          string            `json:"decoder,omitempty"` // Registered decoder converting a non-JSON body for json responses
 	Transform        string            `json:"transform,omitempty"`
 	Paginated        bool              `json:"paginated,omitempty"`
 	PaginationConfig *PaginationConfig `json:"paginationConfig,omitempty"`
@@ -502,11 +582,89 @@ func (c *CachingConfig) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// PaginationStyle represents how the next page of a paginated response is requested
+type PaginationStyle string
+
+const (
+	PaginationStyleToken  PaginationStyle = "token"  // Token from the body sent back as a query parameter (or followed if it is a URL)
+	PaginationStyleCursor PaginationStyle = "cursor" // Same as token, with "cursor" as the default query parameter
+	PaginationStyleOffset PaginationStyle = "offset" // Offset/limit query parameters
+	PaginationStylePage   PaginationStyle = "page"   // Page number query parameter
+	PaginationStyleLink   PaginationStyle = "link"   // RFC 5988 Link header with rel="next"
+
+	PaginationStyleConnection PaginationStyle = "connection" // GraphQL cursor connection; endCursor sent back as a variable
+)
+
 // PaginationConfig represents configuration for paginated responses
 type PaginationConfig struct {
-	NextPageTokenPath string `json:"nextPageTokenPath"`
-	DataPath          string `json:"dataPath"`
-	PageSize          int    `json:"pageSize"`
+	Style             PaginationStyle `json:"style,omitempty"` // Defaults to "token"
+	NextPageTokenPath string          `json:"nextPageTokenPath,omitempty"`
+	DataPath          string          `json:"dataPath"`
+	PageSize          int             `json:"pageSize"`
+	TokenParam        string          `json:"tokenParam,omitempty"`  // Query parameter carrying the token/cursor
+	OffsetParam       string          `json:"offsetParam,omitempty"` // Query parameter carrying the offset (default "offset")
+	LimitParam        string          `json:"limitParam,omitempty"`  // Query parameter carrying the page size (default "limit" for offset style)
+	PageParam         string          `json:"pageParam,omitempty"`   // Query parameter carrying the page number (default "page")
+	MaxPages          int             `json:"maxPages,omitempty"`    // Default number of pages to fetch per call
+	MaxItems          int             `json:"maxItems,omitempty"`    // Maximum number of merged items (0 = no limit)
+}
+
+// GetStyle returns the effective pagination style
+func (p *PaginationConfig) GetStyle() PaginationStyle {
+	if p.Style == "" {
+		return PaginationStyleToken
+	}
+	return p.Style
+}
+
+// GetTokenParam returns the query parameter used to send the next-page token
+func (p *PaginationConfig) GetTokenParam() string {
+	if p.TokenParam != "" {
+		return p.TokenParam
+	}
+	switch p.GetStyle() {
+	case PaginationStyleCursor:
+		return "cursor"
+	case PaginationStyleConnection:
+		return "after"
+	}
+	return "pageToken"
+}
+
+// GetOffsetParam returns the query parameter used to send the offset
+func (p *PaginationConfig) GetOffsetParam() string {
+	if p.OffsetParam != "" {
+		return p.OffsetParam
+	}
+	return "offset"
+}
+
+// GetLimitParam returns the query parameter used to send the page size.
+// Only offset style has a default; other styles send no limit unless configured.
+func (p *PaginationConfig) GetLimitParam() string {
+	if p.LimitParam != "" {
+		return p.LimitParam
+	}
+	if p.GetStyle() == PaginationStyleOffset {
+		return "limit"
+	}
+	return ""
+}
+
+// GetPageParam returns the query parameter used to send the page number
+func (p *PaginationConfig) GetPageParam() string {
+	if p.PageParam != "" {
+		return p.PageParam
+	}
+	return "page"
+}
+
+// GetMaxPages returns the default number of pages to fetch per call
+func (p *PaginationConfig) GetMaxPages() int {
+	if p.MaxPages > 0 {
+		return p.MaxPages
+	}
+	return global.DefaultPaginationMaxPages
 }
 
 // LoadConfigFromFile loads configuration from a JSON file
@@ -554,6 +712,15 @@ func LoadConfigFromJSONWithLogger(data []byte, configPath string, logger global.
 
 	config.ConfigPath = configPath
 
+	// Generate the endpoints of services that import an OpenAPI document
+	if err := config.importOpenAPIServices(logger); err != nil {
+		if logger != nil {
+			logger.Errorf("Failed to import OpenAPI document: %v", err)
+		}
+		return nil, NewConfigurationError("openapi", "",
+			"failed to import OpenAPI document", err)
+	}
+
 	// Validate the configuration
 	if logger != nil {
 		logger.Debug("Validating configuration")
@@ -593,14 +760,26 @@ func (c *Config) ValidateWithLogger(logger global.Logger) error {
 		logger.Debug("Starting configuration validation")
 	}
 
-	// Require at least one service OR one command group
-	if len(c.Services) == 0 && len(c.Commands) == 0 {
+	// Require at least one service OR one command group, unless the file only carries a policy or limits
+	if len(c.Services) == 0 && len(c.Commands) == 0 && c.Policy == nil && c.RateLimits == nil {
 		if logger != nil {
 			logger.Error("Configuration validation failed: no services or commands configured")
 		}
 		return fmt.Errorf("no services or commands configured")
 	}
 
+	if c.Policy != nil {
+		if err := c.Policy.ValidateWithLogger(logger); err != nil {
+			return fmt.Errorf("policy: %w", err)
+		}
+	}
+
+	if c.RateLimits != nil {
+		if err := c.RateLimits.ValidateWithLogger(logger); err != nil {
+			return fmt.Errorf("rateLimits: %w", err)
+		}
+	}
+
 	if logger != nil {
 		logger.Debugf("Validating %d services", len(c.Services))
 	}
@@ -617,6 +796,14 @@ func (c *Config) ValidateWithLogger(logger global.Logger) error {
 		}
 	}
 
+	for groupName, commandGroup := range c.Commands {
+		if commandGroup.Sandbox != nil {
+			if err := commandGroup.Sandbox.ValidateWithLogger(groupName, logger); err != nil {
+				return fmt.Errorf("command group %s: sandbox: %w", groupName, err)
+			}
+		}
+	}
+
 	if logger != nil {
 		logger.Debug("Configuration validation completed successfully")
 	}
@@ -645,6 +832,12 @@ func (s *ServiceConfig) ValidateWithLogger(serviceName string, logger global.Log
 	// Branch validation based on transport type
 	switch s.Transport {
 	case TransportTypeStdio:
+		if s.UsesTenantCredentials() {
+			if logger != nil {
+				logger.Errorf("Service %s: %s auth requires the mcp_http or sse transport", serviceName, s.Auth.Type)
+			}
+			return fmt.Errorf("%s auth requires the mcp_http or sse transport", s.Auth.Type)
+		}
 		if s.Command == "" {
 			if logger != nil {
 				logger.Errorf("Service %s: command is required for stdio transport", serviceName)
@@ -688,6 +881,14 @@ func (s *ServiceConfig) ValidateWithLogger(serviceName string, logger global.Log
 				return fmt.Errorf("auth configuration: %w", err)
 			}
 		}
+		if s.UsesTenantCredentials() {
+			if err := s.validateTenantCredentials(); err != nil {
+				if logger != nil {
+					logger.Errorf("Service %s: %v", serviceName, err)
+				}
+				return err
+			}
+		}
 		if logger != nil {
 			logger.Debugf("Service %s: %s hub service validated (baseURL: %s)", serviceName, s.Transport, s.BaseURL)
 		}
@@ -741,6 +942,31 @@ func (s *ServiceConfig) ValidateWithLogger(serviceName string, logger global.Log
 	return nil
 }
 
+// validateTenantCredentials checks the auth configuration of a hub service that
+// uses per-tenant credentials. Credentials are sent as headers or cookies on
+// every request of the tenant's session; query parameters cannot be used because
+// the session URL is fixed when the transport connects.
+func (s *ServiceConfig) validateTenantCredentials() error {
+	if s.SessionIdleTimeout < 0 {
+		return fmt.Errorf("sessionIdleTimeout cannot be negative")
+	}
+	switch s.Auth.Type {
+	case AuthTypeUserCredentials:
+		fields, _ := s.Auth.Config["fields"].([]interface{})
+		for _, fieldRaw := range fields {
+			field, _ := fieldRaw.(map[string]interface{})
+			if location, _ := field["location"].(string); location == "query" {
+				return fmt.Errorf("user_credentials field '%v' cannot use location 'query' for a hub service", field["name"])
+			}
+		}
+	case AuthTypeSessionJWT:
+		if location, _ := s.Auth.Config["tokenLocation"].(string); location == "query" {
+			return fmt.Errorf("session_jwt tokenLocation cannot be 'query' for a hub service")
+		}
+	}
+	return nil
+}
+
 // Validate validates a service configuration
 func (s *ServiceConfig) Validate() error {
 	return s.ValidateWithLogger("", nil)
@@ -1037,8 +1263,38 @@ func (e *EndpointConfig) ValidateWithLogger(serviceName string, logger global.Lo
 		}
 	}
 
-	// Validate requestBody encoding configuration if present
-	if e.RequestBody != nil {
+	if e.GraphQL != nil {
+		if err := e.validateGraphQL(); err != nil {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s graphql configuration validation failed: %v", serviceName, e.ID, err)
+			}
+			return fmt.Errorf("graphql configuration: %w", err)
+		}
+	} else if e.Response.PaginationConfig != nil && e.Response.PaginationConfig.GetStyle() == PaginationStyleConnection {
+		if logger != nil {
+			logger.Errorf("Service %s: endpoint %s uses connection pagination without graphql", serviceName, e.ID)
+		}
+		return fmt.Errorf("connection pagination requires a graphql endpoint")
+	}
+
+	if e.SOAP != nil {
+		if err := e.validateSOAP(); err != nil {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s soap configuration validation failed: %v", serviceName, e.ID, err)
+			}
+			return fmt.Errorf("soap configuration: %w", err)
+		}
+	}
+
+	// Validate requestBody configuration if present
+	if e.RequestBody != nil && !e.RequestBody.usesEncoder() {
+		if err := e.RequestBody.validateFormat(); err != nil {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s requestBody validation failed: %v", serviceName, e.ID, err)
+			}
+			return fmt.Errorf("requestBody: %w", err)
+		}
+	} else if e.RequestBody != nil {
 		if e.RequestBody.Encoding == "" {
 			if logger != nil {
 				logger.Errorf("Service %s: endpoint %s requestBody.encoding is required", serviceName, e.ID)
@@ -1051,12 +1307,6 @@ func (e *EndpointConfig) ValidateWithLogger(serviceName string, logger global.Lo
 			}
 			return fmt.Errorf("requestBody.wrapperPath is required")
 		}
-		if _, ok := GetBodyEncoder(e.RequestBody.Encoding); !ok {
-			if logger != nil {
-				logger.Errorf("Service %s: endpoint %s unknown requestBody encoding: %s", serviceName, e.ID, e.RequestBody.Encoding)
-			}
-			return fmt.Errorf("unknown requestBody encoding: %s", e.RequestBody.Encoding)
-		}
 		if logger != nil {
 			logger.Debugf("Service %s: endpoint %s requestBody encoding validated: %s → %s",
 				serviceName, e.ID, e.RequestBody.Encoding, e.RequestBody.WrapperPath)
@@ -1131,6 +1381,15 @@ func (p *ParameterConfig) ValidateWithLogger(serviceName, endpointID string, log
 		return fmt.Errorf("invalid parameter location: %s", p.Location)
 	}
 
+	if p.Schema != nil {
+		if err := p.validateSchema(); err != nil {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s parameter %s has an invalid schema: %v", serviceName, endpointID, p.Name, err)
+			}
+			return err
+		}
+	}
+
 	if p.Validation != nil {
 		if logger != nil {
 			logger.Debugf("Service %s: endpoint %s parameter %s validating validation rules", serviceName, endpointID, p.Name)
@@ -1155,6 +1414,26 @@ func (p *ParameterConfig) Validate() error {
 	return p.ValidateWithLogger("", "", nil)
 }
 
+// validateSchema checks the parameter schema and that its type agrees with the parameter type
+func (p *ParameterConfig) validateSchema() error {
+	if err := CheckSchema(p.Schema); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	raw, ok := p.Schema["type"]
+	if !ok {
+		return nil
+	}
+	types, _ := schemaTypeNames(raw)
+	for _, name := range types {
+		if name == string(p.Type) || (name == "integer" && p.Type == ParameterTypeNumber) ||
+			(name == "number" && p.Type == ParameterTypeInteger) {
+			return nil
+		}
+	}
+	return fmt.Errorf("schema type %v does not match parameter type %s", raw, p.Type)
+}
+
 // ValidateWithLogger validates a validation configuration with logging support
 func (v *ValidationConfig) ValidateWithLogger(serviceName, endpointID, parameterName string, logger global.Logger) error {
 	if logger != nil {
@@ -1188,6 +1467,13 @@ func (v *ValidationConfig) ValidateWithLogger(serviceName, endpointID, parameter
 		return fmt.Errorf("minLength cannot be greater than maxLength")
 	}
 
+	if v.Minimum != nil && v.Maximum != nil && *v.Minimum > *v.Maximum {
+		if logger != nil {
+			logger.Errorf("Service %s: endpoint %s parameter %s minimum (%v) cannot be greater than maximum (%v)", serviceName, endpointID, parameterName, *v.Minimum, *v.Maximum)
+		}
+		return fmt.Errorf("minimum cannot be greater than maximum")
+	}
+
 	if logger != nil && len(v.Enum) > 0 {
 		logger.Debugf("Service %s: endpoint %s parameter %s has %d enum values", serviceName, endpointID, parameterName, len(v.Enum))
 	}
@@ -1215,6 +1501,7 @@ func (r *ResponseConfig) ValidateWithLogger(serviceName, endpointID string, logg
 		ResponseTypeJSON:   true,
 		ResponseTypeText:   true,
 		ResponseTypeBinary: true,
+		ResponseTypeXML:    true,
 	}
 
 	if !validTypes[r.Type] {
@@ -1231,6 +1518,28 @@ func (r *ResponseConfig) ValidateWithLogger(serviceName, endpointID string, logg
 		return fmt.Errorf("paginated response requires paginationConfig")
 	}
 
+	if r.Paginated && r.Type == ResponseTypeXML {
+		if logger != nil {
+			logger.Errorf("Service %s: endpoint %s xml responses cannot be paginated", serviceName, endpointID)
+		}
+		return fmt.Errorf("xml responses cannot be paginated")
+	}
+
+	if r.Decoder != "" {
+		if r.Type != ResponseTypeJSON {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s decoder requires a json response, got %s", serviceName, endpointID, r.Type)
+			}
+			return fmt.Errorf("decoder requires a json response, got %s", r.Type)
+		}
+		if r.Paginated {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s decoded responses cannot be paginated", serviceName, endpointID)
+			}
+			return fmt.Errorf("decoded responses cannot be paginated")
+		}
+	}
+
 	if r.PaginationConfig != nil {
 		if logger != nil {
 			logger.Debugf("Service %s: endpoint %s validating pagination configuration", serviceName, endpointID)
@@ -1266,11 +1575,21 @@ func (p *PaginationConfig) ValidateWithLogger(serviceName, endpointID string, lo
 			serviceName, endpointID, p.PageSize)
 	}
 
-	if p.NextPageTokenPath == "" {
+	switch p.GetStyle() {
+	case PaginationStyleToken, PaginationStyleCursor:
+		if p.NextPageTokenPath == "" {
+			if logger != nil {
+				logger.Errorf("Service %s: endpoint %s nextPageTokenPath is required for pagination", serviceName, endpointID)
+			}
+			return fmt.Errorf("nextPageTokenPath is required for pagination")
+		}
+	case PaginationStyleOffset, PaginationStylePage, PaginationStyleLink, PaginationStyleConnection:
+		// No token path needed; the next page is derived from the request, headers or pageInfo
+	default:
 		if logger != nil {
-			logger.Errorf("Service %s: endpoint %s nextPageTokenPath is required for pagination", serviceName, endpointID)
+			logger.Errorf("Service %s: endpoint %s has invalid pagination style: %s", serviceName, endpointID, p.Style)
 		}
-		return fmt.Errorf("nextPageTokenPath is required for pagination")
+		return fmt.Errorf("invalid pagination style: %s", p.Style)
 	}
 
 	if p.DataPath == "" {
@@ -1287,9 +1606,24 @@ func (p *PaginationConfig) ValidateWithLogger(serviceName, endpointID string, lo
 		return fmt.Errorf("pageSize must be positive")
 	}
 
+	if p.MaxPages < 0 || p.MaxPages > global.MaxPaginationPages {
+		if logger != nil {
+			logger.Errorf("Service %s: endpoint %s maxPages must be between 0 and %d, got: %d",
+				serviceName, endpointID, global.MaxPaginationPages, p.MaxPages)
+		}
+		return fmt.Errorf("maxPages must be between 0 and %d", global.MaxPaginationPages)
+	}
+
+	if p.MaxItems < 0 {
+		if logger != nil {
+			logger.Errorf("Service %s: endpoint %s maxItems cannot be negative, got: %d", serviceName, endpointID, p.MaxItems)
+		}
+		return fmt.Errorf("maxItems cannot be negative")
+	}
+
 	if logger != nil {
-		logger.Debugf("Service %s: endpoint %s pagination configuration validated successfully (nextToken: %s, data: %s)",
-			serviceName, endpointID, p.NextPageTokenPath, p.DataPath)
+		logger.Debugf("Service %s: endpoint %s pagination configuration validated successfully (style: %s, nextToken: %s, data: %s)",
+			serviceName, endpointID, p.GetStyle(), p.NextPageTokenPath, p.DataPath)
 	}
 
 	return nil
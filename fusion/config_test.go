@@ -1378,6 +1378,79 @@ func TestServiceConfig_ToolRefreshInterval(t *testing.T) {
 	assert.Equal(t, 5*time.Minute, service.ToolRefreshInterval)
 }
 
+func TestServiceConfig_TenantCredentialsValidation(t *testing.T) {
+	oauth := AuthConfig{Type: AuthTypeOAuth2External, Config: map[string]interface{}{
+		"clientId": "client", "tokenURL": "https://auth.example.com/token",
+	}}
+	credentialFields := func(location string) AuthConfig {
+		return AuthConfig{Type: AuthTypeUserCredentials, Config: map[string]interface{}{
+			"fields": []interface{}{map[string]interface{}{"name": "apiKey", "location": location}},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		service  ServiceConfig
+		errorMsg string
+	}{
+		{
+			name:    "oauth2_external over mcp_http",
+			service: ServiceConfig{Name: "Hub", Transport: TransportTypeMCPHTTP, BaseURL: "http://localhost:9090/mcp", Auth: oauth},
+		},
+		{
+			name:    "user_credentials header over sse",
+			service: ServiceConfig{Name: "Hub", Transport: TransportTypeSSE, BaseURL: "http://localhost:9090/sse", Auth: credentialFields("header")},
+		},
+		{
+			name:     "per-tenant auth over stdio",
+			service:  ServiceConfig{Name: "Hub", Transport: TransportTypeStdio, Command: "/usr/bin/test-server", Auth: oauth},
+			errorMsg: "oauth2_external auth requires the mcp_http or sse transport",
+		},
+		{
+			name:     "user_credentials in the query",
+			service:  ServiceConfig{Name: "Hub", Transport: TransportTypeMCPHTTP, BaseURL: "http://localhost:9090/mcp", Auth: credentialFields("query")},
+			errorMsg: "cannot use location 'query'",
+		},
+		{
+			name: "session_jwt token in the query",
+			service: ServiceConfig{Name: "Hub", Transport: TransportTypeMCPHTTP, BaseURL: "http://localhost:9090/mcp", Auth: AuthConfig{
+				Type: AuthTypeSessionJWT, Config: map[string]interface{}{
+					"loginURL": "/login", "tokenPath": "token", "tokenLocation": "query", "queryParam": "token",
+				},
+			}},
+			errorMsg: "session_jwt tokenLocation cannot be 'query'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.service.UsesTenantCredentials())
+			err := tt.service.Validate()
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			}
+		})
+	}
+
+	bearer := ServiceConfig{Transport: TransportTypeMCPHTTP, Auth: AuthConfig{Type: AuthTypeBearer}}
+	assert.False(t, bearer.UsesTenantCredentials(), "static credentials are shared by all tenants")
+	rest := ServiceConfig{Auth: oauth}
+	assert.False(t, rest.UsesTenantCredentials(), "only hub services use tenant sessions")
+}
+
+func TestServiceConfig_SessionIdleTimeout(t *testing.T) {
+	var service ServiceConfig
+	err := json.Unmarshal([]byte(`{"transport": "mcp_http", "baseURL": "http://localhost/mcp", "sessionIdleTimeout": "5m"}`), &service)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, service.SessionIdleTimeout)
+
+	err = json.Unmarshal([]byte(`{"transport": "mcp_http", "sessionIdleTimeout": "soon"}`), &service)
+	assert.Error(t, err)
+}
+
 func TestLoadConfigFromJSON_StdioTransport(t *testing.T) {
 	configJSON := `{
 		"services": {
@@ -8,6 +8,7 @@ package fusion
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -250,6 +251,53 @@ func (e APIError) GetCategory() ErrorCategory {
 	return e.Category
 }
 
+// GraphQLError represents errors reported in the errors array of a GraphQL response
+type GraphQLError struct {
+	Service       string   `json:"service"`
+	Endpoint      string   `json:"endpoint"`
+	StatusCode    int      `json:"status_code"`
+	Messages      []string `json:"messages"`
+	CorrelationID string   `json:"correlation_id,omitempty"`
+}
+
+// Error implements the error interface. GraphQL error messages describe the
+// failed query (unknown fields, missing objects, denied access) rather than the
+// server, so they are passed on to the caller.
+func (e GraphQLError) Error() string {
+	base := "GraphQL request failed: " + strings.Join(e.Messages, "; ")
+	if e.CorrelationID != "" {
+		return base + " [" + e.CorrelationID + "]"
+	}
+	return base
+}
+
+// SOAPFaultError represents a fault returned in the body of a SOAP response
+type SOAPFaultError struct {
+	Service       string `json:"service"`
+	Endpoint      string `json:"endpoint"`
+	StatusCode    int    `json:"status_code"`
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	Detail        string `json:"detail,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// Error implements the error interface. Like GraphQL errors, fault strings
+// describe the failed operation and are passed on to the caller.
+func (e SOAPFaultError) Error() string {
+	base := "SOAP fault"
+	if e.Code != "" {
+		base += " " + e.Code
+	}
+	if e.Message != "" {
+		base += ": " + e.Message
+	}
+	if e.CorrelationID != "" {
+		return base + " [" + e.CorrelationID + "]"
+	}
+	return base
+}
+
 // TransformationError represents errors during parameter or response transformation
 type TransformationError struct {
 	Type       string      `json:"type"` // "parameter" or "response"
@@ -545,6 +593,24 @@ func AsAPIError(err error) (*APIError, bool) {
 	return nil, false
 }
 
+// AsGraphQLError safely extracts a GraphQLError from an error chain
+func AsGraphQLError(err error) (*GraphQLError, bool) {
+	var gqlErr *GraphQLError
+	if errors.As(err, &gqlErr) {
+		return gqlErr, true
+	}
+	return nil, false
+}
+
+// AsSOAPFaultError safely extracts a SOAPFaultError from an error chain
+func AsSOAPFaultError(err error) (*SOAPFaultError, bool) {
+	var faultErr *SOAPFaultError
+	if errors.As(err, &faultErr) {
+		return faultErr, true
+	}
+	return nil, false
+}
+
 // AsValidationError safely extracts a ValidationError from an error chain
 func AsValidationError(err error) (*ValidationError, bool) {
 	var valErr *ValidationError
@@ -39,6 +39,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strconv"
@@ -73,20 +74,25 @@ var _ global.PromptProvider = (*Fusion)(nil)
 // - logger: Structured logging with correlation ID support
 // - metricsCollector: Real-time metrics and health monitoring
 // - circuitBreakers: Per-service circuit breaker protection
+// - serviceQuotas: Per-tenant, per-service upstream rate limit quota, used to pace requests
 //
 // Thread Safety:
 // All public methods are thread-safe and can be called concurrently from multiple
 // goroutines. Internal state is protected by appropriate synchronization primitives.
 type Fusion struct {
 	config                 *Config                    // Service configuration and endpoints
+	configMutex            sync.RWMutex               // Protects config during hot reload
 	multiTenantAuth        *MultiTenantAuthManager    // Multi-tenant authentication manager (required)
 	httpClient             *http.Client               // HTTP client with timeouts
 	cache                  Cache                      // Database cache from multi-tenant auth manager
+	responseCache          *ResponseCache             // Per-tenant cache of endpoint responses
 	logger                 global.Logger              // Structured logging interface
 	metricsCollector       *MetricsCollector          // Performance and health metrics
 	correlationIDGenerator *CorrelationIDGenerator    // Request correlation tracking
 	circuitBreakers        map[string]*CircuitBreaker // Per-service circuit breakers
 	circuitBreakersMutex   sync.RWMutex               // Protects circuitBreakers map
+	serviceQuotas          map[string]*serviceQuota   // Per-tenant, per-service upstream quota reported by responses
+	serviceQuotasMutex     sync.Mutex                 // Protects serviceQuotas map
 
 	// Connection health management
 	connectionCleanupTicker *time.Ticker  // Periodic connection cleanup
@@ -117,6 +123,22 @@ type Fusion struct {
 	// downloadDir is the directory where binary responses are saved.
 	// If empty, binary responses return an informational message only.
 	downloadDir string
+
+	// jobsDir is the directory where asynchronous command job output is kept.
+	// Defaults to "jobs" under the database directory.
+	jobsDir string
+
+	// jobLimits bounds the asynchronous command jobs that are kept and run
+	jobLimits JobLimits
+
+	// jobs runs asynchronous command jobs; nil when no database is configured
+	jobs *JobManager
+
+	// bodyEncoders and responseDecoders are the encoders and decoders endpoints
+	// can select: the built-ins plus those added with WithBodyEncoder and
+	// WithResponseDecoder. Read-only once New returns.
+	bodyEncoders     map[string]BodyEncoder
+	responseDecoders map[string]ResponseDecoder
 }
 
 // NativeToolPrefixRegistrar allows registering prefixes for native (non-config-driven)
@@ -305,6 +327,21 @@ func WithDownloadDir(dir string) Option {
 	}
 }
 
+// WithJobsDir sets the directory where asynchronous command job output is kept.
+func WithJobsDir(dir string) Option {
+	return func(f *Fusion) {
+		f.jobsDir = dir
+	}
+}
+
+// WithJobLimits sets how long finished asynchronous command jobs are kept and
+// how many may run at the same time. By default there are no limits.
+func WithJobLimits(limits JobLimits) Option {
+	return func(f *Fusion) {
+		f.jobLimits = limits
+	}
+}
+
 // WithDatabase sets the database for native tool operations such as the knowledge store.
 func WithDatabase(database db.Database) Option {
 	return func(f *Fusion) {
@@ -337,6 +374,30 @@ func WithAllowDestructive(allow bool) Option {
 	}
 }
 
+// WithBodyEncoder adds a body encoder that this instance's endpoints can select
+// with requestBody.encoding, replacing any encoder of the same name. It panics
+// if the name is empty or the encoder is nil.
+func WithBodyEncoder(name string, encoder BodyEncoder) Option {
+	if strings.TrimSpace(name) == "" || encoder == nil {
+		panic(fmt.Sprintf("fusion: invalid body encoder %q", name))
+	}
+	return func(f *Fusion) {
+		f.bodyEncoders[name] = encoder
+	}
+}
+
+// WithResponseDecoder adds a response decoder that this instance's endpoints
+// can select with response.decoder, replacing any decoder of the same name. It
+// panics if the name is empty or the decoder is nil.
+func WithResponseDecoder(name string, decoder ResponseDecoder) Option {
+	if strings.TrimSpace(name) == "" || decoder == nil {
+		panic(fmt.Sprintf("fusion: invalid response decoder %q", name))
+	}
+	return func(f *Fusion) {
+		f.responseDecoders[name] = decoder
+	}
+}
+
 // New creates a new production-ready Fusion instance with the provided configuration options.
 // This is the primary constructor for the Fusion provider and initializes all components
 // required for API integration including multi-tenant authentication, database caching,
@@ -410,11 +471,21 @@ func New(options ...Option) *Fusion {
 			Transport: transport,
 			Timeout:   global.HTTPDefaultClientTimeout,
 		},
-		cache:                  nil,                            // Cache will be set by multi-tenant auth manager
+		cache:                  nil, // Cache will be set by multi-tenant auth manager
+		responseCache:          NewResponseCache(nil),
 		metricsCollector:       NewMetricsCollector(nil, true), // Enable metrics by default
 		correlationIDGenerator: NewCorrelationIDGenerator(),
 		circuitBreakers:        make(map[string]*CircuitBreaker),
+		serviceQuotas:          make(map[string]*serviceQuota),
 		maxResponseBytes:       global.DefaultMaxResponseBytes,
+		bodyEncoders:           make(map[string]BodyEncoder, len(bodyEncoders)),
+		responseDecoders:       make(map[string]ResponseDecoder, len(responseDecoders)),
+	}
+	for name, encoder := range bodyEncoders {
+		fusion.bodyEncoders[name] = encoder
+	}
+	for name, decoder := range responseDecoders {
+		fusion.responseDecoders[name] = decoder
 	}
 
 	// Apply all options
@@ -457,6 +528,8 @@ func New(options ...Option) *Fusion {
 		panic("Multi-tenant auth manager must have a valid database cache")
 	}
 
+	fusion.responseCache.logger = fusion.logger
+
 	// Update metrics collector with logger
 	if fusion.metricsCollector != nil {
 		fusion.metricsCollector.logger = fusion.logger
@@ -488,6 +561,15 @@ func New(options ...Option) *Fusion {
 		}
 	}
 
+	// Asynchronous command jobs keep their records in the database
+	if fusion.database != nil {
+		jobsDir := fusion.jobsDir
+		if jobsDir == "" {
+			jobsDir = filepath.Join(fusion.database.DataDir(), "jobs")
+		}
+		fusion.jobs = NewJobManager(fusion.database, jobsDir, fusion.jobLimits, fusion.logger)
+	}
+
 	// Initialize connection health management
 	fusion.shutdownChan = make(chan struct{})
 	fusion.startConnectionHealthManagement()
@@ -503,6 +585,8 @@ func New(options ...Option) *Fusion {
 
 // GetConfig returns the current configuration
 func (f *Fusion) GetConfig() *Config {
+	f.configMutex.RLock()
+	defer f.configMutex.RUnlock()
 	return f.config
 }
 
@@ -518,6 +602,11 @@ func (f *Fusion) GetCache() Cache {
 	return f.cache
 }
 
+// GetResponseCache returns the per-tenant endpoint response cache
+func (f *Fusion) GetResponseCache() *ResponseCache {
+	return f.responseCache
+}
+
 // GetLogger returns the logger
 func (f *Fusion) GetLogger() global.Logger {
 	return f.logger
@@ -569,7 +658,8 @@ func (f *Fusion) DownloadDir() string {
 // - google_list_calendar_events
 // - google_list_files
 func (f *Fusion) RegisterTools() []global.ToolDefinition {
-	if f.config == nil {
+	config := f.GetConfig()
+	if config == nil {
 		if f.logger != nil {
 			f.logger.Warning("No configuration loaded, cannot register tools")
 		}
@@ -579,15 +669,21 @@ func (f *Fusion) RegisterTools() []global.ToolDefinition {
 	var tools []global.ToolDefinition
 
 	// Register service tools (existing)
-	for serviceName, service := range f.config.Services {
+	for serviceName, service := range config.Services {
 		for _, endpoint := range service.Endpoints {
+			if err := f.checkEndpointCodecs(&endpoint); err != nil {
+				if f.logger != nil {
+					f.logger.Errorf("Skipping tool %s_%s: %v", serviceName, endpoint.ID, err)
+				}
+				continue
+			}
 			tool := f.createToolDefinition(serviceName, service, &endpoint)
 			tools = append(tools, tool)
 		}
 	}
 
 	// Register auth setup tools for services requiring authentication
-	for serviceName, service := range f.config.Services {
+	for serviceName, service := range config.Services {
 		if service.Auth.Type == AuthTypeOAuth2External || service.Auth.Type == AuthTypeUserCredentials {
 			tool := f.createAuthSetupToolDefinition(serviceName, service)
 			tools = append(tools, tool)
@@ -598,23 +694,36 @@ func (f *Fusion) RegisterTools() []global.ToolDefinition {
 	}
 
 	// Register command tools (NEW)
-	for groupName, commandGroup := range f.config.Commands {
+	hasAsyncCommands := false
+	for groupName, commandGroup := range config.Commands {
 		for i := range commandGroup.Commands {
 			command := &commandGroup.Commands[i]
 			tool := f.createCommandToolDefinition(groupName, commandGroup, command)
 			tools = append(tools, tool)
+			hasAsyncCommands = hasAsyncCommands || command.Async
+		}
+	}
+
+	// Register job tools when any command runs asynchronously
+	if hasAsyncCommands {
+		if f.jobs == nil && f.logger != nil {
+			f.logger.Warning("Asynchronous commands are configured but no database is available - they will fail")
 		}
+		tools = append(tools, f.createJobToolDefinitions()...)
 	}
 
 	// Register native tool prefixes so auth middleware recognises them
 	if f.nativeToolPrefixRegistrar != nil {
 		f.nativeToolPrefixRegistrar.RegisterNativeToolPrefix("command")
+		if hasAsyncCommands {
+			f.nativeToolPrefixRegistrar.RegisterNativeToolPrefix("job")
+		}
 	}
 
 	// Register services with the shared metrics collector
 	if f.sharedCollector != nil {
 		// API services (non-hub, config-driven)
-		for serviceName, svc := range f.config.Services {
+		for serviceName, svc := range config.Services {
 			if svc.IsHubService() {
 				continue
 			}
@@ -630,6 +739,22 @@ func (f *Fusion) RegisterTools() []global.ToolDefinition {
 	return tools
 }
 
+// checkEndpointCodecs checks that the body encoder and response decoder an
+// endpoint selects are available to this instance
+func (f *Fusion) checkEndpointCodecs(endpoint *EndpointConfig) error {
+	if endpoint.RequestBody != nil && endpoint.RequestBody.Encoding != "" {
+		if _, ok := f.bodyEncoders[endpoint.RequestBody.Encoding]; !ok {
+			return fmt.Errorf("unknown requestBody encoding: %s", endpoint.RequestBody.Encoding)
+		}
+	}
+	if endpoint.Response.Decoder != "" {
+		if _, ok := f.responseDecoders[endpoint.Response.Decoder]; !ok {
+			return fmt.Errorf("unknown response decoder: %s", endpoint.Response.Decoder)
+		}
+	}
+	return nil
+}
+
 // createToolDefinition creates a tool definition from an endpoint configuration
 func (f *Fusion) createToolDefinition(serviceName string, service *ServiceConfig, endpoint *EndpointConfig) global.ToolDefinition {
 	// Validate parameter names for conflicts
@@ -673,6 +798,7 @@ func (f *Fusion) createToolDefinition(serviceName string, service *ServiceConfig
 			Items:       string(param.Items),
 			Default:     param.Default,
 			Examples:    param.Examples,
+			Schema:      param.Schema,
 		}
 
 		// Copy validation rules if present
@@ -700,14 +826,32 @@ func (f *Fusion) createToolDefinition(serviceName string, service *ServiceConfig
 		parameters = append(parameters, globalParam)
 	}
 
+	// Paginated endpoints expose an optional page budget unless the endpoint
+	// already defines a parameter with the same name
+	if endpoint.Response.Paginated && endpoint.Response.PaginationConfig != nil &&
+		endpoint.GetParameterByName(MaxPagesArgument) == nil {
+		minPages := float64(1)
+		maxPages := float64(global.MaxPaginationPages)
+		pagesParam := global.Parameter{
+			Name:        MaxPagesArgument,
+			Description: "Maximum number of result pages to fetch and merge",
+			Type:        string(ParameterTypeNumber),
+			Default:     endpoint.Response.PaginationConfig.GetMaxPages(),
+			Minimum:     &minPages,
+			Maximum:     &maxPages,
+		}
+		pagesParam.Description = pagesParam.EnhancedDescription()
+		parameters = append(parameters, pagesParam)
+	}
+
 	// Create the tool handler
 	handler := f.createToolHandler(serviceName, service, endpoint)
 
 	// Generate tool name by combining service and endpoint names
 	toolName := fmt.Sprintf("%s_%s", serviceName, endpoint.ID)
 
-	// Compute default hints based on HTTP method
-	hints := global.ComputeDefaultHints(endpoint.Method)
+	// Compute default hints based on HTTP method (GraphQL queries count as reads)
+	hints := global.ComputeDefaultHints(endpoint.effectiveMethod())
 
 	// Override with any explicitly configured hints
 	if endpoint.Hints != nil {
@@ -785,6 +929,7 @@ func (f *Fusion) createCommandToolDefinition(groupName string, commandGroup *Com
 			Items:       string(param.Items),
 			Default:     param.Default,
 			Examples:    param.Examples,
+			Schema:      param.Schema,
 		}
 
 		// Copy validation rules if present
@@ -818,9 +963,14 @@ func (f *Fusion) createCommandToolDefinition(groupName string, commandGroup *Com
 	// Generate tool name: command_{id}
 	toolName := fmt.Sprintf("command_%s", command.ID)
 
+	description := command.Description
+	if command.Async {
+		description += " Runs asynchronously: returns a job ID immediately. Use job_status, job_output and job_cancel to follow the job."
+	}
+
 	return global.ToolDefinition{
 		Name:        toolName,
-		Description: command.Description,
+		Description: description,
 		Parameters:  parameters,
 		Handler:     handler,
 	}
@@ -832,8 +982,14 @@ func (f *Fusion) createCommandToolHandler(commandGroup *CommandGroupConfig, comm
 		// Create command handler
 		handler := NewCommandHandler(f, commandGroup, command)
 
-		// Execute command
+		// Keep the MCP request's values (e.g. the tenant context) but not its
+		// cancellation; commands run for their own configured timeout
 		ctx := context.Background()
+		if mcpCtx, ok := args["__mcp_context"].(context.Context); ok {
+			ctx = context.WithoutCancel(mcpCtx)
+		}
+
+		// Execute command
 		return handler.Handle(ctx, args)
 	}
 }
@@ -915,46 +1071,61 @@ func (f *Fusion) RegisterPrompts() []global.PromptDefinition {
 
 // Validate validates the current configuration
 func (f *Fusion) Validate() error {
-	if f.config == nil {
+	config := f.GetConfig()
+	if config == nil {
 		return NewConfigurationError("config", "", "no configuration loaded", nil)
 	}
 
-	return f.config.Validate()
+	return config.Validate()
 }
 
 // ReloadConfig reloads the configuration from the original file
 func (f *Fusion) ReloadConfig() error {
-	if f.config == nil || f.config.ConfigPath == "" {
+	config := f.GetConfig()
+	if config == nil || config.ConfigPath == "" {
 		return NewConfigurationError("configPath", "", "no config path available for reload", nil)
 	}
 
-	newConfig, err := LoadConfigFromFile(f.config.ConfigPath)
+	newConfig, err := LoadConfigFromFile(config.ConfigPath)
 	if err != nil {
 		return NewConfigurationError("config", "", "failed to reload configuration", err)
 	}
 
-	// Update configuration
+	f.ApplyConfig(newConfig)
+	return nil
+}
+
+// ApplyConfig replaces the active configuration with an already validated one.
+// Tool handlers registered before the swap keep the service and endpoint
+// definitions they were created with; callers re-register tools (see
+// RegisterTools) to expose the new definitions.
+func (f *Fusion) ApplyConfig(newConfig *Config) {
+	newConfig.Logger = f.logger
+	newConfig.HTTPClient = f.httpClient
+	newConfig.Cache = f.cache
+
+	f.configMutex.Lock()
 	f.config = newConfig
-	f.config.Logger = f.logger
-	// Legacy authManager reference removed
-	f.config.HTTPClient = f.httpClient
-	f.config.Cache = f.cache
+	f.configMutex.Unlock()
+
+	// Cached responses may have been produced under the old endpoint definitions
+	f.responseCache.Clear()
 
 	if f.logger != nil {
-		f.logger.Info("Configuration reloaded successfully")
+		f.logger.Infof("Configuration reloaded successfully (%d services, %d command groups)",
+			len(newConfig.Services), len(newConfig.Commands))
 	}
-
-	return nil
 }
 
 // GetServiceNames returns a list of configured service names
 func (f *Fusion) GetServiceNames() []string {
-	if f.config == nil {
+	config := f.GetConfig()
+	if config == nil {
 		return []string{}
 	}
 
-	names := make([]string, 0, len(f.config.Services))
-	for name := range f.config.Services {
+	names := make([]string, 0, len(config.Services))
+	for name := range config.Services {
 		names = append(names, name)
 	}
 	return names
@@ -962,20 +1133,22 @@ func (f *Fusion) GetServiceNames() []string {
 
 // GetService returns a service configuration by name
 func (f *Fusion) GetService(name string) *ServiceConfig {
-	if f.config == nil {
+	config := f.GetConfig()
+	if config == nil {
 		return nil
 	}
 
-	return f.config.Services[name]
+	return config.Services[name]
 }
 
 // HasService checks if a service is configured
 func (f *Fusion) HasService(name string) bool {
-	if f.config == nil {
+	config := f.GetConfig()
+	if config == nil {
 		return false
 	}
 
-	_, exists := f.config.Services[name]
+	_, exists := config.Services[name]
 	return exists
 }
 
@@ -1800,6 +1973,22 @@ func (f *Fusion) getOrCreateCircuitBreaker(serviceName string, config *CircuitBr
 	return cb
 }
 
+// getServiceQuota gets or creates the quota tracker for a tenant's use of a
+// service. Upstream quotas usually belong to the credentials in use, so one
+// tenant exhausting its quota must not slow down the others.
+func (f *Fusion) getServiceQuota(tenantHash, serviceKey string) *serviceQuota {
+	f.serviceQuotasMutex.Lock()
+	defer f.serviceQuotasMutex.Unlock()
+
+	key := tenantHash + "/" + serviceKey
+	quota, exists := f.serviceQuotas[key]
+	if !exists {
+		quota = &serviceQuota{}
+		f.serviceQuotas[key] = quota
+	}
+	return quota
+}
+
 // GetCircuitBreakerMetrics returns circuit breaker metrics for a service
 func (f *Fusion) GetCircuitBreakerMetrics(serviceName string) *CircuitBreakerMetrics {
 	f.circuitBreakersMutex.RLock()
@@ -1825,6 +2014,16 @@ func (f *Fusion) GetAllCircuitBreakerMetrics() map[string]*CircuitBreakerMetrics
 	return result
 }
 
+// CircuitBreakerStates implements metrics.CircuitBreakerSource.
+func (f *Fusion) CircuitBreakerStates() map[string]string {
+	raw := f.GetAllCircuitBreakerMetrics()
+	result := make(map[string]string, len(raw))
+	for name, m := range raw {
+		result[name] = strings.ToLower(m.State.String())
+	}
+	return result
+}
+
 // GetMetrics returns metrics for all services
 func (f *Fusion) GetMetrics() map[string]*ServiceMetrics {
 	if f.metricsCollector == nil {
@@ -1917,6 +2116,11 @@ func (f *Fusion) Shutdown() {
 		// Signal shutdown to background goroutines
 		close(f.shutdownChan)
 
+		// Stop running command jobs; they are recorded as interrupted
+		if f.jobs != nil {
+			f.jobs.Shutdown()
+		}
+
 		// Final connection cleanup
 		f.cleanupConnections()
 
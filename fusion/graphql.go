@@ -0,0 +1,448 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GraphQL operation types
+const (
+	graphQLQuery        = "query"
+	graphQLMutation     = "mutation"
+	graphQLSubscription = "subscription"
+)
+
+// graphQLOperation is an operation definition found in a GraphQL document
+type graphQLOperation struct {
+	kind string // query, mutation or subscription
+	name string // Empty for anonymous operations
+}
+
+// parseGraphQLOperations lists the operation definitions of a GraphQL document.
+// It only tokenizes the top level of the document, which is enough to find the
+// operation types and names; the server remains responsible for full validation.
+func parseGraphQLOperations(document string) []graphQLOperation {
+	var operations []graphQLOperation
+	depth := 0
+	inDefinition := false // A definition keyword was seen and its selection set has not started
+	expectName := false   // The next name at the top level names the current operation
+
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+		switch {
+		case c == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+
+		case c == '"':
+			if strings.HasPrefix(document[i:], `"""`) {
+				end := strings.Index(document[i+3:], `"""`)
+				if end < 0 {
+					return operations
+				}
+				i += end + 5
+				continue
+			}
+			for i++; i < len(document) && document[i] != '"' && document[i] != '\n'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+
+		case c == '{' || c == '(':
+			if depth == 0 && c == '{' {
+				if !inDefinition {
+					// A bare selection set is an anonymous query
+					operations = append(operations, graphQLOperation{kind: graphQLQuery})
+				}
+				inDefinition = false
+			}
+			expectName = false
+			depth++
+
+		case c == '}' || c == ')':
+			if depth > 0 {
+				depth--
+			}
+
+		case isGraphQLNameStart(c):
+			start := i
+			for i+1 < len(document) && isGraphQLNameChar(document[i+1]) {
+				i++
+			}
+			if depth > 0 {
+				continue
+			}
+			word := document[start : i+1]
+			switch {
+			case !inDefinition && (word == graphQLQuery || word == graphQLMutation || word == graphQLSubscription):
+				operations = append(operations, graphQLOperation{kind: word})
+				inDefinition, expectName = true, true
+			case !inDefinition && word == "fragment":
+				inDefinition, expectName = true, false
+			case expectName:
+				operations[len(operations)-1].name = word
+				expectName = false
+			}
+
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',':
+			expectName = false
+		}
+	}
+
+	return operations
+}
+
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return isGraphQLNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// operation returns the operation the endpoint executes: the one named by
+// operationName, or the only operation of the document
+func (g *GraphQLConfig) operation() (graphQLOperation, error) {
+	operations := parseGraphQLOperations(g.Query)
+	if len(operations) == 0 {
+		return graphQLOperation{}, fmt.Errorf("query does not define an operation")
+	}
+
+	if g.OperationName == "" {
+		if len(operations) > 1 {
+			return graphQLOperation{}, fmt.Errorf("operationName is required when the query defines %d operations", len(operations))
+		}
+		return operations[0], nil
+	}
+
+	for _, operation := range operations {
+		if operation.name == g.OperationName {
+			return operation, nil
+		}
+	}
+	return graphQLOperation{}, fmt.Errorf("query does not define operation %s", g.OperationName)
+}
+
+// validateGraphQL checks that the endpoint can be sent as a GraphQL operation
+func (e *EndpointConfig) validateGraphQL() error {
+	if strings.TrimSpace(e.GraphQL.Query) == "" {
+		return fmt.Errorf("query is required")
+	}
+	if e.Method != http.MethodPost {
+		return fmt.Errorf("graphql endpoints must use POST, got %s", e.Method)
+	}
+
+	operation, err := e.GraphQL.operation()
+	if err != nil {
+		return err
+	}
+	if operation.kind == graphQLSubscription {
+		return fmt.Errorf("subscriptions are not supported")
+	}
+
+	if e.RequestBody != nil {
+		return fmt.Errorf("requestBody cannot be used with graphql")
+	}
+	for _, param := range e.Parameters {
+		if param.Location == ParameterLocationFile || param.Location == ParameterLocationFilePath {
+			return fmt.Errorf("parameter %s: %s parameters cannot be used with graphql", param.Name, param.Location)
+		}
+	}
+
+	if e.Response.Type != ResponseTypeJSON {
+		return fmt.Errorf("graphql endpoints must use a json response, got %s", e.Response.Type)
+	}
+	if e.Response.Decoder != "" {
+		return fmt.Errorf("response decoder cannot be used with graphql")
+	}
+	if e.Response.PaginationConfig != nil && e.Response.PaginationConfig.GetStyle() != PaginationStyleConnection {
+		return fmt.Errorf("graphql endpoints only support connection pagination, got %s",
+			e.Response.PaginationConfig.GetStyle())
+	}
+
+	return nil
+}
+
+// effectiveMethod returns the HTTP method that describes the effect of the
+// endpoint. GraphQL queries are sent as POST requests but only read data, so
+// they are treated as GET for tool hints and response caching.
+func (e *EndpointConfig) effectiveMethod() string {
+	if e.GraphQL != nil {
+		if operation, err := e.GraphQL.operation(); err == nil && operation.kind == graphQLQuery {
+			return http.MethodGet
+		}
+	}
+	return e.Method
+}
+
+// graphQLPayload builds the GraphQL request document. Body parameters become
+// the operation's variables; for connection pagination the page size is added
+// when a limitParam is configured and the caller did not supply it.
+func (h *HTTPHandler) graphQLPayload(args map[string]interface{}) (map[string]interface{}, error) {
+	mapper := NewMapper(h.fusion.logger)
+	variables, err := mapper.BuildRequestBody(h.endpoint.Parameters, args, nil)
+	if err != nil {
+		return nil, err
+	}
+	if variables == nil {
+		variables = make(map[string]interface{})
+	}
+
+	if h.endpoint.Response.Paginated && h.endpoint.Response.PaginationConfig != nil {
+		pagination := h.endpoint.Response.PaginationConfig
+		if limitParam := pagination.GetLimitParam(); limitParam != "" {
+			if _, ok := variables[limitParam]; !ok {
+				variables[limitParam] = pagination.PageSize
+			}
+		}
+	}
+
+	payload := map[string]interface{}{
+		"query":     h.endpoint.GraphQL.Query,
+		"variables": variables,
+	}
+	if h.endpoint.GraphQL.OperationName != "" {
+		payload["operationName"] = h.endpoint.GraphQL.OperationName
+	}
+	return payload, nil
+}
+
+// graphQLData returns the data member of a decoded GraphQL response, or a
+// GraphQLError when the response reports errors
+func (h *HTTPHandler) graphQLData(response interface{}, statusCode int, correlationID string) (interface{}, error) {
+	envelope, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("GraphQL response is not an object")
+	}
+
+	if errs, ok := envelope["errors"].([]interface{}); ok && len(errs) > 0 {
+		gqlErr := &GraphQLError{
+			Service:       h.service.Name,
+			Endpoint:      h.endpoint.ID,
+			StatusCode:    statusCode,
+			CorrelationID: correlationID,
+		}
+		for _, raw := range errs {
+			gqlErr.Messages = append(gqlErr.Messages, graphQLErrorMessage(raw))
+		}
+		return nil, gqlErr
+	}
+
+	data, ok := envelope["data"]
+	if !ok {
+		return nil, fmt.Errorf("GraphQL response has neither data nor errors")
+	}
+	return data, nil
+}
+
+// graphQLErrorMessage formats one entry of a GraphQL errors array, including
+// the path of the failed field when present
+func graphQLErrorMessage(raw interface{}) string {
+	entry, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", raw)
+	}
+
+	message, _ := entry["message"].(string)
+	if message == "" {
+		message = "unknown error"
+	}
+	if path, ok := entry["path"].([]interface{}); ok && len(path) > 0 {
+		segments := make([]string, len(path))
+		for i, segment := range path {
+			segments[i] = fmt.Sprintf("%v", segment)
+		}
+		message += " (at " + strings.Join(segments, ".") + ")"
+	}
+	return message
+}
+
+// graphQLErrorResponse returns the GraphQLError carried by a failed HTTP
+// response, or nil when the body is not a GraphQL error document.
+// Authentication failures are left to the generic API error handling.
+func (h *HTTPHandler) graphQLErrorResponse(body []byte, statusCode int, correlationID string) error {
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return nil
+	}
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+	if _, err := h.graphQLData(response, statusCode, correlationID); err != nil {
+		if gqlErr, ok := AsGraphQLError(err); ok {
+			return gqlErr
+		}
+	}
+	return nil
+}
+
+// connectionItemsKey returns the member of a GraphQL connection holding its
+// items: nodes when present, otherwise edges
+func connectionItemsKey(connection map[string]interface{}) string {
+	if _, ok := connection["nodes"].([]interface{}); ok {
+		return "nodes"
+	}
+	if _, ok := connection["edges"].([]interface{}); ok {
+		return "edges"
+	}
+	return ""
+}
+
+// connectionItemsPath returns the path of the items of the connection at
+// dataPath, or dataPath itself when no connection is found there
+func connectionItemsPath(data interface{}, dataPath string) string {
+	if connection, ok := lookupJSONPath(data, dataPath).(map[string]interface{}); ok {
+		if key := connectionItemsKey(connection); key != "" {
+			return dataPath + "." + key
+		}
+	}
+	return dataPath
+}
+
+// connectionNextCursor returns the endCursor of a connection that has a next page
+func connectionNextCursor(connection map[string]interface{}) (string, bool) {
+	pageInfo, ok := connection["pageInfo"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	if hasNext, _ := pageInfo["hasNextPage"].(bool); !hasNext {
+		return "", false
+	}
+	cursor, _ := pageInfo["endCursor"].(string)
+	return cursor, cursor != ""
+}
+
+// fetchRemainingConnectionPages follows the GraphQL cursor connection at
+// dataPath in data. The operation is sent again with the endCursor of each page
+// in the cursor variable until pageInfo.hasNextPage is false or the page, item
+// or byte budget is exhausted. The nodes (or edges) of every page are merged
+// into the first page, whose pageInfo is replaced by that of the last page
+// fetched so that callers can continue from where the merge stopped.
+func (h *HTTPHandler) fetchRemainingConnectionPages(firstResp *http.Response, firstData interface{}, firstPageBytes int,
+	args map[string]interface{}, correlationID string) paginationResult {
+
+	pagination := h.endpoint.Response.PaginationConfig
+	result := paginationResult{data: firstData, pages: 1}
+
+	connection, ok := lookupJSONPath(firstData, pagination.DataPath).(map[string]interface{})
+	if !ok {
+		if h.fusion.logger != nil {
+			h.fusion.logger.Debugf("Pagination: dataPath %q is not a connection, returning first page only [%s]",
+				pagination.DataPath, correlationID)
+		}
+		return result
+	}
+	itemsKey := connectionItemsKey(connection)
+	if itemsKey == "" {
+		return result
+	}
+	items := append([]interface{}{}, connection[itemsKey].([]interface{})...)
+	result.items = len(items)
+
+	payload, err := h.graphQLPayload(args)
+	if err != nil || firstResp.Request == nil {
+		return result
+	}
+	variables := payload["variables"].(map[string]interface{})
+
+	maxPages := h.effectiveMaxPages(args)
+	maxBytes := h.fusion.MaxResponseBytes()
+	totalBytes := firstPageBytes
+
+	prevReq, last := firstResp.Request, connection
+	for {
+		cursor, hasNext := connectionNextCursor(last)
+		if !hasNext {
+			break
+		}
+		if result.pages >= maxPages ||
+			(pagination.MaxItems > 0 && len(items) >= pagination.MaxItems) ||
+			(maxBytes > 0 && totalBytes >= maxBytes) {
+			result.morePages = true
+			break
+		}
+
+		variables[pagination.GetTokenParam()] = cursor
+		body, err := json.Marshal(payload)
+		if err != nil {
+			break
+		}
+		nextReq := withBody(prevReq, body)
+
+		if h.fusion.logger != nil {
+			h.fusion.logger.Debugf("Pagination: fetching page %d of at most %d after cursor %s [%s]",
+				result.pages+1, maxPages, cursor, correlationID)
+		}
+
+		nextResponse, nextResp, bodyLen, err := h.fetchPage(nextReq, correlationID)
+		if err == nil {
+			nextResponse, err = h.graphQLData(nextResponse, nextResp.StatusCode, correlationID)
+		}
+		if err != nil {
+			if h.fusion.logger != nil {
+				h.fusion.logger.Warningf("Pagination: stopping after %d pages for %s.%s: %v [%s]",
+					result.pages, h.service.Name, h.endpoint.ID, err, correlationID)
+			}
+			result.morePages = true
+			break
+		}
+
+		next, ok := lookupJSONPath(nextResponse, pagination.DataPath).(map[string]interface{})
+		if !ok {
+			break
+		}
+		nextItems, ok := next[itemsKey].([]interface{})
+		if !ok {
+			break
+		}
+
+		result.pages++
+		totalBytes += bodyLen
+		items = append(items, nextItems...)
+		prevReq, last = nextReq, next
+
+		if len(nextItems) == 0 {
+			break
+		}
+	}
+
+	if pagination.MaxItems > 0 && len(items) > pagination.MaxItems {
+		items = items[:pagination.MaxItems]
+		result.morePages = true
+	}
+	result.items = len(items)
+
+	connection[itemsKey] = items
+	if pageInfo, ok := last["pageInfo"]; ok {
+		connection["pageInfo"] = pageInfo
+	}
+
+	if h.fusion.logger != nil && result.pages > 1 {
+		h.fusion.logger.Infof("Pagination: merged %d items from %d pages for %s.%s (more available: %t) [%s]",
+			result.items, result.pages, h.service.Name, h.endpoint.ID, result.morePages, correlationID)
+	}
+
+	return result
+}
+
+// withBody returns a copy of req that sends body instead of the original body
+func withBody(req *http.Request, body []byte) *http.Request {
+	next := req.Clone(req.Context())
+	next.Body = io.NopCloser(bytes.NewReader(body))
+	next.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	next.ContentLength = int64(len(body))
+	return next
+}
@@ -0,0 +1,347 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tenebris-tech/mlogger"
+)
+
+// graphQLRequest is the request document received by the test servers
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// newGraphQLTestFusion builds a Fusion instance with the given GraphQL endpoint
+// backed by serverURL.
+func newGraphQLTestFusion(t *testing.T, serverURL string, endpoint EndpointConfig) *Fusion {
+	t.Helper()
+	endpoint.Method = http.MethodPost
+	endpoint.Path = "/graphql"
+	if endpoint.Parameters == nil {
+		endpoint.Parameters = []ParameterConfig{}
+	}
+	if err := endpoint.Validate(); err != nil {
+		t.Fatalf("invalid test endpoint: %v", err)
+	}
+
+	config := &Config{
+		Services: map[string]*ServiceConfig{
+			"gql": {
+				ServiceKey: "gql",
+				Name:       "gql",
+				BaseURL:    serverURL,
+				Auth:       AuthConfig{Type: AuthTypeNone},
+				Endpoints:  []EndpointConfig{endpoint},
+			},
+		},
+	}
+	return New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+}
+
+// graphQLServer decodes each request document and replies with the value
+// returned by respond, using the status code it returns
+func graphQLServer(t *testing.T, respond func(req graphQLRequest) (int, interface{})) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request document: %v", err)
+		}
+		status, response := respond(req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestParseGraphQLOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     []graphQLOperation
+	}{
+		{"shorthand", `{ viewer { login } }`, []graphQLOperation{{kind: "query"}}},
+		{"named query", `query Viewer($first: Int = 10) { viewer { login } }`, []graphQLOperation{{kind: "query", name: "Viewer"}}},
+		{"anonymous mutation", `mutation ($id: ID!) { close(id: $id) { ok } }`, []graphQLOperation{{kind: "mutation"}}},
+		{"directive", `query @cached { a }`, []graphQLOperation{{kind: "query"}}},
+		{
+			"fragments and comments",
+			"# query Commented { a }\nfragment F on User { query }\nquery Q { ...F }\nmutation M { b(s: \"query X {\") }",
+			[]graphQLOperation{{kind: "query", name: "Q"}, {kind: "mutation", name: "M"}},
+		},
+		{"block string", `query A { a(s: """ } mutation B { """) }`, []graphQLOperation{{kind: "query", name: "A"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseGraphQLOperations(tt.document)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("operation %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointConfig_ValidateGraphQL(t *testing.T) {
+	valid := func() EndpointConfig {
+		return EndpointConfig{
+			ID: "viewer", Name: "Viewer", Method: "POST", Path: "/graphql",
+			GraphQL:  &GraphQLConfig{Query: `query Viewer { viewer { login } }`},
+			Response: ResponseConfig{Type: ResponseTypeJSON},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(e *EndpointConfig)
+		wantErr string
+	}{
+		{"valid", func(e *EndpointConfig) {}, ""},
+		{"missing query", func(e *EndpointConfig) { e.GraphQL.Query = " " }, "query is required"},
+		{"GET", func(e *EndpointConfig) { e.Method = "GET" }, "must use POST"},
+		{"subscription", func(e *EndpointConfig) { e.GraphQL.Query = `subscription { events { id } }` }, "not supported"},
+		{"ambiguous operation", func(e *EndpointConfig) { e.GraphQL.Query += ` mutation M { a }` }, "operationName is required"},
+		{"unknown operation", func(e *EndpointConfig) { e.GraphQL.OperationName = "Other" }, "does not define operation Other"},
+		{"text response", func(e *EndpointConfig) { e.Response.Type = ResponseTypeText }, "json response"},
+		{"file parameter", func(e *EndpointConfig) {
+			e.Parameters = []ParameterConfig{{Name: "f", Type: ParameterTypeString, Location: ParameterLocationFile}}
+		}, "cannot be used with graphql"},
+		{"token pagination", func(e *EndpointConfig) {
+			e.Response.Paginated = true
+			e.Response.PaginationConfig = &PaginationConfig{NextPageTokenPath: "next", DataPath: "items", PageSize: 10}
+		}, "only support connection pagination"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := valid()
+			tt.modify(&endpoint)
+			err := endpoint.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	rest := EndpointConfig{
+		ID: "list", Name: "List", Method: "GET", Path: "/items",
+		Response: ResponseConfig{Type: ResponseTypeJSON, Paginated: true,
+			PaginationConfig: &PaginationConfig{Style: PaginationStyleConnection, DataPath: "items", PageSize: 10}},
+	}
+	if err := rest.Validate(); err == nil {
+		t.Error("expected connection pagination to require a graphql endpoint")
+	}
+}
+
+func TestGraphQL_QueryVariablesAndTransform(t *testing.T) {
+	var received graphQLRequest
+	server := graphQLServer(t, func(req graphQLRequest) (int, interface{}) {
+		received = req
+		return http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"user": map[string]interface{}{"login": "octocat", "followers": 42}},
+		}
+	})
+	defer server.Close()
+
+	query := `query User($login: String!, $filter: UserFilter) { user(login: $login) { login followers } }`
+	f := newGraphQLTestFusion(t, server.URL, EndpointConfig{
+		ID: "user", Name: "User",
+		GraphQL: &GraphQLConfig{Query: query, OperationName: "User"},
+		Parameters: []ParameterConfig{
+			{Name: "login", Type: ParameterTypeString, Required: true, Location: ParameterLocationBody},
+			{Name: "active", Type: ParameterTypeBoolean, Location: ParameterLocationBody,
+				Transform: &TransformConfig{TargetName: "filter.active", Expression: "."}},
+		},
+		Response: ResponseConfig{Type: ResponseTypeJSON, Transform: ".user.login"},
+	})
+	tool := findTool(t, f.RegisterTools(), "gql_user")
+	if tool.Hints == nil || tool.Hints.ReadOnly == nil || !*tool.Hints.ReadOnly {
+		t.Errorf("expected a query to be read-only, got %+v", tool.Hints)
+	}
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{"login": "octocat", "active": true}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != `"octocat"` {
+		t.Errorf("result = %s, want the transform applied to data", result)
+	}
+
+	if received.Query != query || received.OperationName != "User" {
+		t.Errorf("request = %+v", received)
+	}
+	filter, _ := received.Variables["filter"].(map[string]interface{})
+	if received.Variables["login"] != "octocat" || filter["active"] != true {
+		t.Errorf("variables = %v", received.Variables)
+	}
+}
+
+func TestGraphQL_MutationHints(t *testing.T) {
+	f := newGraphQLTestFusion(t, "http://localhost", EndpointConfig{
+		ID: "close", Name: "Close",
+		GraphQL:  &GraphQLConfig{Query: `mutation Close($id: ID!) { closeIssue(input: {issueId: $id}) { issue { id } } }`},
+		Response: ResponseConfig{Type: ResponseTypeJSON},
+	})
+	tool := findTool(t, f.RegisterTools(), "gql_close")
+	if tool.Hints == nil || tool.Hints.ReadOnly == nil || *tool.Hints.ReadOnly {
+		t.Errorf("expected a mutation not to be read-only, got %+v", tool.Hints)
+	}
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response interface{}
+		wantMsg  string
+	}{
+		{
+			"errors with partial data", http.StatusOK,
+			map[string]interface{}{
+				"data": map[string]interface{}{"repository": nil},
+				"errors": []interface{}{map[string]interface{}{
+					"message": "Could not resolve to a Repository with the name 'x'.",
+					"path":    []interface{}{"repository"},
+				}},
+			},
+			"Could not resolve to a Repository with the name 'x'. (at repository)",
+		},
+		{
+			"errors with a 4xx status", http.StatusBadRequest,
+			map[string]interface{}{"errors": []interface{}{
+				map[string]interface{}{"message": "Field 'nme' doesn't exist"},
+				map[string]interface{}{"message": "Variable $id is required"},
+			}},
+			"Field 'nme' doesn't exist; Variable $id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := graphQLServer(t, func(graphQLRequest) (int, interface{}) { return tt.status, tt.response })
+			defer server.Close()
+
+			f := newGraphQLTestFusion(t, server.URL, EndpointConfig{
+				ID: "repo", Name: "Repo",
+				GraphQL:  &GraphQLConfig{Query: `{ repository(name: "x") { id } }`},
+				Response: ResponseConfig{Type: ResponseTypeJSON},
+			})
+			tool := findTool(t, f.RegisterTools(), "gql_repo")
+
+			_, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+			gqlErr, ok := AsGraphQLError(err)
+			if !ok {
+				t.Fatalf("expected a GraphQLError, got %v", err)
+			}
+			if gqlErr.StatusCode != tt.status || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %v (status %d), want %q", err, gqlErr.StatusCode, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestGraphQL_ConnectionPagination(t *testing.T) {
+	var requests atomic.Int32
+	var firstSizes []interface{}
+	server := graphQLServer(t, func(req graphQLRequest) (int, interface{}) {
+		requests.Add(1)
+		firstSizes = append(firstSizes, req.Variables["first"])
+
+		start, hasNext, cursor := 0, true, "c2"
+		switch req.Variables["after"] {
+		case "c2":
+			start, cursor = 2, "c4"
+		case "c4":
+			start, hasNext, cursor = 4, false, "c5"
+		}
+		nodes := pageItems(start, 2)
+		if !hasNext {
+			nodes = pageItems(start, 1)
+		}
+		return http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"viewer": map[string]interface{}{"repositories": map[string]interface{}{
+				"totalCount": 5,
+				"nodes":      nodes,
+				"pageInfo":   map[string]interface{}{"hasNextPage": hasNext, "endCursor": cursor},
+			}},
+		}}
+	})
+	defer server.Close()
+
+	endpoint := func(transform string) EndpointConfig {
+		return EndpointConfig{
+			ID: "repos", Name: "Repos",
+			GraphQL: &GraphQLConfig{Query: `query ($first: Int!, $after: String) {
+				viewer { repositories(first: $first, after: $after) { totalCount nodes { id } pageInfo { hasNextPage endCursor } } }
+			}`},
+			Response: ResponseConfig{
+				Type:      ResponseTypeJSON,
+				Transform: transform,
+				Paginated: true,
+				PaginationConfig: &PaginationConfig{
+					Style: PaginationStyleConnection, DataPath: "viewer.repositories", PageSize: 2, LimitParam: "first",
+				},
+			},
+		}
+	}
+
+	// Without a transform the merged nodes are returned
+	f := newGraphQLTestFusion(t, server.URL, endpoint(""))
+	result, err := findTool(t, f.RegisterTools(), "gql_repos").Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 5 {
+		t.Errorf("expected 5 merged nodes, got %d", len(items))
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 page requests, got %d", got)
+	}
+	for _, size := range firstSizes {
+		if size != float64(2) {
+			t.Errorf("expected pageSize in the first variable, got %v", firstSizes)
+			break
+		}
+	}
+
+	// With a page budget, the transform sees the pageInfo of the last page fetched
+	requests.Store(0)
+	f = newGraphQLTestFusion(t, server.URL, endpoint(".viewer.repositories | {count: (.nodes | length), next: .pageInfo.endCursor}"))
+	result, err = findTool(t, f.RegisterTools(), "gql_repos").Handler(
+		withTenant("tenant-a", map[string]interface{}{MaxPagesArgument: float64(2)}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var summary map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &summary); err != nil {
+		t.Fatalf("unexpected result %q: %v", result, err)
+	}
+	if summary["count"] != float64(4) || summary["next"] != "c4" || requests.Load() != 2 {
+		t.Errorf("summary = %v after %d requests", summary, requests.Load())
+	}
+}
@@ -123,6 +123,19 @@ func (h *HTTPHandler) Handle(ctx context.Context, args map[string]interface{}) (
 		return "", err
 	}
 
+	// Serve cacheable GET requests from the response cache when possible
+	cacheKey, cacheTTL := h.responseCacheKey(ctx, args)
+	if cacheKey != "" {
+		if cached, ok := h.fusion.responseCache.Get(cacheKey); ok {
+			h.recordCacheLookup(true)
+			if h.fusion.logger != nil {
+				h.fusion.logger.Infof("Serving %s.%s from response cache [%s]", h.service.Name, h.endpoint.ID, correlationID)
+			}
+			return cached, nil
+		}
+		h.recordCacheLookup(false)
+	}
+
 	// Build request
 	req, err := h.buildRequest(ctx, args)
 	if err != nil {
@@ -404,6 +417,13 @@ func (h *HTTPHandler) Handle(ctx context.Context, args map[string]interface{}) (
 		return "", err
 	}
 
+	// Cache successful reads; a successful write invalidates the service's cached reads
+	if cacheKey != "" {
+		h.fusion.responseCache.Set(cacheKey, result, cacheTTL)
+	} else if h.endpoint.effectiveMethod() != http.MethodGet && h.fusion.responseCache != nil {
+		h.fusion.responseCache.InvalidateService(h.service.ServiceKey)
+	}
+
 	totalLatency := time.Since(startTime)
 	if h.fusion.logger != nil {
 		h.fusion.logger.Infof("Successfully handled request for %s.%s in %v [%s]", h.service.Name, h.endpoint.ID, totalLatency, correlationID)
@@ -509,6 +529,7 @@ func buildMultipartBody(params []ParameterConfig, args map[string]interface{}) (
 // buildRequest constructs an HTTP request based on the endpoint configuration
 func (h *HTTPHandler) buildRequest(ctx context.Context, args map[string]interface{}) (*http.Request, error) {
 	mapper := NewMapper(h.fusion.logger)
+	mapper.bodyEncoders = h.fusion.bodyEncoders
 
 	// Build URL with path parameters, using endpoint-level baseURL override if set
 	baseURL := h.service.BaseURL
@@ -524,13 +545,48 @@ func (h *HTTPHandler) buildRequest(ctx context.Context, args map[string]interfac
 	var body io.Reader
 	var bodyContentType string
 	if h.endpoint.Method == "POST" || h.endpoint.Method == "PUT" || h.endpoint.Method == "PATCH" {
-		if hasFileParams(h.endpoint.Parameters, args) {
+		if h.endpoint.GraphQL != nil {
+			payload, err := h.graphQLPayload(args)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build GraphQL variables: %w", err)
+			}
+			payloadBytes, err := json.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+			}
+			body = bytes.NewReader(payloadBytes)
+			bodyContentType = "application/json"
+		} else if h.endpoint.SOAP != nil {
+			bodyData, err := mapper.BuildRequestBody(h.endpoint.Parameters, args, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build request body: %w", err)
+			}
+			envelope, ct, err := h.buildSOAPEnvelope(bodyData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build SOAP envelope: %w", err)
+			}
+			body = strings.NewReader(envelope)
+			bodyContentType = ct
+		} else if hasFileParams(h.endpoint.Parameters, args) {
 			multipartBody, ct, err := buildMultipartBody(h.endpoint.Parameters, args)
 			if err != nil {
 				return nil, fmt.Errorf("failed to build multipart body: %w", err)
 			}
 			body = multipartBody
 			bodyContentType = ct
+		} else if h.endpoint.RequestBody != nil && !h.endpoint.RequestBody.usesEncoder() {
+			bodyData, err := mapper.BuildRequestBody(h.endpoint.Parameters, args, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build request body: %w", err)
+			}
+			content, ct, err := h.buildFormattedBody(bodyData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s request body: %w", h.endpoint.RequestBody.GetFormat(), err)
+			}
+			if content != "" {
+				body = strings.NewReader(content)
+				bodyContentType = ct
+			}
 		} else {
 			bodyData, err := mapper.BuildRequestBody(h.endpoint.Parameters, args, h.endpoint.RequestBody)
 			if err != nil {
@@ -557,7 +613,11 @@ func (h *HTTPHandler) buildRequest(ctx context.Context, args map[string]interfac
 	if body != nil {
 		req.Header.Set("Content-Type", bodyContentType)
 	}
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", h.acceptHeader())
+	if h.endpoint.SOAP != nil && h.endpoint.SOAP.Version != soapVersion12 {
+		// SOAP 1.1 requires the header even when the action is empty
+		req.Header.Set("SOAPAction", `"`+h.endpoint.SOAP.Action+`"`)
+	}
 
 	// Add custom headers from parameters
 	if err := mapper.ApplyHeaders(req, h.endpoint.Parameters, args); err != nil {
@@ -647,6 +707,24 @@ func (h *HTTPHandler) executeRequest(ctx context.Context, req *http.Request, cor
 		}
 	}
 
+	// Pace the request if the service reported that the tenant's quota is running low
+	tenantHash := ""
+	if tenantContext, ok := ctx.Value(global.TenantContextKey).(*TenantContext); ok && tenantContext != nil {
+		tenantHash = tenantContext.TenantHash
+	}
+	quota := h.fusion.getServiceQuota(tenantHash, h.service.ServiceKey)
+	if wait := quota.reserve(time.Now()); wait > 0 {
+		if h.fusion.logger != nil {
+			h.fusion.logger.Infof("Delaying request by %v to stay within the %s rate limit [%s]",
+				wait, h.service.ServiceKey, correlationID)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, metrics, ctx.Err()
+		}
+	}
+
 	// Execute with circuit breaker if enabled
 	var resp *http.Response
 	var err error
@@ -658,6 +736,8 @@ func (h *HTTPHandler) executeRequest(ctx context.Context, req *http.Request, cor
 					retryConfig.MaxAttempts, req.Method, req.URL.String(), correlationID)
 			}
 			retryExecutor := NewRetryExecutor(retryConfig, h.fusion.logger)
+			retryExecutor.quota = quota
+			retryExecutor.soap = h.endpoint.SOAP != nil
 			resp, err = retryExecutor.Execute(ctx, httpClient, req)
 			if err != nil && resp == nil {
 				// Count retry attempts from the error context
@@ -668,7 +748,8 @@ func (h *HTTPHandler) executeRequest(ctx context.Context, req *http.Request, cor
 			if h.fusion.logger != nil {
 				h.fusion.logger.Debugf("Executing HTTP request: %s %s", req.Method, req.URL.String())
 			}
-			resp, err = httpClient.Do(req)
+			resp, err = doTracedRequest(httpClient, req, 1)
+			quota.update(resp, time.Now())
 			if err != nil {
 				if h.fusion.logger != nil {
 					h.fusion.logger.Debugf("HTTP request failed: %v", err)
@@ -745,6 +826,8 @@ func (h *HTTPHandler) executeRequest(ctx context.Context, req *http.Request, cor
 	// ServiceName (display name, e.g. "Microsoft 365") to match registration.
 	if h.fusion.sharedCollector != nil && metrics != nil {
 		h.fusion.sharedCollector.RecordRequest(h.service.ServiceKey, !metrics.Success)
+		h.fusion.sharedCollector.RecordLatency(h.service.ServiceKey, metrics.Latency)
+		h.fusion.sharedCollector.RecordErrorCategory(h.service.ServiceKey, string(metrics.ErrorCategory))
 	}
 
 	return resp, metrics, err
@@ -780,6 +863,17 @@ func (h *HTTPHandler) handleResponse(ctx context.Context, resp *http.Response, c
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
+		// GraphQL servers may report request errors with a 4xx status and an errors array
+		if h.endpoint.GraphQL != nil {
+			if gqlErr := h.graphQLErrorResponse(body, resp.StatusCode, correlationID); gqlErr != nil {
+				return "", gqlErr
+			}
+		}
+		if h.endpoint.SOAP != nil {
+			if faultErr := h.soapFaultResponse(body, resp.StatusCode, correlationID); faultErr != nil {
+				return "", faultErr
+			}
+		}
 		if h.fusion.logger != nil {
 			h.fusion.logger.Errorf("API error [%s]: status=%d, body=%s", correlationID, resp.StatusCode, string(body))
 		}
@@ -790,13 +884,59 @@ func (h *HTTPHandler) handleResponse(ctx context.Context, resp *http.Response, c
 
 	// Handle different response types
 	switch h.endpoint.Response.Type {
-	case "json":
-		// Parse JSON response
+	case "json", "xml":
+		// Parse the response; XML and decoded bodies are converted to their JSON representation
 		var data interface{}
-		if err := json.Unmarshal(body, &data); err != nil {
+		if h.endpoint.Response.Type == ResponseTypeXML {
+			if data, err = decodeXML(body); err != nil {
+				return "", fmt.Errorf("failed to parse XML response: %w", err)
+			}
+		} else if h.endpoint.Response.Decoder != "" {
+			decoder, ok := h.fusion.responseDecoders[h.endpoint.Response.Decoder]
+			if !ok {
+				return "", fmt.Errorf("unknown response decoder: %s", h.endpoint.Response.Decoder)
+			}
+			if data, err = decoder.Decode(body, resp.Header.Get("Content-Type")); err != nil {
+				return "", fmt.Errorf("failed to decode response with %s: %w", h.endpoint.Response.Decoder, err)
+			}
+			// Registered decoders may return any Go types
+			if data, err = toJSONValue(data); err != nil {
+				return "", fmt.Errorf("failed to convert %s response: %w", h.endpoint.Response.Decoder, err)
+			}
+		} else if err := json.Unmarshal(body, &data); err != nil {
 			return "", fmt.Errorf("failed to parse JSON response: %w", err)
 		}
 
+		// SOAP faults fail the call; otherwise everything below works on the Body content
+		if h.endpoint.SOAP != nil {
+			if data, err = h.soapBody(data, resp.StatusCode, correlationID); err != nil {
+				if h.fusion.logger != nil {
+					h.fusion.logger.Errorf("SOAP fault [%s]: %v", correlationID, err)
+				}
+				return "", err
+			}
+		}
+
+		// GraphQL errors fail the call; otherwise everything below works on data
+		if h.endpoint.GraphQL != nil {
+			if data, err = h.graphQLData(data, resp.StatusCode, correlationID); err != nil {
+				if h.fusion.logger != nil {
+					h.fusion.logger.Errorf("GraphQL error [%s]: %v", correlationID, err)
+				}
+				return "", err
+			}
+		}
+
+		// Follow additional pages for paginated endpoints, merging their items
+		// into the first page at dataPath.
+		if h.endpoint.Response.Paginated && h.endpoint.Response.PaginationConfig != nil {
+			if h.endpoint.GraphQL != nil {
+				data = h.fetchRemainingConnectionPages(resp, data, len(body), args, correlationID).data
+			} else {
+				data = h.fetchRemainingPages(resp, data, len(body), args, correlationID).data
+			}
+		}
+
 		// For paginated responses without an explicit transform, extract the data array
 		// using the configured dataPath. When a transform is present it is expected to
 		// select the data itself, so we leave the full object intact for the transform.
@@ -804,11 +944,12 @@ func (h *HTTPHandler) handleResponse(ctx context.Context, resp *http.Response, c
 			h.endpoint.Response.PaginationConfig != nil &&
 			h.endpoint.Response.Transform == "" {
 			dataPath := h.endpoint.Response.PaginationConfig.DataPath
+			if h.endpoint.GraphQL != nil {
+				dataPath = connectionItemsPath(data, dataPath)
+			}
 			if dataPath != "" {
-				if obj, ok := data.(map[string]interface{}); ok {
-					if pageData, exists := obj[dataPath]; exists {
-						data = pageData
-					}
+				if pageData := lookupJSONPath(data, dataPath); pageData != nil {
+					data = pageData
 				}
 			}
 		}
@@ -19,13 +19,15 @@ import (
 type Mapper struct {
 	logger             global.Logger
 	timeTokenProcessor *TimeTokenProcessor
+	bodyEncoders       map[string]BodyEncoder // Encoders selectable by requestBody.encoding
 }
 
-// NewMapper creates a new Mapper
+// NewMapper creates a new Mapper using the built-in body encoders
 func NewMapper(logger global.Logger) *Mapper {
 	return &Mapper{
 		logger:             logger,
 		timeTokenProcessor: NewTimeTokenProcessor(logger),
+		bodyEncoders:       bodyEncoders,
 	}
 }
 
@@ -316,7 +318,7 @@ func (m *Mapper) BuildRequestBody(params []ParameterConfig, args map[string]inte
 
 	// Apply body encoding if configured
 	if requestBody != nil && len(flatParamNames) > 0 {
-		encoder, ok := GetBodyEncoder(requestBody.Encoding)
+		encoder, ok := m.bodyEncoders[requestBody.Encoding]
 		if !ok {
 			return nil, fmt.Errorf("unknown body encoding: %s", requestBody.Encoding)
 		}
@@ -487,7 +489,26 @@ func (m *Mapper) ConvertToMCPParameters(params []ParameterConfig) map[string]int
 			if len(param.Validation.Enum) > 0 {
 				prop["enum"] = param.Validation.Enum
 			}
-			// Min/Max are not yet supported in ValidationConfig
+			if param.Validation.Minimum != nil {
+				prop["minimum"] = *param.Validation.Minimum
+			}
+			if param.Validation.Maximum != nil {
+				prop["maximum"] = *param.Validation.Maximum
+			}
+			if param.Validation.Format != "" {
+				prop["format"] = param.Validation.Format
+			}
+		}
+
+		// A full JSON Schema replaces the generated property definition
+		if param.Schema != nil {
+			prop = make(map[string]interface{}, len(param.Schema)+1)
+			for key, value := range param.Schema {
+				prop[key] = value
+			}
+			if _, ok := prop["description"]; !ok && param.Description != "" {
+				prop["description"] = param.Description
+			}
 		}
 
 		// Add default value if specified
@@ -37,25 +37,28 @@ type ServiceMetrics struct {
 	AvgLatency    time.Duration             `json:"avg_latency"`
 	ErrorsByType  map[ErrorCategory]int64   `json:"errors_by_type"`
 	EndpointStats map[string]*EndpointStats `json:"endpoint_stats"`
+	CacheHits     int64                     `json:"cache_hits"`
+	CacheMisses   int64                     `json:"cache_misses"`
 	LastError     time.Time                 `json:"last_error"`
 	LastRequest   time.Time                 `json:"last_request"`
 }
 
 // EndpointStats contains metrics for a specific endpoint
 type EndpointStats struct {
-	EndpointID    string                  `json:"endpoint_id"`
-	RequestCount  int64                   `json:"request_count"`
-	ErrorCount    int64                   `json:"error_count"`
-	SuccessCount  int64                   `json:"success_count"`
-	TotalLatency  time.Duration           `json:"total_latency"`
-	MinLatency    time.Duration           `json:"min_latency"`
-	MaxLatency    time.Duration           `json:"max_latency"`
-	AvgLatency    time.Duration           `json:"avg_latency"`
-	ErrorsByType  map[ErrorCategory]int64 `json:"errors_by_type"`
-	RetryCount    int64                   `json:"retry_count"`
-	CacheHitCount int64                   `json:"cache_hit_count"`
-	LastError     time.Time               `json:"last_error"`
-	LastRequest   time.Time               `json:"last_request"`
+	EndpointID     string                  `json:"endpoint_id"`
+	RequestCount   int64                   `json:"request_count"`
+	ErrorCount     int64                   `json:"error_count"`
+	SuccessCount   int64                   `json:"success_count"`
+	TotalLatency   time.Duration           `json:"total_latency"`
+	MinLatency     time.Duration           `json:"min_latency"`
+	MaxLatency     time.Duration           `json:"max_latency"`
+	AvgLatency     time.Duration           `json:"avg_latency"`
+	ErrorsByType   map[ErrorCategory]int64 `json:"errors_by_type"`
+	RetryCount     int64                   `json:"retry_count"`
+	CacheHitCount  int64                   `json:"cache_hit_count"`
+	CacheMissCount int64                   `json:"cache_miss_count"`
+	LastError      time.Time               `json:"last_error"`
+	LastRequest    time.Time               `json:"last_request"`
 }
 
 // RequestMetrics represents metrics for a single request
@@ -187,6 +190,45 @@ func (mc *MetricsCollector) RecordRequest(req RequestMetrics) {
 	}
 }
 
+// RecordCacheLookup records a response cache hit or miss for an endpoint.
+// Cache hits do not reach the upstream API and are therefore not counted as
+// requests; they only update the cache counters.
+func (mc *MetricsCollector) RecordCacheLookup(serviceName, endpointID string, hit bool) {
+	if !mc.enabled {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	serviceMetrics, exists := mc.metrics[serviceName]
+	if !exists {
+		serviceMetrics = &ServiceMetrics{
+			ServiceName:   serviceName,
+			ErrorsByType:  make(map[ErrorCategory]int64),
+			EndpointStats: make(map[string]*EndpointStats),
+		}
+		mc.metrics[serviceName] = serviceMetrics
+	}
+
+	endpointStats, exists := serviceMetrics.EndpointStats[endpointID]
+	if !exists {
+		endpointStats = &EndpointStats{
+			EndpointID:   endpointID,
+			ErrorsByType: make(map[ErrorCategory]int64),
+		}
+		serviceMetrics.EndpointStats[endpointID] = endpointStats
+	}
+
+	if hit {
+		serviceMetrics.CacheHits++
+		endpointStats.CacheHitCount++
+	} else {
+		serviceMetrics.CacheMisses++
+		endpointStats.CacheMissCount++
+	}
+}
+
 // GetServiceMetrics returns metrics for a specific service
 func (mc *MetricsCollector) GetServiceMetrics(serviceName string) *ServiceMetrics {
 	if !mc.enabled {
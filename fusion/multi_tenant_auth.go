@@ -17,6 +17,8 @@ import (
 
 	"github.com/PivotLLM/MCPFusion/db"
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/metrics"
+	"github.com/PivotLLM/MCPFusion/tracing"
 )
 
 // NoAuthTenantHash is the special tenant hash used when authentication is disabled
@@ -67,6 +69,7 @@ type MultiTenantAuthManager struct {
 	logger            global.Logger
 	mu                sync.RWMutex
 	invalidationLocks sync.Map // Per-tenant token invalidation locks (key: string, value: *sync.Mutex)
+	collector         *metrics.Collector
 }
 
 // NewMultiTenantAuthManager creates a new multi-tenant authentication manager
@@ -92,6 +95,39 @@ func (mtam *MultiTenantAuthManager) registerDefaultStrategies(httpClient *http.C
 	mtam.RegisterStrategy(NewSessionJWTStrategy(httpClient, mtam.logger))
 }
 
+// SetCollector sets the shared metrics collector used to count token refreshes
+func (mtam *MultiTenantAuthManager) SetCollector(c *metrics.Collector) {
+	mtam.mu.Lock()
+	defer mtam.mu.Unlock()
+	mtam.collector = c
+}
+
+// recordTokenRefresh counts a token refresh attempt in the shared collector
+func (mtam *MultiTenantAuthManager) recordTokenRefresh(serviceName string, success bool) {
+	mtam.mu.RLock()
+	collector := mtam.collector
+	mtam.mu.RUnlock()
+
+	if collector != nil {
+		collector.RecordTokenRefresh(serviceName, success)
+	}
+}
+
+// refreshToken refreshes a token with the strategy, tracing and counting the attempt
+func (mtam *MultiTenantAuthManager) refreshToken(ctx context.Context, strategy AuthStrategy,
+	tenantContext *TenantContext, tokenInfo *TokenInfo, authConfig AuthConfig) (*TokenInfo, error) {
+
+	ctx, span := tracing.Start(ctx, "auth.refresh_token", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("mcpfusion.service", tenantContext.ServiceName)
+	span.SetAttribute("mcpfusion.tenant", tenantContext.ShortHash())
+
+	refreshedToken, err := strategy.RefreshToken(ctx, tokenInfo, authConfig.Config)
+	mtam.recordTokenRefresh(tenantContext.ServiceName, err == nil)
+	span.RecordError(err)
+	return refreshedToken, err
+}
+
 // RegisterStrategy registers an authentication strategy
 func (mtam *MultiTenantAuthManager) RegisterStrategy(strategy AuthStrategy) {
 	mtam.mu.Lock()
@@ -113,6 +149,23 @@ func (mtam *MultiTenantAuthManager) RegisterStrategy(strategy AuthStrategy) {
 func (mtam *MultiTenantAuthManager) GetToken(ctx context.Context, tenantContext *TenantContext,
 	authConfig AuthConfig) (*TokenInfo, error) {
 
+	ctx, span := tracing.Start(ctx, "auth.get_token", tracing.SpanKindInternal)
+	defer span.End()
+	span.SetAttribute("mcpfusion.auth.type", string(authConfig.Type))
+	if tenantContext != nil {
+		span.SetAttribute("mcpfusion.service", tenantContext.ServiceName)
+		span.SetAttribute("mcpfusion.tenant", tenantContext.ShortHash())
+	}
+
+	tokenInfo, err := mtam.getToken(ctx, tenantContext, authConfig)
+	span.RecordError(err)
+	return tokenInfo, err
+}
+
+// getToken implements GetToken
+func (mtam *MultiTenantAuthManager) getToken(ctx context.Context, tenantContext *TenantContext,
+	authConfig AuthConfig) (*TokenInfo, error) {
+
 	if tenantContext == nil {
 		return nil, NewAuthenticationError("", "", "tenant context is required", nil)
 	}
@@ -180,7 +233,8 @@ func (mtam *MultiTenantAuthManager) GetToken(ctx context.Context, tenantContext
 				mtam.logger.Debugf("Attempting to refresh token for tenant %s service: %s",
 					tenantContext.ShortHash(), tenantContext.ServiceName)
 			}
-			if refreshedToken, err := strategy.RefreshToken(ctx, tokenInfo, authConfig.Config); err == nil {
+			refreshedToken, err := mtam.refreshToken(ctx, strategy, tenantContext, tokenInfo, authConfig)
+			if err == nil {
 				mtam.CacheToken(tenantContext, refreshedToken)
 				if mtam.logger != nil {
 					mtam.logger.Infof("Successfully refreshed token for tenant %s service: %s",
@@ -425,7 +479,7 @@ func (mtam *MultiTenantAuthManager) RefreshIfPossible(ctx context.Context, tenan
 			tenantContext.ShortHash(), tenantContext.ServiceName, authConfig.Type)
 	}
 
-	refreshedToken, err := strategy.RefreshToken(ctx, tokenInfo, authConfig.Config)
+	refreshedToken, err := mtam.refreshToken(ctx, strategy, tenantContext, tokenInfo, authConfig)
 	if err != nil {
 		if mtam.logger != nil {
 			mtam.logger.Warningf("Token refresh failed for tenant %s service %s: %v",
@@ -674,6 +728,12 @@ func (mtam *MultiTenantAuthManager) ExtractTenantFromToken(token string) (*Tenan
 		return tenantContext, nil
 	}
 
+	// Access tokens issued by the built-in OAuth authorization server resolve to
+	// the tenant of the API token the user signed in with
+	if strings.HasPrefix(token, db.OAuthAccessTokenPrefix) {
+		return mtam.extractTenantFromAccessToken(token)
+	}
+
 	// Validate the token against the database
 	if mtam.db != nil {
 		valid, hash, err := mtam.db.ValidateAPIToken(token)
@@ -699,27 +759,7 @@ func (mtam *MultiTenantAuthManager) ExtractTenantFromToken(token string) (*Tenan
 			}
 		}
 
-		// Look up user ID from the key hash
-		var userID string
-		if resolvedUserID, err := mtam.db.GetUserByAPIKey(hash); err == nil {
-			userID = resolvedUserID
-			if mtam.logger != nil {
-				mtam.logger.Debugf("Resolved user ID %s for API key %s", userID, hash[:12])
-			}
-		}
-
-		tenantContext := &TenantContext{
-			TenantHash:  hash,
-			UserID:      userID,
-			ServiceName: "default", // Service name will be resolved from request context
-			Metadata:    make(map[string]string),
-			CreatedAt:   time.Now(),
-		}
-
-		if metadata != nil {
-			tenantContext.Description = metadata.Description
-		}
-
+		tenantContext := mtam.tenantFromTokenHash(hash, metadata)
 		if mtam.logger != nil {
 			mtam.logger.Debugf("Validated and extracted tenant context: %s", tenantContext.String())
 		}
@@ -747,6 +787,80 @@ func (mtam *MultiTenantAuthManager) ExtractTenantFromToken(token string) (*Tenan
 	return tenantContext, nil
 }
 
+// ExtractTenantFromTokenHash returns the tenant context of an existing API token
+// identified by its hash. It is used when the caller was authenticated by other
+// means, such as a client certificate mapped to the token.
+func (mtam *MultiTenantAuthManager) ExtractTenantFromTokenHash(hash string) (*TenantContext, error) {
+	if mtam.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	metadata, err := mtam.db.GetAPITokenMetadata(hash)
+	if err != nil {
+		if mtam.logger != nil {
+			mtam.logger.Warningf("API token lookup by hash failed: %v", err)
+		}
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	tenantContext := mtam.tenantFromTokenHash(hash, metadata)
+	if mtam.logger != nil {
+		mtam.logger.Debugf("Extracted tenant context from token hash: %s", tenantContext.String())
+	}
+
+	return tenantContext, nil
+}
+
+// extractTenantFromAccessToken validates an access token issued by the built-in
+// OAuth authorization server and returns the tenant context of the API token it
+// was granted for. Deleting that API token invalidates its access tokens.
+func (mtam *MultiTenantAuthManager) extractTenantFromAccessToken(token string) (*TenantContext, error) {
+	if mtam.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	data, err := mtam.db.ValidateOAuthAccessToken(token)
+	if err != nil {
+		if mtam.logger != nil {
+			mtam.logger.Warningf("Invalid OAuth access token: %v", err)
+		}
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	tenantContext, err := mtam.ExtractTenantFromTokenHash(data.TenantHash)
+	if err != nil {
+		return nil, err
+	}
+	tenantContext.Metadata["oauth_client_id"] = data.ClientID
+	return tenantContext, nil
+}
+
+// tenantFromTokenHash builds the tenant context of a validated API token
+func (mtam *MultiTenantAuthManager) tenantFromTokenHash(hash string, metadata *db.APITokenMetadata) *TenantContext {
+	// Look up user ID from the key hash
+	var userID string
+	if resolvedUserID, err := mtam.db.GetUserByAPIKey(hash); err == nil {
+		userID = resolvedUserID
+		if mtam.logger != nil {
+			mtam.logger.Debugf("Resolved user ID %s for API key %s", userID, hash[:12])
+		}
+	}
+
+	tenantContext := &TenantContext{
+		TenantHash:  hash,
+		UserID:      userID,
+		ServiceName: "default", // Service name will be resolved from request context
+		Metadata:    make(map[string]string),
+		CreatedAt:   time.Now(),
+	}
+
+	if metadata != nil {
+		tenantContext.Description = metadata.Description
+	}
+
+	return tenantContext
+}
+
 // ExtractTenantFromAuthCode validates an auth code and returns a TenantContext
 // with the tenant hash stored at code creation time
 func (mtam *MultiTenantAuthManager) ExtractTenantFromAuthCode(code string) (*TenantContext, error) {
@@ -852,12 +966,39 @@ func (mtam *MultiTenantAuthManager) GetTenantTokens(tenantHash string) (map[stri
 	return tokenInfoMap, nil
 }
 
-// ListTenants returns a list of all tenants (this would need to be implemented based on your tenant management strategy)
+// ListTenants returns the hashes of all tenants. Tenants with stored OAuth
+// tokens or credentials come first, followed by the remaining API tokens.
 func (mtam *MultiTenantAuthManager) ListTenants() ([]string, error) {
-	// This is a placeholder implementation
-	// In a real system, you'd query your database or tenant registry
+	if mtam.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	stored, err := mtam.db.ListTenants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	apiTokens, err := mtam.db.ListAPITokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	seen := make(map[string]bool, len(stored)+len(apiTokens))
+	tenants := make([]string, 0, len(stored)+len(apiTokens))
+	for _, tenant := range stored {
+		if !seen[tenant.Hash] {
+			seen[tenant.Hash] = true
+			tenants = append(tenants, tenant.Hash)
+		}
+	}
+	for _, token := range apiTokens {
+		if !seen[token.Hash] {
+			seen[token.Hash] = true
+			tenants = append(tenants, token.Hash)
+		}
+	}
+
 	if mtam.logger != nil {
-		mtam.logger.Debug("Listing tenants (placeholder implementation)")
+		mtam.logger.Debugf("Listed %d tenants", len(tenants))
 	}
-	return []string{}, nil
+	return tenants, nil
 }
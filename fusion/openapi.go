@@ -0,0 +1,1239 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// openAPIMaxRefDepth limits chains of $ref pointers, which could otherwise loop
+const openAPIMaxRefDepth = 32
+
+// openAPIMaxSchemaDepth limits how deeply schemas are inlined; recursive
+// schemas are truncated to an unconstrained schema at this depth
+const openAPIMaxSchemaDepth = 8
+
+// openAPIEnvPattern matches runs of characters not allowed in environment variable names
+var openAPIEnvPattern = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// openAPIUnderscorePattern matches runs of underscores in generated endpoint IDs
+var openAPIUnderscorePattern = regexp.MustCompile(`_+`)
+
+// openAPIMethods are the operation methods imported, in the order endpoints are generated
+var openAPIMethods = []string{"get", "put", "post", "delete", "patch"}
+
+// openAPIUnsupportedMethods are operation methods that cannot become endpoints
+var openAPIUnsupportedMethods = []string{"head", "options", "trace"}
+
+// openAPISchemaKeywords are the JSON Schema keywords kept when an OpenAPI
+// schema is converted; OpenAPI extensions such as nullable, discriminator and
+// xml are dropped
+var openAPISchemaKeywords = map[string]bool{
+	"type": true, "enum": true, "const": true, "format": true, "pattern": true,
+	"minLength": true, "maxLength": true, "minimum": true, "maximum": true,
+	"exclusiveMinimum": true, "exclusiveMaximum": true, "multipleOf": true,
+	"items": true, "minItems": true, "maxItems": true, "uniqueItems": true,
+	"properties": true, "required": true, "additionalProperties": true,
+	"minProperties": true, "maxProperties": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true,
+	"title": true, "description": true, "default": true, "examples": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// OpenAPIConfig imports the endpoints of a service from an OpenAPI 3 or
+// Swagger 2 document. The filters select a subset of the operations; patterns
+// use path.Match syntax and are matched against the operation ID and the
+// generated endpoint ID.
+type OpenAPIConfig struct {
+	Spec              string   `json:"spec"`                        // Path of the document; relative paths are resolved against the config file
+	Tags              []string `json:"tags,omitempty"`              // Only import operations with one of these tags
+	Operations        []string `json:"operations,omitempty"`        // Only import operations matching one of these patterns
+	Exclude           []string `json:"exclude,omitempty"`           // Skip operations matching one of these patterns
+	IncludeDeprecated bool     `json:"includeDeprecated,omitempty"` // Also import operations marked deprecated
+}
+
+// OpenAPIImport is a service generated from an OpenAPI document
+type OpenAPIImport struct {
+	Service  *ServiceConfig
+	Warnings []string // Operations, parameters and security schemes that were skipped or approximated
+}
+
+// openAPIDocument is a parsed OpenAPI 3 or Swagger 2 document
+type openAPIDocument struct {
+	root      map[string]interface{}
+	swagger   bool // Swagger 2.0 rather than OpenAPI 3
+	warnings  []string
+	expanding map[string]bool // Schema references being inlined, to detect recursion
+}
+
+// ImportOpenAPI generates a service from an OpenAPI 3.x or Swagger 2.0
+// document in JSON or YAML. Each selected operation becomes an endpoint; the
+// service key names the environment variables referenced by the generated
+// auth configuration. Anything that cannot be represented is skipped and
+// reported in the warnings rather than failing the import.
+func ImportOpenAPI(data []byte, serviceKey string, options *OpenAPIConfig) (*OpenAPIImport, error) {
+	if options == nil {
+		options = &OpenAPIConfig{}
+	}
+
+	root, err := parseOpenAPIDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &openAPIDocument{root: root}
+	openAPIVersion, _ := root["openapi"].(string)
+	swaggerVersion, _ := root["swagger"].(string)
+	switch {
+	case strings.HasPrefix(openAPIVersion, "3."):
+	case swaggerVersion == "2.0":
+		d.swagger = true
+	default:
+		return nil, fmt.Errorf("not an OpenAPI 3 or Swagger 2 document")
+	}
+
+	info, _ := root["info"].(map[string]interface{})
+	service := &ServiceConfig{
+		ServiceKey: serviceKey,
+		BaseURL:    d.baseURL(),
+		Auth:       d.auth(serviceKey),
+	}
+	service.Name, _ = info["title"].(string)
+	if service.Name == "" {
+		service.Name = serviceKey
+	}
+
+	paths, _ := root["paths"].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for pathName := range paths {
+		pathNames = append(pathNames, pathName)
+	}
+	sort.Strings(pathNames)
+
+	ids := make(map[string]bool)
+	for _, pathName := range pathNames {
+		pathItem, err := d.resolve(paths[pathName])
+		if err != nil {
+			d.warnf("%s: %v", pathName, err)
+			continue
+		}
+		for _, method := range openAPIUnsupportedMethods {
+			if _, ok := pathItem[method]; ok {
+				d.warnf("%s %s: method is not supported", strings.ToUpper(method), pathName)
+			}
+		}
+		for _, method := range openAPIMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok || !d.selected(operation, method, pathName, options) {
+				continue
+			}
+			endpoint, ok := d.endpoint(pathName, method, pathItem, operation, service.BaseURL)
+			if !ok {
+				continue
+			}
+			for id, n := endpoint.ID, 2; ids[endpoint.ID]; n++ {
+				endpoint.ID = fmt.Sprintf("%s_%d", id, n)
+			}
+			ids[endpoint.ID] = true
+			service.Endpoints = append(service.Endpoints, *endpoint)
+		}
+	}
+
+	if len(service.Endpoints) == 0 {
+		return nil, fmt.Errorf("no operations were imported")
+	}
+
+	return &OpenAPIImport{Service: service, Warnings: d.warnings}, nil
+}
+
+// parseOpenAPIDocument decodes a JSON or YAML document into JSON values
+func parseOpenAPIDocument(data []byte) (map[string]interface{}, error) {
+	var root map[string]interface{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON document: %w", err)
+		}
+		return root, nil
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+	}
+	root, ok := normalizeYAML(document).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document is not an object")
+	}
+	return root, nil
+}
+
+// normalizeYAML converts decoded YAML into the values encoding/json produces:
+// mapping keys such as response codes become strings, numbers become float64
+// and timestamps become strings
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, member := range v {
+			v[key] = normalizeYAML(member)
+		}
+		return v
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, member := range v {
+			object[fmt.Sprint(key)] = normalizeYAML(member)
+		}
+		return object
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case time.Time:
+		// Unquoted dates and timestamps, e.g. in examples, stay strings
+		if v.Equal(time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)) {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339Nano)
+	default:
+		return value
+	}
+}
+
+// warnf records something that could not be imported, once
+func (d *openAPIDocument) warnf(format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+	for _, existing := range d.warnings {
+		if existing == warning {
+			return
+		}
+	}
+	d.warnings = append(d.warnings, warning)
+}
+
+// resolve follows local $ref pointers and returns the object they lead to.
+// A missing node resolves to nil.
+func (d *openAPIDocument) resolve(node interface{}) (map[string]interface{}, error) {
+	object, _ := node.(map[string]interface{})
+	for depth := 0; object != nil; depth++ {
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, nil
+		}
+		if depth >= openAPIMaxRefDepth {
+			return nil, fmt.Errorf("too many nested references at %s", ref)
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("external reference %s is not supported", ref)
+		}
+
+		var current interface{} = d.root
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			parent, _ := current.(map[string]interface{})
+			if current = parent[token]; current == nil {
+				return nil, fmt.Errorf("unresolved reference %s", ref)
+			}
+		}
+		if object, ok = current.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("reference %s is not an object", ref)
+		}
+	}
+	return nil, nil
+}
+
+// selected reports whether an operation passes the import filters
+func (d *openAPIDocument) selected(operation map[string]interface{}, method, pathName string, options *OpenAPIConfig) bool {
+	if deprecated, _ := operation["deprecated"].(bool); deprecated && !options.IncludeDeprecated {
+		return false
+	}
+
+	if len(options.Tags) > 0 {
+		tags, _ := operation["tags"].([]interface{})
+		found := false
+		for _, tag := range tags {
+			for _, wanted := range options.Tags {
+				if name, _ := tag.(string); strings.EqualFold(name, wanted) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	operationID, _ := operation["operationId"].(string)
+	names := []string{operationID, openAPIEndpointID(operationID, method, pathName)}
+	if len(options.Operations) > 0 && !matchesAnyPattern(options.Operations, names) {
+		return false
+	}
+	return !matchesAnyPattern(options.Exclude, names)
+}
+
+// matchesAnyPattern reports whether any name matches any of the patterns
+func matchesAnyPattern(patterns, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// endpoint converts an operation into an endpoint, or reports why it cannot be imported
+func (d *openAPIDocument) endpoint(pathName, method string, pathItem, operation map[string]interface{}, baseURL string) (*EndpointConfig, bool) {
+	operationID, _ := operation["operationId"].(string)
+	label := fmt.Sprintf("%s %s", strings.ToUpper(method), pathName)
+
+	summary, _ := operation["summary"].(string)
+	description, _ := operation["description"].(string)
+	summary, description = strings.TrimSpace(summary), strings.TrimSpace(description)
+	if description != "" && description != summary && summary != "" {
+		description = summary + "\n\n" + description
+	} else if description == "" {
+		description = summary
+	}
+
+	endpoint := &EndpointConfig{
+		ID:          openAPIEndpointID(operationID, method, pathName),
+		Name:        summary,
+		Description: description,
+		Method:      strings.ToUpper(method),
+		Path:        pathName,
+		Parameters:  []ParameterConfig{},
+		Response:    ResponseConfig{Type: d.responseType(operation)},
+	}
+	if endpoint.Name == "" {
+		endpoint.Name = endpoint.ID
+	}
+	if deprecated, _ := operation["deprecated"].(bool); deprecated {
+		endpoint.Description = strings.TrimSpace(endpoint.Description + " (deprecated)")
+	}
+
+	hints := global.ComputeDefaultHints(endpoint.Method)
+	endpoint.Hints = &HintsConfig{ReadOnly: hints.ReadOnly, Destructive: hints.Destructive}
+
+	// Servers declared on the operation or path override the document's
+	if !d.swagger {
+		for _, servers := range []interface{}{operation["servers"], pathItem["servers"]} {
+			if serverURL := openAPIServerURL(servers); serverURL != "" {
+				if serverURL != baseURL {
+					endpoint.BaseURL = serverURL
+				}
+				break
+			}
+		}
+	}
+
+	params, ok := d.parameters(label, pathItem, operation)
+	if !ok {
+		return nil, false
+	}
+	endpoint.Parameters = append(endpoint.Parameters, params...)
+
+	var bodyParams []ParameterConfig
+	if d.swagger {
+		bodyParams, endpoint.RequestBody, ok = d.swaggerBody(label, pathItem, operation)
+	} else {
+		bodyParams, endpoint.RequestBody, ok = d.requestBody(label, operation)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	// Body properties may share a name with a path, query or header parameter;
+	// they are renamed and mapped back to their field with a transform
+	names := make(map[string]bool, len(endpoint.Parameters))
+	for _, param := range endpoint.Parameters {
+		names[param.Name] = true
+	}
+	for _, param := range bodyParams {
+		if names[param.Name] {
+			field := param.Name
+			param.Name = "body_" + field
+			param.Alias = SanitizeParameterName(param.Name)
+			param.Transform = &TransformConfig{TargetName: field, Expression: "."}
+		}
+		names[param.Name] = true
+		endpoint.Parameters = append(endpoint.Parameters, param)
+	}
+
+	return endpoint, true
+}
+
+// parameters converts the path, query and header parameters of an operation,
+// including those declared on its path
+func (d *openAPIDocument) parameters(label string, pathItem, operation map[string]interface{}) ([]ParameterConfig, bool) {
+	// Operation parameters override path parameters with the same name and location
+	var declared []map[string]interface{}
+	index := make(map[string]int)
+	for _, list := range []interface{}{pathItem["parameters"], operation["parameters"]} {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			param, err := d.resolve(item)
+			if err != nil {
+				d.warnf("%s: skipped parameter: %v", label, err)
+				continue
+			}
+			if param == nil {
+				continue
+			}
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			key := in + ":" + name
+			if i, ok := index[key]; ok {
+				declared[i] = param
+			} else {
+				index[key] = len(declared)
+				declared = append(declared, param)
+			}
+		}
+	}
+
+	var params []ParameterConfig
+	for _, param := range declared {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+		description, _ := param["description"].(string)
+
+		var location ParameterLocation
+		switch in {
+		case "path":
+			location, required = ParameterLocationPath, true
+		case "query":
+			location = ParameterLocationQuery
+		case "header":
+			location = ParameterLocationHeader
+		case "body", "formData":
+			continue // Swagger 2 request bodies are converted by swaggerBody
+		default:
+			if required {
+				d.warnf("%s: required %s parameter %s is not supported", label, in, name)
+				return nil, false
+			}
+			d.warnf("%s: skipped %s parameter %s", label, in, name)
+			continue
+		}
+
+		var schema map[string]interface{}
+		if d.swagger {
+			// Swagger 2 parameters carry their schema keywords directly
+			schema = d.convertSchema(swaggerParameterSchema(param), 0)
+		} else if raw, ok := param["schema"]; ok {
+			schema = d.convertSchema(raw, 0)
+		} else if content, ok := param["content"].(map[string]interface{}); ok {
+			for _, media := range content {
+				mediaObject, _ := media.(map[string]interface{})
+				schema = d.convertSchema(mediaObject["schema"], 0)
+				break
+			}
+		}
+
+		params = append(params, d.parameter(label, name, location, required, description, schema))
+	}
+	return params, true
+}
+
+// requestBody converts the properties of an OpenAPI 3 JSON, multipart or
+// form-encoded request body into body and file parameters. Form-encoded bodies
+// also return the request body configuration that selects the form format.
+func (d *openAPIDocument) requestBody(label string, operation map[string]interface{}) ([]ParameterConfig, *RequestBodyConfig, bool) {
+	body, err := d.resolve(operation["requestBody"])
+	if err != nil {
+		d.warnf("%s: skipped operation: %v", label, err)
+		return nil, nil, false
+	}
+	if body == nil {
+		return nil, nil, true
+	}
+	required, _ := body["required"].(bool)
+	content, _ := body["content"].(map[string]interface{})
+
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		if isJSONMediaType(mediaType) {
+			media, _ := content[mediaType].(map[string]interface{})
+			params, ok := d.bodyParameters(label, d.convertSchema(media["schema"], 0), required, false)
+			return params, nil, ok
+		}
+	}
+	if media, ok := content["multipart/form-data"].(map[string]interface{}); ok {
+		params, ok := d.bodyParameters(label, d.convertSchema(media["schema"], 0), required, true)
+		return params, nil, ok
+	}
+	if media, ok := content["application/x-www-form-urlencoded"].(map[string]interface{}); ok {
+		params, ok := d.bodyParameters(label, d.convertSchema(media["schema"], 0), required, false)
+		return params, &RequestBodyConfig{Format: RequestBodyFormatForm}, ok
+	}
+
+	d.warnf("%s: skipped operation: request body media types %s are not supported", label, strings.Join(mediaTypes, ", "))
+	return nil, nil, false
+}
+
+// swaggerBody converts the body or formData parameters of a Swagger 2 operation.
+// formData parameters are sent as multipart when the operation consumes it or
+// has file parameters, and form-encoded otherwise.
+func (d *openAPIDocument) swaggerBody(label string, pathItem, operation map[string]interface{}) ([]ParameterConfig, *RequestBodyConfig, bool) {
+	var formData []map[string]interface{}
+	for _, list := range []interface{}{pathItem["parameters"], operation["parameters"]} {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			param, err := d.resolve(item)
+			if err != nil || param == nil {
+				continue
+			}
+			switch param["in"] {
+			case "body":
+				required, _ := param["required"].(bool)
+				params, ok := d.bodyParameters(label, d.convertSchema(param["schema"], 0), required, false)
+				return params, nil, ok
+			case "formData":
+				formData = append(formData, param)
+			}
+		}
+	}
+	if len(formData) == 0 {
+		return nil, nil, true
+	}
+
+	consumes, _ := operation["consumes"].([]interface{})
+	if consumes == nil {
+		consumes, _ = d.root["consumes"].([]interface{})
+	}
+	multipart := false
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" {
+			multipart = true
+		}
+	}
+	for _, field := range formData {
+		if field["type"] == "file" {
+			multipart = true
+		}
+	}
+
+	params := make([]ParameterConfig, 0, len(formData))
+	for _, field := range formData {
+		name, _ := field["name"].(string)
+		required, _ := field["required"].(bool)
+		description, _ := field["description"].(string)
+		if field["type"] == "file" {
+			params = append(params, ParameterConfig{Name: name, Alias: openAPIAlias(name), Description: description,
+				Type: ParameterTypeString, Required: required, Location: ParameterLocationFile})
+			continue
+		}
+		schema := d.convertSchema(swaggerParameterSchema(field), 0)
+		params = append(params, d.parameter(label, name, ParameterLocationBody, required, description, schema))
+	}
+	if !multipart {
+		return params, &RequestBodyConfig{Format: RequestBodyFormatForm}, true
+	}
+	return params, nil, true
+}
+
+// bodyParameters converts the properties of an object body schema into parameters.
+// In a multipart body, binary string properties become file uploads.
+func (d *openAPIDocument) bodyParameters(label string, schema map[string]interface{}, bodyRequired, multipart bool) ([]ParameterConfig, bool) {
+	if schema == nil {
+		return nil, true
+	}
+	properties, required := openAPIObjectProperties(schema)
+	if len(properties) == 0 {
+		d.warnf("%s: skipped operation: request body is not an object with properties", label)
+		return nil, false
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]ParameterConfig, 0, len(names))
+	for _, name := range names {
+		property, _ := properties[name].(map[string]interface{})
+		isRequired := bodyRequired && required[name]
+		if format, _ := property["format"].(string); multipart && format == "binary" {
+			description, _ := property["description"].(string)
+			params = append(params, ParameterConfig{Name: name, Alias: openAPIAlias(name), Description: description,
+				Type: ParameterTypeString, Required: isRequired, Location: ParameterLocationFile})
+			continue
+		}
+		params = append(params, d.parameter(label, name, ParameterLocationBody, isRequired, "", property))
+	}
+	return params, true
+}
+
+// openAPIObjectProperties returns the properties of an object schema and the
+// names of the required ones, merging the members of an allOf
+func openAPIObjectProperties(schema map[string]interface{}) (map[string]interface{}, map[string]bool) {
+	properties := make(map[string]interface{})
+	required := make(map[string]bool)
+
+	members := []interface{}{schema}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		members = append(members, allOf...)
+	}
+	for _, member := range members {
+		object, _ := member.(map[string]interface{})
+		memberProperties, _ := object["properties"].(map[string]interface{})
+		for name, property := range memberProperties {
+			properties[name] = property
+		}
+		names, _ := object["required"].([]interface{})
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+	return properties, required
+}
+
+// parameter builds a parameter from its schema. Scalars map onto the type and
+// validation rules; arrays, objects and combinators keep the full schema.
+func (d *openAPIDocument) parameter(label, name string, location ParameterLocation, required bool, description string, schema map[string]interface{}) ParameterConfig {
+	if schema == nil {
+		schema = map[string]interface{}{}
+	}
+	if description == "" {
+		description, _ = schema["description"].(string)
+	}
+
+	param := ParameterConfig{
+		Name:        name,
+		Alias:       openAPIAlias(name),
+		Description: description,
+		Type:        openAPIParameterType(schema),
+		Required:    required,
+		Location:    location,
+		Default:     schema["default"],
+	}
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		param.Examples = examples
+	}
+
+	_, hasAllOf := schema["allOf"]
+	_, hasAnyOf := schema["anyOf"]
+	_, hasOneOf := schema["oneOf"]
+	_, hasNot := schema["not"]
+	switch {
+	case param.Type == ParameterTypeArray || param.Type == ParameterTypeObject || hasAllOf || hasAnyOf || hasOneOf || hasNot:
+		if items, ok := schema["items"].(map[string]interface{}); ok && openAPIParameterType(items) == ParameterTypeObject {
+			param.Items = ParameterTypeObject
+		}
+		param.Schema = schema
+		if err := param.validateSchema(); err != nil {
+			d.warnf("%s: parameter %s: schema dropped: %v", label, name, err)
+			param.Schema = nil
+		}
+	default:
+		param.Validation = openAPIValidation(schema)
+	}
+	return param
+}
+
+// openAPIValidation returns the validation rules of a scalar schema, or nil if it has none
+func openAPIValidation(schema map[string]interface{}) *ValidationConfig {
+	validation := &ValidationConfig{}
+	validation.Pattern, _ = schema["pattern"].(string)
+	validation.Format, _ = schema["format"].(string)
+	validation.Enum, _ = schema["enum"].([]interface{})
+	if n, ok := jsonNumber(schema["minLength"]); ok {
+		minLength := int(n)
+		validation.MinLength = &minLength
+	}
+	if n, ok := jsonNumber(schema["maxLength"]); ok {
+		maxLength := int(n)
+		validation.MaxLength = &maxLength
+	}
+	for _, key := range []string{"minimum", "exclusiveMinimum"} {
+		if n, ok := jsonNumber(schema[key]); ok && validation.Minimum == nil {
+			validation.Minimum = &n
+		}
+	}
+	for _, key := range []string{"maximum", "exclusiveMaximum"} {
+		if n, ok := jsonNumber(schema[key]); ok && validation.Maximum == nil {
+			validation.Maximum = &n
+		}
+	}
+
+	if validation.Pattern == "" && validation.Format == "" && len(validation.Enum) == 0 &&
+		validation.MinLength == nil && validation.MaxLength == nil && validation.Minimum == nil && validation.Maximum == nil {
+		return nil
+	}
+	return validation
+}
+
+// openAPIParameterType returns the parameter type of a schema
+func openAPIParameterType(schema map[string]interface{}) ParameterType {
+	types, _ := schemaTypeNames(schema["type"])
+	for _, name := range types {
+		switch ParameterType(name) {
+		case ParameterTypeString, ParameterTypeNumber, ParameterTypeInteger, ParameterTypeBoolean, ParameterTypeArray, ParameterTypeObject:
+			return ParameterType(name)
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return ParameterTypeObject
+	}
+	if _, ok := schema["items"]; ok {
+		return ParameterTypeArray
+	}
+	return ParameterTypeString
+}
+
+// openAPIAlias returns an MCP-compliant alias for a name that is not one
+func openAPIAlias(name string) string {
+	if IsValidMCPParameterName(name) {
+		return ""
+	}
+	return SanitizeParameterName(name)
+}
+
+// swaggerParameterSchema returns the schema keywords of a Swagger 2 parameter,
+// which are declared on the parameter itself
+func swaggerParameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{}, len(param))
+	for key, value := range param {
+		switch key {
+		case "name", "in", "required", "allowEmptyValue", "collectionFormat":
+		default:
+			schema[key] = value
+		}
+	}
+	return schema
+}
+
+// convertSchema inlines the references of an OpenAPI schema and reduces it to
+// the keywords it shares with JSON Schema. Properties marked readOnly are
+// dropped, since they are never sent in a request. A schema that refers to
+// itself is inlined once; the recursive reference keeps only its type.
+func (d *openAPIDocument) convertSchema(node interface{}, depth int) map[string]interface{} {
+	schema, err := d.resolve(node)
+	if err != nil {
+		d.warnf("schema replaced by an unconstrained schema: %v", err)
+		return map[string]interface{}{}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	object, _ := node.(map[string]interface{})
+	if ref, ok := object["$ref"].(string); ok {
+		if d.expanding[ref] || depth >= openAPIMaxSchemaDepth {
+			truncated := map[string]interface{}{}
+			if schemaType, ok := schema["type"]; ok {
+				truncated["type"] = schemaType
+			}
+			return truncated
+		}
+		if d.expanding == nil {
+			d.expanding = make(map[string]bool)
+		}
+		d.expanding[ref] = true
+		defer delete(d.expanding, ref)
+	} else if depth >= openAPIMaxSchemaDepth {
+		return map[string]interface{}{}
+	}
+
+	converted := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "properties":
+			properties, _ := value.(map[string]interface{})
+			convertedProperties := make(map[string]interface{}, len(properties))
+			for name, property := range properties {
+				convertedProperty := d.convertSchema(property, depth+1)
+				if readOnly, _ := convertedProperty["readOnly"].(bool); readOnly {
+					continue
+				}
+				convertedProperties[name] = convertedProperty
+			}
+			converted[key] = convertedProperties
+		case "items", "not":
+			converted[key] = d.convertSchema(value, depth+1)
+		case "additionalProperties":
+			if _, ok := value.(bool); ok {
+				converted[key] = value
+			} else {
+				converted[key] = d.convertSchema(value, depth+1)
+			}
+		case "allOf", "anyOf", "oneOf":
+			members, _ := value.([]interface{})
+			convertedMembers := make([]interface{}, 0, len(members))
+			for _, member := range members {
+				convertedMembers = append(convertedMembers, d.convertSchema(member, depth+1))
+			}
+			converted[key] = convertedMembers
+		case "exclusiveMinimum", "exclusiveMaximum":
+			// Boolean forms are converted below
+			if _, ok := value.(bool); !ok {
+				converted[key] = value
+			}
+		case "example":
+			if _, ok := schema["examples"]; !ok {
+				converted["examples"] = []interface{}{value}
+			}
+		default:
+			if openAPISchemaKeywords[key] {
+				converted[key] = value
+			}
+		}
+	}
+
+	// OpenAPI 3.0 and Swagger 2 make minimum and maximum exclusive with a flag
+	for bound, key := range map[string]string{"minimum": "exclusiveMinimum", "maximum": "exclusiveMaximum"} {
+		limit, hasLimit := schema[bound]
+		if exclusive, _ := schema[key].(bool); exclusive && hasLimit {
+			converted[key] = limit
+			delete(converted, bound)
+		}
+	}
+
+	// OpenAPI 3.0 marks nullable values with a flag rather than a type
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if name, ok := converted["type"].(string); ok {
+			converted["type"] = []interface{}{name, "null"}
+		}
+	}
+
+	// Required properties that were dropped as readOnly are no longer required
+	if required, ok := converted["required"].([]interface{}); ok {
+		properties, _ := converted["properties"].(map[string]interface{})
+		kept := make([]interface{}, 0, len(required))
+		for _, name := range required {
+			s, _ := name.(string)
+			if _, exists := properties[s]; exists || properties == nil {
+				kept = append(kept, name)
+			}
+		}
+		converted["required"] = kept
+	}
+
+	// Patterns use ECMA-262 syntax, which Go cannot always compile
+	if pattern, ok := converted["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			d.warnf("pattern %q dropped: %v", pattern, err)
+			delete(converted, "pattern")
+		}
+	}
+
+	return converted
+}
+
+// responseType returns the response type of an operation from the media
+// types of its first success response
+func (d *openAPIDocument) responseType(operation map[string]interface{}) ResponseType {
+	var mediaTypes []string
+	if d.swagger {
+		produces, _ := operation["produces"].([]interface{})
+		if produces == nil {
+			produces, _ = d.root["produces"].([]interface{})
+		}
+		for _, mediaType := range produces {
+			if s, ok := mediaType.(string); ok {
+				mediaTypes = append(mediaTypes, s)
+			}
+		}
+	} else {
+		responses, _ := operation["responses"].(map[string]interface{})
+		codes := make([]string, 0, len(responses))
+		for code := range responses {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		codes = append(codes, "default")
+		for _, code := range codes {
+			if !strings.HasPrefix(code, "2") && code != "default" {
+				continue
+			}
+			response, err := d.resolve(responses[code])
+			if err != nil || response == nil {
+				continue
+			}
+			content, _ := response["content"].(map[string]interface{})
+			for mediaType := range content {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+			break
+		}
+	}
+
+	if len(mediaTypes) == 0 {
+		return ResponseTypeJSON
+	}
+	for _, mediaType := range mediaTypes {
+		if isJSONMediaType(mediaType) {
+			return ResponseTypeJSON
+		}
+	}
+	for _, mediaType := range mediaTypes {
+		if strings.HasPrefix(mediaType, "text/") || strings.Contains(mediaType, "xml") {
+			return ResponseTypeText
+		}
+	}
+	return ResponseTypeBinary
+}
+
+// isJSONMediaType reports whether a media type carries JSON
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "*/*" || strings.Contains(strings.ToLower(mediaType), "json")
+}
+
+// baseURL returns the URL of the first server of the document
+func (d *openAPIDocument) baseURL() string {
+	if !d.swagger {
+		baseURL := openAPIServerURL(d.root["servers"])
+		if baseURL == "" {
+			d.warnf("document declares no servers; set baseURL")
+		} else if !strings.Contains(baseURL, "://") {
+			d.warnf("server URL %s is relative; set baseURL", baseURL)
+		}
+		return baseURL
+	}
+
+	host, _ := d.root["host"].(string)
+	if host == "" {
+		d.warnf("document declares no host; set baseURL")
+		return ""
+	}
+	scheme := "https"
+	if schemes, ok := d.root["schemes"].([]interface{}); ok && len(schemes) > 0 {
+		scheme, _ = schemes[0].(string)
+		for _, s := range schemes {
+			if s == "https" {
+				scheme = "https"
+			}
+		}
+	}
+	basePath, _ := d.root["basePath"].(string)
+	return scheme + "://" + host + strings.TrimSuffix(basePath, "/")
+}
+
+// openAPIServerURL returns the URL of the first entry of an OpenAPI 3 servers
+// list with its variables replaced by their defaults
+func openAPIServerURL(servers interface{}) string {
+	list, _ := servers.([]interface{})
+	if len(list) == 0 {
+		return ""
+	}
+	server, _ := list[0].(map[string]interface{})
+	serverURL, _ := server["url"].(string)
+	variables, _ := server["variables"].(map[string]interface{})
+	for name, variable := range variables {
+		object, _ := variable.(map[string]interface{})
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", fmt.Sprint(object["default"]))
+	}
+	return strings.TrimSuffix(serverURL, "/")
+}
+
+// auth maps the security scheme the document requires onto an auth
+// configuration. Secrets are left as ${VAR} placeholders named after the service.
+func (d *openAPIDocument) auth(serviceKey string) AuthConfig {
+	var schemes map[string]interface{}
+	if d.swagger {
+		schemes, _ = d.root["securityDefinitions"].(map[string]interface{})
+	} else {
+		components, _ := d.root["components"].(map[string]interface{})
+		schemes, _ = components["securitySchemes"].(map[string]interface{})
+	}
+	if len(schemes) == 0 {
+		return openAPINoAuth()
+	}
+
+	// Use the first scheme the document requires, or else the first it defines
+	var name string
+	var scopes []interface{}
+	requirements, _ := d.root["security"].([]interface{})
+	for _, requirement := range requirements {
+		object, _ := requirement.(map[string]interface{})
+		names := make([]string, 0, len(object))
+		for candidate := range object {
+			names = append(names, candidate)
+		}
+		sort.Strings(names)
+		if len(names) > 0 {
+			name = names[0]
+			scopes, _ = object[name].([]interface{})
+			break
+		}
+	}
+	if name == "" {
+		names := make([]string, 0, len(schemes))
+		for candidate := range schemes {
+			names = append(names, candidate)
+		}
+		sort.Strings(names)
+		name = names[0]
+	}
+
+	scheme, err := d.resolve(schemes[name])
+	if err != nil || scheme == nil {
+		d.warnf("security scheme %s could not be resolved; auth set to none", name)
+		return openAPINoAuth()
+	}
+
+	prefix := openAPIEnvPrefix(serviceKey)
+	schemeType, _ := scheme["type"].(string)
+	description, _ := scheme["description"].(string)
+	httpScheme, _ := scheme["scheme"].(string)
+
+	switch {
+	case schemeType == "http" && strings.EqualFold(httpScheme, "bearer"):
+		return AuthConfig{Type: AuthTypeBearer, Config: map[string]interface{}{
+			"token": "${" + prefix + "_TOKEN}",
+		}}
+
+	case schemeType == "basic" || (schemeType == "http" && strings.EqualFold(httpScheme, "basic")):
+		config := map[string]interface{}{
+			"authMethod": AuthMethodBasicAuth,
+			"fields": []interface{}{
+				map[string]interface{}{"name": "username", "label": "Username"},
+				map[string]interface{}{"name": "password", "label": "Password"},
+			},
+		}
+		if description != "" {
+			config["instructions"] = description
+		}
+		return AuthConfig{Type: AuthTypeUserCredentials, Config: config}
+
+	case schemeType == "apiKey":
+		keyName, _ := scheme["name"].(string)
+		location, _ := scheme["in"].(string)
+		config := map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{
+					"name":      SanitizeParameterName(strings.ToLower(keyName)),
+					"label":     keyName,
+					"location":  location,
+					"paramName": keyName,
+				},
+			},
+		}
+		if description != "" {
+			config["instructions"] = description
+		}
+		return AuthConfig{Type: AuthTypeUserCredentials, Config: config}
+
+	case schemeType == "oauth2":
+		flow := scheme
+		if !d.swagger {
+			flows, _ := scheme["flows"].(map[string]interface{})
+			flow, _ = flows["authorizationCode"].(map[string]interface{})
+		} else if scheme["flow"] != "accessCode" {
+			flow = nil
+		}
+		if flow == nil {
+			d.warnf("security scheme %s: only the authorization code flow is supported; auth set to none", name)
+			return openAPINoAuth()
+		}
+		config := map[string]interface{}{
+			"clientId":     "${" + prefix + "_CLIENT_ID}",
+			"clientSecret": "${" + prefix + "_CLIENT_SECRET}",
+			"tokenURL":     flow["tokenUrl"],
+		}
+		if authorizationURL, ok := flow["authorizationUrl"].(string); ok {
+			config["authorizationURL"] = authorizationURL
+		}
+		if scope := openAPIScopes(scopes, flow["scopes"]); scope != "" {
+			config["scope"] = scope
+		}
+		return AuthConfig{Type: AuthTypeOAuth2External, Config: config}
+
+	case schemeType == "openIdConnect":
+		discoveryURL, _ := scheme["openIdConnectUrl"].(string)
+		d.warnf("security scheme %s: set %s_TOKEN_URL to the token endpoint of the issuer", name, prefix)
+		config := map[string]interface{}{
+			"clientId":     "${" + prefix + "_CLIENT_ID}",
+			"clientSecret": "${" + prefix + "_CLIENT_SECRET}",
+			"issuer":       strings.TrimSuffix(discoveryURL, "/.well-known/openid-configuration"),
+			"tokenURL":     "${" + prefix + "_TOKEN_URL}",
+			"scope":        strings.TrimSpace("openid " + openAPIScopes(scopes, nil)),
+		}
+		return AuthConfig{Type: AuthTypeOAuth2External, Config: config}
+	}
+
+	d.warnf("security scheme %s of type %s is not supported; auth set to none", name, schemeType)
+	return openAPINoAuth()
+}
+
+// openAPINoAuth returns the auth configuration of a service without authentication
+func openAPINoAuth() AuthConfig {
+	return AuthConfig{Type: AuthTypeNone, Config: map[string]interface{}{}}
+}
+
+// openAPIScopes returns the scopes a security requirement lists, or else all
+// scopes the flow defines, as a space-separated string
+func openAPIScopes(required []interface{}, defined interface{}) string {
+	var scopes []string
+	for _, scope := range required {
+		if s, ok := scope.(string); ok && s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		definedScopes, _ := defined.(map[string]interface{})
+		for scope := range definedScopes {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+	}
+	return strings.Join(scopes, " ")
+}
+
+// openAPIEnvPrefix returns the environment variable prefix for a service key
+func openAPIEnvPrefix(serviceKey string) string {
+	prefix := strings.Trim(openAPIEnvPattern.ReplaceAllString(strings.ToUpper(serviceKey), "_"), "_")
+	if prefix == "" {
+		return "SERVICE"
+	}
+	return prefix
+}
+
+// openAPIEndpointID returns a snake_case endpoint ID from an operation ID,
+// or from the method and path when the operation has none
+func openAPIEndpointID(operationID, method, pathName string) string {
+	source := operationID
+	if source == "" {
+		source = method + "_" + strings.NewReplacer("{", "by_", "}", "").Replace(pathName)
+	}
+
+	var b strings.Builder
+	previousLower := false
+	for _, r := range source {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if previousLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + 'a' - 'A')
+			previousLower = false
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			previousLower = true
+		default:
+			b.WriteByte('_')
+			previousLower = false
+		}
+	}
+
+	id := strings.Trim(openAPIUnderscorePattern.ReplaceAllString(b.String(), "_"), "_")
+	if id == "" {
+		return strings.ToLower(method)
+	}
+	return id
+}
+
+// importOpenAPIServices generates the endpoints of services that import an
+// OpenAPI document. Endpoints written in the configuration take precedence
+// over generated endpoints with the same ID, and the service's own baseURL and
+// auth take precedence over those of the document.
+func (c *Config) importOpenAPIServices(logger global.Logger) error {
+	for serviceName, service := range c.Services {
+		if service == nil || service.OpenAPI == nil {
+			continue
+		}
+
+		specPath := service.OpenAPI.Spec
+		if specPath == "" {
+			return fmt.Errorf("service %s: openapi.spec is required", serviceName)
+		}
+		if !filepath.IsAbs(specPath) && c.ConfigPath != "" {
+			specPath = filepath.Join(filepath.Dir(c.ConfigPath), specPath)
+		}
+		data, err := os.ReadFile(specPath)
+		if err != nil {
+			return fmt.Errorf("service %s: failed to read OpenAPI document: %w", serviceName, err)
+		}
+
+		imported, err := ImportOpenAPI(data, serviceName, service.OpenAPI)
+		if err != nil {
+			return fmt.Errorf("service %s: failed to import OpenAPI document %s: %w", serviceName, specPath, err)
+		}
+		if logger != nil {
+			for _, warning := range imported.Warnings {
+				logger.Warningf("Service %s: OpenAPI import: %s", serviceName, warning)
+			}
+		}
+
+		if service.Name == "" {
+			service.Name = imported.Service.Name
+		}
+		if service.BaseURL == "" {
+			service.BaseURL = imported.Service.BaseURL
+		}
+		if service.Auth.Type == "" {
+			// Generated auth refers to secrets by ${VAR} placeholders
+			if service.Auth, err = expandAuthConfig(imported.Service.Auth); err != nil {
+				return fmt.Errorf("service %s: %w", serviceName, err)
+			}
+		}
+
+		defined := make(map[string]bool, len(service.Endpoints))
+		for _, endpoint := range service.Endpoints {
+			defined[endpoint.ID] = true
+		}
+		count := 0
+		for _, endpoint := range imported.Service.Endpoints {
+			if !defined[endpoint.ID] {
+				service.Endpoints = append(service.Endpoints, endpoint)
+				count++
+			}
+		}
+
+		if logger != nil {
+			logger.Infof("Service %s: imported %d endpoints from %s", serviceName, count, specPath)
+		}
+	}
+	return nil
+}
+
+// expandAuthConfig replaces environment variable placeholders in an auth configuration
+func expandAuthConfig(auth AuthConfig) (AuthConfig, error) {
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return auth, fmt.Errorf("failed to encode auth configuration: %w", err)
+	}
+	if data, err = expandEnvironmentVariables(data); err != nil {
+		return auth, err
+	}
+	var expanded AuthConfig
+	if err := json.Unmarshal(data, &expanded); err != nil {
+		return auth, fmt.Errorf("failed to decode auth configuration: %w", err)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,491 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const petstoreOpenAPI3 = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: https://{region}.pets.example.com/v1/
+    variables:
+      region:
+        default: eu
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+  parameters:
+    PetID:
+      name: id
+      in: path
+      required: true
+      schema: {type: integer}
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string, minLength: 1}
+        born: {type: string, format: date, example: 2020-01-01}
+        weight: {type: number, minimum: 0, exclusiveMinimum: true, nullable: true}
+        tags:
+          type: array
+          items: {$ref: '#/components/schemas/Tag'}
+        parent: {$ref: '#/components/schemas/Pet'}
+    Tag:
+      type: object
+      properties:
+        label: {type: string}
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      summary: List pets
+      description: Returns all pets.
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, minimum: 1, maximum: 100}}
+        - {name: $filter, in: query, schema: {type: string}}
+        - {name: session, in: cookie, schema: {type: string}}
+      responses:
+        200: {description: ok, content: {application/json: {schema: {type: array}}}}
+    post:
+      operationId: createPet
+      tags: [pets]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        201: {description: created}
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: getPet
+      tags: [pets]
+      responses:
+        200: {description: ok, content: {text/plain: {schema: {type: string}}}}
+    patch:
+      operationId: renamePet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id: {type: string}
+      responses: {200: {description: ok}}
+    delete:
+      operationId: deletePet
+      tags: [admin]
+      deprecated: true
+      responses: {204: {description: gone}}
+    put:
+      operationId: replacePet
+      tags: [admin]
+      requestBody:
+        content:
+          application/xml: {schema: {type: object}}
+      responses: {200: {description: ok}}
+    head:
+      responses: {200: {description: ok}}
+  /pets/{id}/photo:
+    post:
+      tags: [pets]
+      parameters:
+        - $ref: '#/components/parameters/PetID'
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file: {type: string, format: binary}
+                caption: {type: string}
+      responses: {200: {description: ok}}
+`
+
+const petstoreSwagger2 = `{
+  "swagger": "2.0",
+  "info": {"title": "Legacy Pets", "version": "1"},
+  "host": "legacy.example.com",
+  "basePath": "/api/",
+  "schemes": ["http", "https"],
+  "produces": ["application/xml"],
+  "securityDefinitions": {"basic": {"type": "basic"}},
+  "definitions": {
+    "Owner": {
+      "type": "object",
+      "required": ["email"],
+      "properties": {"email": {"type": "string", "format": "email"}, "age": {"type": "integer", "maximum": 150}}
+    }
+  },
+  "paths": {
+    "/owners": {
+      "post": {
+        "operationId": "create_owner",
+        "parameters": [{"name": "owner", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Owner"}}],
+        "produces": ["application/json"],
+        "responses": {"201": {"description": "created"}}
+      },
+      "get": {
+        "operationId": "listOwners",
+        "parameters": [{"name": "status", "in": "query", "type": "string", "enum": ["active", "gone"], "required": true}],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/owners/{id}/avatar": {
+      "put": {
+        "consumes": ["multipart/form-data"],
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "image", "in": "formData", "type": "file", "required": true},
+          {"name": "note", "in": "formData", "type": "string"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/owners/{id}/rename": {
+      "post": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "name", "in": "formData", "type": "string"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+func findEndpoint(t *testing.T, service *ServiceConfig, id string) *EndpointConfig {
+	t.Helper()
+	endpoint := service.GetEndpointByID(id)
+	if endpoint == nil {
+		ids := make([]string, 0, len(service.Endpoints))
+		for _, e := range service.Endpoints {
+			ids = append(ids, e.ID)
+		}
+		t.Fatalf("endpoint %s not imported; got %v", id, ids)
+	}
+	return endpoint
+}
+
+func hasWarning(warnings []string, substring string) bool {
+	for _, warning := range warnings {
+		if strings.Contains(warning, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestImportOpenAPI_OpenAPI3(t *testing.T) {
+	imported, err := ImportOpenAPI([]byte(petstoreOpenAPI3), "pets", nil)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	service := imported.Service
+
+	if service.Name != "Petstore" || service.BaseURL != "https://eu.pets.example.com/v1" {
+		t.Errorf("service = %q at %q", service.Name, service.BaseURL)
+	}
+	if err := service.Validate(); err != nil {
+		t.Fatalf("generated service does not validate: %v", err)
+	}
+
+	// Deprecated and unsupported operations are skipped
+	if len(service.Endpoints) != 5 {
+		t.Errorf("expected 5 endpoints, got %d", len(service.Endpoints))
+	}
+	if service.GetEndpointByID("delete_pet") != nil || service.GetEndpointByID("replace_pet") != nil {
+		t.Error("deprecated or unsupported operations were imported")
+	}
+	for _, want := range []string{"HEAD /pets/{id}", "application/xml", "cookie parameter session"} {
+		if !hasWarning(imported.Warnings, want) {
+			t.Errorf("expected a warning about %q, got %v", want, imported.Warnings)
+		}
+	}
+
+	list := findEndpoint(t, service, "list_pets")
+	if list.Method != "GET" || list.Name != "List pets" || list.Description != "List pets\n\nReturns all pets." {
+		t.Errorf("list_pets = %s %q %q", list.Method, list.Name, list.Description)
+	}
+	if list.Hints == nil || !*list.Hints.ReadOnly || *list.Hints.Destructive {
+		t.Errorf("list_pets hints = %+v", list.Hints)
+	}
+	limit := list.GetParameterByName("limit")
+	if limit == nil || limit.Type != ParameterTypeInteger || limit.Location != ParameterLocationQuery ||
+		limit.Validation == nil || *limit.Validation.Minimum != 1 || *limit.Validation.Maximum != 100 {
+		t.Errorf("limit = %+v", limit)
+	}
+	if filter := list.GetParameterByName("$filter"); filter == nil || filter.Alias != "filter" {
+		t.Errorf("$filter = %+v", filter)
+	}
+
+	create := findEndpoint(t, service, "create_pet")
+	if create.GetParameterByName("id") != nil {
+		t.Error("readOnly property id was imported as a body parameter")
+	}
+	name := create.GetParameterByName("name")
+	if name == nil || !name.Required || name.Location != ParameterLocationBody || *name.Validation.MinLength != 1 {
+		t.Errorf("name = %+v", name)
+	}
+	if born := create.GetParameterByName("born"); born == nil || len(born.Examples) != 1 || born.Examples[0] != "2020-01-01" {
+		t.Errorf("born = %+v", born)
+	}
+	tags := create.GetParameterByName("tags")
+	if tags == nil || tags.Items != ParameterTypeObject || tags.Schema == nil {
+		t.Fatalf("tags = %+v", tags)
+	}
+	if err := ValidateSchemaValue(tags.Schema, "tags", []interface{}{map[string]interface{}{"label": 1.0}}); err == nil {
+		t.Error("expected the inlined Tag schema to reject a numeric label")
+	}
+	weight := create.GetParameterByName("weight")
+	if weight == nil || weight.Validation == nil || *weight.Validation.Minimum != 0 {
+		t.Errorf("weight = %+v", weight)
+	}
+	// The recursive reference keeps only its type
+	parent := create.GetParameterByName("parent")
+	if parent == nil || len(parent.Schema) != 1 || parent.Schema["type"] != "object" {
+		t.Errorf("parent = %+v", parent)
+	}
+
+	if get := findEndpoint(t, service, "get_pet"); get.Response.Type != ResponseTypeText || len(get.Parameters) != 1 {
+		t.Errorf("get_pet = %+v", get)
+	}
+
+	// A body property that shares a name with a path parameter is renamed
+	rename := findEndpoint(t, service, "rename_pet")
+	bodyID := rename.GetParameterByName("body_id")
+	if bodyID == nil || bodyID.Transform == nil || bodyID.Transform.TargetName != "id" {
+		t.Errorf("body_id = %+v", bodyID)
+	}
+
+	// Operations without an ID are named after the method and path
+	photo := findEndpoint(t, service, "post_pets_by_id_photo")
+	if file := photo.GetParameterByName("file"); file == nil || file.Location != ParameterLocationFile {
+		t.Errorf("file = %+v", file)
+	}
+	if caption := photo.GetParameterByName("caption"); caption == nil || caption.Location != ParameterLocationBody {
+		t.Errorf("caption = %+v", caption)
+	}
+
+	if service.Auth.Type != AuthTypeUserCredentials {
+		t.Fatalf("auth type = %s", service.Auth.Type)
+	}
+	field := service.Auth.Config["fields"].([]interface{})[0].(map[string]interface{})
+	if field["location"] != "header" || field["paramName"] != "X-API-Key" {
+		t.Errorf("auth field = %v", field)
+	}
+}
+
+func TestImportOpenAPI_Swagger2(t *testing.T) {
+	imported, err := ImportOpenAPI([]byte(petstoreSwagger2), "legacy", nil)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	service := imported.Service
+
+	if service.BaseURL != "https://legacy.example.com/api" {
+		t.Errorf("baseURL = %q", service.BaseURL)
+	}
+	if err := service.Validate(); err != nil {
+		t.Fatalf("generated service does not validate: %v", err)
+	}
+	rename := findEndpoint(t, service, "post_owners_by_id_rename")
+	if rename.RequestBody == nil || rename.RequestBody.Format != RequestBodyFormatForm || rename.GetParameterByName("name") == nil {
+		t.Errorf("form-encoded operation = %+v", rename)
+	}
+
+	create := findEndpoint(t, service, "create_owner")
+	if create.Response.Type != ResponseTypeJSON {
+		t.Errorf("create_owner response = %s", create.Response.Type)
+	}
+	email := create.GetParameterByName("email")
+	if email == nil || !email.Required || email.Validation == nil || email.Validation.Format != "email" {
+		t.Errorf("email = %+v", email)
+	}
+
+	list := findEndpoint(t, service, "list_owners")
+	if list.Response.Type != ResponseTypeText {
+		t.Errorf("list_owners response = %s", list.Response.Type)
+	}
+	status := list.GetParameterByName("status")
+	if status == nil || !status.Required || status.Validation == nil || len(status.Validation.Enum) != 2 {
+		t.Errorf("status = %+v", status)
+	}
+
+	avatar := findEndpoint(t, service, "put_owners_by_id_avatar")
+	if image := avatar.GetParameterByName("image"); image == nil || image.Location != ParameterLocationFile || !image.Required {
+		t.Errorf("image = %+v", image)
+	}
+
+	if service.Auth.Type != AuthTypeUserCredentials || service.Auth.Config["authMethod"] != AuthMethodBasicAuth {
+		t.Errorf("auth = %+v", service.Auth)
+	}
+}
+
+func TestImportOpenAPI_Filters(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *OpenAPIConfig
+		want    []string
+	}{
+		{"tags", &OpenAPIConfig{Tags: []string{"ADMIN"}, IncludeDeprecated: true}, []string{"delete_pet"}},
+		{"operation patterns", &OpenAPIConfig{Operations: []string{"get*", "list_*"}}, []string{"list_pets", "get_pet"}},
+		{"exclude", &OpenAPIConfig{Tags: []string{"pets"}, Exclude: []string{"*Pet", "post_*"}}, []string{"list_pets"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported, err := ImportOpenAPI([]byte(petstoreOpenAPI3), "pets", tt.options)
+			if err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+			var got []string
+			for _, endpoint := range imported.Service.Endpoints {
+				got = append(got, endpoint.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("imported %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ImportOpenAPI([]byte(petstoreOpenAPI3), "pets", &OpenAPIConfig{Tags: []string{"none"}}); err == nil {
+		t.Error("expected an error when no operations are selected")
+	}
+}
+
+func TestImportOpenAPI_SecuritySchemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   string
+		wantType AuthType
+		wantKey  string
+		wantVal  interface{}
+	}{
+		{"bearer", `{"type": "http", "scheme": "bearer"}`, AuthTypeBearer, "token", "${MY_API_TOKEN}"},
+		{"authorization code", `{"type": "oauth2", "flows": {"authorizationCode": {"authorizationUrl": "https://id.example.com/authorize",
+			"tokenUrl": "https://id.example.com/token", "scopes": {"write": "", "read": ""}}}}`, AuthTypeOAuth2External, "scope", "read write"},
+		{"openid connect", `{"type": "openIdConnect", "openIdConnectUrl": "https://id.example.com/.well-known/openid-configuration"}`,
+			AuthTypeOAuth2External, "issuer", "https://id.example.com"},
+		{"client credentials", `{"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://id.example.com/token", "scopes": {}}}}`,
+			AuthTypeNone, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := `{"openapi": "3.1.0", "info": {"title": "API"}, "servers": [{"url": "https://api.example.com"}],
+				"components": {"securitySchemes": {"main": ` + tt.scheme + `}},
+				"paths": {"/items": {"get": {"operationId": "listItems", "responses": {}}}}}`
+			imported, err := ImportOpenAPI([]byte(spec), "my-api", nil)
+			if err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+			auth := imported.Service.Auth
+			if auth.Type != tt.wantType {
+				t.Fatalf("auth type = %s, want %s", auth.Type, tt.wantType)
+			}
+			if tt.wantKey != "" && auth.Config[tt.wantKey] != tt.wantVal {
+				t.Errorf("auth config %s = %v, want %v", tt.wantKey, auth.Config[tt.wantKey], tt.wantVal)
+			}
+			if err := auth.Validate(); err != nil {
+				t.Errorf("generated auth does not validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestImportOpenAPI_InvalidDocument(t *testing.T) {
+	for _, spec := range []string{`{"openapi": "2.5"}`, `not: [valid`, `{"swagger": "2.0", "paths": {"/a": {"$ref": "other.yaml#/a"}}}`} {
+		if _, err := ImportOpenAPI([]byte(spec), "svc", nil); err == nil {
+			t.Errorf("expected an error for %s", spec)
+		}
+	}
+}
+
+func TestOpenAPIEndpointID(t *testing.T) {
+	tests := []struct {
+		operationID, method, path, want string
+	}{
+		{"listPets", "get", "/pets", "list_pets"},
+		{"Users.GetByID", "get", "/users/{id}", "users_get_by_id"},
+		{"create-invoice", "post", "/invoices", "create_invoice"},
+		{"", "delete", "/users/{userId}/keys", "delete_users_by_user_id_keys"},
+	}
+	for _, tt := range tests {
+		if got := openAPIEndpointID(tt.operationID, tt.method, tt.path); got != tt.want {
+			t.Errorf("openAPIEndpointID(%q, %q, %q) = %q, want %q", tt.operationID, tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_OpenAPI(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "petstore.yaml"), []byte(petstoreOpenAPI3), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PETS_KEY", "secret")
+
+	configJSON := `{
+	  "services": {
+	    "pets": {
+	      "baseURL": "https://staging.pets.example.com",
+	      "auth": {"type": "bearer", "config": {"token": "${PETS_KEY}"}},
+	      "openapi": {"spec": "petstore.yaml", "tags": ["pets"], "exclude": ["post_*"]},
+	      "endpoints": [
+	        {"id": "list_pets", "name": "Custom list", "description": "Hand-written", "method": "GET", "path": "/pets",
+	         "parameters": [], "response": {"type": "json"}}
+	      ]
+	    }
+	  }
+	}`
+	configPath := filepath.Join(dir, "pets.json")
+
+	config, err := LoadConfigFromJSON([]byte(configJSON), configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	service := config.Services["pets"]
+
+	if service.Name != "Petstore" || service.BaseURL != "https://staging.pets.example.com" {
+		t.Errorf("service = %q at %q", service.Name, service.BaseURL)
+	}
+	if service.Auth.Type != AuthTypeBearer || service.Auth.Config["token"] != "secret" {
+		t.Errorf("auth = %+v", service.Auth)
+	}
+	if len(service.Endpoints) != 4 {
+		t.Errorf("expected 4 endpoints, got %d", len(service.Endpoints))
+	}
+	if list := findEndpoint(t, service, "list_pets"); list.Name != "Custom list" {
+		t.Errorf("generated endpoint replaced the configured one: %+v", list)
+	}
+	findEndpoint(t, service, "rename_pet")
+
+	// A missing document fails the load
+	missing := strings.Replace(configJSON, "petstore.yaml", "missing.yaml", 1)
+	if _, err := LoadConfigFromJSON([]byte(missing), configPath); err == nil {
+		t.Error("expected an error for a missing OpenAPI document")
+	}
+}
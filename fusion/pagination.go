@@ -0,0 +1,370 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// MaxPagesArgument is the optional tool argument that lets the caller override
+// the number of pages fetched for a paginated endpoint.
+const MaxPagesArgument = "max_pages"
+
+// paginationResult describes the outcome of following additional pages
+type paginationResult struct {
+	data      interface{} // First page with the merged items placed at dataPath
+	pages     int         // Number of pages fetched, including the first
+	items     int         // Number of merged items
+	morePages bool        // True when the budget ran out before the last page
+}
+
+// effectiveMaxPages resolves the page budget for a call from the max_pages
+// argument, the endpoint configuration and the global default, clamped to
+// global.MaxPaginationPages.
+func (h *HTTPHandler) effectiveMaxPages(args map[string]interface{}) int {
+	maxPages := h.endpoint.Response.PaginationConfig.GetMaxPages()
+
+	if raw, ok := args[MaxPagesArgument]; ok && h.endpoint.GetParameterByName(MaxPagesArgument) == nil {
+		switch v := raw.(type) {
+		case float64:
+			maxPages = int(v)
+		case int:
+			maxPages = v
+		case int64:
+			maxPages = int(v)
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				maxPages = n
+			}
+		}
+	}
+
+	if maxPages < 1 {
+		maxPages = 1
+	}
+	if maxPages > global.MaxPaginationPages {
+		maxPages = global.MaxPaginationPages
+	}
+	return maxPages
+}
+
+// fetchRemainingPages follows the pagination links of a JSON response until the
+// last page is reached or the page, item or byte budget is exhausted. The items
+// of every page are merged into the first page at dataPath so that transforms
+// written against a single page continue to work.
+//
+// Only GET requests are followed. Failures fetching later pages are logged and
+// the pages collected so far are returned, flagged as incomplete.
+func (h *HTTPHandler) fetchRemainingPages(firstResp *http.Response, firstPage interface{}, firstPageBytes int,
+	args map[string]interface{}, correlationID string) paginationResult {
+
+	pagination := h.endpoint.Response.PaginationConfig
+	result := paginationResult{data: firstPage, pages: 1}
+
+	firstItems, ok := lookupJSONPath(firstPage, pagination.DataPath).([]interface{})
+	if !ok {
+		if h.fusion.logger != nil {
+			h.fusion.logger.Debugf("Pagination: dataPath %q is not an array, returning first page only [%s]",
+				pagination.DataPath, correlationID)
+		}
+		return result
+	}
+	items := append([]interface{}{}, firstItems...)
+	result.items = len(items)
+
+	firstReq := firstResp.Request
+	if firstReq == nil || firstReq.Method != http.MethodGet {
+		return result
+	}
+
+	maxPages := h.effectiveMaxPages(args)
+	maxBytes := h.fusion.MaxResponseBytes()
+	totalBytes := firstPageBytes
+
+	prevReq, prevResp, page := firstReq, firstResp, firstPage
+	pageItems := len(firstItems)
+	for {
+		nextReq, hasNext := h.nextPageRequest(firstReq, prevReq, prevResp, page, pageItems, result.pages, correlationID)
+		if !hasNext {
+			break
+		}
+		if result.pages >= maxPages ||
+			(pagination.MaxItems > 0 && len(items) >= pagination.MaxItems) ||
+			(maxBytes > 0 && totalBytes >= maxBytes) {
+			result.morePages = true
+			break
+		}
+
+		if h.fusion.logger != nil {
+			h.fusion.logger.Debugf("Pagination: fetching page %d of at most %d: %s [%s]",
+				result.pages+1, maxPages, nextReq.URL.String(), correlationID)
+		}
+
+		nextPage, nextResp, bodyLen, err := h.fetchPage(nextReq, correlationID)
+		if err != nil {
+			if h.fusion.logger != nil {
+				h.fusion.logger.Warningf("Pagination: stopping after %d pages for %s.%s: %v [%s]",
+					result.pages, h.service.Name, h.endpoint.ID, err, correlationID)
+			}
+			result.morePages = true
+			break
+		}
+
+		nextItems, ok := lookupJSONPath(nextPage, pagination.DataPath).([]interface{})
+		if !ok {
+			break
+		}
+
+		result.pages++
+		totalBytes += bodyLen
+		items = append(items, nextItems...)
+		prevReq, prevResp, page, pageItems = nextReq, nextResp, nextPage, len(nextItems)
+
+		if len(nextItems) == 0 {
+			break
+		}
+	}
+
+	if pagination.MaxItems > 0 && len(items) > pagination.MaxItems {
+		items = items[:pagination.MaxItems]
+		result.morePages = true
+	}
+	result.items = len(items)
+
+	setJSONPath(firstPage, pagination.DataPath, items)
+	if style := pagination.GetStyle(); style == PaginationStyleToken || style == PaginationStyleCursor {
+		// Reflect the state of the last page fetched so transforms see an accurate token
+		if result.morePages {
+			setJSONPath(firstPage, pagination.NextPageTokenPath, lookupJSONPath(page, pagination.NextPageTokenPath))
+		} else {
+			deleteJSONPath(firstPage, pagination.NextPageTokenPath)
+		}
+	}
+	result.data = firstPage
+
+	if h.fusion.logger != nil && result.pages > 1 {
+		h.fusion.logger.Infof("Pagination: merged %d items from %d pages for %s.%s (more available: %t) [%s]",
+			result.items, result.pages, h.service.Name, h.endpoint.ID, result.morePages, correlationID)
+	}
+
+	return result
+}
+
+// fetchPage executes a follow-up page request and decodes its JSON body
+func (h *HTTPHandler) fetchPage(req *http.Request, correlationID string) (interface{}, *http.Response, int, error) {
+	resp, _, err := h.executeRequest(req.Context(), req, correlationID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, global.MaxResponseBodyReadBytes))
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read page body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, nil, 0, fmt.Errorf("page request returned status %d", resp.StatusCode)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to parse page JSON: %w", err)
+	}
+	return data, resp, len(body), nil
+}
+
+// nextPageRequest builds the request for the page following prevResp, or returns
+// false when there is no further page. pagesFetched is the number of pages
+// retrieved so far; pageItems is the number of items on the previous page.
+func (h *HTTPHandler) nextPageRequest(firstReq, prevReq *http.Request, prevResp *http.Response, page interface{},
+	pageItems, pagesFetched int, correlationID string) (*http.Request, bool) {
+
+	pagination := h.endpoint.Response.PaginationConfig
+
+	switch pagination.GetStyle() {
+	case PaginationStyleLink:
+		next := parseLinkNext(prevResp.Header.Values("Link"))
+		if next == "" {
+			return nil, false
+		}
+		return h.followURL(prevReq, next, correlationID)
+
+	case PaginationStyleOffset:
+		query := firstReq.URL.Query()
+		limit := pagination.PageSize
+		if limitParam := pagination.GetLimitParam(); limitParam != "" {
+			if n, err := strconv.Atoi(query.Get(limitParam)); err == nil && n > 0 {
+				limit = n
+			}
+		}
+		if pageItems < limit {
+			return nil, false
+		}
+		start, _ := strconv.Atoi(query.Get(pagination.GetOffsetParam()))
+		return withQueryParams(prevReq, map[string]string{
+			pagination.GetOffsetParam(): strconv.Itoa(start + pagesFetched*limit),
+			pagination.GetLimitParam():  strconv.Itoa(limit),
+		}), true
+
+	case PaginationStylePage:
+		if pageItems == 0 || pageItems < pagination.PageSize {
+			return nil, false
+		}
+		start := 1
+		if n, err := strconv.Atoi(firstReq.URL.Query().Get(pagination.GetPageParam())); err == nil {
+			start = n
+		}
+		params := map[string]string{pagination.GetPageParam(): strconv.Itoa(start + pagesFetched)}
+		if limitParam := pagination.GetLimitParam(); limitParam != "" && firstReq.URL.Query().Get(limitParam) == "" {
+			params[limitParam] = strconv.Itoa(pagination.PageSize)
+		}
+		return withQueryParams(prevReq, params), true
+
+	default: // token and cursor
+		token := lookupJSONPath(page, pagination.NextPageTokenPath)
+		if token == nil {
+			return nil, false
+		}
+		tokenStr := fmt.Sprintf("%v", token)
+		if tokenStr == "" {
+			return nil, false
+		}
+		// Some APIs (e.g. Microsoft Graph @odata.nextLink) return a complete URL
+		if strings.HasPrefix(tokenStr, "http://") || strings.HasPrefix(tokenStr, "https://") {
+			return h.followURL(prevReq, tokenStr, correlationID)
+		}
+		return withQueryParams(prevReq, map[string]string{pagination.GetTokenParam(): tokenStr}), true
+	}
+}
+
+// followURL builds a request for a next-page URL supplied by the upstream API.
+// Relative URLs are resolved against the previous request. URLs pointing at a
+// different scheme or host are refused so that credentials are never sent to
+// a server other than the one the endpoint is configured for.
+func (h *HTTPHandler) followURL(prevReq *http.Request, rawURL, correlationID string) (*http.Request, bool) {
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		if h.fusion.logger != nil {
+			h.fusion.logger.Warningf("Pagination: ignoring invalid next-page URL %q: %v [%s]", rawURL, err, correlationID)
+		}
+		return nil, false
+	}
+	next := prevReq.URL.ResolveReference(ref)
+	if next.Scheme != prevReq.URL.Scheme || next.Host != prevReq.URL.Host {
+		if h.fusion.logger != nil {
+			h.fusion.logger.Warningf("Pagination: refusing to follow next-page URL on a different host (%s) [%s]",
+				next.Host, correlationID)
+		}
+		return nil, false
+	}
+
+	req := prevReq.Clone(prevReq.Context())
+	req.URL = next
+	req.Host = next.Host
+	return req, true
+}
+
+// withQueryParams returns a copy of req with the given query parameters set.
+// Parameters with an empty name are ignored.
+func withQueryParams(req *http.Request, params map[string]string) *http.Request {
+	next := req.Clone(req.Context())
+	query := next.URL.Query()
+	for name, value := range params {
+		if name != "" {
+			query.Set(name, value)
+		}
+	}
+	next.URL.RawQuery = query.Encode()
+	return next
+}
+
+// parseLinkNext extracts the rel="next" target from RFC 5988 Link header values
+func parseLinkNext(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			segments := strings.Split(link, ";")
+			if len(segments) < 2 {
+				continue
+			}
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				name, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// lookupJSONPath returns the value at a dot-separated path in decoded JSON.
+// Keys that themselves contain dots (e.g. "@odata.nextLink") are matched
+// before the path is split. Returns nil when any segment is missing.
+func lookupJSONPath(data interface{}, path string) interface{} {
+	obj, key, ok := locateJSONPath(data, path)
+	if !ok {
+		return nil
+	}
+	return obj[key]
+}
+
+// setJSONPath sets the value at a dot-separated path if its parent object exists
+func setJSONPath(data interface{}, path string, value interface{}) {
+	if obj, key, ok := locateJSONPath(data, path); ok {
+		obj[key] = value
+		return
+	}
+	// Create the final key when only the leaf is missing
+	if obj, ok := data.(map[string]interface{}); ok && !strings.Contains(path, ".") {
+		obj[path] = value
+	}
+}
+
+// deleteJSONPath removes the value at a dot-separated path if present
+func deleteJSONPath(data interface{}, path string) {
+	if obj, key, ok := locateJSONPath(data, path); ok {
+		delete(obj, key)
+	}
+}
+
+// locateJSONPath finds the object holding the final key of path
+func locateJSONPath(data interface{}, path string) (map[string]interface{}, string, bool) {
+	obj, ok := data.(map[string]interface{})
+	if !ok || path == "" {
+		return nil, "", false
+	}
+	if _, exists := obj[path]; exists {
+		return obj, path, true
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		if child, exists := obj[path[:i]]; exists {
+			if parent, key, found := locateJSONPath(child, path[i+1:]); found {
+				return parent, key, true
+			}
+		}
+	}
+	return nil, "", false
+}
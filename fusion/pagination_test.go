@@ -0,0 +1,310 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tenebris-tech/mlogger"
+)
+
+// newPaginationTestFusion builds a Fusion instance with a single paginated GET
+// endpoint backed by serverURL.
+func newPaginationTestFusion(t *testing.T, serverURL string, pagination *PaginationConfig) *Fusion {
+	t.Helper()
+	config := &Config{
+		Services: map[string]*ServiceConfig{
+			"pagesvc": {
+				ServiceKey: "pagesvc",
+				Name:       "pagesvc",
+				BaseURL:    serverURL,
+				Auth:       AuthConfig{Type: AuthTypeNone},
+				Endpoints: []EndpointConfig{
+					{
+						ID:         "list",
+						Name:       "List",
+						Method:     "GET",
+						Path:       "/items",
+						Parameters: []ParameterConfig{},
+						Response: ResponseConfig{
+							Type:             ResponseTypeJSON,
+							Paginated:        true,
+							PaginationConfig: pagination,
+						},
+					},
+				},
+			},
+		},
+	}
+	return New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+}
+
+// pageItems returns n items numbered from start.
+func pageItems(start, n int) []interface{} {
+	items := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, map[string]interface{}{"id": start + i})
+	}
+	return items
+}
+
+// decodeItems decodes a tool result holding a JSON array of items.
+func decodeItems(t *testing.T, result string) []interface{} {
+	t.Helper()
+	var items []interface{}
+	if err := json.Unmarshal([]byte(result), &items); err != nil {
+		t.Fatalf("expected JSON array result, got %q: %v", result, err)
+	}
+	return items
+}
+
+func TestPagination_TokenStyle(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page := map[string]interface{}{}
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			page["items"] = pageItems(0, 2)
+			page["next"] = "t2"
+		case "t2":
+			page["items"] = pageItems(2, 2)
+			page["next"] = "t3"
+		case "t3":
+			page["items"] = pageItems(4, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{NextPageTokenPath: "next", DataPath: "items", PageSize: 2})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 5 {
+		t.Errorf("expected 5 merged items, got %d", len(items))
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 page requests, got %d", got)
+	}
+}
+
+func TestPagination_NextLinkURL(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := map[string]interface{}{"value": pageItems(0, 1)}
+		if r.URL.Query().Get("$skiptoken") == "" {
+			page["@odata.nextLink"] = server.URL + "/items?$skiptoken=abc"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{NextPageTokenPath: "@odata.nextLink", DataPath: "value", PageSize: 1})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 2 {
+		t.Errorf("expected 2 merged items, got %d", len(items))
+	}
+}
+
+func TestPagination_RefusesOtherHost(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"value":           pageItems(0, 1),
+			"@odata.nextLink": "https://attacker.example/items?page=2",
+		})
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{NextPageTokenPath: "@odata.nextLink", DataPath: "value", PageSize: 1})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	if _, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected only the first page to be fetched, got %d requests", got)
+	}
+}
+
+func TestPagination_OffsetStyle(t *testing.T) {
+	const total = 7
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 3
+		}
+		n := min(limit, max(total-offset, 0))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": pageItems(offset, n)})
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{Style: PaginationStyleOffset, DataPath: "results", PageSize: 3})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := decodeItems(t, result)
+	if len(items) != total {
+		t.Fatalf("expected %d merged items, got %d", total, len(items))
+	}
+	if last := items[total-1].(map[string]interface{})["id"]; last != float64(total-1) {
+		t.Errorf("expected last item id %d, got %v", total-1, last)
+	}
+}
+
+func TestPagination_PageStyleAndMaxPagesArgument(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": pageItems((page-1)*2, 2)})
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{Style: PaginationStylePage, DataPath: "data", PageSize: 2, MaxPages: 3})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 6 {
+		t.Errorf("expected 6 items from the configured 3 pages, got %d", len(items))
+	}
+
+	requests.Store(0)
+	result, err = tool.Handler(withTenant("tenant-a", map[string]interface{}{MaxPagesArgument: float64(5)}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 10 {
+		t.Errorf("expected 10 items with max_pages=5, got %d", len(items))
+	}
+	if got := requests.Load(); got != 5 {
+		t.Errorf("expected 5 page requests, got %d", got)
+	}
+}
+
+func TestPagination_LinkHeaderAndMaxItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`</items?p=%d>; rel="next", </items?p=0>; rel="first"`, page+1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"entries": pageItems(page*3, 3)})
+	}))
+	defer server.Close()
+
+	f := newPaginationTestFusion(t, server.URL, &PaginationConfig{Style: PaginationStyleLink, DataPath: "entries", PageSize: 3})
+	tool := findTool(t, f.RegisterTools(), "pagesvc_list")
+
+	result, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 12 {
+		t.Errorf("expected 12 items from 4 linked pages, got %d", len(items))
+	}
+
+	f = newPaginationTestFusion(t, server.URL, &PaginationConfig{Style: PaginationStyleLink, DataPath: "entries", PageSize: 3, MaxItems: 5})
+	tool = findTool(t, f.RegisterTools(), "pagesvc_list")
+	result, err = tool.Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := decodeItems(t, result); len(items) != 5 {
+		t.Errorf("expected items to be capped at 5, got %d", len(items))
+	}
+}
+
+func TestParseLinkNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"github style", []string{`<https://api.example.com/x?page=2>; rel="next", <https://api.example.com/x?page=9>; rel="last"`}, "https://api.example.com/x?page=2"},
+		{"multiple rels", []string{`</x?page=3>; rel="prefetch next"`}, "/x?page=3"},
+		{"separate headers", []string{`</x?page=1>; rel="prev"`, `</x?page=3>; rel=next`}, "/x?page=3"},
+		{"no next", []string{`</x?page=1>; rel="prev"`}, ""},
+		{"malformed", []string{`garbage`}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLinkNext(tt.values); got != tt.want {
+				t.Errorf("parseLinkNext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupJSONPath(t *testing.T) {
+	data := map[string]interface{}{
+		"@odata.nextLink": "direct",
+		"meta":            map[string]interface{}{"paging": map[string]interface{}{"next": "nested"}},
+	}
+	if got := lookupJSONPath(data, "@odata.nextLink"); got != "direct" {
+		t.Errorf("expected dotted key lookup, got %v", got)
+	}
+	if got := lookupJSONPath(data, "meta.paging.next"); got != "nested" {
+		t.Errorf("expected nested lookup, got %v", got)
+	}
+	if got := lookupJSONPath(data, "meta.missing"); got != nil {
+		t.Errorf("expected nil for missing path, got %v", got)
+	}
+}
+
+func TestPaginationConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  PaginationConfig
+		wantErr bool
+	}{
+		{"token requires path", PaginationConfig{DataPath: "items", PageSize: 10}, true},
+		{"offset without path", PaginationConfig{Style: PaginationStyleOffset, DataPath: "items", PageSize: 10}, false},
+		{"link without path", PaginationConfig{Style: PaginationStyleLink, DataPath: "items", PageSize: 10}, false},
+		{"unknown style", PaginationConfig{Style: "bogus", DataPath: "items", PageSize: 10}, true},
+		{"max pages too large", PaginationConfig{Style: PaginationStylePage, DataPath: "items", PageSize: 10, MaxPages: 1000}, true},
+		{"negative max items", PaginationConfig{Style: PaginationStylePage, DataPath: "items", PageSize: 10, MaxItems: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.ValidateWithLogger("svc", "ep", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// PolicyEffect is the outcome of a policy rule
+type PolicyEffect string
+
+const (
+	PolicyEffectAllow PolicyEffect = "allow"
+	PolicyEffectDeny  PolicyEffect = "deny"
+)
+
+// ErrToolDenied is returned by PolicyAuthorizer when a policy denies a tool
+var ErrToolDenied = errors.New("tool denied by policy")
+
+// PolicyConfig represents a declarative tool access policy.
+//
+// Rules are evaluated in order and the first matching rule decides. When no
+// rule matches, DefaultEffect applies (allow when unset).
+type PolicyConfig struct {
+	DefaultEffect PolicyEffect `json:"defaultEffect,omitempty"`
+	Rules         []PolicyRule `json:"rules"`
+}
+
+// PolicyRule matches tool requests and allows or denies them.
+//
+// Every non-empty condition must match for the rule to apply. List conditions
+// match when any entry matches, and entries are glob patterns as understood by
+// path.Match (e.g. "microsoft365_*", "3fa9*"). ReadOnly and Destructive match
+// against the hints the tool declares (its hints configuration, or the
+// annotations of a proxied hub tool). A tool that does not declare the hint
+// never matches, even though MCP clients assume readOnly false and destructive
+// true for it.
+type PolicyRule struct {
+	Description string       `json:"description,omitempty"`
+	Effect      PolicyEffect `json:"effect"`
+	Tenants     []string     `json:"tenants,omitempty"`  // Tenant (API token) hashes
+	Users       []string     `json:"users,omitempty"`    // User IDs
+	Services    []string     `json:"services,omitempty"` // Service names (tool prefix)
+	Tools       []string     `json:"tools,omitempty"`    // Full MCP tool names
+	ReadOnly    *bool        `json:"readOnly,omitempty"`
+	Destructive *bool        `json:"destructive,omitempty"`
+}
+
+// GetDefaultEffect returns the effect applied when no rule matches
+func (p *PolicyConfig) GetDefaultEffect() PolicyEffect {
+	if p.DefaultEffect == "" {
+		return PolicyEffectAllow
+	}
+	return p.DefaultEffect
+}
+
+// ValidateWithLogger validates the policy configuration with logging support
+func (p *PolicyConfig) ValidateWithLogger(logger global.Logger) error {
+	if p.DefaultEffect != "" && !p.DefaultEffect.isValid() {
+		if logger != nil {
+			logger.Errorf("Policy: invalid defaultEffect: %s", p.DefaultEffect)
+		}
+		return fmt.Errorf("invalid defaultEffect: %s", p.DefaultEffect)
+	}
+
+	for i := range p.Rules {
+		if err := p.Rules[i].validate(); err != nil {
+			if logger != nil {
+				logger.Errorf("Policy: rule %d is invalid: %v", i, err)
+			}
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+
+	if logger != nil {
+		logger.Debugf("Policy configuration validated successfully (%d rules, default: %s)",
+			len(p.Rules), p.GetDefaultEffect())
+	}
+	return nil
+}
+
+// isValid reports whether the effect is a known value
+func (e PolicyEffect) isValid() bool {
+	return e == PolicyEffectAllow || e == PolicyEffectDeny
+}
+
+// validate checks the effect and that every pattern is well-formed
+func (r *PolicyRule) validate() error {
+	if !r.Effect.isValid() {
+		return fmt.Errorf("effect must be %q or %q, got %q", PolicyEffectAllow, PolicyEffectDeny, r.Effect)
+	}
+	for field, patterns := range map[string][]string{
+		"tenants": r.Tenants, "users": r.Users, "services": r.Services, "tools": r.Tools,
+	} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid %s pattern %q: %w", field, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to req
+func (r *PolicyRule) matches(req global.ToolRequest) bool {
+	if !matchesAny(r.Tenants, req.TenantHash) ||
+		!matchesAny(r.Users, req.UserID) ||
+		!matchesAny(r.Services, req.ServiceName) ||
+		!matchesAny(r.Tools, req.ToolName) {
+		return false
+	}
+	if r.ReadOnly != nil && (req.Hints == nil || req.Hints.ReadOnly == nil || *req.Hints.ReadOnly != *r.ReadOnly) {
+		return false
+	}
+	if r.Destructive != nil && (req.Hints == nil || req.Hints.Destructive == nil || *req.Hints.Destructive != *r.Destructive) {
+		return false
+	}
+	return true
+}
+
+// matchesAny reports whether value matches one of patterns. An empty pattern
+// list matches everything.
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyAuthorizer is a global.Authorizer that enforces a PolicyConfig
+type PolicyAuthorizer struct {
+	policy *PolicyConfig
+	logger global.Logger
+	mu     sync.RWMutex // Protects policy during hot reload
+}
+
+// NewPolicyAuthorizer creates an authorizer for a validated policy
+func NewPolicyAuthorizer(policy *PolicyConfig, logger global.Logger) *PolicyAuthorizer {
+	if policy == nil {
+		policy = &PolicyConfig{}
+	}
+	return &PolicyAuthorizer{policy: policy, logger: logger}
+}
+
+// SetPolicy replaces the enforced policy. A nil policy allows every tool.
+func (a *PolicyAuthorizer) SetPolicy(policy *PolicyConfig) {
+	if policy == nil {
+		policy = &PolicyConfig{}
+	}
+	a.mu.Lock()
+	a.policy = policy
+	a.mu.Unlock()
+}
+
+// Authorize returns nil when the policy allows req, or an error wrapping ErrToolDenied
+func (a *PolicyAuthorizer) Authorize(_ context.Context, req global.ToolRequest) error {
+	a.mu.RLock()
+	policy := a.policy
+	a.mu.RUnlock()
+
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if !rule.matches(req) {
+			continue
+		}
+		if a.logger != nil {
+			a.logger.Debugf("Policy: rule %d (%s) matched tool %s: %s", i, rule.Description, req.ToolName, rule.Effect)
+		}
+		if rule.Effect == PolicyEffectDeny {
+			if rule.Description != "" {
+				return fmt.Errorf("%w: %s", ErrToolDenied, rule.Description)
+			}
+			return ErrToolDenied
+		}
+		return nil
+	}
+
+	if policy.GetDefaultEffect() == PolicyEffectDeny {
+		return ErrToolDenied
+	}
+	return nil
+}
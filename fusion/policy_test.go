@@ -0,0 +1,102 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+func TestPolicyAuthorizer_FirstMatchWins(t *testing.T) {
+	policy := &PolicyConfig{
+		Rules: []PolicyRule{
+			{Effect: PolicyEffectAllow, Tenants: []string{"ops*"}, Tools: []string{"m365_mail_delete*"}},
+			{Effect: PolicyEffectDeny, Services: []string{"m365"}, Destructive: global.BoolPtr(true), Description: "no destructive m365"},
+			{Effect: PolicyEffectDeny, Users: []string{"intern"}, ReadOnly: global.BoolPtr(false)},
+		},
+	}
+	if err := policy.ValidateWithLogger(nil); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	authorizer := NewPolicyAuthorizer(policy, nil)
+
+	destructive := &global.ToolHints{ReadOnly: global.BoolPtr(false), Destructive: global.BoolPtr(true)}
+	readOnly := &global.ToolHints{ReadOnly: global.BoolPtr(true), Destructive: global.BoolPtr(false)}
+	write := &global.ToolHints{ReadOnly: global.BoolPtr(false), Destructive: global.BoolPtr(false)}
+
+	tests := []struct {
+		name    string
+		req     global.ToolRequest
+		allowed bool
+	}{
+		{"ops may delete", global.ToolRequest{TenantHash: "ops123", ServiceName: "m365", ToolName: "m365_mail_delete", Hints: destructive}, true},
+		{"others may not delete", global.ToolRequest{TenantHash: "abc", ServiceName: "m365", ToolName: "m365_mail_delete", Hints: destructive}, false},
+		{"reads allowed by default", global.ToolRequest{TenantHash: "abc", ServiceName: "m365", ToolName: "m365_mail_list", Hints: readOnly}, true},
+		{"intern may read", global.ToolRequest{TenantHash: "abc", UserID: "intern", ServiceName: "m365", ToolName: "m365_mail_list", Hints: readOnly}, true},
+		{"intern may not write", global.ToolRequest{TenantHash: "abc", UserID: "intern", ServiceName: "m365", ToolName: "m365_mail_send", Hints: write}, false},
+		{"unknown hints never match hint rules", global.ToolRequest{TenantHash: "abc", UserID: "intern", ServiceName: "m365", ToolName: "m365_mail_send"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizer.Authorize(context.Background(), tt.req)
+			if tt.allowed && err != nil {
+				t.Errorf("expected allow, got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrToolDenied) {
+				t.Errorf("expected ErrToolDenied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicyAuthorizer_DefaultDeny(t *testing.T) {
+	authorizer := NewPolicyAuthorizer(&PolicyConfig{
+		DefaultEffect: PolicyEffectDeny,
+		Rules:         []PolicyRule{{Effect: PolicyEffectAllow, Services: []string{"knowledge", "health"}}},
+	}, nil)
+
+	if err := authorizer.Authorize(context.Background(), global.ToolRequest{ServiceName: "knowledge", ToolName: "knowledge_get"}); err != nil {
+		t.Errorf("expected knowledge to be allowed, got %v", err)
+	}
+	if err := authorizer.Authorize(context.Background(), global.ToolRequest{ServiceName: "command", ToolName: "command_nmap"}); !errors.Is(err, ErrToolDenied) {
+		t.Errorf("expected command to be denied, got %v", err)
+	}
+}
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PolicyConfig
+	}{
+		{"bad default", PolicyConfig{DefaultEffect: "maybe"}},
+		{"missing effect", PolicyConfig{Rules: []PolicyRule{{Tools: []string{"x"}}}}},
+		{"bad pattern", PolicyConfig{Rules: []PolicyRule{{Effect: PolicyEffectDeny, Tools: []string{"[oops"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.ValidateWithLogger(nil); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_PolicyOnly(t *testing.T) {
+	config, err := LoadConfigFromJSON([]byte(`{"policy": {"defaultEffect": "deny", "rules": [{"effect": "allow", "tools": ["health_*"]}]}}`), "policy.json")
+	if err != nil {
+		t.Fatalf("expected policy-only config to load, got %v", err)
+	}
+	if config.Policy == nil || len(config.Policy.Rules) != 1 || config.Policy.GetDefaultEffect() != PolicyEffectDeny {
+		t.Errorf("unexpected policy: %+v", config.Policy)
+	}
+
+	if _, err := LoadConfigFromJSON([]byte(`{"policy": {"rules": [{"effect": "permit"}]}}`), "bad.json"); err == nil {
+		t.Error("expected invalid policy to be rejected")
+	}
+}
@@ -0,0 +1,189 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	quotaPacingFraction = 0.1            // Start pacing when less than this share of the quota remains
+	quotaPacingMinimum  = 10             // Start pacing below this many requests when the limit is unknown
+	quotaMaxWait        = time.Minute    // Longest a request is held back before being sent anyway
+	serverMaxWait       = 24 * time.Hour // Longest wait accepted from Retry-After or a reset header
+	epochThreshold      = 1000000000     // Reset values above this are Unix timestamps, not seconds
+)
+
+// rateLimitHeaders lists the header name prefixes that report an upstream quota:
+// the common X-RateLimit-* convention and the IETF RateLimit-* fields
+var rateLimitHeaders = []string{"X-RateLimit-", "RateLimit-"}
+
+// parseRetryAfter returns the wait requested by a rate limited or unavailable
+// response. Retry-After may be a number of seconds or an HTTP date; when it is
+// absent, an exhausted quota's reset time is used instead.
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	if value := strings.TrimSpace(resp.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			if wait, ok := secondsDuration(seconds); ok {
+				return wait, true
+			}
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(0, date.Sub(now)), true
+		}
+	}
+
+	// A reset time only says when to retry if the quota is what ran out
+	remaining, known := parseRateLimitCount(resp.Header, "Remaining")
+	if resp.StatusCode == http.StatusTooManyRequests || (known && remaining == 0) {
+		if reset, ok := parseRateLimitReset(resp.Header, now); ok {
+			return max(0, reset.Sub(now)), true
+		}
+	}
+	return 0, false
+}
+
+// rateLimitHeader returns the first non-empty quota header with the given suffix
+func rateLimitHeader(header http.Header, suffix string) string {
+	for _, prefix := range rateLimitHeaders {
+		if value := strings.TrimSpace(header.Get(prefix + suffix)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseRateLimitReset returns when the quota resets. The value is either a
+// Unix timestamp (GitHub style) or a number of seconds from now.
+func parseRateLimitReset(header http.Header, now time.Time) (time.Time, bool) {
+	value := rateLimitHeader(header, "Reset")
+	if value == "" {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if seconds > epochThreshold {
+		// A reset time in the past means the quota has already been renewed
+		seconds = max(0, seconds-float64(now.UnixNano())/float64(time.Second))
+	}
+	wait, ok := secondsDuration(seconds)
+	if !ok {
+		return time.Time{}, false
+	}
+	return now.Add(wait), true
+}
+
+// secondsDuration converts a number of seconds sent by a server into a wait,
+// rejecting negative and non-finite values and capping it at serverMaxWait so
+// that huge values cannot overflow into a negative duration
+func secondsDuration(seconds float64) (time.Duration, bool) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) || seconds < 0 {
+		return 0, false
+	}
+	if seconds >= serverMaxWait.Seconds() {
+		return serverMaxWait, true
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// parseRateLimitCount parses a Limit or Remaining quota header. IETF fields
+// may carry parameters after the number (e.g. "100;w=60").
+func parseRateLimitCount(header http.Header, suffix string) (int, bool) {
+	value := rateLimitHeader(header, suffix)
+	if i := strings.IndexAny(value, ";,"); i >= 0 {
+		value = value[:i]
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || count < 0 {
+		return 0, false
+	}
+	return count, true
+}
+
+// serviceQuota remembers the quota a service reported in its latest response
+// and paces requests so they are spread over the time left until the quota
+// resets, rather than running into 429 responses.
+type serviceQuota struct {
+	mu           sync.Mutex
+	limit        int       // Requests allowed per window; 0 when not reported
+	remaining    int       // Requests left in the current window
+	reset        time.Time // When the window ends; zero when unknown
+	blockedUntil time.Time // Set by Retry-After; nothing is sent before then
+	next         time.Time // Earliest time the next paced request may be sent
+}
+
+// update records the quota reported by resp
+func (q *serviceQuota) update(resp *http.Response, now time.Time) {
+	if resp == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if wait, ok := parseRetryAfter(resp, now); ok {
+		q.blockedUntil = now.Add(wait)
+	}
+
+	remaining, ok := parseRateLimitCount(resp.Header, "Remaining")
+	if !ok {
+		return
+	}
+	q.remaining = remaining
+	q.limit, _ = parseRateLimitCount(resp.Header, "Limit")
+	q.reset, _ = parseRateLimitReset(resp.Header, now)
+}
+
+// reserve returns how long the caller should wait before sending its request,
+// and counts the request against the remaining quota
+func (q *serviceQuota) reserve(now time.Time) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	start := now
+	if q.blockedUntil.After(start) {
+		start = q.blockedUntil
+	}
+
+	if q.reset.After(now) {
+		low := q.remaining < quotaPacingMinimum
+		if q.limit > 0 {
+			low = float64(q.remaining) < float64(q.limit)*quotaPacingFraction
+		}
+		switch {
+		case q.remaining <= 0:
+			// Exhausted: nothing succeeds before the window resets
+			start = maxTime(start, q.reset)
+		case low:
+			// Spread what is left evenly over the rest of the window
+			interval := q.reset.Sub(now) / time.Duration(q.remaining+1)
+			start = maxTime(start, q.next)
+			q.next = start.Add(interval)
+		}
+		q.remaining--
+	}
+
+	return min(start.Sub(now), quotaMaxWait)
+}
+
+// maxTime returns the later of a and b
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,183 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// quotaResponse builds a response with the given status and headers
+func quotaResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		resp   *http.Response
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", quotaResponse(429, map[string]string{"Retry-After": "7"}), 7 * time.Second, true},
+		{"http date", quotaResponse(503, map[string]string{"Retry-After": now.Add(90 * time.Second).Format(http.TimeFormat)}), 90 * time.Second, true},
+		{"date in the past", quotaResponse(503, map[string]string{"Retry-After": now.Add(-time.Hour).Format(http.TimeFormat)}), 0, true},
+		{"reset delta", quotaResponse(429, map[string]string{"X-RateLimit-Reset": "12"}), 12 * time.Second, true},
+		{"reset epoch", quotaResponse(429, map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)}), 30 * time.Second, true},
+		{"ietf reset", quotaResponse(429, map[string]string{"RateLimit-Reset": "5"}), 5 * time.Second, true},
+		{"503 with quota left", quotaResponse(503, map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": "12"}), 0, false},
+		{"503 with quota exhausted", quotaResponse(503, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "12"}), 12 * time.Second, true},
+		{"ignored on 500", quotaResponse(500, map[string]string{"Retry-After": "7"}), 0, false},
+		{"invalid", quotaResponse(429, map[string]string{"Retry-After": "soon"}), 0, false},
+		{"infinite", quotaResponse(429, map[string]string{"Retry-After": "Inf"}), 0, false},
+		{"not a number", quotaResponse(429, map[string]string{"Retry-After": "NaN"}), 0, false},
+		{"negative", quotaResponse(429, map[string]string{"Retry-After": "-5"}), 0, false},
+		{"huge seconds", quotaResponse(429, map[string]string{"Retry-After": "1e30"}), serverMaxWait, true},
+		{"overflowing seconds", quotaResponse(503, map[string]string{"Retry-After": "9223372037"}), serverMaxWait, true},
+		{"huge reset", quotaResponse(429, map[string]string{"X-RateLimit-Reset": "1e30"}), serverMaxWait, true},
+		{"infinite reset", quotaResponse(429, map[string]string{"X-RateLimit-Reset": "Inf"}), 0, false},
+		{"no response", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.resp, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestServiceQuota_Pacing(t *testing.T) {
+	now := time.Now()
+	quota := &serviceQuota{}
+
+	// Plenty of quota left: no delay
+	quota.update(quotaResponse(200, map[string]string{
+		"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "50", "X-RateLimit-Reset": "60",
+	}), now)
+	if wait := quota.reserve(now); wait != 0 {
+		t.Errorf("expected no delay with quota left, got %v", wait)
+	}
+
+	// Running low: the remaining requests are spread over the window
+	quota.update(quotaResponse(200, map[string]string{
+		"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "3", "X-RateLimit-Reset": "60",
+	}), now)
+	first := quota.reserve(now)
+	second := quota.reserve(now)
+	if first != 0 || second != 15*time.Second {
+		t.Errorf("expected paced delays 0s and 15s, got %v and %v", first, second)
+	}
+
+	// Exhausted: wait for the reset, capped at quotaMaxWait
+	quota.update(quotaResponse(200, map[string]string{
+		"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "20",
+	}), now)
+	if wait := quota.reserve(now); wait != 20*time.Second {
+		t.Errorf("expected to wait for the reset, got %v", wait)
+	}
+	quota.update(quotaResponse(200, map[string]string{
+		"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "3600",
+	}), now)
+	if wait := quota.reserve(now); wait != quotaMaxWait {
+		t.Errorf("expected the wait to be capped at %v, got %v", quotaMaxWait, wait)
+	}
+
+	// Retry-After holds back every request to the service
+	quota = &serviceQuota{}
+	quota.update(quotaResponse(429, map[string]string{"Retry-After": "4"}), now)
+	if wait := quota.reserve(now.Add(time.Second)); wait != 3*time.Second {
+		t.Errorf("expected to wait out Retry-After, got %v", wait)
+	}
+}
+
+func TestRetryExecutor_HonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		times = append(times, time.Now())
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	executor := NewRetryExecutor(&RetryConfig{
+		Enabled:     true,
+		MaxAttempts: 2,
+		Strategy:    RetryStrategyFixed,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    500 * time.Millisecond,
+	}, nil)
+	executor.quota = &serviceQuota{}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	resp, err := executor.Execute(context.Background(), server.Client(), req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	drainAndClose(resp)
+
+	// Retry-After (1s) wins over the 10ms base delay but is capped by maxDelay
+	if gap := times[1].Sub(times[0]); gap < 450*time.Millisecond || gap > 900*time.Millisecond {
+		t.Errorf("expected a retry delay of about 500ms, got %v", gap)
+	}
+	if executor.quota.remaining != 42 {
+		t.Errorf("expected the remaining quota to be recorded, got %d", executor.quota.remaining)
+	}
+}
+
+func TestServiceQuota_PerTenant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The quota of tenant-a's credentials is exhausted for the next minute
+		if r.Header.Get("X-Tenant") == "tenant-a" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "60")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	f := newBodyTestFusion(t, server.URL, EndpointConfig{
+		ID: "get", Name: "Get", Method: http.MethodGet, Path: "/items",
+		Parameters: []ParameterConfig{{Name: "X-Tenant", Type: ParameterTypeString, Location: ParameterLocationHeader}},
+		Response:   ResponseConfig{Type: ResponseTypeJSON},
+	})
+	tool := findTool(t, f.RegisterTools(), "svc_get")
+
+	if _, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{"X-Tenant": "tenant-a"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wait := f.getServiceQuota("tenant-a", "svc").reserve(time.Now()); wait < 50*time.Second {
+		t.Errorf("expected tenant-a to wait for the reset, got %v", wait)
+	}
+
+	start := time.Now()
+	if _, err := tool.Handler(withTenant("tenant-b", map[string]interface{}{"X-Tenant": "tenant-b"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected tenant-b not to be paced by tenant-a's quota, took %v", elapsed)
+	}
+}
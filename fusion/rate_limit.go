@@ -0,0 +1,307 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/metrics"
+)
+
+// Dimensions a rate limit rule can keep separate limits for
+const (
+	RateLimitPerTenant  = "tenant"
+	RateLimitPerService = "service"
+	RateLimitPerTool    = "tool"
+)
+
+const (
+	rateLimitPruneInterval    = time.Minute // How often idle limit state is discarded
+	rateLimitConcurrencyRetry = time.Second // Retry hint when a concurrency limit is hit
+)
+
+// RateLimitConfig limits how fast and how many tool requests may run.
+//
+// Every rule that matches a request applies, and the request is admitted only
+// if none of them is exhausted.
+type RateLimitConfig struct {
+	Limits []RateLimitRule `json:"limits"`
+}
+
+// RateLimitRule is a token bucket and/or a cap on requests in flight.
+//
+// Tenants, Services and Tools select the requests the rule applies to, using
+// the same glob patterns as policy rules; empty lists match everything. Per
+// lists the dimensions ("tenant", "service", "tool") that get their own limit:
+// with Per ["tenant"] each tenant has its own bucket, while an empty Per shares
+// one bucket between every matching request.
+//
+// RequestsPerMinute is the sustained rate and Burst the number of requests
+// that may be made at once after a quiet period (default: one second's worth,
+// at least 1). MaxConcurrent caps the requests running at the same time.
+type RateLimitRule struct {
+	Description       string   `json:"description,omitempty"`
+	Tenants           []string `json:"tenants,omitempty"`  // Tenant (API token) hashes
+	Services          []string `json:"services,omitempty"` // Service names (tool prefix)
+	Tools             []string `json:"tools,omitempty"`    // Full MCP tool names
+	Per               []string `json:"per,omitempty"`
+	RequestsPerMinute float64  `json:"requestsPerMinute,omitempty"`
+	Burst             int      `json:"burst,omitempty"`
+	MaxConcurrent     int      `json:"maxConcurrent,omitempty"`
+}
+
+// ValidateWithLogger validates the rate limit configuration with logging support
+func (c *RateLimitConfig) ValidateWithLogger(logger global.Logger) error {
+	for i := range c.Limits {
+		if err := c.Limits[i].validate(); err != nil {
+			if logger != nil {
+				logger.Errorf("Rate limits: limit %d is invalid: %v", i, err)
+			}
+			return fmt.Errorf("limit %d: %w", i, err)
+		}
+	}
+
+	if logger != nil {
+		logger.Debugf("Rate limit configuration validated successfully (%d limits)", len(c.Limits))
+	}
+	return nil
+}
+
+// validate checks the limit values, dimensions and patterns
+func (r *RateLimitRule) validate() error {
+	if r.RequestsPerMinute < 0 || r.Burst < 0 || r.MaxConcurrent < 0 {
+		return fmt.Errorf("requestsPerMinute, burst and maxConcurrent must not be negative")
+	}
+	if r.RequestsPerMinute == 0 && r.MaxConcurrent == 0 {
+		return fmt.Errorf("requestsPerMinute or maxConcurrent must be set")
+	}
+	if r.Burst > 0 && r.RequestsPerMinute == 0 {
+		return fmt.Errorf("burst requires requestsPerMinute")
+	}
+	for _, dimension := range r.Per {
+		switch dimension {
+		case RateLimitPerTenant, RateLimitPerService, RateLimitPerTool:
+		default:
+			return fmt.Errorf("per must contain %q, %q or %q, got %q",
+				RateLimitPerTenant, RateLimitPerService, RateLimitPerTool, dimension)
+		}
+	}
+	for field, patterns := range map[string][]string{
+		"tenants": r.Tenants, "services": r.Services, "tools": r.Tools,
+	} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid %s pattern %q: %w", field, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetBurst returns the bucket size, defaulting to one second's worth of requests
+func (r *RateLimitRule) GetBurst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return max(1, int(math.Ceil(r.RequestsPerMinute/60)))
+}
+
+// matches reports whether the rule applies to req
+func (r *RateLimitRule) matches(req global.ToolRequest) bool {
+	return matchesAny(r.Tenants, req.TenantHash) &&
+		matchesAny(r.Services, req.ServiceName) &&
+		matchesAny(r.Tools, req.ToolName)
+}
+
+// key identifies the limit req counts against for the rule at index
+func (r *RateLimitRule) key(index int, req global.ToolRequest) string {
+	parts := []string{fmt.Sprint(index)}
+	for _, dimension := range r.Per {
+		switch dimension {
+		case RateLimitPerTenant:
+			parts = append(parts, req.TenantHash)
+		case RateLimitPerService:
+			parts = append(parts, req.ServiceName)
+		case RateLimitPerTool:
+			parts = append(parts, req.ToolName)
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// name describes the rule at index for error messages
+func (r *RateLimitRule) name(index int) string {
+	if r.Description != "" {
+		return r.Description
+	}
+	return fmt.Sprintf("limit %d", index)
+}
+
+// limitState is the bucket and in-flight count of one limit
+type limitState struct {
+	rate     float64 // Tokens added per second; 0 when only concurrency is limited
+	burst    float64
+	tokens   float64
+	updated  time.Time
+	inFlight int
+}
+
+// refill adds the tokens earned since the last update
+func (s *limitState) refill(now time.Time) {
+	if s.rate > 0 {
+		s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.updated).Seconds()*s.rate)
+	}
+	s.updated = now
+}
+
+// idle reports whether the state carries no information and can be discarded
+func (s *limitState) idle() bool {
+	return s.inFlight == 0 && s.tokens >= s.burst
+}
+
+// RateLimiter is a global.RateLimiter that enforces a RateLimitConfig.
+// Throttled requests are counted in the shared metrics collector.
+type RateLimiter struct {
+	collector *metrics.Collector
+	logger    global.Logger
+	now       func() time.Time
+	mu        sync.Mutex
+	config    *RateLimitConfig
+	limits    map[string]*limitState
+	pruned    time.Time
+}
+
+// NewRateLimiter creates a rate limiter for a validated configuration. A nil
+// configuration admits every request.
+func NewRateLimiter(config *RateLimitConfig, collector *metrics.Collector, logger global.Logger) *RateLimiter {
+	l := &RateLimiter{
+		collector: collector,
+		logger:    logger,
+		now:       time.Now,
+	}
+	l.SetConfig(config)
+	return l
+}
+
+// SetConfig replaces the enforced limits. Unchanged limits keep their state;
+// otherwise every limit starts afresh and requests already in flight no longer
+// count against it.
+func (l *RateLimiter) SetConfig(config *RateLimitConfig) {
+	if config == nil {
+		config = &RateLimitConfig{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.config != nil && reflect.DeepEqual(l.config, config) {
+		return
+	}
+	l.config = config
+	l.limits = make(map[string]*limitState)
+}
+
+// Acquire admits req if every matching limit has capacity, or returns a
+// *global.ThrottleError naming the first exhausted limit
+func (l *RateLimiter) Acquire(_ context.Context, req global.ToolRequest) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	var matched, held []*limitState // held also need releasing
+	for i := range l.config.Limits {
+		rule := &l.config.Limits[i]
+		if !rule.matches(req) {
+			continue
+		}
+
+		key := rule.key(i, req)
+		state := l.limits[key]
+		if state == nil {
+			state = &limitState{rate: rule.RequestsPerMinute / 60, updated: now}
+			if rule.RequestsPerMinute > 0 {
+				state.burst = float64(rule.GetBurst())
+				state.tokens = state.burst
+			}
+			l.limits[key] = state
+		}
+		state.refill(now)
+
+		if rule.MaxConcurrent > 0 && state.inFlight >= rule.MaxConcurrent {
+			return nil, l.throttled(req, &global.ThrottleError{
+				Limit:      rule.name(i),
+				Kind:       global.ThrottleConcurrency,
+				RetryAfter: rateLimitConcurrencyRetry,
+			})
+		}
+		if state.rate > 0 && state.tokens < 1 {
+			return nil, l.throttled(req, &global.ThrottleError{
+				Limit:      rule.name(i),
+				Kind:       global.ThrottleRate,
+				RetryAfter: time.Duration((1 - state.tokens) / state.rate * float64(time.Second)),
+			})
+		}
+		matched = append(matched, state)
+		if rule.MaxConcurrent > 0 {
+			held = append(held, state)
+		}
+	}
+
+	// Every limit has capacity: take a token and a concurrency slot from each
+	for _, state := range matched {
+		if state.rate > 0 {
+			state.tokens--
+		}
+	}
+	for _, state := range held {
+		state.inFlight++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			for _, state := range held {
+				state.inFlight--
+			}
+		})
+	}, nil
+}
+
+// throttled records and logs a rejected request
+func (l *RateLimiter) throttled(req global.ToolRequest, err *global.ThrottleError) error {
+	if l.collector != nil {
+		l.collector.RecordThrottle(req.ServiceName)
+	}
+	if l.logger != nil {
+		l.logger.Warningf("Rate limit: throttled tool %s for tenant %s: %v (retry after %s)",
+			req.ToolName, (&TenantContext{TenantHash: req.TenantHash}).ShortHash(), err, err.RetryAfter.Round(time.Millisecond))
+	}
+	return err
+}
+
+// prune discards the state of limits that are idle. Must be called with mu held.
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < rateLimitPruneInterval {
+		return
+	}
+	l.pruned = now
+	for key, state := range l.limits {
+		state.refill(now)
+		if state.idle() {
+			delete(l.limits, key)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/metrics"
+)
+
+// newTestRateLimiter creates a limiter whose clock is advanced by the returned function
+func newTestRateLimiter(t *testing.T, config *RateLimitConfig, collector *metrics.Collector) (*RateLimiter, func(time.Duration)) {
+	t.Helper()
+	if err := config.ValidateWithLogger(nil); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	limiter := NewRateLimiter(config, collector, nil)
+	limiter.now = func() time.Time { return now }
+	return limiter, func(d time.Duration) { now = now.Add(d) }
+}
+
+// throttleOf returns the ThrottleError in err, or nil
+func throttleOf(err error) *global.ThrottleError {
+	var throttle *global.ThrottleError
+	if errors.As(err, &throttle) {
+		return throttle
+	}
+	return nil
+}
+
+func TestRateLimiter_TokenBucketPerTenant(t *testing.T) {
+	collector := metrics.New()
+	collector.RegisterService("m365", global.TransportAPI, nil)
+	limiter, advance := newTestRateLimiter(t, &RateLimitConfig{
+		Limits: []RateLimitRule{{Description: "tenant rate", Per: []string{RateLimitPerTenant}, RequestsPerMinute: 60, Burst: 2}},
+	}, collector)
+	ctx := context.Background()
+	alice := global.ToolRequest{TenantHash: "alice", ServiceName: "m365", ToolName: "m365_mail_list"}
+	bob := global.ToolRequest{TenantHash: "bob", ServiceName: "m365", ToolName: "m365_mail_list"}
+
+	for i := 0; i < 2; i++ {
+		release, err := limiter.Acquire(ctx, alice)
+		if err != nil {
+			t.Fatalf("request %d within burst was throttled: %v", i, err)
+		}
+		release()
+	}
+
+	_, err := limiter.Acquire(ctx, alice)
+	throttle := throttleOf(err)
+	if throttle == nil {
+		t.Fatalf("expected a ThrottleError, got %v", err)
+	}
+	if throttle.Kind != global.ThrottleRate || throttle.Limit != "tenant rate" || throttle.RetryAfter != time.Second {
+		t.Errorf("unexpected throttle %+v", throttle)
+	}
+
+	// Another tenant has its own bucket
+	if _, err := limiter.Acquire(ctx, bob); err != nil {
+		t.Errorf("other tenant was throttled: %v", err)
+	}
+
+	// The bucket refills at the configured rate
+	advance(time.Second)
+	if _, err := limiter.Acquire(ctx, alice); err != nil {
+		t.Errorf("request after refill was throttled: %v", err)
+	}
+
+	if stats := collector.GetServiceStats("m365"); stats.Throttled != 1 {
+		t.Errorf("expected 1 throttled request, got %d", stats.Throttled)
+	}
+}
+
+func TestRateLimiter_MaxConcurrent(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, &RateLimitConfig{
+		Limits: []RateLimitRule{{Tools: []string{"command_*"}, Per: []string{RateLimitPerTool}, MaxConcurrent: 1}},
+	}, nil)
+	ctx := context.Background()
+	build := global.ToolRequest{TenantHash: "alice", ServiceName: "command", ToolName: "command_build"}
+
+	release, err := limiter.Acquire(ctx, build)
+	if err != nil {
+		t.Fatalf("first request was throttled: %v", err)
+	}
+
+	_, err = limiter.Acquire(ctx, build)
+	if throttle := throttleOf(err); throttle == nil || throttle.Kind != global.ThrottleConcurrency {
+		t.Fatalf("expected a concurrency ThrottleError, got %v", err)
+	}
+
+	// Other tools and services are not affected
+	if _, err := limiter.Acquire(ctx, global.ToolRequest{ServiceName: "command", ToolName: "command_test"}); err != nil {
+		t.Errorf("other tool was throttled: %v", err)
+	}
+	if _, err := limiter.Acquire(ctx, global.ToolRequest{ServiceName: "m365", ToolName: "m365_mail_list"}); err != nil {
+		t.Errorf("unmatched tool was throttled: %v", err)
+	}
+
+	release()
+	release() // Releasing twice must not free a second slot
+	release, err = limiter.Acquire(ctx, build)
+	if err != nil {
+		t.Fatalf("request after release was throttled: %v", err)
+	}
+	if _, err := limiter.Acquire(ctx, build); err == nil {
+		t.Error("expected the slot to be taken again")
+	}
+	release()
+}
+
+func TestRateLimiter_ThrottledRequestTakesNoCapacity(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, &RateLimitConfig{
+		Limits: []RateLimitRule{
+			{Description: "shared", RequestsPerMinute: 60, Burst: 5},
+			{Description: "per tenant", Per: []string{RateLimitPerTenant}, RequestsPerMinute: 60, Burst: 1},
+		},
+	}, nil)
+	ctx := context.Background()
+
+	if _, err := limiter.Acquire(ctx, global.ToolRequest{TenantHash: "alice"}); err != nil {
+		t.Fatalf("first request was throttled: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		_, err := limiter.Acquire(ctx, global.ToolRequest{TenantHash: "alice"})
+		if throttle := throttleOf(err); throttle == nil || throttle.Limit != "per tenant" {
+			t.Fatalf("expected the per-tenant limit, got %v", err)
+		}
+	}
+
+	// Throttled requests did not drain the shared bucket
+	for _, tenant := range []string{"bob", "carol", "dave", "erin"} {
+		if _, err := limiter.Acquire(ctx, global.ToolRequest{TenantHash: tenant}); err != nil {
+			t.Errorf("tenant %s was throttled: %v", tenant, err)
+		}
+	}
+}
+
+func TestRateLimiter_SetConfig(t *testing.T) {
+	config := &RateLimitConfig{Limits: []RateLimitRule{{RequestsPerMinute: 1}}}
+	limiter, _ := newTestRateLimiter(t, config, nil)
+	ctx := context.Background()
+
+	if _, err := limiter.Acquire(ctx, global.ToolRequest{}); err != nil {
+		t.Fatalf("first request was throttled: %v", err)
+	}
+
+	// Reloading identical limits keeps their state
+	limiter.SetConfig(&RateLimitConfig{Limits: []RateLimitRule{{RequestsPerMinute: 1}}})
+	if _, err := limiter.Acquire(ctx, global.ToolRequest{}); err == nil {
+		t.Error("expected unchanged limits to keep throttling")
+	}
+
+	// Removing the limits admits everything
+	limiter.SetConfig(nil)
+	for i := 0; i < 5; i++ {
+		if _, err := limiter.Acquire(ctx, global.ToolRequest{}); err != nil {
+			t.Fatalf("request without limits was throttled: %v", err)
+		}
+	}
+}
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    RateLimitRule
+		wantErr bool
+	}{
+		{"rate", RateLimitRule{RequestsPerMinute: 10}, false},
+		{"concurrency", RateLimitRule{MaxConcurrent: 2, Per: []string{"tenant", "service"}}, false},
+		{"no limit", RateLimitRule{Per: []string{"tenant"}}, true},
+		{"negative", RateLimitRule{RequestsPerMinute: -1}, true},
+		{"burst without rate", RateLimitRule{MaxConcurrent: 1, Burst: 5}, true},
+		{"unknown dimension", RateLimitRule{RequestsPerMinute: 10, Per: []string{"user"}}, true},
+		{"bad pattern", RateLimitRule{RequestsPerMinute: 10, Tools: []string{"["}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&RateLimitConfig{Limits: []RateLimitRule{tt.rule}}).ValidateWithLogger(nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if burst := (&RateLimitRule{RequestsPerMinute: 600}).GetBurst(); burst != 10 {
+		t.Errorf("expected default burst 10, got %d", burst)
+	}
+	if burst := (&RateLimitRule{RequestsPerMinute: 5}).GetBurst(); burst != 1 {
+		t.Errorf("expected default burst 1, got %d", burst)
+	}
+}
+
+func TestLoadConfig_RateLimitsOnly(t *testing.T) {
+	config, err := LoadConfigFromJSON([]byte(`{"rateLimits": {"limits": [{"per": ["tenant"], "requestsPerMinute": 60}]}}`), "limits.json")
+	if err != nil {
+		t.Fatalf("expected limits-only config to load, got %v", err)
+	}
+	if config.RateLimits == nil || len(config.RateLimits.Limits) != 1 {
+		t.Errorf("unexpected rate limits: %+v", config.RateLimits)
+	}
+
+	if _, err := LoadConfigFromJSON([]byte(`{"rateLimits": {"limits": [{"per": ["tenant"]}]}}`), "bad.json"); err == nil {
+		t.Error("expected a limit without a rate or concurrency cap to be rejected")
+	}
+}
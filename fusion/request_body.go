@@ -0,0 +1,337 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/itchyny/gojq"
+)
+
+// bodyTemplateFuncs are the functions available to request body templates
+var bodyTemplateFuncs = template.FuncMap{
+	// json renders a value as JSON, e.g. {"name": {{json .name}}}
+	"json": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+}
+
+// validateFormat checks a request body that is built by a template or jq
+// expression, or sent as a form or XML document
+func (r *RequestBodyConfig) validateFormat() error {
+	if r.Template != "" && r.JQ != "" {
+		return fmt.Errorf("template and jq cannot be combined")
+	}
+
+	switch r.GetFormat() {
+	case RequestBodyFormatJSON:
+		if r.Encoding != "" || r.WrapperPath != "" {
+			return fmt.Errorf("encoding and wrapperPath cannot be combined with template or jq")
+		}
+	case RequestBodyFormatForm:
+		if r.Template != "" {
+			return fmt.Errorf("template cannot be used with form bodies")
+		}
+	case RequestBodyFormatXML:
+		if r.JQ != "" {
+			return fmt.Errorf("jq cannot be used with xml bodies")
+		}
+		if strings.TrimSpace(r.Template) == "" {
+			return fmt.Errorf("template is required for xml bodies")
+		}
+	default:
+		return fmt.Errorf("invalid format: %s", r.Format)
+	}
+
+	if r.GetFormat() != RequestBodyFormatJSON && (r.Encoding != "" || r.WrapperPath != "") {
+		return fmt.Errorf("encoding and wrapperPath only apply to json bodies")
+	}
+	if r.Template != "" {
+		if _, err := parseBodyTemplate("requestBody", r.Template); err != nil {
+			return err
+		}
+	}
+	if r.JQ != "" {
+		if _, err := gojq.Parse(r.JQ); err != nil {
+			return fmt.Errorf("invalid jq expression: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildFormattedBody serializes body parameters as a templated, jq-built, form
+// or XML request body, returning the content and its Content-Type. Bodies
+// without content are returned empty so that no body is sent.
+func (h *HTTPHandler) buildFormattedBody(body map[string]interface{}) (string, string, error) {
+	requestBody := h.endpoint.RequestBody
+	format := requestBody.GetFormat()
+
+	if requestBody.JQ != "" {
+		value, err := evaluateBodyJQ(requestBody.JQ, body)
+		if err != nil || value == nil {
+			return "", "", err
+		}
+		if format == RequestBodyFormatForm {
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				return "", "", fmt.Errorf("jq must produce an object for form bodies, got %T", value)
+			}
+			content, err := encodeFormBody(fields)
+			return content, "application/x-www-form-urlencoded", err
+		}
+		encoded, err := json.Marshal(value)
+		return string(encoded), "application/json", err
+	}
+
+	switch format {
+	case RequestBodyFormatJSON:
+		content, err := renderJSONTemplate("requestBody", requestBody.Template, h.endpoint.Parameters, body)
+		return content, "application/json", err
+	case RequestBodyFormatForm:
+		content, err := encodeFormBody(body)
+		return content, "application/x-www-form-urlencoded", err
+	case RequestBodyFormatXML:
+		content, err := renderXMLTemplate("requestBody", requestBody.Template, h.endpoint.Parameters, body)
+		return content, "application/xml; charset=utf-8", err
+	default:
+		return "", "", fmt.Errorf("unsupported request body format: %s", requestBody.Format)
+	}
+}
+
+// parseBodyTemplate parses a request body template
+func parseBodyTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(bodyTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// bodyTemplateData builds the data of a body template from the body parameters,
+// converting each value with convert. Declared body parameters that were not
+// supplied are set to missing, so templates can test them with {{if .name}}.
+func bodyTemplateData(params []ParameterConfig, body map[string]interface{},
+	convert func(interface{}) interface{}, missing interface{}) map[string]interface{} {
+
+	data := make(map[string]interface{}, len(body))
+	for key, value := range body {
+		data[key] = convert(value)
+	}
+	for _, param := range params {
+		if param.Location != ParameterLocationBody {
+			continue
+		}
+		target := param.GetTransformedParameterName()
+		if _, ok := data[strings.SplitN(target, ".", 2)[0]]; !ok {
+			setNestedValue(data, target, missing)
+		}
+	}
+	return data
+}
+
+// renderJSONTemplate executes a JSON body template with the body parameters as
+// data and checks that the result is a JSON document. String values are
+// JSON-escaped, so "{{.name}}" cannot break out of its string; the json
+// function renders a value as a complete JSON value instead.
+func renderJSONTemplate(name, text string, params []ParameterConfig, body map[string]interface{}) (string, error) {
+	tmpl, err := parseBodyTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	data := bodyTemplateData(params, body, jsonTemplateValue, nil)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return "", fmt.Errorf("%s template did not produce valid JSON", name)
+	}
+	return buf.String(), nil
+}
+
+// jsonString is a string argument of a JSON body template. It prints as the
+// escaped content of a JSON string, while the json function and comparisons
+// still see the original value.
+type jsonString string
+
+// String returns the value escaped for use inside a JSON string
+func (s jsonString) String() string {
+	encoded, _ := json.Marshal(string(s))
+	return string(encoded[1 : len(encoded)-1])
+}
+
+// jsonTemplateValue converts a decoded argument into JSON template data with
+// all strings wrapped as jsonString. Maps and slices keep their shape so that
+// templates can use range and nested fields.
+func jsonTemplateValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		escaped := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			escaped[key] = jsonTemplateValue(item)
+		}
+		return escaped
+	case []interface{}:
+		escaped := make([]interface{}, len(v))
+		for i, item := range v {
+			escaped[i] = jsonTemplateValue(item)
+		}
+		return escaped
+	case string:
+		return jsonString(v)
+	default:
+		return v
+	}
+}
+
+// evaluateBodyJQ runs a jq expression with the body parameters as input and
+// returns its first output
+func evaluateBodyJQ(expression string, body map[string]interface{}) (interface{}, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+
+	// gojq only accepts JSON values, so normalise the Go types of the arguments
+	var input interface{} = map[string]interface{}{}
+	if len(body) > 0 {
+		if input, err = toJSONValue(body); err != nil {
+			return nil, fmt.Errorf("failed to convert jq input: %w", err)
+		}
+	}
+
+	value, ok := query.Run(input).Next()
+	if !ok {
+		return nil, fmt.Errorf("jq expression produced no output")
+	}
+	if err, isErr := value.(error); isErr {
+		return nil, fmt.Errorf("jq expression failed: %w", err)
+	}
+	return value, nil
+}
+
+// renderXMLTemplate executes an XML body template. The template data holds the
+// body parameters keyed by name (or transform.targetName), with every value
+// XML-escaped so that arguments cannot inject markup. Declared body parameters
+// that were not supplied are present as empty strings, so templates can test
+// them with {{if .name}}.
+func renderXMLTemplate(name, text string, params []ParameterConfig, body map[string]interface{}) (string, error) {
+	tmpl, err := parseBodyTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	data := bodyTemplateData(params, body, xmlTemplateValue, "")
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// xmlTemplateValue converts a decoded argument into template data with all
+// scalar values rendered as XML-escaped strings. Maps and slices keep their
+// shape so that templates can use range and nested fields.
+func xmlTemplateValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		escaped := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			escaped[key] = xmlTemplateValue(item)
+		}
+		return escaped
+	case []interface{}:
+		escaped := make([]interface{}, len(v))
+		for i, item := range v {
+			escaped[i] = xmlTemplateValue(item)
+		}
+		return escaped
+	case nil:
+		return ""
+	default:
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(formatBodyScalar(v)))
+		return buf.String()
+	}
+}
+
+// formatBodyScalar renders a scalar argument for XML and form bodies. Numbers
+// are written without exponents so that large integers survive unchanged.
+func formatBodyScalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// encodeFormBody URL-encodes body parameters as application/x-www-form-urlencoded.
+// Arrays repeat their key and nested objects use bracketed keys (a[b]=c), the
+// convention of most form-based APIs.
+func encodeFormBody(body map[string]interface{}) (string, error) {
+	if len(body) == 0 {
+		return "", nil
+	}
+	values := url.Values{}
+	if err := addFormValues(values, "", body); err != nil {
+		return "", err
+	}
+	return values.Encode(), nil
+}
+
+// addFormValues adds value to values under key, flattening maps and slices
+func addFormValues(values url.Values, key string, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for name := range v {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		for _, name := range keys {
+			childKey := name
+			if key != "" {
+				childKey = key + "[" + name + "]"
+			}
+			if err := addFormValues(values, childKey, v[name]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				encoded, err := json.Marshal(item)
+				if err != nil {
+					return fmt.Errorf("failed to encode form field %s: %w", key, err)
+				}
+				values.Add(key, string(encoded))
+			default:
+				values.Add(key, formatBodyScalar(item))
+			}
+		}
+	case nil:
+		values.Add(key, "")
+	default:
+		values.Add(key, formatBodyScalar(v))
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/tenebris-tech/mlogger"
+)
+
+// newBodyTestFusion builds a Fusion instance with a single endpoint of the
+// "svc" service backed by serverURL
+func newBodyTestFusion(t *testing.T, serverURL string, endpoint EndpointConfig) *Fusion {
+	t.Helper()
+	if err := endpoint.Validate(); err != nil {
+		t.Fatalf("invalid test endpoint: %v", err)
+	}
+
+	config := &Config{
+		Services: map[string]*ServiceConfig{
+			"svc": {
+				ServiceKey: "svc",
+				Name:       "svc",
+				BaseURL:    serverURL,
+				Auth:       AuthConfig{Type: AuthTypeNone},
+				Endpoints:  []EndpointConfig{endpoint},
+			},
+		},
+	}
+	return New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+}
+
+func TestEncodeFormBody(t *testing.T) {
+	body := map[string]interface{}{
+		"name":     "Jane Doe & co",
+		"amount":   float64(12345678901),
+		"tags":     []interface{}{"a", "b"},
+		"metadata": map[string]interface{}{"order": "42", "ref": map[string]interface{}{"id": "x"}},
+	}
+
+	content, err := encodeFormBody(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	values, err := url.ParseQuery(content)
+	if err != nil {
+		t.Fatalf("invalid form body %q: %v", content, err)
+	}
+
+	if values.Get("name") != "Jane Doe & co" || values.Get("amount") != "12345678901" {
+		t.Errorf("scalar values = %v", values)
+	}
+	if tags := values["tags"]; len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("tags = %v, want a repeated key", tags)
+	}
+	if values.Get("metadata[order]") != "42" || values.Get("metadata[ref][id]") != "x" {
+		t.Errorf("nested values = %v", values)
+	}
+
+	if content, _ := encodeFormBody(nil); content != "" {
+		t.Errorf("empty body encoded as %q", content)
+	}
+}
+
+func TestRenderXMLTemplate(t *testing.T) {
+	params := []ParameterConfig{
+		{Name: "city", Type: ParameterTypeString, Location: ParameterLocationBody},
+		{Name: "units", Type: ParameterTypeString, Location: ParameterLocationBody},
+		{Name: "days", Type: ParameterTypeArray, Location: ParameterLocationBody},
+	}
+	text := `<Forecast><City>{{.city}}</City>{{if .units}}<Units>{{.units}}</Units>{{end}}` +
+		`{{range .days}}<Day>{{.}}</Day>{{end}}</Forecast>`
+
+	got, err := renderXMLTemplate("body", text, params, map[string]interface{}{
+		"city": `<Paris> & "Lyon"`,
+		"days": []interface{}{float64(1), float64(2)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<Forecast><City>&lt;Paris&gt; &amp; &#34;Lyon&#34;</City><Day>1</Day><Day>2</Day></Forecast>`
+	if got != want {
+		t.Errorf("rendered %s, want %s", got, want)
+	}
+}
+
+func TestRequestBodyConfig_ValidateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    RequestBodyConfig
+		wantErr string
+	}{
+		{"form", RequestBodyConfig{Format: RequestBodyFormatForm}, ""},
+		{"xml", RequestBodyConfig{Format: RequestBodyFormatXML, Template: "<a>{{.a}}</a>"}, ""},
+		{"form with template", RequestBodyConfig{Format: RequestBodyFormatForm, Template: "x"}, "cannot be used with form"},
+		{"xml without template", RequestBodyConfig{Format: RequestBodyFormatXML}, "template is required"},
+		{"invalid template", RequestBodyConfig{Format: RequestBodyFormatXML, Template: "{{.a"}, "invalid template"},
+		{"wrapper path", RequestBodyConfig{Format: RequestBodyFormatForm, WrapperPath: "data"}, "only apply to json"},
+		{"unknown format", RequestBodyConfig{Format: "yaml"}, "invalid format"},
+		{"json template", RequestBodyConfig{Template: `{"a": {{json .a}}}`}, ""},
+		{"json jq", RequestBodyConfig{JQ: "{a: .a}"}, ""},
+		{"form jq", RequestBodyConfig{Format: RequestBodyFormatForm, JQ: "{a: .a}"}, ""},
+		{"template and jq", RequestBodyConfig{Template: "{}", JQ: "."}, "cannot be combined"},
+		{"jq with encoding", RequestBodyConfig{JQ: ".", Encoding: "rfc2822_base64url"}, "cannot be combined with template or jq"},
+		{"xml jq", RequestBodyConfig{Format: RequestBodyFormatXML, JQ: "."}, "jq cannot be used"},
+		{"invalid jq", RequestBodyConfig{JQ: "{a:"}, "invalid jq expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.body.validateFormat()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderJSONTemplate(t *testing.T) {
+	params := []ParameterConfig{
+		{Name: "title", Type: ParameterTypeString, Location: ParameterLocationBody},
+		{Name: "labels", Type: ParameterTypeArray, Location: ParameterLocationBody},
+	}
+	text := `{"title": {{json .title}}{{if .labels}}, "labels": {{json .labels}}{{end}}}`
+
+	got, err := renderJSONTemplate("body", text, params, map[string]interface{}{"title": `say "hi"`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `{"title": "say \"hi\""}` {
+		t.Errorf("rendered %s", got)
+	}
+
+	got, err = renderJSONTemplate("body", `{"title": "{{.title}}"{{range .labels}}, "{{.}}": true{{end}}}`, params,
+		map[string]interface{}{"title": `x", "admin": "true`, "labels": []interface{}{`a": 1, "b`}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `{"title": "x\", \"admin\": \"true", "a\": 1, \"b": true}` {
+		t.Errorf("rendered %s", got)
+	}
+
+	_, err = renderJSONTemplate("body", `{"title": {{.title}}}`, params, map[string]interface{}{"title": "secret"})
+	if err == nil {
+		t.Fatal("expected an error for a template producing invalid JSON")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error includes the rendered body: %v", err)
+	}
+}
+
+func TestEvaluateBodyJQ(t *testing.T) {
+	value, err := evaluateBodyJQ(`{query: {term: .q}, size: (.size // 10)}`, map[string]interface{}{"q": "go", "size": 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query, _ := value.(map[string]interface{})["query"].(map[string]interface{})
+	if query["term"] != "go" || value.(map[string]interface{})["size"] != float64(5) {
+		t.Errorf("value = %v", value)
+	}
+
+	if value, err := evaluateBodyJQ(`.size // 10`, nil); err != nil || value != 10 {
+		t.Errorf("value = %v, err = %v, want the default from an empty input", value, err)
+	}
+	if _, err := evaluateBodyJQ(`error("bad")`, nil); err == nil {
+		t.Error("expected the jq error to be returned")
+	}
+}
+
+func TestFormattedRequestBodies(t *testing.T) {
+	var contentType, received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		received = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	params := []ParameterConfig{
+		{Name: "customer", Type: ParameterTypeString, Required: true, Location: ParameterLocationBody},
+		{Name: "amount", Type: ParameterTypeNumber, Location: ParameterLocationBody},
+	}
+
+	tests := []struct {
+		name            string
+		body            *RequestBodyConfig
+		wantContentType string
+		want            string
+	}{
+		{
+			"form", &RequestBodyConfig{Format: RequestBodyFormatForm},
+			"application/x-www-form-urlencoded", "amount=250&customer=cus_1",
+		},
+		{
+			"xml", &RequestBodyConfig{Format: RequestBodyFormatXML, Template: `<charge customer="{{.customer}}">{{.amount}}</charge>`},
+			"application/xml; charset=utf-8", `<charge customer="cus_1">250</charge>`,
+		},
+		{
+			"json template", &RequestBodyConfig{Template: `{"charge": {"customer": {{json .customer}}, "lines": [{"amount": {{json .amount}}}]}}`},
+			"application/json", `{"charge": {"customer": "cus_1", "lines": [{"amount": 250}]}}`,
+		},
+		{
+			"json jq", &RequestBodyConfig{JQ: `{charge: {customer, cents: (.amount * 100)}}`},
+			"application/json", `{"charge":{"cents":25000,"customer":"cus_1"}}`,
+		},
+		{
+			"form jq", &RequestBodyConfig{Format: RequestBodyFormatForm, JQ: `{customer, "amount_cents": (.amount * 100)}`},
+			"application/x-www-form-urlencoded", "amount_cents=25000&customer=cus_1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newBodyTestFusion(t, server.URL, EndpointConfig{
+				ID: "charge", Name: "Charge", Method: http.MethodPost, Path: "/charges",
+				Parameters:  params,
+				RequestBody: tt.body,
+				Response:    ResponseConfig{Type: ResponseTypeJSON},
+			})
+			tool := findTool(t, f.RegisterTools(), "svc_charge")
+			if _, err := tool.Handler(withTenant("tenant-a", map[string]interface{}{"customer": "cus_1", "amount": 250})); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if contentType != tt.wantContentType || received != tt.want {
+				t.Errorf("sent %q as %q, want %q as %q", received, contentType, tt.want, tt.wantContentType)
+			}
+		})
+	}
+}
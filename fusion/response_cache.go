@@ -0,0 +1,246 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// responseCacheKeyPrefix namespaces response cache entries.
+const responseCacheKeyPrefix = "response:"
+
+// responseCacheMaxEntries bounds the number of cached responses held in memory.
+// When the limit is reached, expired entries are purged first and, if that is
+// not enough, the entry closest to expiry is evicted.
+const responseCacheMaxEntries = 1000
+
+// cacheKeyPlaceholder matches {param} placeholders in a caching key template.
+var cacheKeyPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// ResponseCache is an in-memory cache of processed endpoint responses.
+//
+// Keys are scoped by service and tenant hash so that one tenant can never
+// receive another tenant's cached data. Entries are stored as the final tool
+// result string (after transforms), so a hit skips the upstream call entirely.
+type ResponseCache struct {
+	items  map[string]*cacheItem
+	mu     sync.RWMutex
+	logger global.Logger
+}
+
+// NewResponseCache creates an empty response cache
+func NewResponseCache(logger global.Logger) *ResponseCache {
+	return &ResponseCache{
+		items:  make(map[string]*cacheItem),
+		logger: logger,
+	}
+}
+
+// Get returns the cached response for key, if present and not expired
+func (rc *ResponseCache) Get(key string) (string, bool) {
+	rc.mu.RLock()
+	item, exists := rc.items[key]
+	rc.mu.RUnlock()
+
+	if !exists {
+		return "", false
+	}
+
+	if item.isExpired() {
+		rc.mu.Lock()
+		// Re-check under the write lock; another goroutine may have replaced it
+		if current, ok := rc.items[key]; ok && current.isExpired() {
+			delete(rc.items, key)
+		}
+		rc.mu.Unlock()
+		return "", false
+	}
+
+	value, ok := item.value.(string)
+	return value, ok
+}
+
+// Set stores a response under key for the given TTL. Non-positive TTLs are ignored.
+func (rc *ResponseCache) Set(key, value string, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, exists := rc.items[key]; !exists && len(rc.items) >= responseCacheMaxEntries {
+		rc.evictLocked()
+	}
+
+	rc.items[key] = &cacheItem{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	}
+}
+
+// InvalidateService removes every cached response for a service across all tenants.
+// It returns the number of entries removed.
+func (rc *ResponseCache) InvalidateService(serviceKey string) int {
+	prefix := responseCacheKeyPrefix + serviceKey + ":"
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	removed := 0
+	for key := range rc.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(rc.items, key)
+			removed++
+		}
+	}
+
+	if removed > 0 && rc.logger != nil {
+		rc.logger.Debugf("Invalidated %d cached responses for service %s", removed, serviceKey)
+	}
+
+	return removed
+}
+
+// Clear removes all cached responses
+func (rc *ResponseCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.items = make(map[string]*cacheItem)
+}
+
+// Len returns the number of entries currently held, including expired entries
+// that have not yet been purged.
+func (rc *ResponseCache) Len() int {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return len(rc.items)
+}
+
+// evictLocked makes room for a new entry. The caller MUST hold rc.mu for writing.
+func (rc *ResponseCache) evictLocked() {
+	now := time.Now()
+	for key, item := range rc.items {
+		if now.After(item.expiresAt) {
+			delete(rc.items, key)
+		}
+	}
+	if len(rc.items) < responseCacheMaxEntries {
+		return
+	}
+
+	var oldestKey string
+	var oldestExpiry time.Time
+	for key, item := range rc.items {
+		if oldestKey == "" || item.expiresAt.Before(oldestExpiry) {
+			oldestKey = key
+			oldestExpiry = item.expiresAt
+		}
+	}
+	delete(rc.items, oldestKey)
+}
+
+// buildResponseCacheKey builds the cache key for an endpoint call.
+//
+// The key always begins with the service key and tenant hash. When the endpoint
+// defines a key template, {param} placeholders are expanded from args; otherwise
+// a hash of all arguments is used so that different calls never collide.
+func buildResponseCacheKey(serviceKey, tenantHash, endpointID, template string, args map[string]interface{}) string {
+	var suffix string
+	if template != "" {
+		suffix = cacheKeyPlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+			name := match[1 : len(match)-1]
+			value, ok := args[name]
+			if !ok || value == nil {
+				return ""
+			}
+			return cacheKeyValue(value)
+		})
+	} else {
+		suffix = hashCacheArgs(args)
+	}
+
+	return fmt.Sprintf("%s%s:%s:%s:%s", responseCacheKeyPrefix, serviceKey, tenantHash, endpointID, suffix)
+}
+
+// cacheKeyValue renders an argument value for inclusion in a cache key
+func cacheKeyValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// hashCacheArgs returns a stable SHA-256 digest of the argument map
+func hashCacheArgs(args map[string]interface{}) string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hasher := sha256.New()
+	for _, name := range names {
+		hasher.Write([]byte(name))
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(cacheKeyValue(args[name])))
+		hasher.Write([]byte{0})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// responseCacheKey returns the cache key and TTL for this call, or an empty key
+// when the response must not be cached. Only GET endpoints with caching enabled
+// and a positive TTL are cached, and only when a tenant context is present so
+// that entries are always tenant-scoped. Binary responses are never cached
+// because handling them has side effects (files written to disk).
+func (h *HTTPHandler) responseCacheKey(ctx context.Context, args map[string]interface{}) (string, time.Duration) {
+	caching := h.endpoint.Response.Caching
+	if h.fusion.responseCache == nil || caching == nil || !caching.Enabled || caching.TTL <= 0 {
+		return "", 0
+	}
+	if h.endpoint.effectiveMethod() != http.MethodGet || h.endpoint.Response.Type == ResponseTypeBinary {
+		return "", 0
+	}
+
+	tenantContext, ok := ctx.Value(global.TenantContextKey).(*TenantContext)
+	if !ok || tenantContext == nil || tenantContext.TenantHash == "" {
+		return "", 0
+	}
+
+	key := buildResponseCacheKey(h.service.ServiceKey, tenantContext.TenantHash, h.endpoint.ID, caching.Key, args)
+	return key, caching.TTL
+}
+
+// recordCacheLookup reports a response cache hit or miss to the metrics collectors
+func (h *HTTPHandler) recordCacheLookup(hit bool) {
+	if h.fusion.metricsCollector != nil {
+		h.fusion.metricsCollector.RecordCacheLookup(h.service.Name, h.endpoint.ID, hit)
+	}
+	if h.fusion.sharedCollector != nil {
+		h.fusion.sharedCollector.RecordCacheLookup(h.service.ServiceKey, hit)
+	}
+}
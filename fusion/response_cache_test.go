@@ -0,0 +1,250 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/metrics"
+	"github.com/tenebris-tech/mlogger"
+)
+
+// newCachingTestFusion builds a Fusion instance with a cached GET endpoint, an
+// uncached GET endpoint and a POST endpoint, all backed by server.
+func newCachingTestFusion(t *testing.T, serverURL string, caching *CachingConfig, collector *metrics.Collector) *Fusion {
+	t.Helper()
+	config := &Config{
+		Services: map[string]*ServiceConfig{
+			"cachesvc": {
+				ServiceKey: "cachesvc",
+				Name:       "cachesvc",
+				BaseURL:    serverURL,
+				Auth:       AuthConfig{Type: AuthTypeNone},
+				Endpoints: []EndpointConfig{
+					{
+						ID:     "list",
+						Name:   "List",
+						Method: "GET",
+						Path:   "/items",
+						Parameters: []ParameterConfig{
+							{Name: "folder", Type: ParameterTypeString, Location: ParameterLocationQuery},
+							{Name: "top", Type: ParameterTypeNumber, Location: ParameterLocationQuery},
+						},
+						Response: ResponseConfig{Type: ResponseTypeJSON, Caching: caching},
+					},
+					{
+						ID:         "uncached",
+						Name:       "Uncached",
+						Method:     "GET",
+						Path:       "/items",
+						Parameters: []ParameterConfig{},
+						Response:   ResponseConfig{Type: ResponseTypeJSON},
+					},
+					{
+						ID:         "create",
+						Name:       "Create",
+						Method:     "POST",
+						Path:       "/items",
+						Parameters: []ParameterConfig{},
+						Response:   ResponseConfig{Type: ResponseTypeJSON},
+					},
+				},
+			},
+		},
+	}
+
+	options := []Option{WithConfig(config), WithLogger(mlogger.NewMemoryLogger())}
+	if collector != nil {
+		options = append(options, WithSharedCollector(collector))
+	}
+	return New(options...)
+}
+
+// countingServer returns a JSON server that counts GET requests.
+func countingServer(gets *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+}
+
+// withTenant adds a tenant context with the given hash to an args map.
+func withTenant(tenantHash string, args map[string]any) map[string]any {
+	tc := &TenantContext{TenantHash: tenantHash, CreatedAt: time.Now()}
+	args["__mcp_context"] = context.WithValue(context.Background(), global.TenantContextKey, tc)
+	return args
+}
+
+func TestResponseCache_HitSkipsUpstream(t *testing.T) {
+	var gets atomic.Int32
+	server := countingServer(&gets)
+	defer server.Close()
+
+	collector := metrics.New()
+	f := newCachingTestFusion(t, server.URL, &CachingConfig{Enabled: true, TTL: time.Minute}, collector)
+	tool := findTool(t, f.RegisterTools(), "cachesvc_list")
+
+	for i := 0; i < 3; i++ {
+		result, err := tool.Handler(withTenant("tenant-a", map[string]any{"folder": "inbox"}))
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if !strings.Contains(result, "ok") {
+			t.Fatalf("call %d: unexpected result %q", i, result)
+		}
+	}
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request, got %d", got)
+	}
+
+	stats := f.GetServiceMetrics("cachesvc")
+	if stats == nil || stats.CacheHits != 2 || stats.CacheMisses != 1 {
+		t.Errorf("expected 2 hits / 1 miss in fusion metrics, got %+v", stats)
+	}
+	shared := collector.GetServiceStats("cachesvc")
+	if shared == nil || shared.CacheHits != 2 || shared.CacheMisses != 1 {
+		t.Errorf("expected 2 hits / 1 miss in shared collector, got %+v", shared)
+	}
+}
+
+func TestResponseCache_ArgumentsAndTenantsAreSeparate(t *testing.T) {
+	var gets atomic.Int32
+	server := countingServer(&gets)
+	defer server.Close()
+
+	f := newCachingTestFusion(t, server.URL, &CachingConfig{Enabled: true, TTL: time.Minute}, nil)
+	tool := findTool(t, f.RegisterTools(), "cachesvc_list")
+
+	calls := []struct {
+		tenant string
+		args   map[string]any
+	}{
+		{"tenant-a", map[string]any{"folder": "inbox"}},
+		{"tenant-a", map[string]any{"folder": "sent"}},
+		{"tenant-b", map[string]any{"folder": "inbox"}},
+		{"tenant-a", map[string]any{"folder": "inbox"}}, // hit
+		{"tenant-b", map[string]any{"folder": "inbox"}}, // hit
+	}
+	for i, c := range calls {
+		if _, err := tool.Handler(withTenant(c.tenant, c.args)); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if got := gets.Load(); got != 3 {
+		t.Errorf("expected 3 upstream requests, got %d", got)
+	}
+}
+
+func TestResponseCache_KeyTemplate(t *testing.T) {
+	var gets atomic.Int32
+	server := countingServer(&gets)
+	defer server.Close()
+
+	// The template only references folder, so differing "top" values share an entry.
+	caching := &CachingConfig{Enabled: true, TTL: time.Minute, Key: "folder:{folder}"}
+	f := newCachingTestFusion(t, server.URL, caching, nil)
+	tool := findTool(t, f.RegisterTools(), "cachesvc_list")
+
+	for _, top := range []float64{10, 20} {
+		if _, err := tool.Handler(withTenant("tenant-a", map[string]any{"folder": "inbox", "top": top})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request, got %d", got)
+	}
+}
+
+func TestResponseCache_WriteInvalidatesService(t *testing.T) {
+	var gets atomic.Int32
+	server := countingServer(&gets)
+	defer server.Close()
+
+	f := newCachingTestFusion(t, server.URL, &CachingConfig{Enabled: true, TTL: time.Minute}, nil)
+	tools := f.RegisterTools()
+	list := findTool(t, tools, "cachesvc_list")
+	create := findTool(t, tools, "cachesvc_create")
+
+	if _, err := list.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := create.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := list.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gets.Load(); got != 2 {
+		t.Errorf("expected cache to be invalidated by POST (2 upstream GETs), got %d", got)
+	}
+}
+
+func TestResponseCache_DisabledAndExpired(t *testing.T) {
+	var gets atomic.Int32
+	server := countingServer(&gets)
+	defer server.Close()
+
+	f := newCachingTestFusion(t, server.URL, &CachingConfig{Enabled: true, TTL: 20 * time.Millisecond}, nil)
+	tools := f.RegisterTools()
+	list := findTool(t, tools, "cachesvc_list")
+	uncached := findTool(t, tools, "cachesvc_uncached")
+
+	for i := 0; i < 2; i++ {
+		if _, err := uncached.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := gets.Load(); got != 2 {
+		t.Fatalf("expected uncached endpoint to hit upstream twice, got %d", got)
+	}
+
+	if _, err := list.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, err := list.Handler(withTenant("tenant-a", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gets.Load(); got != 4 {
+		t.Errorf("expected expired entry to be refetched (4 upstream GETs), got %d", got)
+	}
+}
+
+func TestBuildResponseCacheKey(t *testing.T) {
+	args := map[string]interface{}{"a": "x", "b": float64(2)}
+	same := map[string]interface{}{"b": float64(2), "a": "x"}
+
+	k1 := buildResponseCacheKey("svc", "t1", "ep", "", args)
+	k2 := buildResponseCacheKey("svc", "t1", "ep", "", same)
+	if k1 != k2 {
+		t.Errorf("expected argument order not to affect the key: %q vs %q", k1, k2)
+	}
+	if !strings.HasPrefix(k1, "response:svc:t1:ep:") {
+		t.Errorf("unexpected key layout: %q", k1)
+	}
+	if k3 := buildResponseCacheKey("svc", "t2", "ep", "", args); k3 == k1 {
+		t.Error("expected different tenants to produce different keys")
+	}
+
+	templated := buildResponseCacheKey("svc", "t1", "ep", "{a}-{b}-{missing}", args)
+	if templated != "response:svc:t1:ep:x-2-" {
+		t.Errorf("unexpected templated key: %q", templated)
+	}
+}
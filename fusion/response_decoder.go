@@ -0,0 +1,312 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ResponseDecoder converts a response body that is not JSON into the generic
+// JSON representation used for transforms. contentType is the Content-Type
+// header of the response.
+type ResponseDecoder interface {
+	Decode(body []byte, contentType string) (interface{}, error)
+}
+
+// ResponseDecoderFunc adapts an ordinary function to the ResponseDecoder interface.
+type ResponseDecoderFunc func(body []byte, contentType string) (interface{}, error)
+
+// Decode calls f(body, contentType).
+func (f ResponseDecoderFunc) Decode(body []byte, contentType string) (interface{}, error) {
+	return f(body, contentType)
+}
+
+// responseDecoders holds the built-in response decoders. Read-only after init;
+// each Fusion instance starts from a copy and adds decoders passed to
+// WithResponseDecoder.
+var responseDecoders = map[string]ResponseDecoder{
+	"csv":       ResponseDecoderFunc(decodeCSV),
+	"ndjson":    ResponseDecoderFunc(decodeNDJSON),
+	"multipart": ResponseDecoderFunc(decodeMultipart),
+	"email":     ResponseDecoderFunc(decodeEmail),
+}
+
+// maxMIMEDepth bounds the nesting of multipart email bodies
+const maxMIMEDepth = 16
+
+// GetResponseDecoder returns the built-in decoder registered under the given name.
+func GetResponseDecoder(name string) (ResponseDecoder, bool) {
+	dec, ok := responseDecoders[name]
+	return dec, ok
+}
+
+// toJSONValue converts a Go value into the generic types produced by
+// encoding/json, which is what transforms and jq expressions accept
+func toJSONValue(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var converted interface{}
+	if err := json.Unmarshal(encoded, &converted); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// decodeCSV converts a CSV document into an array of objects keyed by the
+// names in its header row. Values are kept as strings.
+func decodeCSV(body []byte, _ string) (interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	rows := make([]interface{}, 0, len(records))
+	if len(records) == 0 {
+		return rows, nil
+	}
+	header := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// decodeNDJSON converts newline-delimited JSON into an array of its values.
+// Blank lines are ignored.
+func decodeNDJSON(body []byte, _ string) (interface{}, error) {
+	values := make([]interface{}, 0)
+	for i, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(line, &value); err != nil {
+			return nil, fmt.Errorf("invalid NDJSON on line %d: %w", i+1, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// decodeMultipart converts a multipart response (e.g. multipart/related or
+// multipart/mixed) into an array with one object per part, holding its headers,
+// form name and filename when present, and its body. JSON bodies are parsed,
+// text bodies kept as strings and other bodies base64-encoded.
+func decodeMultipart(body []byte, contentType string) (interface{}, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return nil, fmt.Errorf("response is not multipart: %q", contentType)
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	parts := make([]interface{}, 0)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body: %w", err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart body: %w", err)
+		}
+
+		entry := map[string]interface{}{"headers": mimeHeaders(part.Header)}
+		if name := part.FormName(); name != "" {
+			entry["name"] = name
+		}
+		if filename := part.FileName(); filename != "" {
+			entry["filename"] = filename
+		}
+		value, encoding := decodePartBody(part.Header.Get("Content-Type"), data)
+		entry["body"] = value
+		if encoding != "" {
+			entry["encoding"] = encoding
+		}
+		parts = append(parts, entry)
+	}
+}
+
+// decodePartBody converts the body of a MIME part. It returns the value and
+// "base64" when binary content had to be encoded.
+func decodePartBody(contentType string, data []byte) (interface{}, string) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json") {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, ""
+		}
+	}
+	if strings.HasPrefix(mediaType, "text/") || utf8.Valid(data) {
+		return string(data), ""
+	}
+	return base64.StdEncoding.EncodeToString(data), "base64"
+}
+
+// mimeHeaders flattens MIME headers, keeping repeated headers as arrays
+func mimeHeaders(header map[string][]string) map[string]interface{} {
+	headers := make(map[string]interface{}, len(header))
+	decoder := new(mime.WordDecoder)
+	for key, values := range header {
+		decoded := make([]interface{}, len(values))
+		for i, value := range values {
+			if text, err := decoder.DecodeHeader(value); err == nil {
+				value = text
+			}
+			decoded[i] = value
+		}
+		if len(decoded) == 1 {
+			headers[key] = decoded[0]
+		} else {
+			headers[key] = decoded
+		}
+	}
+	return headers
+}
+
+// emailContent collects the text, HTML and attachments of a MIME message
+type emailContent struct {
+	text        []string
+	html        []string
+	attachments []interface{}
+}
+
+// decodeEmail parses an RFC 5322 message (as returned by "raw" message APIs)
+// into its headers, addresses, subject, date, text and HTML bodies and a list
+// of attachments. Encoded words, transfer encodings and charsets are decoded.
+func decodeEmail(body []byte, _ string) (interface{}, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email message: %w", err)
+	}
+
+	result := map[string]interface{}{"headers": mimeHeaders(msg.Header)}
+	for _, field := range []string{"From", "To", "Cc", "Bcc", "Reply-To"} {
+		if msg.Header.Get(field) == "" {
+			continue
+		}
+		addresses, err := msg.Header.AddressList(field)
+		if err != nil {
+			continue
+		}
+		list := make([]interface{}, len(addresses))
+		for i, addr := range addresses {
+			list[i] = map[string]interface{}{"name": addr.Name, "address": addr.Address}
+		}
+		result[strings.ToLower(field)] = list
+	}
+	if subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); err == nil {
+		result["subject"] = subject
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		result["date"] = date.UTC().Format(time.RFC3339)
+	}
+
+	var content emailContent
+	if err := content.addPart(textproto.MIMEHeader(msg.Header), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	result["text"] = strings.Join(content.text, "\n")
+	result["html"] = strings.Join(content.html, "\n")
+	if content.attachments == nil {
+		content.attachments = []interface{}{}
+	}
+	result["attachments"] = content.attachments
+	return result, nil
+}
+
+// addPart adds a MIME part to the content, descending into multipart parts
+func (c *emailContent) addPart(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxMIMEDepth {
+		return fmt.Errorf("email message is nested deeper than %d parts", maxMIMEDepth)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("invalid multipart email body: %w", err)
+			}
+			if err := c.addPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if disposition == "attachment" || filename != "" || (mediaType != "text/plain" && mediaType != "text/html") {
+		size, err := io.Copy(io.Discard, body)
+		if err != nil {
+			return fmt.Errorf("failed to read email attachment: %w", err)
+		}
+		c.attachments = append(c.attachments, map[string]interface{}{
+			"filename": filename, "contentType": mediaType, "size": size,
+		})
+		return nil
+	}
+
+	if label := params["charset"]; label != "" && !strings.EqualFold(label, "utf-8") && !strings.EqualFold(label, "us-ascii") {
+		if body, err = charset.NewReaderLabel(label, body); err != nil {
+			return fmt.Errorf("unsupported email charset %s: %w", label, err)
+		}
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read email body: %w", err)
+	}
+	if mediaType == "text/html" {
+		c.html = append(c.html, string(data))
+	} else {
+		c.text = append(c.text, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tenebris-tech/mlogger"
+)
+
+func TestGetResponseDecoder_BuiltIn(t *testing.T) {
+	for _, name := range []string{"csv", "ndjson", "multipart", "email"} {
+		dec, ok := GetResponseDecoder(name)
+		assert.True(t, ok, name)
+		assert.NotNil(t, dec, name)
+	}
+	_, ok := GetResponseDecoder("nonexistent")
+	assert.False(t, ok)
+}
+
+func TestDecodeCSV(t *testing.T) {
+	value, err := decodeCSV([]byte("\xef\xbb\xbfid,name\n1,\"Doe, Jane\"\n2,Bob\n"), "text/csv")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "1", "name": "Doe, Jane"},
+		map[string]interface{}{"id": "2", "name": "Bob"},
+	}, value)
+
+	value, err = decodeCSV(nil, "text/csv")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{}, value)
+
+	_, err = decodeCSV([]byte("a,b\n1\n"), "text/csv")
+	assert.Error(t, err)
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	value, err := decodeNDJSON([]byte("{\"a\":1}\n\n{\"a\":2}\r\n"), "application/x-ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"a": float64(1)},
+		map[string]interface{}{"a": float64(2)},
+	}, value)
+
+	_, err = decodeNDJSON([]byte("{\"a\":1}\nnot json\n"), "application/x-ndjson")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestDecodeMultipart(t *testing.T) {
+	body := "--b1\r\n" +
+		"Content-Type: application/json\r\n" +
+		"Content-ID: <meta>\r\n\r\n" +
+		"{\"name\":\"report.bin\"}\r\n" +
+		"--b1\r\n" +
+		"Content-Type: application/octet-stream\r\n" +
+		"Content-Disposition: attachment; filename=\"report.bin\"\r\n\r\n" +
+		"\x00\xff\r\n" +
+		"--b1--\r\n"
+
+	value, err := decodeMultipart([]byte(body), `multipart/related; boundary=b1; type="application/json"`)
+	require.NoError(t, err)
+	parts, ok := value.([]interface{})
+	require.True(t, ok)
+	require.Len(t, parts, 2)
+
+	first := parts[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "report.bin"}, first["body"])
+	assert.Equal(t, "<meta>", first["headers"].(map[string]interface{})["Content-Id"])
+
+	second := parts[1].(map[string]interface{})
+	assert.Equal(t, "report.bin", second["filename"])
+	assert.Equal(t, "AP8=", second["body"])
+	assert.Equal(t, "base64", second["encoding"])
+
+	_, err = decodeMultipart([]byte(body), "application/json")
+	assert.Error(t, err)
+}
+
+func TestDecodeEmail(t *testing.T) {
+	message := "From: =?UTF-8?Q?Ren=C3=A9e?= <renee@example.com>\r\n" +
+		"To: bob@example.com, Carol <carol@example.com>\r\n" +
+		"Subject: =?UTF-8?B?UmFwcG9ydCBtZW5zdWVs?=\r\n" +
+		"Date: Mon, 05 Oct 2026 14:30:00 +0200\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=outer\r\n\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=inner\r\n\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain; charset=ISO-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n\r\n" +
+		"Voici le r=E9sum=E9.\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n\r\n" +
+		"<p>Voici</p>\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: application/pdf; name=\"report.pdf\"\r\n" +
+		"Content-Transfer-Encoding: base64\r\n\r\n" +
+		"JVBERi0=\r\n" +
+		"--outer--\r\n"
+
+	value, err := decodeEmail([]byte(message), "message/rfc822")
+	require.NoError(t, err)
+	converted, err := toJSONValue(value)
+	require.NoError(t, err)
+	email := converted.(map[string]interface{})
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Renée", "address": "renee@example.com"}}, email["from"])
+	assert.Len(t, email["to"], 2)
+	assert.Equal(t, "Rapport mensuel", email["subject"])
+	assert.Equal(t, "2026-10-05T12:30:00Z", email["date"])
+	assert.Equal(t, "Voici le résumé.", email["text"])
+	assert.Equal(t, "<p>Voici</p>", email["html"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"filename": "report.pdf", "contentType": "application/pdf", "size": float64(5)},
+	}, email["attachments"])
+
+	_, err = decodeEmail([]byte("not an email"), "")
+	assert.Error(t, err)
+}
+
+func TestWithResponseDecoder_EndpointDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "*/*", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("alpha=1;beta=2"))
+	}))
+	defer server.Close()
+
+	pairs := ResponseDecoderFunc(func(body []byte, contentType string) (interface{}, error) {
+		values := map[string]int{}
+		for _, pair := range strings.Split(string(body), ";") {
+			key, value, _ := strings.Cut(pair, "=")
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = number
+		}
+		return values, nil
+	})
+	endpoint := EndpointConfig{
+		ID: "stats", Name: "Stats", Method: http.MethodGet, Path: "/stats",
+		Parameters: []ParameterConfig{},
+		Response:   ResponseConfig{Type: ResponseTypeJSON, Decoder: "test_pairs", Transform: ".alpha + .beta"},
+	}
+	config := &Config{Services: map[string]*ServiceConfig{
+		"svc": {ServiceKey: "svc", Name: "svc", BaseURL: server.URL, Auth: AuthConfig{Type: AuthTypeNone}, Endpoints: []EndpointConfig{endpoint}},
+	}}
+
+	f := New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()), WithResponseDecoder("test_pairs", pairs))
+	require.NoError(t, endpoint.Validate())
+
+	result, err := findTool(t, f.RegisterTools(), "svc_stats").Handler(withTenant("tenant-a", map[string]interface{}{}))
+	require.NoError(t, err)
+	var total float64
+	require.NoError(t, json.Unmarshal([]byte(result), &total))
+	assert.Equal(t, float64(3), total)
+
+	// Decoders belong to the instance that added them
+	other := New(WithConfig(config), WithLogger(mlogger.NewMemoryLogger()))
+	assert.Empty(t, other.RegisterTools())
+}
+
+func TestResponseConfig_ValidateDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ResponseConfig
+		wantErr string
+	}{
+		{"valid", ResponseConfig{Type: ResponseTypeJSON, Decoder: "csv"}, ""},
+		{"text response", ResponseConfig{Type: ResponseTypeText, Decoder: "csv"}, "requires a json response"},
+		{"paginated", ResponseConfig{Type: ResponseTypeJSON, Decoder: "ndjson", Paginated: true,
+			PaginationConfig: &PaginationConfig{NextPageTokenPath: "next", DataPath: "items", PageSize: 10}}, "cannot be paginated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
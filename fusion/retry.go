@@ -41,6 +41,8 @@ func drainAndClose(resp *http.Response) {
 type RetryExecutor struct {
 	config *RetryConfig
 	logger global.Logger
+	quota  *serviceQuota // Updated from every response, if set
+	soap   bool          // SOAP endpoint: faults reported with a 5xx status are not retried
 }
 
 // NewRetryExecutor creates a new retry executor
@@ -59,7 +61,7 @@ func NewRetryExecutor(config *RetryConfig, logger global.Logger) *RetryExecutor
 func (r *RetryExecutor) Execute(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
 	if !r.config.Enabled {
 		// No retry, execute once
-		resp, err := client.Do(req)
+		resp, err := doTracedRequest(client, req, 1)
 		if err != nil {
 			err = r.wrapNetworkError(err, req)
 		}
@@ -95,7 +97,10 @@ func (r *RetryExecutor) Execute(ctx context.Context, client *http.Client, req *h
 		}
 
 		// Execute the request
-		resp, err := client.Do(clonedReq)
+		resp, err := doTracedRequest(client, clonedReq, attempt+1)
+		if r.quota != nil {
+			r.quota.update(resp, time.Now())
+		}
 
 		// Wrap network errors in NetworkError type
 		if err != nil {
@@ -129,7 +134,7 @@ func (r *RetryExecutor) Execute(ctx context.Context, client *http.Client, req *h
 
 		// Don't wait after the last attempt
 		if attempt < r.config.MaxAttempts-1 {
-			delay := r.calculateDelay(attempt)
+			delay := r.retryDelay(attempt, err, resp)
 			if r.logger != nil {
 				r.logger.Debugf("Waiting %v before retry attempt %d/%d",
 					delay, attempt+2, r.config.MaxAttempts)
@@ -180,6 +185,8 @@ func (r *RetryExecutor) shouldRetry(err error, resp *http.Response, attempt int)
 	// Check HTTP status codes
 	if resp != nil {
 		switch {
+		case resp.StatusCode >= 500 && r.soap && isSOAPFaultResponse(resp): // SOAP 1.1 faults use 500
+			return false, fmt.Sprintf("SOAP fault: HTTP %d", resp.StatusCode)
 		case resp.StatusCode >= 500: // Server errors
 			return true, fmt.Sprintf("server error: HTTP %d", resp.StatusCode)
 		case resp.StatusCode == 429: // Rate limited
@@ -264,6 +271,29 @@ func (r *RetryExecutor) isRetryableError(errorType string) bool {
 	return false
 }
 
+// retryDelay returns the delay before the next retry attempt. A wait requested
+// by the server, through Retry-After or an exhausted rate limit quota, takes
+// precedence over the configured strategy and is capped by MaxDelay.
+func (r *RetryExecutor) retryDelay(attempt int, err error, resp *http.Response) time.Duration {
+	wait, ok := parseRetryAfter(resp, time.Now())
+	if !ok {
+		if netErr, isNetErr := AsNetworkError(err); isNetErr && netErr.RetryAfter != nil {
+			wait, ok = *netErr.RetryAfter, true
+		}
+	}
+	if !ok {
+		return r.calculateDelay(attempt)
+	}
+
+	if r.config.MaxDelay > 0 && wait > r.config.MaxDelay {
+		if r.logger != nil {
+			r.logger.Debugf("Server requested a %v wait, capped at maxDelay %v", wait, r.config.MaxDelay)
+		}
+		wait = r.config.MaxDelay
+	}
+	return wait
+}
+
 // calculateDelay calculates the delay before the next retry attempt
 func (r *RetryExecutor) calculateDelay(attempt int) time.Duration {
 	var delay time.Duration
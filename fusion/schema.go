@@ -0,0 +1,248 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Parameter schemas are JSON Schema documents (draft 2020-12 unless "$schema"
+// names another draft) that describe the value of a single tool parameter.
+// They are emitted unchanged in the tool's input schema and enforced before any
+// upstream request is made. References ($ref) may point into the schema
+// itself, e.g. to "#/$defs/user"; external references are never loaded.
+
+// schemaResourceURL identifies a parameter schema while it is compiled
+const schemaResourceURL = "mem:///parameter-schema.json"
+
+// compiledSchemas caches compiled parameter schemas by their canonical JSON
+var compiledSchemas sync.Map // string -> *jsonschema.Schema
+
+// schemaMessages renders validation error messages
+var schemaMessages = message.NewPrinter(language.English)
+
+// CheckSchema reports whether a parameter schema is well formed: it is valid
+// against its draft's meta-schema, its references resolve and its patterns
+// compile.
+func CheckSchema(schema map[string]interface{}) error {
+	_, err := compileSchema(schema)
+	return err
+}
+
+// compileSchema compiles a parameter schema, reusing an earlier compilation
+// of an identical schema
+func compileSchema(schema map[string]interface{}) (*jsonschema.Schema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	if cached, ok := compiledSchemas.Load(string(data)); ok {
+		return cached.(*jsonschema.Schema), nil
+	}
+
+	// Decode a private copy with json.Number values, as the compiler expects
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := compiler.AddResource(schemaResourceURL, doc); err != nil {
+		return nil, err
+	}
+	compiled, err := compiler.Compile(schemaResourceURL)
+	if err != nil {
+		return nil, schemaCompileError(err)
+	}
+
+	compiledSchemas.Store(string(data), compiled)
+	return compiled, nil
+}
+
+// schemaCompileError shortens compiler errors to the reason the schema is invalid
+func schemaCompileError(err error) error {
+	var schemaErr *jsonschema.SchemaValidationError
+	var loadErr *jsonschema.LoadURLError
+	switch {
+	case errors.As(err, &schemaErr):
+		var validationErr *jsonschema.ValidationError
+		if errors.As(schemaErr.Err, &validationErr) {
+			// Report the first meta-schema failure, however deeply nested
+			for len(validationErr.Causes) > 0 {
+				validationErr = validationErr.Causes[0]
+			}
+			location := "/" + strings.Join(validationErr.InstanceLocation, "/")
+			return fmt.Errorf("at %s: %s", location, validationErr.ErrorKind.LocalizedString(schemaMessages))
+		}
+	case errors.As(err, &loadErr):
+		return fmt.Errorf("external reference %s is not supported", loadErr.URL)
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), schemaResourceURL, "schema"))
+}
+
+// ValidateSchemaValue validates a value against a parameter schema. The name
+// identifies the value in errors; nested values are reported by their path,
+// e.g. "attendees[0].email".
+func ValidateSchemaValue(schema map[string]interface{}, name string, value interface{}) error {
+	return validateSchemaValue(schema, name, value)
+}
+
+func validateSchemaValue(schema map[string]interface{}, name string, value interface{}) error {
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return NewValidationError(name, value, "schema", "invalid parameter schema: "+err.Error())
+	}
+
+	err = compiled.Validate(value)
+	if err == nil {
+		return nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return NewValidationError(name, value, "schema", err.Error())
+	}
+
+	// Report the first specific failure by the path of the failing value
+	leaf := schemaErrorLeaf(validationErr)
+	path, failing := schemaValuePath(name, value, leaf.InstanceLocation)
+	keywords := leaf.ErrorKind.KeywordPath()
+	rule := "schema"
+	if len(keywords) > 0 {
+		rule = keywords[0]
+	}
+
+	switch k := leaf.ErrorKind.(type) {
+	case *kind.Required:
+		return NewValidationError(path+"."+k.Missing[0], nil, rule, "property is required")
+	case *kind.AdditionalProperties:
+		property := k.Properties[0]
+		if object, ok := failing.(map[string]interface{}); ok {
+			failing = object[property]
+		}
+		return NewValidationError(path+"."+property, failing, rule, "property is not allowed")
+	}
+	return NewValidationError(path, failing, rule, leaf.ErrorKind.LocalizedString(schemaMessages))
+}
+
+// schemaErrorLeaf descends through the errors that only group others (the
+// schema itself, references and allOf) to the first specific failure. The
+// failures of anyOf, oneOf and not are reported as a whole.
+func schemaErrorLeaf(err *jsonschema.ValidationError) *jsonschema.ValidationError {
+	for len(err.Causes) > 0 {
+		switch err.ErrorKind.(type) {
+		case *kind.Schema, *kind.Group, *kind.Reference, *kind.AllOf:
+		default:
+			return err
+		}
+		next := err.Causes[0]
+		for _, cause := range err.Causes[1:] {
+			if strings.Join(cause.InstanceLocation, "/") < strings.Join(next.InstanceLocation, "/") {
+				next = cause
+			}
+		}
+		err = next
+	}
+	return err
+}
+
+// schemaValuePath converts the JSON pointer tokens of a failing value into a
+// path such as "attendees[1].email" and returns the value found there
+func schemaValuePath(name string, value interface{}, location []string) (string, interface{}) {
+	path := name
+	for _, token := range location {
+		switch v := value.(type) {
+		case []interface{}:
+			path += "[" + token + "]"
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(v) {
+				value = v[i]
+			} else {
+				value = nil
+			}
+		case map[string]interface{}:
+			path += "." + token
+			value = v[token]
+		default:
+			path += "." + token
+			value = nil
+		}
+	}
+	return path, value
+}
+
+// checkFormat validates a string against a JSON Schema format. Unknown
+// formats are annotations only and always pass, as the specification requires.
+func checkFormat(format, value string) error {
+	if format == "url" {
+		format = "uri"
+	}
+	compiled, err := compileSchema(map[string]interface{}{"format": format})
+	if err != nil {
+		return err
+	}
+	err = compiled.Validate(value)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return errors.New(schemaErrorLeaf(validationErr).ErrorKind.LocalizedString(schemaMessages))
+	}
+	return err
+}
+
+// schemaTypeNames returns the type names of a "type" keyword value
+func schemaTypeNames(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			names = append(names, name)
+		}
+		return names, len(names) > 0
+	case []string:
+		return v, len(v) > 0
+	default:
+		return nil, false
+	}
+}
+
+// jsonNumber returns the value of a decoded JSON number. Strings are not numbers.
+func jsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
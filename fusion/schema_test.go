@@ -0,0 +1,330 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// attendeesSchema is an array of typed objects with nested constraints
+const attendeesSchema = `{
+	"type": "array",
+	"minItems": 1,
+	"maxItems": 3,
+	"items": {
+		"type": "object",
+		"required": ["email"],
+		"additionalProperties": false,
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"role": {"enum": ["required", "optional"]},
+			"priority": {"type": "integer", "minimum": 1, "maximum": 5}
+		}
+	}
+}`
+
+func mustSchema(t *testing.T, raw string) map[string]interface{} {
+	t.Helper()
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		t.Fatalf("invalid test schema: %v", err)
+	}
+	return schema
+}
+
+func mustValue(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatalf("invalid test value: %v", err)
+	}
+	return value
+}
+
+func TestCheckSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"nested object", attendeesSchema, false},
+		{"type list", `{"type": ["string", "null"]}`, false},
+		{"combinators", `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, false},
+		{"annotations", `{"type": "string", "title": "Name", "examples": ["a"]}`, false},
+		{"local ref", `{"$defs": {"user": {"type": "string"}}, "$ref": "#/$defs/user"}`, false},
+		{"conditional", `{"type": "object", "if": {"required": ["a"]}, "then": {"required": ["b"]}}`, false},
+		{"unresolved ref", `{"$ref": "#/definitions/user"}`, true},
+		{"external ref", `{"$ref": "https://example.com/user.json"}`, true},
+		{"nested invalid keyword", `{"type": "object", "properties": {"a": {"minLength": -1}}}`, true},
+		{"unknown type", `{"type": "date"}`, true},
+		{"invalid pattern", `{"type": "string", "pattern": "("}`, true},
+		{"minimum not a number", `{"type": "number", "minimum": "1"}`, true},
+		{"empty oneOf", `{"oneOf": []}`, true},
+		{"required not strings", `{"type": "object", "required": [1]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSchema(mustSchema(t, tt.schema))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaValue(t *testing.T) {
+	schema := mustSchema(t, attendeesSchema)
+
+	tests := []struct {
+		name      string
+		value     string
+		wantParam string
+		wantRule  string
+	}{
+		{"valid", `[{"email": "a@example.com", "role": "optional", "priority": 2}]`, "", ""},
+		{"not an array", `{"email": "a@example.com"}`, "attendees", "type"},
+		{"too few items", `[]`, "attendees", "minItems"},
+		{"too many items", `[{"email": "a@x.io"}, {"email": "b@x.io"}, {"email": "c@x.io"}, {"email": "d@x.io"}]`, "attendees", "maxItems"},
+		{"missing required", `[{"email": "a@example.com"}, {"role": "optional"}]`, "attendees[1].email", "required"},
+		{"bad format", `[{"email": "not-an-email"}]`, "attendees[0].email", "format"},
+		{"bad enum", `[{"email": "a@example.com", "role": "owner"}]`, "attendees[0].role", "enum"},
+		{"not an integer", `[{"email": "a@example.com", "priority": 1.5}]`, "attendees[0].priority", "type"},
+		{"above maximum", `[{"email": "a@example.com", "priority": 9}]`, "attendees[0].priority", "maximum"},
+		{"additional property", `[{"email": "a@example.com", "name": "A"}]`, "attendees[0].name", "additionalProperties"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchemaValue(schema, "attendees", mustValue(t, tt.value))
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if validationErr.Parameter != tt.wantParam || validationErr.Rule != tt.wantRule {
+				t.Errorf("got %s/%s, want %s/%s", validationErr.Parameter, validationErr.Rule, tt.wantParam, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaValue_Combinators(t *testing.T) {
+	schema := mustSchema(t, `{"oneOf": [{"type": "string", "format": "date"}, {"type": "integer", "minimum": 0}]}`)
+
+	for _, value := range []interface{}{"2026-01-31", float64(7)} {
+		if err := ValidateSchemaValue(schema, "when", value); err != nil {
+			t.Errorf("value %v: unexpected error: %v", value, err)
+		}
+	}
+	for _, value := range []interface{}{"tomorrow", float64(-1), true} {
+		if err := ValidateSchemaValue(schema, "when", value); err == nil {
+			t.Errorf("value %v: expected an error", value)
+		}
+	}
+
+	not := mustSchema(t, `{"type": "string", "not": {"enum": ["admin"]}}`)
+	if err := ValidateSchemaValue(not, "role", "admin"); err == nil {
+		t.Error("expected not to reject a matching value")
+	}
+}
+
+func TestValidateSchemaValue_Ref(t *testing.T) {
+	schema := mustSchema(t, `{
+		"$defs": {"person": {"type": "object", "required": ["email"], "properties": {"email": {"type": "string", "format": "email"}}}},
+		"type": "object",
+		"properties": {"organizer": {"$ref": "#/$defs/person"}, "attendees": {"type": "array", "items": {"$ref": "#/$defs/person"}}}
+	}`)
+
+	if err := ValidateSchemaValue(schema, "meeting", mustValue(t, `{"organizer": {"email": "a@example.com"}, "attendees": [{"email": "b@example.com"}]}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := ValidateSchemaValue(schema, "meeting", mustValue(t, `{"organizer": {"email": "a@example.com"}, "attendees": [{"email": "b"}]}`))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "meeting.attendees[0].email" || validationErr.Rule != "format" {
+		t.Fatalf("expected a format error for meeting.attendees[0].email, got %v", err)
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		valid  bool
+	}{
+		{"email", "user@example.com", true},
+		{"email", "user", false},
+		{"date-time", "2026-01-31T10:00:00Z", true},
+		{"date-time", "2026-01-31", false},
+		{"date", "2026-02-30", false},
+		{"time", "10:15:00Z", true},
+		{"time", "10:15", false},
+		{"uri", "https://example.com/a", true},
+		{"uri", "/relative", false},
+		{"uri-reference", "/relative", true},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", true},
+		{"uuid", "123e4567", false},
+		{"ipv4", "192.0.2.1", true},
+		{"ipv4", "2001:db8::1", false},
+		{"ipv6", "2001:db8::1", true},
+		{"hostname", "api.example.com", true},
+		{"hostname", "bad_host!", false},
+		{"unknown-format", "anything", true},
+	}
+
+	for _, tt := range tests {
+		err := checkFormat(tt.format, tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("checkFormat(%q, %q) error = %v, want valid %v", tt.format, tt.value, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateParameters_Schema(t *testing.T) {
+	validator := NewValidator(nil)
+	params := []ParameterConfig{{
+		Name:     "attendees",
+		Type:     ParameterTypeArray,
+		Required: true,
+		Location: ParameterLocationBody,
+		Schema:   mustSchema(t, attendeesSchema),
+	}}
+
+	args := map[string]interface{}{"attendees": mustValue(t, `[{"email": "a@example.com"}]`)}
+	if err := validator.ValidateParameters(params, args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args = map[string]interface{}{"attendees": mustValue(t, `[{"email": "a@example.com"}, {"email": "b"}]`)}
+	err := validator.ValidateParameters(params, args)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "attendees[1].email" {
+		t.Fatalf("expected an error for attendees[1].email, got %v", err)
+	}
+}
+
+func TestValidateParameters_RangeAndFormat(t *testing.T) {
+	minimum, maximum := 1.0, 100.0
+	validator := NewValidator(nil)
+	params := []ParameterConfig{
+		{
+			Name:       "top",
+			Type:       ParameterTypeNumber,
+			Location:   ParameterLocationQuery,
+			Validation: &ValidationConfig{Minimum: &minimum, Maximum: &maximum},
+		},
+		{
+			Name:       "start",
+			Type:       ParameterTypeString,
+			Location:   ParameterLocationQuery,
+			Validation: &ValidationConfig{Format: "date-time"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr bool
+	}{
+		{"in range", map[string]interface{}{"top": float64(10)}, false},
+		{"numeric string in range", map[string]interface{}{"top": "50"}, false},
+		{"below minimum", map[string]interface{}{"top": float64(0)}, true},
+		{"above maximum", map[string]interface{}{"top": "101"}, true},
+		{"valid date-time", map[string]interface{}{"start": "2026-01-31T10:00:00Z"}, false},
+		{"invalid date-time", map[string]interface{}{"start": "yesterday"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateParameters(params, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParameterConfig_SchemaValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   ParameterConfig
+		wantErr bool
+	}{
+		{
+			name:  "matching type",
+			param: ParameterConfig{Name: "count", Type: ParameterTypeNumber, Location: ParameterLocationQuery, Schema: map[string]interface{}{"type": "integer"}},
+		},
+		{
+			name:    "mismatched type",
+			param:   ParameterConfig{Name: "count", Type: ParameterTypeString, Location: ParameterLocationQuery, Schema: map[string]interface{}{"type": "array"}},
+			wantErr: true,
+		},
+		{
+			name:    "unresolved reference",
+			param:   ParameterConfig{Name: "user", Type: ParameterTypeObject, Location: ParameterLocationBody, Schema: map[string]interface{}{"$ref": "#/user"}},
+			wantErr: true,
+		},
+		{
+			name: "minimum above maximum",
+			param: ParameterConfig{Name: "count", Type: ParameterTypeNumber, Location: ParameterLocationQuery,
+				Validation: &ValidationConfig{Minimum: floatPtr(10), Maximum: floatPtr(1)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.param.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertToMCPParameters_Schema(t *testing.T) {
+	mapper := NewMapper(nil)
+	schema := mapper.ConvertToMCPParameters([]ParameterConfig{
+		{
+			Name:        "attendees",
+			Description: "Meeting attendees",
+			Type:        ParameterTypeArray,
+			Schema:      mustSchema(t, attendeesSchema),
+		},
+		{
+			Name:       "top",
+			Type:       ParameterTypeNumber,
+			Validation: &ValidationConfig{Minimum: floatPtr(1), Maximum: floatPtr(100)},
+		},
+	})
+
+	properties := schema["properties"].(map[string]interface{})
+	attendees := properties["attendees"].(map[string]interface{})
+	if attendees["minItems"] != float64(1) || attendees["items"] == nil {
+		t.Errorf("schema keywords not emitted: %v", attendees)
+	}
+	if attendees["description"] != "Meeting attendees" {
+		t.Errorf("description = %v", attendees["description"])
+	}
+
+	top := properties["top"].(map[string]interface{})
+	if top["minimum"] != float64(1) || top["maximum"] != float64(100) {
+		t.Errorf("range not emitted: %v", top)
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,206 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// SOAP versions and their envelope namespaces
+const (
+	soapVersion11 = "1.1"
+	soapVersion12 = "1.2"
+
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// validateSOAP checks that the endpoint can be sent as a SOAP operation
+func (e *EndpointConfig) validateSOAP() error {
+	if e.GraphQL != nil {
+		return fmt.Errorf("soap and graphql cannot be combined")
+	}
+	if e.Method != http.MethodPost {
+		return fmt.Errorf("soap endpoints must use POST, got %s", e.Method)
+	}
+
+	switch e.SOAP.Version {
+	case "", soapVersion11, soapVersion12:
+	default:
+		return fmt.Errorf("invalid version: %s", e.SOAP.Version)
+	}
+	if strings.ContainsAny(e.SOAP.Action, "\"\r\n") {
+		return fmt.Errorf("action cannot contain quotes or line breaks")
+	}
+
+	if strings.TrimSpace(e.SOAP.Body) == "" {
+		return fmt.Errorf("body is required")
+	}
+	if _, err := parseBodyTemplate("body", e.SOAP.Body); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
+	if _, err := parseBodyTemplate("header", e.SOAP.Header); err != nil {
+		return fmt.Errorf("header: %w", err)
+	}
+
+	if e.RequestBody != nil {
+		return fmt.Errorf("requestBody cannot be used with soap")
+	}
+	for _, param := range e.Parameters {
+		if param.Location == ParameterLocationFile || param.Location == ParameterLocationFilePath {
+			return fmt.Errorf("parameter %s: %s parameters cannot be used with soap", param.Name, param.Location)
+		}
+	}
+
+	if e.Response.Type != ResponseTypeXML {
+		return fmt.Errorf("soap endpoints must use an xml response, got %s", e.Response.Type)
+	}
+	return nil
+}
+
+// buildSOAPEnvelope renders the header and body templates with the body
+// parameters and wraps them in a SOAP envelope. It returns the envelope and
+// the Content-Type to send it with.
+func (h *HTTPHandler) buildSOAPEnvelope(body map[string]interface{}) (string, string, error) {
+	soap := h.endpoint.SOAP
+
+	content, err := renderXMLTemplate("body", soap.Body, h.endpoint.Parameters, body)
+	if err != nil {
+		return "", "", err
+	}
+	header := ""
+	if soap.Header != "" {
+		if header, err = renderXMLTemplate("header", soap.Header, h.endpoint.Parameters, body); err != nil {
+			return "", "", err
+		}
+	}
+
+	namespace, contentType := soap11Namespace, "text/xml; charset=utf-8"
+	if soap.Version == soapVersion12 {
+		namespace, contentType = soap12Namespace, "application/soap+xml; charset=utf-8"
+		if soap.Action != "" {
+			contentType += `; action="` + soap.Action + `"`
+		}
+	}
+
+	var envelope strings.Builder
+	envelope.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	envelope.WriteString(`<soap:Envelope xmlns:soap="` + namespace + `">`)
+	if strings.TrimSpace(header) != "" {
+		envelope.WriteString("<soap:Header>" + header + "</soap:Header>")
+	}
+	envelope.WriteString("<soap:Body>" + content + "</soap:Body>")
+	envelope.WriteString("</soap:Envelope>")
+
+	return envelope.String(), contentType, nil
+}
+
+// soapBody unwraps a converted SOAP response, returning the content of its Body
+// or a SOAPFaultError when the Body holds a fault
+func (h *HTTPHandler) soapBody(document interface{}, statusCode int, correlationID string) (interface{}, error) {
+	root, _ := document.(map[string]interface{})
+	envelope, ok := root["Envelope"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response is not a SOAP envelope")
+	}
+
+	body, ok := envelope["Body"].(map[string]interface{})
+	if !ok {
+		// An empty Body is converted to an empty string
+		return map[string]interface{}{}, nil
+	}
+
+	if fault, ok := body["Fault"]; ok {
+		return nil, h.soapFault(fault, statusCode, correlationID)
+	}
+	return body, nil
+}
+
+// soapFault converts a SOAP 1.1 or 1.2 Fault element into a SOAPFaultError
+func (h *HTTPHandler) soapFault(fault interface{}, statusCode int, correlationID string) *SOAPFaultError {
+	faultErr := &SOAPFaultError{
+		Service:       h.service.Name,
+		Endpoint:      h.endpoint.ID,
+		StatusCode:    statusCode,
+		CorrelationID: correlationID,
+	}
+
+	fields, _ := fault.(map[string]interface{})
+	if _, ok := fields["Code"]; ok {
+		// SOAP 1.2: Code/Value, Reason/Text and Detail
+		code, _ := fields["Code"].(map[string]interface{})
+		reason, _ := fields["Reason"].(map[string]interface{})
+		faultErr.Code = xmlText(code["Value"])
+		faultErr.Message = xmlText(reason["Text"])
+		faultErr.Detail = soapFaultDetail(fields["Detail"])
+	} else {
+		faultErr.Code = xmlText(fields["faultcode"])
+		faultErr.Message = xmlText(fields["faultstring"])
+		faultErr.Detail = soapFaultDetail(fields["detail"])
+	}
+	return faultErr
+}
+
+// soapFaultDetail renders the detail of a fault as compact JSON
+func soapFaultDetail(detail interface{}) string {
+	if detail == nil || detail == "" {
+		return ""
+	}
+	if text, ok := detail.(string); ok {
+		return text
+	}
+	encoded, err := json.Marshal(detail)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// soapFaultResponse returns the fault carried by a failed HTTP response, or nil
+// when the body is not a SOAP fault. SOAP services report faults with status 500.
+func (h *HTTPHandler) soapFaultResponse(body []byte, statusCode int, correlationID string) error {
+	document, err := decodeXML(body)
+	if err != nil {
+		return nil
+	}
+	if _, err := h.soapBody(document, statusCode, correlationID); err != nil {
+		if faultErr, ok := AsSOAPFaultError(err); ok {
+			return faultErr
+		}
+	}
+	return nil
+}
+
+// isSOAPFaultResponse reports whether the body of resp is a SOAP envelope
+// holding a fault. The body is read and replaced so it can still be consumed.
+func isSOAPFaultResponse(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, global.MaxResponseBodyReadBytes))
+	drainAndClose(resp)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+
+	document, err := decodeXML(data)
+	if err != nil {
+		return false
+	}
+	root, _ := document.(map[string]interface{})
+	envelope, _ := root["Envelope"].(map[string]interface{})
+	body, _ := envelope["Body"].(map[string]interface{})
+	_, ok := body["Fault"]
+	return ok
+}
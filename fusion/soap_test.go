@@ -0,0 +1,246 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// soapRequest is a request received by the SOAP test server
+type soapRequest struct {
+	contentType string
+	action      string
+	envelope    string
+}
+
+// soapServer records each request and replies with the given status and envelope
+func soapServer(t *testing.T, status int, response string, received *soapRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		data, _ := io.ReadAll(r.Body)
+		*received = soapRequest{
+			contentType: r.Header.Get("Content-Type"),
+			action:      r.Header.Get("SOAPAction"),
+			envelope:    string(data),
+		}
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+}
+
+// soapEndpoint returns a SOAP endpoint calling the GetQuote operation
+func soapEndpoint(version string) EndpointConfig {
+	return EndpointConfig{
+		ID: "quote", Name: "Quote", Method: http.MethodPost, Path: "/stock",
+		SOAP: &SOAPConfig{
+			Version: version,
+			Action:  "urn:GetQuote",
+			Header:  `{{if .token}}<auth:Token xmlns:auth="urn:auth">{{.token}}</auth:Token>{{end}}`,
+			Body:    `<m:GetQuote xmlns:m="urn:stock"><m:Symbol>{{.symbol}}</m:Symbol></m:GetQuote>`,
+		},
+		Parameters: []ParameterConfig{
+			{Name: "symbol", Type: ParameterTypeString, Required: true, Location: ParameterLocationBody},
+			{Name: "token", Type: ParameterTypeString, Location: ParameterLocationBody},
+		},
+		Response: ResponseConfig{Type: ResponseTypeXML, Transform: ".GetQuoteResponse.Price"},
+	}
+}
+
+func TestEndpointConfig_ValidateSOAP(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *EndpointConfig)
+		wantErr string
+	}{
+		{"valid", func(e *EndpointConfig) {}, ""},
+		{"GET", func(e *EndpointConfig) { e.Method = http.MethodGet }, "must use POST"},
+		{"version", func(e *EndpointConfig) { e.SOAP.Version = "2.0" }, "invalid version"},
+		{"quoted action", func(e *EndpointConfig) { e.SOAP.Action = `"urn:x"` }, "cannot contain quotes"},
+		{"missing body", func(e *EndpointConfig) { e.SOAP.Body = "" }, "body is required"},
+		{"invalid header", func(e *EndpointConfig) { e.SOAP.Header = "{{if .a}}" }, "header: invalid template"},
+		{"json response", func(e *EndpointConfig) { e.Response.Type = ResponseTypeJSON }, "xml response"},
+		{"request body", func(e *EndpointConfig) {
+			e.RequestBody = &RequestBodyConfig{Format: RequestBodyFormatForm}
+		}, "requestBody cannot be used"},
+		{"file parameter", func(e *EndpointConfig) {
+			e.Parameters = append(e.Parameters, ParameterConfig{Name: "f", Type: ParameterTypeString, Location: ParameterLocationFile})
+		}, "cannot be used with soap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := soapEndpoint("")
+			tt.modify(&endpoint)
+			err := endpoint.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSOAP_Envelopes(t *testing.T) {
+	tests := []struct {
+		version         string
+		namespace       string
+		wantContentType string
+		wantAction      string
+	}{
+		{"", soap11Namespace, "text/xml; charset=utf-8", `"urn:GetQuote"`},
+		{"1.2", soap12Namespace, `application/soap+xml; charset=utf-8; action="urn:GetQuote"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run("version "+tt.version, func(t *testing.T) {
+			response := `<soap:Envelope xmlns:soap="` + tt.namespace + `"><soap:Body>` +
+				`<m:GetQuoteResponse xmlns:m="urn:stock"><m:Price>182.5</m:Price></m:GetQuoteResponse>` +
+				`</soap:Body></soap:Envelope>`
+			var received soapRequest
+			server := soapServer(t, http.StatusOK, response, &received)
+			defer server.Close()
+
+			f := newBodyTestFusion(t, server.URL, soapEndpoint(tt.version))
+			result, err := findTool(t, f.RegisterTools(), "svc_quote").Handler(
+				withTenant("tenant-a", map[string]interface{}{"symbol": "A&B", "token": "secret"}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != `"182.5"` {
+				t.Errorf("result = %s, want the Body content transformed", result)
+			}
+
+			if received.contentType != tt.wantContentType || received.action != tt.wantAction {
+				t.Errorf("headers = %q / %q, want %q / %q", received.contentType, received.action, tt.wantContentType, tt.wantAction)
+			}
+			for _, want := range []string{
+				`<soap:Envelope xmlns:soap="` + tt.namespace + `">`,
+				`<soap:Header><auth:Token xmlns:auth="urn:auth">secret</auth:Token></soap:Header>`,
+				`<m:Symbol>A&amp;B</m:Symbol>`,
+			} {
+				if !strings.Contains(received.envelope, want) {
+					t.Errorf("envelope %s does not contain %s", received.envelope, want)
+				}
+			}
+		})
+	}
+
+	// The header is omitted when its template renders nothing
+	var received soapRequest
+	server := soapServer(t, http.StatusOK, `<Envelope><Body/></Envelope>`, &received)
+	defer server.Close()
+	f := newBodyTestFusion(t, server.URL, soapEndpoint(""))
+	if _, err := findTool(t, f.RegisterTools(), "svc_quote").Handler(
+		withTenant("tenant-a", map[string]interface{}{"symbol": "X"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(received.envelope, "Header") {
+		t.Errorf("expected no SOAP header, got %s", received.envelope)
+	}
+}
+
+func TestSOAP_Faults(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		response    string
+		wantCode    string
+		wantMessage string
+		wantDetail  string
+	}{
+		{
+			"SOAP 1.1 fault with status 500", http.StatusInternalServerError,
+			`<soap:Envelope xmlns:soap="` + soap11Namespace + `"><soap:Body><soap:Fault>` +
+				`<faultcode>soap:Client</faultcode><faultstring>Unknown symbol</faultstring>` +
+				`<detail><symbol>ZZZ</symbol></detail></soap:Fault></soap:Body></soap:Envelope>`,
+			"soap:Client", "Unknown symbol", `{"symbol":"ZZZ"}`,
+		},
+		{
+			"SOAP 1.2 fault with status 200", http.StatusOK,
+			`<env:Envelope xmlns:env="` + soap12Namespace + `"><env:Body><env:Fault>` +
+				`<env:Code><env:Value>env:Receiver</env:Value></env:Code>` +
+				`<env:Reason><env:Text xml:lang="en">Quote service unavailable</env:Text></env:Reason>` +
+				`</env:Fault></env:Body></env:Envelope>`,
+			"env:Receiver", "Quote service unavailable", "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received soapRequest
+			server := soapServer(t, tt.status, tt.response, &received)
+			defer server.Close()
+
+			f := newBodyTestFusion(t, server.URL, soapEndpoint(""))
+			_, err := findTool(t, f.RegisterTools(), "svc_quote").Handler(
+				withTenant("tenant-a", map[string]interface{}{"symbol": "ZZZ"}))
+			faultErr, ok := AsSOAPFaultError(err)
+			if !ok {
+				t.Fatalf("expected a SOAPFaultError, got %v", err)
+			}
+			if faultErr.StatusCode != tt.status || faultErr.Code != tt.wantCode ||
+				faultErr.Message != tt.wantMessage || faultErr.Detail != tt.wantDetail {
+				t.Errorf("fault = %+v", faultErr)
+			}
+		})
+	}
+}
+
+func TestSOAP_FaultsAreNotRetried(t *testing.T) {
+	fault := `<soap:Envelope xmlns:soap="` + soap11Namespace + `"><soap:Body><soap:Fault>` +
+		`<faultcode>soap:Client</faultcode><faultstring>Unknown symbol</faultstring>` +
+		`</soap:Fault></soap:Body></soap:Envelope>`
+	tests := []struct {
+		name     string
+		response string
+		want     int
+	}{
+		{"fault", fault, 1},
+		{"server error", "Internal Server Error", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			endpoint := soapEndpoint("")
+			endpoint.Retry = &RetryConfig{Enabled: true, MaxAttempts: 3, Strategy: RetryStrategyFixed,
+				BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+			f := newBodyTestFusion(t, server.URL, endpoint)
+			_, err := findTool(t, f.RegisterTools(), "svc_quote").Handler(
+				withTenant("tenant-a", map[string]interface{}{"symbol": "ZZZ"}))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if _, ok := AsSOAPFaultError(err); ok != (tt.want == 1) {
+				t.Errorf("unexpected error %v", err)
+			}
+			if requests != tt.want {
+				t.Errorf("expected %d requests, got %d", tt.want, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
+)
+
+// doTracedRequest sends one attempt of an upstream request in a client span
+// and propagates the trace to the upstream API in the traceparent header.
+// Outside a traced tool call it is equivalent to client.Do.
+func doTracedRequest(client *http.Client, req *http.Request, attempt int) (*http.Response, error) {
+	ctx, span := tracing.Start(req.Context(), "HTTP "+req.Method, tracing.SpanKindClient)
+	if span == nil {
+		return client.Do(req)
+	}
+	defer span.End()
+
+	// The query is left out because it may carry credentials
+	target := *req.URL
+	target.RawQuery = ""
+	target.User = nil
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("url.full", target.String())
+	span.SetAttribute("server.address", req.URL.Hostname())
+	span.SetAttribute("mcpfusion.attempt", attempt)
+	if service, ok := ctx.Value(global.ServiceNameKey).(string); ok {
+		span.SetAttribute("mcpfusion.service", service)
+	}
+	if tenantContext, ok := ctx.Value(global.TenantContextKey).(*TenantContext); ok {
+		span.SetAttribute("mcpfusion.tenant", tenantContext.ShortHash())
+	}
+
+	tracing.Inject(ctx, req.Header)
+	resp, err := client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return resp, err
+	}
+
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		span.SetStatus(tracing.StatusError, "HTTP "+strconv.Itoa(resp.StatusCode))
+	}
+	return resp, err
+}
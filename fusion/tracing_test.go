@@ -0,0 +1,77 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
+)
+
+// TestRetryExecutorTracing ensures every attempt gets its own client span and
+// carries it to the upstream API in the traceparent header
+func TestRetryExecutorTracing(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(tracing.TraceParentHeader))
+		if len(received) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter := tracing.NewMemoryExporter()
+	tracer := tracing.New(tracing.WithExporter(exporter))
+	ctx, root := tracer.Start(context.Background(), "tools/call svc_get", tracing.SpanKindServer)
+	ctx = context.WithValue(ctx, global.ServiceNameKey, "svc")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/items?key=secret", nil)
+	executor := NewRetryExecutor(&RetryConfig{
+		Enabled:       true,
+		MaxAttempts:   3,
+		Strategy:      RetryStrategyFixed,
+		BaseDelay:     10 * time.Millisecond,
+		BackoffFactor: 2.0,
+	}, nil)
+	resp, err := executor.Execute(ctx, server.Client(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	root.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	spans := exporter.Spans()
+	if len(spans) != 3 || len(received) != 2 {
+		t.Fatalf("expected 3 spans and 2 requests, got %d and %d", len(spans), len(received))
+	}
+	for i, span := range spans[:2] {
+		if span.Name != "HTTP GET" || span.Parent != root.SpanContext().SpanID {
+			t.Errorf("attempt %d: unexpected span %+v", i+1, span)
+		}
+		if span.Attribute("mcpfusion.attempt") != i+1 || span.Attribute("mcpfusion.service") != "svc" {
+			t.Errorf("attempt %d: unexpected attributes %+v", i+1, span.Attributes)
+		}
+		if span.Attribute("url.full") != server.URL+"/items" {
+			t.Errorf("attempt %d: the query must not be recorded, got %v", i+1, span.Attribute("url.full"))
+		}
+		if received[i] != span.SpanContext.TraceParent() {
+			t.Errorf("attempt %d: expected traceparent %q, got %q", i+1, span.SpanContext.TraceParent(), received[i])
+		}
+	}
+	if spans[0].Status != tracing.StatusError || spans[0].Attribute("http.response.status_code") != http.StatusServiceUnavailable {
+		t.Errorf("expected the first attempt to fail, got %+v", spans[0])
+	}
+}
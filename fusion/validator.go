@@ -7,6 +7,7 @@ package fusion
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -74,6 +75,16 @@ func (v *Validator) ValidateParameters(params []ParameterConfig, args map[string
 				return err
 			}
 		}
+
+		// Validate the value, including nested values, against the parameter schema
+		if param.Schema != nil {
+			if err := validateSchemaValue(param.Schema, param.Name, args[param.Name]); err != nil {
+				if v.logger != nil {
+					v.logger.Errorf("Schema validation failed for parameter %s: %v", param.Name, err)
+				}
+				return err
+			}
+		}
 	}
 
 	if v.logger != nil {
@@ -108,6 +119,22 @@ func (v *Validator) validateType(param ParameterConfig, value interface{}) error
 				"expected number type")
 		}
 
+	case "integer":
+		switch typed := value.(type) {
+		case int, int32, int64:
+			// Valid integer types
+		case float32, float64, string:
+			// Accept whole numbers, including numeric strings
+			n, ok := numericValue(typed)
+			if !ok || n != math.Trunc(n) {
+				return NewValidationError(param.Name, value, "type",
+					"expected integer type")
+			}
+		default:
+			return NewValidationError(param.Name, value, "type",
+				"expected integer type")
+		}
+
 	case "boolean":
 		switch value.(type) {
 		case bool:
@@ -219,11 +246,41 @@ func (v *Validator) applyValidationRules(param ParameterConfig, value interface{
 		}
 	}
 
-	// Numeric range validation not supported yet in ValidationConfig
+	// Numeric range validation; numeric strings are accepted as for the type check
+	if validation.Minimum != nil || validation.Maximum != nil {
+		if n, ok := numericValue(value); ok {
+			if validation.Minimum != nil && n < *validation.Minimum {
+				return NewValidationError(param.Name, value, "minimum",
+					fmt.Sprintf("value must be at least %v", *validation.Minimum))
+			}
+			if validation.Maximum != nil && n > *validation.Maximum {
+				return NewValidationError(param.Name, value, "maximum",
+					fmt.Sprintf("value must be at most %v", *validation.Maximum))
+			}
+		}
+	}
+
+	// Format validation for strings
+	if validation.Format != "" {
+		if str, ok := value.(string); ok {
+			if err := checkFormat(validation.Format, str); err != nil {
+				return NewValidationError(param.Name, str, "format", err.Error())
+			}
+		}
+	}
 
 	return nil
 }
 
+// numericValue returns the value of a number or numeric string
+func numericValue(value interface{}) (float64, bool) {
+	if str, ok := value.(string); ok {
+		n, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		return n, err == nil
+	}
+	return jsonNumber(value)
+}
+
 // tryConvertISOToYYYYMMDD attempts to convert an ISO date string to YYYYMMDD format
 // Returns the converted date string if successful, empty string if conversion fails
 func (v *Validator) tryConvertISOToYYYYMMDD(isoDate string) string {
@@ -346,6 +403,12 @@ func (v *Validator) validateParameterConfig(param ParameterConfig) error {
 		return fmt.Errorf("invalid parameter location for %s: %s", param.Name, param.Location)
 	}
 
+	if param.Schema != nil {
+		if err := param.validateSchema(); err != nil {
+			return fmt.Errorf("parameter %s: %w", param.Name, err)
+		}
+	}
+
 	// Validate transformation if present
 	if param.Transform != nil {
 		if param.Transform.TargetName == "" {
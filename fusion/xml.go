@@ -0,0 +1,137 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// maxXMLDepth bounds the element nesting accepted when converting XML to JSON
+const maxXMLDepth = 256
+
+// decodeXML converts an XML document into the generic JSON representation used
+// for transforms. The root element becomes a single-key object. Each element is
+// converted as follows:
+//   - an element with only text becomes a string
+//   - attributes become "@name" members and child elements members named after
+//     the element; repeated children become arrays
+//   - text mixed with attributes or children is kept in "#text"
+//
+// Namespace prefixes are dropped and values are kept as strings.
+func decodeXML(data []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("XML document has no root element")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start, 1)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+// decodeXMLElement converts the element opened by start, consuming tokens up to
+// and including its end element
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement, depth int) (interface{}, error) {
+	if depth > maxXMLDepth {
+		return nil, fmt.Errorf("XML document is nested deeper than %d elements", maxXMLDepth)
+	}
+
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		element["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			// Child values are strings or objects, so an array marks a repeated element
+			name := t.Name.Local
+			switch existing := element[name].(type) {
+			case nil:
+				element[name] = child
+			case []interface{}:
+				element[name] = append(existing, child)
+			default:
+				element[name] = []interface{}{existing, child}
+			}
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				element["#text"] = content
+			}
+			return element, nil
+		}
+	}
+}
+
+// xmlText returns the text of a converted XML element: the string itself, or
+// the "#text" member of an element with attributes or children
+func xmlText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		text, _ := v["#text"].(string)
+		return text
+	case []interface{}:
+		if len(v) > 0 {
+			return xmlText(v[0])
+		}
+	}
+	return ""
+}
+
+// acceptHeader returns the Accept header matching the configured response
+func (h *HTTPHandler) acceptHeader() string {
+	switch {
+	case h.endpoint.SOAP != nil && h.endpoint.SOAP.Version == soapVersion12:
+		return "application/soap+xml, application/xml, text/xml"
+	case h.endpoint.SOAP != nil || h.endpoint.Response.Type == ResponseTypeXML:
+		return "application/xml, text/xml"
+	case h.endpoint.Response.Decoder != "":
+		return "*/*"
+	default:
+		return "application/json"
+	}
+}
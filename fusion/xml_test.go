@@ -0,0 +1,98 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package fusion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{"text element", `<name>Jane</name>`, `{"name":"Jane"}`},
+		{"empty element", `<empty/>`, `{"empty":""}`},
+		{
+			"attributes and children",
+			`<?xml version="1.0"?><order id="7"><item sku="a">Pen</item><total>3.50</total></order>`,
+			`{"order":{"@id":"7","item":{"#text":"Pen","@sku":"a"},"total":"3.50"}}`,
+		},
+		{
+			"repeated children",
+			`<list><item>a</item><item>b</item><item><n>c</n></item></list>`,
+			`{"list":{"item":["a","b",{"n":"c"}]}}`,
+		},
+		{
+			"namespaces",
+			`<ns:root xmlns:ns="urn:x" xmlns="urn:y" ns:kind="k"><ns:value>1</ns:value></ns:root>`,
+			`{"root":{"@kind":"k","value":"1"}}`,
+		},
+		{
+			"latin-1 charset",
+			"<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><city>Montr\xe9al</city>",
+			`{"city":"Montréal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := decodeXML([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, _ := json.Marshal(value)
+			if string(got) != tt.want {
+				t.Errorf("decoded %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "not xml", "<open>", strings.Repeat("<a>", maxXMLDepth+1)} {
+		if _, err := decodeXML([]byte(invalid)); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestXMLResponse(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<rates base="EUR"><rate currency="USD">1.08</rate><rate currency="GBP">0.85</rate></rates>`))
+	}))
+	defer server.Close()
+
+	f := newBodyTestFusion(t, server.URL, EndpointConfig{
+		ID: "rates", Name: "Rates", Method: http.MethodGet, Path: "/rates",
+		Parameters: []ParameterConfig{},
+		Response: ResponseConfig{
+			Type:      ResponseTypeXML,
+			Transform: `[.rates.rate[] | {currency: .["@currency"], rate: (.["#text"] | tonumber)}]`,
+		},
+	})
+	result, err := findTool(t, f.RegisterTools(), "svc_rates").Handler(withTenant("tenant-a", map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var rates []map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &rates); err != nil {
+		t.Fatalf("result is not JSON: %v\n%s", err, result)
+	}
+	if len(rates) != 2 || rates[0]["currency"] != "USD" || rates[0]["rate"] != 1.08 {
+		t.Errorf("rates = %v", rates)
+	}
+	if !strings.Contains(accept, "application/xml") {
+		t.Errorf("Accept = %q, want an XML media type", accept)
+	}
+}
@@ -8,6 +8,7 @@ package global
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // Parameter represents a parameter for a tool, resource, or prompt with rich metadata
@@ -27,6 +28,7 @@ type Parameter struct {
 	Format      string                 `json:"format"`    // "date", "email", "uri", etc.
 	Examples    []interface{}          `json:"examples"`  // Example values
 	Metadata    map[string]interface{} `json:"metadata"`  // Extensible for future needs
+	Schema      map[string]interface{} `json:"schema,omitempty"` // Full JSON Schema of the value; overrides Type and Items when set
 }
 
 // EnhancedDescription generates a rich description with constraint information
@@ -202,8 +204,14 @@ const (
 	ServiceNameKey ContextKey = "service_name"
 	// ToolNameKey is the key used to store the MCP tool name in request contexts
 	ToolNameKey ContextKey = "tool_name"
+	// ProgressNotifierKey is the key used to store a ProgressNotifier in tool call contexts
+	ProgressNotifierKey ContextKey = "progress_notifier"
 )
 
+// ProgressNotifier reports incremental progress of a tool call to the MCP
+// client. progress must increase with every call; message is free text.
+type ProgressNotifier func(progress float64, message string) error
+
 //
 // Authorization
 //
@@ -211,13 +219,16 @@ const (
 // ToolRequest represents the context of a tool invocation for authorization decisions.
 type ToolRequest struct {
 	TenantHash  string
+	UserID      string     // Empty when the API token is not linked to a user
 	ServiceName string
 	ToolName    string
+	Hints       *ToolHints // Hint annotations of the tool, if known
 }
 
 // Authorizer defines an interface for authorizing tool requests.
 // Implementations can enforce access control policies per-tenant, per-service, or per-tool.
-// Return nil to allow the request, or an error to deny it.
+// Return nil to allow the request, or an error to deny it. The MCP server also
+// consults the authorizer when listing tools and hides the ones it denies.
 type Authorizer interface {
 	Authorize(ctx context.Context, req ToolRequest) error
 }
@@ -229,3 +240,35 @@ type AllowAllAuthorizer struct{}
 func (a *AllowAllAuthorizer) Authorize(_ context.Context, _ ToolRequest) error {
 	return nil
 }
+
+//
+// Rate limiting
+//
+
+// Throttle kinds reported by ThrottleError
+const (
+	ThrottleRate        = "rate"        // Too many requests in the limit's time window
+	ThrottleConcurrency = "concurrency" // Too many requests in flight at once
+)
+
+// RateLimiter admits or throttles tool requests. Acquire returns a release
+// function that must be called once the request has finished. When the request
+// exceeds a limit, Acquire returns a *ThrottleError and no release function.
+type RateLimiter interface {
+	Acquire(ctx context.Context, req ToolRequest) (release func(), err error)
+}
+
+// ThrottleError reports a tool request rejected by a rate or concurrency limit
+type ThrottleError struct {
+	Limit      string        // Description of the limit that was exceeded
+	Kind       string        // ThrottleRate or ThrottleConcurrency
+	RetryAfter time.Duration // How long the caller should wait before retrying
+}
+
+// Error implements the error interface
+func (e *ThrottleError) Error() string {
+	if e.Kind == ThrottleConcurrency {
+		return fmt.Sprintf("too many concurrent requests (%s)", e.Limit)
+	}
+	return fmt.Sprintf("rate limit exceeded (%s)", e.Limit)
+}
@@ -39,8 +39,25 @@ const (
 // MCP server (stdio, Streamable HTTP, or SSE) may run before it is cancelled.
 // Operators can override this per service with the "callTimeout" field in the
 // service's JSON configuration.
+//
+// HubDefaultSessionIdleTimeout is how long an unused per-tenant session to a
+// downstream MCP server is kept open before it is closed. Operators can
+// override this per service with the "sessionIdleTimeout" field.
+const (
+	HubDefaultCallTimeout        = 300 * time.Second
+	HubDefaultSessionIdleTimeout = 15 * time.Minute
+)
+
+// Built-in OAuth authorization server lifetimes.
+//
+// OAuthServerCodeTTL is how long an authorization code may be exchanged for
+// tokens. Access tokens expire after OAuthServerAccessTokenTTL and are renewed
+// with a refresh token, which is rotated on use and expires after
+// OAuthServerRefreshTokenTTL.
 const (
-	HubDefaultCallTimeout = 300 * time.Second
+	OAuthServerCodeTTL         = 5 * time.Minute
+	OAuthServerAccessTokenTTL  = time.Hour
+	OAuthServerRefreshTokenTTL = 30 * 24 * time.Hour
 )
 
 // Response size limits.
@@ -62,3 +79,14 @@ const (
 const (
 	MaxKnowledgeQueryLength = 512
 )
+
+// Pagination limits.
+//
+// DefaultPaginationMaxPages is the number of pages fetched for a paginated
+// endpoint when neither the endpoint's paginationConfig.maxPages nor the
+// caller's max_pages argument says otherwise.  MaxPaginationPages is the hard
+// upper bound on pages fetched for a single tool call, regardless of either.
+const (
+	DefaultPaginationMaxPages = 5
+	MaxPaginationPages        = 50
+)
@@ -19,6 +19,7 @@ type RequestRecord struct {
 	Status    string // "ok" or "error"
 	Bytes     int    // response bytes (tools/call) or item count (list operations)
 	IsList    bool   // true for list operations — Bytes is an item count, not a byte count
+	TraceID   string // only when the call was traced
 }
 
 // requestRecordKeyType is the unexported context key type for *RequestRecord.
@@ -54,5 +55,8 @@ func (r *RequestRecord) Fields() []any {
 	} else {
 		fields = append(fields, "bytes", r.Bytes)
 	}
+	if r.TraceID != "" {
+		fields = append(fields, "trace_id", r.TraceID)
+	}
 	return fields
 }
@@ -8,16 +8,38 @@ package hub
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
 	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// withTraceContext wraps a header function so that every request to a
+// downstream server also carries the traceparent of the calling tool span.
+// headerFunc may be nil.
+func withTraceContext(headerFunc transport.HTTPHeaderFunc) transport.HTTPHeaderFunc {
+	return func(ctx context.Context) map[string]string {
+		var headers map[string]string
+		if headerFunc != nil {
+			headers = headerFunc(ctx)
+		}
+		if traceParent := tracing.TraceParent(ctx); traceParent != "" {
+			traced := make(map[string]string, len(headers)+1)
+			maps.Copy(traced, headers)
+			traced[tracing.TraceParentHeader] = traceParent
+			headers = traced
+		}
+		return headers
+	}
+}
+
 // isTransportError returns true if err indicates a transport-level failure
 // (e.g. invalid session ID after upstream restart) rather than an
 // application-level error from the tool itself.
@@ -46,11 +68,18 @@ type MCPClientManager struct {
 	client             *client.Client
 	serviceName        string
 	connected          bool
-	tools              map[string]mcp.Tool // cached tools keyed by name
+	tools              map[string]mcp.Tool             // cached tools keyed by name
+	resources          map[string]mcp.Resource         // cached resources keyed by URI
+	templates          map[string]mcp.ResourceTemplate // cached resource templates keyed by URI template
+	prompts            map[string]mcp.Prompt           // cached prompts keyed by name
+	subscribed         map[string]bool                 // resource URIs subscribed to in the current session
 	logger             global.Logger
 	onToolsChanged     func(serviceName string, added, removed []string)
+	onResourcesChanged func(serviceName string)
+	onPromptsChanged   func(serviceName string)
+	onResourceUpdated  func(serviceName, uri string)
 	callTimeout        time.Duration // per-tool-call timeout for this service
-	progressForwarders sync.Map // downstream token string → *progressForwarder
+	progressForwarders *sync.Map     // downstream token string → *progressForwarder
 	cbMu               sync.Mutex
 	cbFailures         int
 	cbOpenUntil        time.Time
@@ -60,10 +89,15 @@ type MCPClientManager struct {
 // NewMCPClientManager creates a new client manager for the named service.
 func NewMCPClientManager(serviceName string, logger global.Logger) *MCPClientManager {
 	return &MCPClientManager{
-		serviceName: serviceName,
-		tools:       make(map[string]mcp.Tool),
-		logger:      logger,
-		callTimeout: global.HubDefaultCallTimeout,
+		serviceName:        serviceName,
+		tools:              make(map[string]mcp.Tool),
+		resources:          make(map[string]mcp.Resource),
+		templates:          make(map[string]mcp.ResourceTemplate),
+		prompts:            make(map[string]mcp.Prompt),
+		subscribed:         make(map[string]bool),
+		logger:             logger,
+		callTimeout:        global.HubDefaultCallTimeout,
+		progressForwarders: &sync.Map{},
 	}
 }
 
@@ -92,6 +126,30 @@ func (m *MCPClientManager) SetOnToolsChanged(fn func(serviceName string, added,
 	m.onToolsChanged = fn
 }
 
+// SetOnResourcesChanged sets the callback invoked when the downstream server
+// reports that its resources or resource templates changed.
+func (m *MCPClientManager) SetOnResourcesChanged(fn func(serviceName string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onResourcesChanged = fn
+}
+
+// SetOnPromptsChanged sets the callback invoked when the downstream server
+// reports that its prompts changed.
+func (m *MCPClientManager) SetOnPromptsChanged(fn func(serviceName string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPromptsChanged = fn
+}
+
+// SetOnResourceUpdated sets the callback invoked when a subscribed downstream
+// resource is updated. The URI is the downstream (unprefixed) URI.
+func (m *MCPClientManager) SetOnResourceUpdated(fn func(serviceName, uri string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onResourceUpdated = fn
+}
+
 // Connect initializes the MCP session with the downstream server.
 func (m *MCPClientManager) Connect(ctx context.Context) error {
 	m.mu.Lock()
@@ -118,6 +176,7 @@ func (m *MCPClientManager) Connect(ctx context.Context) error {
 
 	m.mu.Lock()
 	m.connected = true
+	m.subscribed = make(map[string]bool) // subscriptions do not survive the session
 	m.mu.Unlock()
 
 	m.cbMu.Lock()
@@ -300,6 +359,253 @@ func (m *MCPClientManager) SetCachedTools(tools map[string]mcp.Tool) {
 	m.tools = tools
 }
 
+// ServerCapabilities returns the capabilities announced by the downstream server.
+func (m *MCPClientManager) ServerCapabilities() mcp.ServerCapabilities {
+	m.mu.RLock()
+	c := m.client
+	m.mu.RUnlock()
+
+	if c == nil {
+		return mcp.ServerCapabilities{}
+	}
+	return c.GetServerCapabilities()
+}
+
+// connectedClient returns the client if the session is usable.
+func (m *MCPClientManager) connectedClient() (*client.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.connected || m.client == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+	return m.client, nil
+}
+
+// ListResources discovers resources from the downstream server without updating
+// the cache. Servers without the resources capability have no resources.
+func (m *MCPClientManager) ListResources(ctx context.Context) (map[string]mcp.Resource, error) {
+	c, err := m.connectedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string]mcp.Resource)
+	if c.GetServerCapabilities().Resources == nil {
+		return resources, nil
+	}
+
+	result, err := c.ListResources(ctx, mcp.ListResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	for _, resource := range result.Resources {
+		resources[resource.URI] = resource
+	}
+	return resources, nil
+}
+
+// ListResourceTemplates discovers resource templates from the downstream server
+// without updating the cache.
+func (m *MCPClientManager) ListResourceTemplates(ctx context.Context) (map[string]mcp.ResourceTemplate, error) {
+	c, err := m.connectedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]mcp.ResourceTemplate)
+	if c.GetServerCapabilities().Resources == nil {
+		return templates, nil
+	}
+
+	result, err := c.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource templates: %w", err)
+	}
+	for _, template := range result.ResourceTemplates {
+		if template.URITemplate == nil || template.URITemplate.Template == nil {
+			continue
+		}
+		templates[template.URITemplate.Raw()] = template
+	}
+	return templates, nil
+}
+
+// ListPrompts discovers prompts from the downstream server without updating the
+// cache. Servers without the prompts capability have no prompts.
+func (m *MCPClientManager) ListPrompts(ctx context.Context) (map[string]mcp.Prompt, error) {
+	c, err := m.connectedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make(map[string]mcp.Prompt)
+	if c.GetServerCapabilities().Prompts == nil {
+		return prompts, nil
+	}
+
+	result, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+	for _, prompt := range result.Prompts {
+		prompts[prompt.Name] = prompt
+	}
+	return prompts, nil
+}
+
+// GetCachedResources returns a copy of the cached resources.
+func (m *MCPClientManager) GetCachedResources() map[string]mcp.Resource {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.resources)
+}
+
+// SetCachedResources replaces the cached resources. Subscriptions to resources
+// that no longer exist are forgotten.
+func (m *MCPClientManager) SetCachedResources(resources map[string]mcp.Resource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resources = resources
+	for uri := range m.subscribed {
+		if _, ok := resources[uri]; !ok {
+			delete(m.subscribed, uri)
+		}
+	}
+}
+
+// GetCachedResourceTemplates returns a copy of the cached resource templates.
+func (m *MCPClientManager) GetCachedResourceTemplates() map[string]mcp.ResourceTemplate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.templates)
+}
+
+// SetCachedResourceTemplates replaces the cached resource templates.
+func (m *MCPClientManager) SetCachedResourceTemplates(templates map[string]mcp.ResourceTemplate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.templates = templates
+}
+
+// HasResourceTemplate reports whether the downstream server currently provides
+// the URI template.
+func (m *MCPClientManager) HasResourceTemplate(uriTemplate string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.templates[uriTemplate]
+	return ok
+}
+
+// GetCachedPrompts returns a copy of the cached prompts.
+func (m *MCPClientManager) GetCachedPrompts() map[string]mcp.Prompt {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.prompts)
+}
+
+// SetCachedPrompts replaces the cached prompts.
+func (m *MCPClientManager) SetCachedPrompts(prompts map[string]mcp.Prompt) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prompts = prompts
+}
+
+// callDownstream runs a resource or prompt request against the downstream
+// server, bounded by the call timeout and subject to the circuit breaker.
+func (m *MCPClientManager) callDownstream(ctx context.Context, what string, fn func(ctx context.Context, c *client.Client) error) error {
+	m.mu.RLock()
+	callTimeout := m.callTimeout
+	m.mu.RUnlock()
+
+	c, err := m.connectedClient()
+	if err != nil {
+		return fmt.Errorf("hub service '%s' is currently unavailable. The server will automatically reconnect",
+			m.serviceName)
+	}
+
+	if m.isCircuitOpen() {
+		remaining := time.Until(m.cbOpenUntil).Round(time.Second)
+		return fmt.Errorf("hub service '%s' circuit breaker is open (resets in %v)", m.serviceName, remaining)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+
+	if m.logger != nil {
+		m.logger.Debugf("Hub service '%s': %s (timeout %v)", m.serviceName, what, callTimeout)
+	}
+	if err := fn(callCtx, c); err != nil {
+		m.recordCallFailure()
+		if callCtx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s timed out after %v", what, callTimeout)
+		}
+		return err
+	}
+
+	m.recordCallSuccess()
+	return nil
+}
+
+// ReadResource reads a resource from the downstream server by its original URI.
+func (m *MCPClientManager) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	var result *mcp.ReadResourceResult
+	err := m.callDownstream(ctx, fmt.Sprintf("reading resource '%s'", uri), func(ctx context.Context, c *client.Client) error {
+		req := mcp.ReadResourceRequest{}
+		req.Params.URI = uri
+		var err error
+		result, err = c.ReadResource(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// GetPrompt gets a prompt from the downstream server by its original name.
+func (m *MCPClientManager) GetPrompt(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	var result *mcp.GetPromptResult
+	err := m.callDownstream(ctx, fmt.Sprintf("getting prompt '%s'", name), func(ctx context.Context, c *client.Client) error {
+		req := mcp.GetPromptRequest{}
+		req.Params.Name = name
+		req.Params.Arguments = args
+		var err error
+		result, err = c.GetPrompt(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// SubscribeResources subscribes to updates of the given downstream resources,
+// skipping those already subscribed to in the current session. It does nothing
+// if the downstream server does not support subscriptions.
+func (m *MCPClientManager) SubscribeResources(ctx context.Context, uris []string) error {
+	c, err := m.connectedClient()
+	if err != nil {
+		return err
+	}
+	if caps := c.GetServerCapabilities().Resources; caps == nil || !caps.Subscribe {
+		return nil
+	}
+
+	for _, uri := range uris {
+		m.mu.RLock()
+		done := m.subscribed[uri]
+		m.mu.RUnlock()
+		if done {
+			continue
+		}
+
+		req := mcp.SubscribeRequest{}
+		req.Params.URI = uri
+		if err := c.Subscribe(ctx, req); err != nil {
+			return fmt.Errorf("failed to subscribe to resource '%s': %w", uri, err)
+		}
+
+		m.mu.Lock()
+		m.subscribed[uri] = true
+		m.mu.Unlock()
+	}
+	return nil
+}
+
 // CallTool invokes a tool on the downstream server, bounded by the service's
 // configured call timeout (global.HubDefaultCallTimeout unless overridden).
 // If meta is non-nil, it is forwarded as _meta in the downstream request
@@ -309,6 +615,38 @@ func (m *MCPClientManager) SetCachedTools(tools map[string]mcp.Tool) {
 // invalidated the session without dropping the TCP connection), CallTool
 // triggers the reconnect loop and retries the call once after reconnection.
 func (m *MCPClientManager) CallTool(ctx context.Context, toolName string, args map[string]interface{}, meta *mcp.Meta) (*mcp.CallToolResult, error) {
+	ctx, span := tracing.Start(ctx, "tools/call "+toolName, tracing.SpanKindClient)
+	defer span.End()
+	span.SetAttribute("mcp.method.name", "tools/call")
+	span.SetAttribute("gen_ai.tool.name", toolName)
+	span.SetAttribute("mcpfusion.service", m.serviceName)
+
+	// Propagate the trace in _meta as well as in the HTTP headers, since
+	// the downstream server may not be reached over HTTP
+	if traceParent := tracing.TraceParent(ctx); traceParent != "" {
+		traced := &mcp.Meta{AdditionalFields: map[string]any{}}
+		if meta != nil {
+			traced.ProgressToken = meta.ProgressToken
+			maps.Copy(traced.AdditionalFields, meta.AdditionalFields)
+		}
+		traced.AdditionalFields[tracing.TraceParentHeader] = traceParent
+		meta = traced
+	}
+
+	result, err := m.callTool(ctx, toolName, args, meta)
+	switch {
+	case err != nil:
+		span.RecordError(err)
+	case result != nil && result.IsError:
+		span.SetStatus(tracing.StatusError, "tool returned an error")
+	default:
+		span.SetStatus(tracing.StatusOK, "")
+	}
+	return result, err
+}
+
+// callTool implements CallTool
+func (m *MCPClientManager) callTool(ctx context.Context, toolName string, args map[string]interface{}, meta *mcp.Meta) (*mcp.CallToolResult, error) {
 	m.mu.RLock()
 	c := m.client
 	connected := m.connected
@@ -406,9 +744,16 @@ func (m *MCPClientManager) UnregisterProgressForwarder(downstreamToken string) {
 	m.progressForwarders.Delete(downstreamToken)
 }
 
-// RegisterNotificationHandler sets up a handler for tool list change
-// notifications from the downstream server. When a notification arrives,
-// tools are refreshed asynchronously.
+// ShareProgressForwarders makes this manager relay progress notifications
+// through the forwarders registered on other. A per-tenant session uses the
+// forwarders of the service's shared session, where tool handlers register them.
+func (m *MCPClientManager) ShareProgressForwarders(other *MCPClientManager) {
+	m.progressForwarders = other.progressForwarders
+}
+
+// RegisterNotificationHandler sets up a handler for list change, resource
+// update and progress notifications from the downstream server. When a list
+// change notification arrives, the list is refreshed asynchronously.
 func (m *MCPClientManager) RegisterNotificationHandler() {
 	m.mu.RLock()
 	c := m.client
@@ -435,6 +780,30 @@ func (m *MCPClientManager) RegisterNotificationHandler() {
 				}
 			}()
 
+		case mcp.MethodNotificationResourcesListChanged, mcp.MethodNotificationPromptsListChanged:
+			if m.logger != nil {
+				m.logger.Infof("Hub service '%s': received %s notification", m.serviceName,
+					strings.TrimPrefix(notification.Method, "notifications/"))
+			}
+			m.mu.RLock()
+			callback := m.onResourcesChanged
+			if notification.Method == mcp.MethodNotificationPromptsListChanged {
+				callback = m.onPromptsChanged
+			}
+			m.mu.RUnlock()
+			if callback != nil {
+				go callback(m.serviceName)
+			}
+
+		case mcp.MethodNotificationResourceUpdated:
+			uri, _ := notification.Params.AdditionalFields["uri"].(string)
+			m.mu.RLock()
+			callback := m.onResourceUpdated
+			m.mu.RUnlock()
+			if uri != "" && callback != nil {
+				callback(m.serviceName, uri)
+			}
+
 		case "notifications/progress":
 			tokenVal, ok := notification.Params.AdditionalFields["progressToken"]
 			if !ok {
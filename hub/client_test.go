@@ -0,0 +1,75 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/tracing"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceHeaderKey struct{}
+
+func TestCallTool_PropagatesTraceContext(t *testing.T) {
+	// The downstream tool reports the traceparent of its HTTP request and _meta
+	var metaTraceParent string
+	downstream := server.NewMCPServer("downstream", "1.0")
+	downstream.AddTool(mcp.NewTool("trace"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if req.Params.Meta != nil {
+			metaTraceParent, _ = req.Params.Meta.AdditionalFields[tracing.TraceParentHeader].(string)
+		}
+		header, _ := ctx.Value(traceHeaderKey{}).(string)
+		return mcp.NewToolResultText(header), nil
+	})
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(downstream,
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return context.WithValue(ctx, traceHeaderKey{}, r.Header.Get(tracing.TraceParentHeader))
+		})))
+	t.Cleanup(ts.Close)
+
+	c := NewHTTPClient(&fusion.ServiceConfig{
+		ServiceKey: "svc",
+		Transport:  fusion.TransportTypeMCPHTTP,
+		BaseURL:    ts.URL + "/mcp",
+	}, newTestLogger(t))
+	require.NoError(t, c.Connect(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+
+	exporter := tracing.NewMemoryExporter()
+	tracer := tracing.New(tracing.WithExporter(exporter))
+	ctx, root := tracer.Start(context.Background(), "tools/call svc_trace", tracing.SpanKindServer)
+
+	result, err := c.Manager().CallTool(ctx, "trace", nil, &mcp.Meta{AdditionalFields: map[string]any{"other": "kept"}})
+	require.NoError(t, err)
+	root.End()
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+	client := spans[0]
+	assert.Equal(t, "tools/call trace", client.Name)
+	assert.Equal(t, root.SpanContext().SpanID, client.Parent)
+	assert.Equal(t, "svc", client.Attribute("mcpfusion.service"))
+	assert.Equal(t, tracing.StatusOK, client.Status)
+
+	// Both the HTTP header and _meta carry the client span
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, client.SpanContext.TraceParent(), result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, client.SpanContext.TraceParent(), metaTraceParent)
+
+	// Calls outside a trace carry no trace context
+	result, err = c.Manager().CallTool(context.Background(), "trace", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.Content[0].(mcp.TextContent).Text)
+}
@@ -24,6 +24,7 @@ type HTTPClient struct {
 	manager      *MCPClientManager
 	backoff      *ExponentialBackoff
 	logger       global.Logger
+	headerFunc   transport.HTTPHeaderFunc
 	disconnectCh chan struct{}
 	disconnectMu sync.Mutex
 }
@@ -62,6 +63,13 @@ func (h *HTTPClient) Manager() *MCPClientManager {
 	return h.manager
 }
 
+// SetHeaderFunc sets a function that supplies the auth headers of every request,
+// replacing the static headers built from the auth configuration. It is used for
+// credentials that belong to a tenant and may be refreshed during the session.
+func (h *HTTPClient) SetHeaderFunc(headerFunc transport.HTTPHeaderFunc) {
+	h.headerFunc = headerFunc
+}
+
 // Connect creates and connects the HTTP MCP client
 func (h *HTTPClient) Connect(ctx context.Context) error {
 	h.logger.Infof("Hub service '%s': connecting to HTTP endpoint: %s", h.config.ServiceKey, h.config.BaseURL)
@@ -69,12 +77,16 @@ func (h *HTTPClient) Connect(ctx context.Context) error {
 	// Build transport options
 	var opts []transport.StreamableHTTPCOption
 
-	// Apply auth headers based on config
-	headers := h.buildAuthHeaders()
-	if len(headers) > 0 {
-		opts = append(opts, transport.WithHTTPHeaders(headers))
+	// Apply auth headers based on config, or per request when a header function is set
+	if h.headerFunc == nil {
+		if headers := h.buildAuthHeaders(); len(headers) > 0 {
+			opts = append(opts, transport.WithHTTPHeaders(headers))
+		}
 	}
 
+	// Propagate the trace context of tool calls to the downstream server
+	opts = append(opts, transport.WithHTTPHeaderFunc(withTraceContext(h.headerFunc)))
+
 	// Create the streamable HTTP MCP client
 	c, err := client.NewStreamableHttpClient(h.config.BaseURL, opts...)
 	if err != nil {
@@ -26,14 +26,17 @@ type hubClient interface {
 	Close() error
 }
 
-// HubProvider manages connections to downstream MCP servers and exposes their tools.
-// It implements global.ToolProvider.
+// HubProvider manages connections to downstream MCP servers and exposes their tools,
+// resources and prompts. It implements global.ToolProvider.
 type HubProvider struct {
 	mu              sync.RWMutex
 	configs         map[string]*fusion.ServiceConfig // hub service configs keyed by service key
 	clients         map[string]hubClient
 	refreshCancels  map[string]context.CancelFunc // per-service periodic refresh cancellation
+	tenantSessions  map[string]*tenantSessions    // per-tenant sessions of services using tenant credentials
+	tenantAuth      TenantAuthenticator
 	mcpServer       *server.MCPServer
+	subscribers     ResourceSubscribers // sessions subscribed to proxied resources; nil = none
 	logger          global.Logger
 	ctx             context.Context
 	cancel          context.CancelFunc
@@ -43,6 +46,13 @@ type HubProvider struct {
 	downloadDir     string // directory for saving image/binary content from tool results; empty = disabled
 }
 
+// ResourceSubscribers reports which client sessions subscribed to a resource.
+// Subscribers returns only the sessions of the given tenant, or of every tenant
+// if tenantHash is empty.
+type ResourceSubscribers interface {
+	Subscribers(uri, tenantHash string) []string
+}
+
 // HubOption defines a functional option for configuring a HubProvider.
 type HubOption func(*HubProvider)
 
@@ -61,12 +71,21 @@ func WithDownloadDir(dir string) HubOption {
 	}
 }
 
+// WithTenantAuth sets the authenticator that supplies each tenant's credentials
+// to hub services using user_credentials, oauth2_external or session_jwt auth.
+func WithTenantAuth(auth TenantAuthenticator) HubOption {
+	return func(h *HubProvider) {
+		h.tenantAuth = auth
+	}
+}
+
 // NewHubProvider creates a new HubProvider with the given hub service configurations.
 func NewHubProvider(configs map[string]*fusion.ServiceConfig, logger global.Logger, opts ...HubOption) *HubProvider {
 	h := &HubProvider{
 		configs:        configs,
 		clients:        make(map[string]hubClient),
 		refreshCancels: make(map[string]context.CancelFunc),
+		tenantSessions: make(map[string]*tenantSessions),
 		logger:         logger,
 	}
 	for _, opt := range opts {
@@ -88,6 +107,14 @@ func (h *HubProvider) SetMCPServer(srv *server.MCPServer) {
 	h.mcpServer = srv
 }
 
+// SetResourceSubscribers sets the subscriptions used to forward resource updates.
+// Without it, resource updates are not forwarded.
+func (h *HubProvider) SetResourceSubscribers(subscribers ResourceSubscribers) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = subscribers
+}
+
 // Start begins connecting to all configured hub services.
 func (h *HubProvider) Start(ctx context.Context) {
 	h.ctx, h.cancel = context.WithCancel(ctx)
@@ -107,12 +134,23 @@ func (h *HubProvider) Start(ctx context.Context) {
 			continue
 		}
 
+		// Services using tenant credentials get a session per tenant; the shared
+		// session only discovers tools
+		if config.UsesTenantCredentials() {
+			if !h.startTenantSessions(serviceKey, config, c) {
+				continue
+			}
+		}
+
 		h.mu.Lock()
 		h.clients[serviceKey] = c
 		h.mu.Unlock()
 
-		// Set up the tools changed callback
+		// Set up the tools, resources and prompts changed callbacks
 		c.Manager().SetOnToolsChanged(h.onToolsChanged)
+		c.Manager().SetOnResourcesChanged(h.onResourcesChanged)
+		c.Manager().SetOnPromptsChanged(h.onPromptsChanged)
+		c.Manager().SetOnResourceUpdated(h.onResourceUpdated)
 
 		// Start the connection in a goroutine
 		h.wg.Add(1)
@@ -128,8 +166,10 @@ func (h *HubProvider) Start(ctx context.Context) {
 					}
 					h.mu.Unlock()
 
-					// Discover and register tools
+					// Discover and register tools, resources and prompts
 					h.discoverAndRegisterTools(key, client.Manager())
+					h.syncResources(key, client.Manager())
+					h.syncPrompts(key, client.Manager())
 
 					// Start periodic refresh if configured
 					if cfg.ToolRefreshInterval > 0 {
@@ -194,7 +234,7 @@ func (h *HubProvider) discoverAndRegisterTools(serviceKey string, manager *MCPCl
 	// Register all discovered tools (overwrites stale handlers for unchanged names).
 	var serverTools []server.ServerTool
 	for _, tool := range newTools {
-		toolDef := ConvertDownstreamTool(serviceKey, tool, manager.CallTool, getOpts)
+		toolDef := ConvertDownstreamTool(serviceKey, tool, h.toolCaller(serviceKey, manager), getOpts)
 		mcpTool, handler := h.convertToServerTool(toolDef, manager)
 		serverTools = append(serverTools, server.ServerTool{
 			Tool:    mcpTool,
@@ -288,21 +328,8 @@ func (h *HubProvider) convertToServerTool(toolDef global.ToolDefinition, manager
 		toolOptions = append(toolOptions, toolOption)
 	}
 
-	// Add hints
-	if toolDef.Hints != nil {
-		if toolDef.Hints.ReadOnly != nil {
-			toolOptions = append(toolOptions, mcp.WithReadOnlyHintAnnotation(*toolDef.Hints.ReadOnly))
-		}
-		if toolDef.Hints.Destructive != nil {
-			toolOptions = append(toolOptions, mcp.WithDestructiveHintAnnotation(*toolDef.Hints.Destructive))
-		}
-		if toolDef.Hints.Idempotent != nil {
-			toolOptions = append(toolOptions, mcp.WithIdempotentHintAnnotation(*toolDef.Hints.Idempotent))
-		}
-		if toolDef.Hints.OpenWorld != nil {
-			toolOptions = append(toolOptions, mcp.WithOpenWorldHintAnnotation(*toolDef.Hints.OpenWorld))
-		}
-	}
+	// Advertise only the hints the tool declares
+	toolOptions = append(toolOptions, withHints(toolDef.Hints))
 
 	mcpTool := mcp.NewTool(toolDef.Name, toolOptions...)
 
@@ -337,6 +364,7 @@ func (h *HubProvider) convertToServerTool(toolDef global.ToolDefinition, manager
 		}
 		ctxOptions["__meta"] = downstreamMeta
 
+		start := time.Now()
 		result, err := toolDef.Handler(ctxOptions)
 
 		// Record to shared collector for cross-package health reporting.
@@ -345,6 +373,7 @@ func (h *HubProvider) convertToServerTool(toolDef global.ToolDefinition, manager
 			if svcName := ctx.Value(global.ServiceNameKey); svcName != nil {
 				if svc, ok := svcName.(string); ok {
 					h.sharedCollector.RecordRequest(svc, err != nil)
+					h.sharedCollector.RecordLatency(svc, time.Since(start))
 				}
 			}
 		}
@@ -358,6 +387,105 @@ func (h *HubProvider) convertToServerTool(toolDef global.ToolDefinition, manager
 	return mcpTool, handler
 }
 
+// startTenantSessions sets up the per-tenant sessions of a service and makes its
+// shared client discover with a tenant's credentials. It returns false if the
+// service cannot be started because no tenant authenticator is configured.
+func (h *HubProvider) startTenantSessions(serviceKey string, config *fusion.ServiceConfig, c hubClient) bool {
+	if h.tenantAuth == nil {
+		h.logger.Errorf("Hub service '%s': %s auth requires tenant authentication, service disabled",
+			serviceKey, config.Auth.Type)
+		return false
+	}
+
+	onUpdated := func(tenantHash, uri string) {
+		h.notifyResourceUpdated(serviceKey, uri, tenantHash)
+	}
+	pool := newTenantSessions(h.ctx, config, h.tenantAuth, c.Manager(), onUpdated, h.logger)
+	switch shared := c.(type) {
+	case *HTTPClient:
+		shared.SetHeaderFunc(pool.discoveryHeaders)
+	case *SSEClient:
+		shared.SetHeaderFunc(pool.discoveryHeaders)
+	}
+
+	h.mu.Lock()
+	h.tenantSessions[serviceKey] = pool
+	h.mu.Unlock()
+	h.routeTenantResources(serviceKey)
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		pool.run(h.ctx)
+	}()
+
+	h.logger.Infof("Hub service '%s': using per-tenant %s sessions (idle timeout %v)",
+		serviceKey, config.Auth.Type, pool.idleTimeout)
+	return true
+}
+
+// sessionFor returns the manager that serves a request to a hub service: the
+// shared session, or the calling tenant's own session when the service uses
+// tenant credentials. The release function must be called once the request
+// is complete.
+func (h *HubProvider) sessionFor(ctx context.Context, serviceKey string, shared *MCPClientManager) (*MCPClientManager, func(), error) {
+	pool := h.tenantPool(serviceKey)
+	if pool == nil {
+		return shared, func() {}, nil
+	}
+
+	tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
+	if !ok || tenantContext == nil || tenantContext.TenantHash == "" {
+		return nil, nil, fmt.Errorf("hub service '%s' requires an authenticated tenant", serviceKey)
+	}
+	return pool.acquire(ctx, tenantContext.TenantHash)
+}
+
+// toolCaller returns the function that calls a downstream tool of a service
+// through the session of the calling tenant, or the shared session.
+func (h *HubProvider) toolCaller(serviceKey string, shared *MCPClientManager) func(ctx context.Context, toolName string, args map[string]interface{}, meta *mcp.Meta) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, toolName string, args map[string]interface{}, meta *mcp.Meta) (*mcp.CallToolResult, error) {
+		session, release, err := h.sessionFor(ctx, serviceKey, shared)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return session.CallTool(ctx, toolName, args, meta)
+	}
+}
+
+// resourceReader returns the function that reads a downstream resource of a
+// service through the session of the calling tenant, or the shared session.
+func (h *HubProvider) resourceReader(serviceKey string, shared *MCPClientManager) func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	return func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+		session, release, err := h.sessionFor(ctx, serviceKey, shared)
+		if err != nil {
+			h.recordRequest(serviceKey, err)
+			return nil, err
+		}
+		defer release()
+		result, err := session.ReadResource(ctx, uri)
+		h.recordRequest(serviceKey, err)
+		return result, err
+	}
+}
+
+// promptGetter returns the function that gets a downstream prompt of a service
+// through the session of the calling tenant, or the shared session.
+func (h *HubProvider) promptGetter(serviceKey string, shared *MCPClientManager) func(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+		session, release, err := h.sessionFor(ctx, serviceKey, shared)
+		if err != nil {
+			h.recordRequest(serviceKey, err)
+			return nil, err
+		}
+		defer release()
+		result, err := session.GetPrompt(ctx, name, args)
+		h.recordRequest(serviceKey, err)
+		return result, err
+	}
+}
+
 // onToolsChanged handles tool changes from any hub service.
 func (h *HubProvider) onToolsChanged(serviceName string, added, removed []string) {
 	h.mu.RLock()
@@ -388,7 +516,7 @@ func (h *HubProvider) onToolsChanged(serviceName string, added, removed []string
 		var serverTools []server.ServerTool
 		for _, name := range added {
 			if tool, ok := cachedTools[name]; ok {
-				toolDef := ConvertDownstreamTool(serviceName, tool, manager.CallTool, getOpts)
+				toolDef := ConvertDownstreamTool(serviceName, tool, h.toolCaller(serviceName, manager), getOpts)
 				mcpTool, handler := h.convertToServerTool(toolDef, manager)
 				serverTools = append(serverTools, server.ServerTool{
 					Tool:    mcpTool,
@@ -403,6 +531,215 @@ func (h *HubProvider) onToolsChanged(serviceName string, added, removed []string
 	}
 }
 
+// syncResources discovers resources and resource templates from a downstream
+// server and registers new or changed ones under the service prefix, removing
+// resources that no longer exist. Downstream resources are subscribed to when
+// the server supports it, so their updates can be forwarded. The resources of
+// services using tenant credentials are listed per tenant, see TenantResources.
+func (h *HubProvider) syncResources(serviceKey string, manager *MCPClientManager) {
+	if h.tenantPool(serviceKey) != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(h.ctx, 30*time.Second)
+	defer cancel()
+
+	newResources, err := manager.ListResources(ctx)
+	if err != nil {
+		h.logger.Errorf("Hub service '%s': failed to discover resources: %v", serviceKey, err)
+		return
+	}
+	newTemplates, err := manager.ListResourceTemplates(ctx)
+	if err != nil {
+		h.logger.Errorf("Hub service '%s': failed to discover resource templates: %v", serviceKey, err)
+		return
+	}
+
+	h.mu.RLock()
+	srv := h.mcpServer
+	h.mu.RUnlock()
+
+	if srv == nil {
+		h.logger.Errorf("Hub service '%s': MCP server not set, cannot register resources", serviceKey)
+		return
+	}
+
+	read := h.resourceReader(serviceKey, manager)
+
+	// Resources: register new and changed ones, then remove those that disappeared.
+	// Unchanged resources keep their handlers, which always use the current session.
+	resourceDiff := DiffKeys(manager.GetCachedResources(), newResources)
+	var serverResources []server.ServerResource
+	for _, uri := range append(resourceDiff.Added, resourceDiff.Changed...) {
+		serverResources = append(serverResources, ConvertDownstreamResource(serviceKey, newResources[uri], read))
+	}
+	if len(serverResources) > 0 {
+		srv.AddResources(serverResources...)
+	}
+	if len(resourceDiff.Removed) > 0 {
+		var prefixedRemoved []string
+		for _, uri := range resourceDiff.Removed {
+			prefixedRemoved = append(prefixedRemoved, PrefixResourceURI(serviceKey, uri))
+		}
+		srv.DeleteResources(prefixedRemoved...)
+	}
+	manager.SetCachedResources(newResources)
+
+	// Resource templates: mcp-go cannot delete a template, so a removed template
+	// stays listed but its handler refuses to read until the template reappears.
+	templateDiff := DiffKeys(manager.GetCachedResourceTemplates(), newTemplates)
+	manager.SetCachedResourceTemplates(newTemplates)
+	var serverTemplates []server.ServerResourceTemplate
+	for _, raw := range append(templateDiff.Added, templateDiff.Changed...) {
+		readTemplate := func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+			if !manager.HasResourceTemplate(raw) {
+				return nil, fmt.Errorf("resource template %s is no longer provided by hub service '%s'", raw, serviceKey)
+			}
+			return read(ctx, uri)
+		}
+		serverTemplate, err := ConvertDownstreamResourceTemplate(serviceKey, newTemplates[raw], readTemplate)
+		if err != nil {
+			h.logger.Warningf("Hub service '%s': skipping resource template: %v", serviceKey, err)
+			continue
+		}
+		serverTemplates = append(serverTemplates, serverTemplate)
+	}
+	if len(serverTemplates) > 0 {
+		srv.AddResourceTemplates(serverTemplates...)
+	}
+
+	// Subscribe to resource updates (a new session starts without subscriptions)
+	uris := make([]string, 0, len(newResources))
+	for uri := range newResources {
+		uris = append(uris, uri)
+	}
+	if err := manager.SubscribeResources(ctx, uris); err != nil {
+		h.logger.Warningf("Hub service '%s': %v", serviceKey, err)
+	}
+
+	if len(newResources) > 0 || len(newTemplates) > 0 || len(resourceDiff.Removed) > 0 {
+		h.logger.Infof("Hub service '%s': registered %d resources (%d added, %d removed) and %d resource templates (%d added, %d removed)",
+			serviceKey, len(newResources), len(resourceDiff.Added), len(resourceDiff.Removed),
+			len(newTemplates), len(templateDiff.Added), len(templateDiff.Removed))
+	}
+}
+
+// syncPrompts discovers prompts from a downstream server and registers new or
+// changed ones under the service prefix, removing prompts that no longer exist.
+// The prompts of services using tenant credentials are registered as tenants
+// list them, see TenantPrompts.
+func (h *HubProvider) syncPrompts(serviceKey string, manager *MCPClientManager) {
+	if h.tenantPool(serviceKey) != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(h.ctx, 30*time.Second)
+	defer cancel()
+
+	newPrompts, err := manager.ListPrompts(ctx)
+	if err != nil {
+		h.logger.Errorf("Hub service '%s': failed to discover prompts: %v", serviceKey, err)
+		return
+	}
+
+	h.mu.RLock()
+	srv := h.mcpServer
+	h.mu.RUnlock()
+
+	if srv == nil {
+		h.logger.Errorf("Hub service '%s': MCP server not set, cannot register prompts", serviceKey)
+		return
+	}
+
+	get := h.promptGetter(serviceKey, manager)
+
+	diff := DiffKeys(manager.GetCachedPrompts(), newPrompts)
+	var serverPrompts []server.ServerPrompt
+	for _, name := range append(diff.Added, diff.Changed...) {
+		serverPrompts = append(serverPrompts, ConvertDownstreamPrompt(serviceKey, newPrompts[name], get))
+	}
+	if len(serverPrompts) > 0 {
+		srv.AddPrompts(serverPrompts...)
+	}
+	if len(diff.Removed) > 0 {
+		var prefixedRemoved []string
+		for _, name := range diff.Removed {
+			prefixedRemoved = append(prefixedRemoved, serviceKey+"_"+name)
+		}
+		srv.DeletePrompts(prefixedRemoved...)
+	}
+	manager.SetCachedPrompts(newPrompts)
+
+	if len(newPrompts) > 0 || len(diff.Removed) > 0 {
+		h.logger.Infof("Hub service '%s': registered %d prompts (%d added, %d removed)",
+			serviceKey, len(newPrompts), len(diff.Added), len(diff.Removed))
+	}
+}
+
+// onResourcesChanged re-syncs resources after a downstream list_changed notification.
+func (h *HubProvider) onResourcesChanged(serviceName string) {
+	h.mu.RLock()
+	client := h.clients[serviceName]
+	h.mu.RUnlock()
+
+	if client != nil && h.ctx != nil {
+		h.syncResources(serviceName, client.Manager())
+	}
+}
+
+// onPromptsChanged re-syncs prompts after a downstream list_changed notification.
+func (h *HubProvider) onPromptsChanged(serviceName string) {
+	h.mu.RLock()
+	client := h.clients[serviceName]
+	h.mu.RUnlock()
+
+	if client != nil && h.ctx != nil {
+		h.syncPrompts(serviceName, client.Manager())
+	}
+}
+
+// onResourceUpdated forwards a downstream resource update received on a shared
+// session to the client sessions subscribed to the resource. The shared session
+// of a service using tenant credentials belongs to one tenant, so its updates
+// are dropped; tenants receive updates through their own sessions.
+func (h *HubProvider) onResourceUpdated(serviceName, uri string) {
+	if h.tenantPool(serviceName) != nil {
+		return
+	}
+	h.notifyResourceUpdated(serviceName, uri, "")
+}
+
+// notifyResourceUpdated notifies the client sessions of a tenant (or of every
+// tenant if tenantHash is empty) subscribed to a resource of its update, using
+// the proxied URI.
+func (h *HubProvider) notifyResourceUpdated(serviceName, uri, tenantHash string) {
+	h.mu.RLock()
+	srv := h.mcpServer
+	subscribers := h.subscribers
+	h.mu.RUnlock()
+
+	if srv == nil || subscribers == nil {
+		return
+	}
+	prefixedURI := PrefixResourceURI(serviceName, uri)
+	for _, sessionID := range subscribers.Subscribers(prefixedURI, tenantHash) {
+		err := srv.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{
+			"uri": prefixedURI,
+		})
+		if err != nil {
+			h.logger.Debugf("Hub service '%s': failed to notify session %s of update to %s: %v",
+				serviceName, sessionID, prefixedURI, err)
+		}
+	}
+}
+
+// recordRequest records a resource or prompt request in the shared collector.
+func (h *HubProvider) recordRequest(serviceKey string, err error) {
+	if h.sharedCollector != nil {
+		h.sharedCollector.RecordRequest(serviceKey, err != nil)
+	}
+}
+
 // periodicRefresh periodically refreshes tools from a downstream server.
 // It stops when refreshCtx is cancelled (on disconnect or shutdown).
 func (h *HubProvider) periodicRefresh(refreshCtx context.Context, serviceKey string, manager *MCPClientManager, interval time.Duration) {
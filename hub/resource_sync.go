@@ -0,0 +1,193 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// resourceURIScheme is the scheme of proxied resource URIs. A downstream URI
+// is namespaced by appending it verbatim to hub://<service>/, which keeps URI
+// templates valid and makes the original URI trivial to recover.
+const resourceURIScheme = "hub://"
+
+// PrefixResourceURI returns the URI (or URI template) under which a downstream
+// resource is exposed, e.g. "file:///notes.txt" from service "docs" becomes
+// "hub://docs/file:///notes.txt".
+func PrefixResourceURI(serviceName, uri string) string {
+	return resourceURIScheme + serviceName + "/" + uri
+}
+
+// UnprefixResourceURI returns the downstream URI of a proxied resource URI, or
+// false if uri does not belong to the service.
+func UnprefixResourceURI(serviceName, uri string) (string, bool) {
+	return strings.CutPrefix(uri, resourceURIScheme+serviceName+"/")
+}
+
+// ServiceFromResourceURI returns the service a proxied resource URI belongs to,
+// or false if uri is not a proxied URI.
+func ServiceFromResourceURI(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, resourceURIScheme)
+	if !ok {
+		return "", false
+	}
+	serviceName, _, ok := strings.Cut(rest, "/")
+	return serviceName, ok && serviceName != ""
+}
+
+// ConvertDownstreamResource converts a downstream MCP resource into a server
+// resource with a prefixed URI and name. The handler reads the resource from
+// the downstream service using its original URI.
+func ConvertDownstreamResource(
+	serviceName string,
+	resource mcp.Resource,
+	readFunc func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error),
+) server.ServerResource {
+	originalURI := resource.URI
+
+	resource.URI = PrefixResourceURI(serviceName, originalURI)
+	resource.Name = fmt.Sprintf("%s_%s", serviceName, resource.Name)
+
+	handler := func(ctx context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		result, err := readFunc(ctx, originalURI)
+		if err != nil {
+			return nil, fmt.Errorf("downstream resource read failed: %w", err)
+		}
+		return prefixResourceContents(serviceName, result.Contents), nil
+	}
+
+	return server.ServerResource{Resource: resource, Handler: handler}
+}
+
+// ConvertDownstreamResourceTemplate converts a downstream MCP resource template
+// into a server resource template with a prefixed URI template and name. The
+// handler strips the prefix from the requested URI and reads it downstream,
+// where the service expands its own template.
+func ConvertDownstreamResourceTemplate(
+	serviceName string,
+	template mcp.ResourceTemplate,
+	readFunc func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error),
+) (server.ServerResourceTemplate, error) {
+	if template.URITemplate == nil || template.URITemplate.Template == nil {
+		return server.ServerResourceTemplate{}, fmt.Errorf("resource template %q has no URI template", template.Name)
+	}
+
+	prefixed, err := uritemplate.New(PrefixResourceURI(serviceName, template.URITemplate.Raw()))
+	if err != nil {
+		return server.ServerResourceTemplate{}, fmt.Errorf("resource template %q: %w", template.Name, err)
+	}
+	template.URITemplate = &mcp.URITemplate{Template: prefixed}
+	template.Name = fmt.Sprintf("%s_%s", serviceName, template.Name)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri, ok := UnprefixResourceURI(serviceName, request.Params.URI)
+		if !ok {
+			return nil, fmt.Errorf("resource %s does not belong to hub service '%s'", request.Params.URI, serviceName)
+		}
+		result, err := readFunc(ctx, uri)
+		if err != nil {
+			return nil, fmt.Errorf("downstream resource read failed: %w", err)
+		}
+		return prefixResourceContents(serviceName, result.Contents), nil
+	}
+
+	return server.ServerResourceTemplate{Template: template, Handler: handler}, nil
+}
+
+// ConvertDownstreamPrompt converts a downstream MCP prompt into a server prompt
+// named <service>_<prompt>. The handler gets the prompt from the downstream
+// service using its original name and rewrites any resource URIs in the
+// returned messages so clients can read them through the hub.
+func ConvertDownstreamPrompt(
+	serviceName string,
+	prompt mcp.Prompt,
+	getFunc func(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error),
+) server.ServerPrompt {
+	originalName := prompt.Name
+	prompt.Name = fmt.Sprintf("%s_%s", serviceName, originalName)
+
+	handler := func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		result, err := getFunc(ctx, originalName, request.Params.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("downstream prompt request failed: %w", err)
+		}
+		for i, message := range result.Messages {
+			result.Messages[i].Content = prefixContentURI(serviceName, message.Content)
+		}
+		return result, nil
+	}
+
+	return server.ServerPrompt{Prompt: prompt, Handler: handler}
+}
+
+// prefixResourceContents rewrites the URIs of resource contents returned by a
+// downstream service into their proxied form
+func prefixResourceContents(serviceName string, contents []mcp.ResourceContents) []mcp.ResourceContents {
+	for i, content := range contents {
+		contents[i] = prefixContentsURI(serviceName, content)
+	}
+	return contents
+}
+
+// prefixContentsURI rewrites the URI of a single text or blob resource contents
+func prefixContentsURI(serviceName string, content mcp.ResourceContents) mcp.ResourceContents {
+	switch c := content.(type) {
+	case mcp.TextResourceContents:
+		c.URI = PrefixResourceURI(serviceName, c.URI)
+		return c
+	case mcp.BlobResourceContents:
+		c.URI = PrefixResourceURI(serviceName, c.URI)
+		return c
+	}
+	return content
+}
+
+// prefixContentURI rewrites the resource URI of embedded resources and resource
+// links in prompt message content
+func prefixContentURI(serviceName string, content mcp.Content) mcp.Content {
+	switch c := content.(type) {
+	case mcp.EmbeddedResource:
+		c.Resource = prefixContentsURI(serviceName, c.Resource)
+		return c
+	case mcp.ResourceLink:
+		c.URI = PrefixResourceURI(serviceName, c.URI)
+		return c
+	}
+	return content
+}
+
+// DiffKeys compares two maps and returns which keys were added and removed,
+// and which kept their key but changed value. The slices are sorted for
+// deterministic output. It is used for resources, templates and prompts the
+// same way DiffTools is used for tools.
+func DiffKeys[T any](oldItems, newItems map[string]T) ToolDiff {
+	var diff ToolDiff
+	for key := range oldItems {
+		if _, exists := newItems[key]; !exists {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	for key, item := range newItems {
+		oldItem, exists := oldItems[key]
+		if !exists {
+			diff.Added = append(diff.Added, key)
+		} else if !reflect.DeepEqual(oldItem, item) {
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
@@ -0,0 +1,247 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixResourceURI(t *testing.T) {
+	prefixed := PrefixResourceURI("docs", "file:///notes/a.txt")
+	assert.Equal(t, "hub://docs/file:///notes/a.txt", prefixed)
+
+	uri, ok := UnprefixResourceURI("docs", prefixed)
+	assert.True(t, ok)
+	assert.Equal(t, "file:///notes/a.txt", uri)
+
+	_, ok = UnprefixResourceURI("other", prefixed)
+	assert.False(t, ok, "a URI of another service must not be unprefixed")
+
+	service, ok := ServiceFromResourceURI(prefixed)
+	assert.True(t, ok)
+	assert.Equal(t, "docs", service)
+
+	_, ok = ServiceFromResourceURI("file:///notes/a.txt")
+	assert.False(t, ok)
+}
+
+func TestConvertDownstreamResource(t *testing.T) {
+	var readURI string
+	readFunc := func(_ context.Context, uri string) (*mcp.ReadResourceResult, error) {
+		readURI = uri
+		return &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: "hello"},
+		}}, nil
+	}
+
+	resource := mcp.NewResource("file:///notes/a.txt", "notes", mcp.WithMIMEType("text/plain"))
+	converted := ConvertDownstreamResource("docs", resource, readFunc)
+
+	assert.Equal(t, "hub://docs/file:///notes/a.txt", converted.Resource.URI)
+	assert.Equal(t, "docs_notes", converted.Resource.Name)
+	assert.Equal(t, "text/plain", converted.Resource.MIMEType)
+
+	contents, err := converted.Handler(context.Background(), mcp.ReadResourceRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "file:///notes/a.txt", readURI, "the downstream read must use the original URI")
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "hub://docs/file:///notes/a.txt", text.URI)
+	assert.Equal(t, "hello", text.Text)
+}
+
+func TestConvertDownstreamResourceTemplate(t *testing.T) {
+	var readURI string
+	readFunc := func(_ context.Context, uri string) (*mcp.ReadResourceResult, error) {
+		readURI = uri
+		return &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			mcp.BlobResourceContents{URI: uri, Blob: "AAAA"},
+		}}, nil
+	}
+
+	template := mcp.NewResourceTemplate("file:///notes/{name}", "note")
+	converted, err := ConvertDownstreamResourceTemplate("docs", template, readFunc)
+	require.NoError(t, err)
+	assert.Equal(t, "hub://docs/file:///notes/{name}", converted.Template.URITemplate.Raw())
+	assert.Equal(t, "docs_note", converted.Template.Name)
+
+	// The upstream server matches the prefixed template and the read is forwarded unprefixed
+	srv := server.NewMCPServer("test", "1.0")
+	srv.AddResourceTemplates(converted)
+	message := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"hub://docs/file:///notes/a.txt"}}`
+	response, ok := srv.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful JSON-RPC response")
+	assert.Equal(t, "file:///notes/a.txt", readURI)
+
+	result, ok := response.Result.(mcp.ReadResourceResult)
+	require.True(t, ok, "unexpected result type %T", response.Result)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "hub://docs/file:///notes/a.txt", result.Contents[0].(mcp.BlobResourceContents).URI)
+}
+
+func TestConvertDownstreamPrompt(t *testing.T) {
+	var gotName string
+	var gotArgs map[string]string
+	getFunc := func(_ context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+		gotName, gotArgs = name, args
+		return mcp.NewGetPromptResult("Review", []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Please review")),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(
+				mcp.TextResourceContents{URI: "file:///notes/a.txt", Text: "hello"})),
+		}), nil
+	}
+
+	prompt := mcp.NewPrompt("review", mcp.WithPromptDescription("Review a note"), mcp.WithArgument("name", mcp.RequiredArgument()))
+	converted := ConvertDownstreamPrompt("docs", prompt, getFunc)
+	assert.Equal(t, "docs_review", converted.Prompt.Name)
+	assert.Equal(t, "Review a note", converted.Prompt.Description)
+	require.Len(t, converted.Prompt.Arguments, 1)
+
+	req := mcp.GetPromptRequest{}
+	req.Params.Arguments = map[string]string{"name": "a"}
+	result, err := converted.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "review", gotName)
+	assert.Equal(t, map[string]string{"name": "a"}, gotArgs)
+	require.Len(t, result.Messages, 2)
+	embedded, ok := result.Messages[1].Content.(mcp.EmbeddedResource)
+	require.True(t, ok)
+	assert.Equal(t, "hub://docs/file:///notes/a.txt", embedded.Resource.(mcp.TextResourceContents).URI)
+}
+
+func TestDiffKeys(t *testing.T) {
+	oldItems := map[string]string{"a": "1", "b": "2", "c": "3"}
+	newItems := map[string]string{"b": "2", "c": "changed", "d": "4"}
+
+	diff := DiffKeys(oldItems, newItems)
+	assert.Equal(t, []string{"d"}, diff.Added)
+	assert.Equal(t, []string{"a"}, diff.Removed)
+	assert.Equal(t, []string{"c"}, diff.Changed)
+}
+
+// newInProcessManager connects a client manager to an in-process downstream server
+func newInProcessManager(t *testing.T, serviceName string, downstream *server.MCPServer) *MCPClientManager {
+	t.Helper()
+	c, err := client.NewInProcessClient(downstream)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+
+	manager := NewMCPClientManager(serviceName, newTestLogger(t))
+	manager.SetClient(c)
+	require.NoError(t, manager.Connect(context.Background()))
+	return manager
+}
+
+func TestHubProvider_SyncResourcesAndPrompts(t *testing.T) {
+	downstream := server.NewMCPServer("downstream", "1.0")
+	readText := func(text string) server.ResourceHandlerFunc {
+		return func(_ context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: req.Params.URI, Text: text}}, nil
+		}
+	}
+	downstream.AddResource(mcp.NewResource("file:///a.txt", "a"), readText("contents of a"))
+	downstream.AddResource(mcp.NewResource("file:///b.txt", "b"), readText("contents of b"))
+	downstream.AddResourceTemplate(mcp.NewResourceTemplate("file:///notes/{name}", "note"),
+		server.ResourceTemplateHandlerFunc(readText("a note")))
+	downstream.AddPrompt(mcp.NewPrompt("greet"), func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("Greeting", []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Hello")),
+		}), nil
+	})
+
+	manager := newInProcessManager(t, "docs", downstream)
+	upstream := server.NewMCPServer("upstream", "1.0")
+	h := NewHubProvider(nil, newTestLogger(t))
+	h.ctx = context.Background()
+	h.SetMCPServer(upstream)
+
+	h.syncResources("docs", manager)
+	h.syncPrompts("docs", manager)
+
+	resources := upstream.ListResources()
+	assert.Len(t, resources, 2)
+	assert.Contains(t, resources, "hub://docs/file:///a.txt")
+	assert.Contains(t, upstream.ListPrompts(), "docs_greet")
+
+	read := func(uri string) (mcp.ReadResourceResult, bool) {
+		message := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + uri + `"}}`
+		response, ok := upstream.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+		if !ok {
+			return mcp.ReadResourceResult{}, false
+		}
+		result, ok := response.Result.(mcp.ReadResourceResult)
+		return result, ok
+	}
+
+	result, ok := read("hub://docs/file:///a.txt")
+	require.True(t, ok)
+	assert.Equal(t, "contents of a", result.Contents[0].(mcp.TextResourceContents).Text)
+	assert.Equal(t, "hub://docs/file:///a.txt", result.Contents[0].(mcp.TextResourceContents).URI)
+
+	result, ok = read("hub://docs/file:///notes/todo")
+	require.True(t, ok)
+	assert.Equal(t, "a note", result.Contents[0].(mcp.TextResourceContents).Text)
+
+	// Removed downstream resources and templates disappear or stop working upstream
+	downstream.DeleteResources("file:///b.txt")
+	downstream.SetResourceTemplates()
+	downstream.DeletePrompts("greet")
+	h.syncResources("docs", manager)
+	h.syncPrompts("docs", manager)
+
+	assert.Len(t, upstream.ListResources(), 1)
+	assert.NotContains(t, upstream.ListResources(), "hub://docs/file:///b.txt")
+	assert.Empty(t, upstream.ListPrompts())
+	_, ok = read("hub://docs/file:///notes/todo")
+	assert.False(t, ok, "a removed resource template must not be readable")
+}
+
+// testSession is a client session whose notifications can be inspected
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) SessionID() string                                   { return s.id }
+
+// testSubscribers maps resource URIs to subscribed session IDs
+type testSubscribers map[string][]string
+
+func (s testSubscribers) Subscribers(uri, _ string) []string { return s[uri] }
+
+func TestHubProvider_ResourceUpdatedNotifiesSubscribers(t *testing.T) {
+	upstream := server.NewMCPServer("upstream", "1.0")
+	alice := &testSession{id: "alice", notifications: make(chan mcp.JSONRPCNotification, 1)}
+	bob := &testSession{id: "bob", notifications: make(chan mcp.JSONRPCNotification, 1)}
+	require.NoError(t, upstream.RegisterSession(context.Background(), alice))
+	require.NoError(t, upstream.RegisterSession(context.Background(), bob))
+
+	h := NewHubProvider(nil, newTestLogger(t))
+	h.SetMCPServer(upstream)
+	h.SetResourceSubscribers(testSubscribers{"hub://docs/file:///a.txt": {"alice"}})
+
+	h.onResourceUpdated("docs", "file:///a.txt")
+	h.onResourceUpdated("docs", "file:///b.txt")
+
+	require.Len(t, alice.notifications, 1)
+	notification := <-alice.notifications
+	assert.Equal(t, string(mcp.MethodNotificationResourceUpdated), notification.Method)
+	assert.Equal(t, "hub://docs/file:///a.txt", notification.Params.AdditionalFields["uri"])
+	assert.Empty(t, bob.notifications, "a session that did not subscribe must not be notified")
+}
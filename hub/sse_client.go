@@ -19,10 +19,11 @@ import (
 
 // SSEClient manages an SSE MCP client connection
 type SSEClient struct {
-	config  *fusion.ServiceConfig
-	manager *MCPClientManager
-	backoff *ExponentialBackoff
-	logger  global.Logger
+	config     *fusion.ServiceConfig
+	manager    *MCPClientManager
+	backoff    *ExponentialBackoff
+	logger     global.Logger
+	headerFunc transport.HTTPHeaderFunc
 }
 
 // NewSSEClient creates a new SSE client for the given service config
@@ -59,6 +60,13 @@ func (s *SSEClient) Manager() *MCPClientManager {
 	return s.manager
 }
 
+// SetHeaderFunc sets a function that supplies the auth headers of every request,
+// replacing the static headers built from the auth configuration. It is used for
+// credentials that belong to a tenant and may be refreshed during the session.
+func (s *SSEClient) SetHeaderFunc(headerFunc transport.HTTPHeaderFunc) {
+	s.headerFunc = headerFunc
+}
+
 // Connect creates and connects the SSE MCP client
 func (s *SSEClient) Connect(ctx context.Context) error {
 	s.logger.Infof("Hub service '%s': connecting to SSE endpoint: %s", s.config.ServiceKey, s.config.BaseURL)
@@ -66,12 +74,16 @@ func (s *SSEClient) Connect(ctx context.Context) error {
 	// Build transport options
 	var opts []transport.ClientOption
 
-	// Apply auth headers based on config
-	headers := s.buildAuthHeaders()
-	if len(headers) > 0 {
-		opts = append(opts, transport.WithHeaders(headers))
+	// Apply auth headers based on config, or per request when a header function is set
+	if s.headerFunc == nil {
+		if headers := s.buildAuthHeaders(); len(headers) > 0 {
+			opts = append(opts, transport.WithHeaders(headers))
+		}
 	}
 
+	// Propagate the trace context of tool calls to the downstream server
+	opts = append(opts, transport.WithHeaderFunc(withTraceContext(s.headerFunc)))
+
 	// Create the SSE MCP client
 	c, err := client.NewSSEMCPClient(s.config.BaseURL, opts...)
 	if err != nil {
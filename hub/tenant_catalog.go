@@ -0,0 +1,248 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The resources, resource templates and prompts of a service using tenant
+// credentials may differ between tenants, so they are not registered with the
+// MCP server like those of other services. Instead the list results are
+// rewritten per request with the calling tenant's own, listed through the
+// tenant's session, and reads and prompt requests are routed to that session.
+
+// tenantResourceRoute is the URI template, below the service prefix, of the
+// resource template that routes reads to the calling tenant's session
+const tenantResourceRoute = "{+uri}"
+
+// tenantPool returns the session pool of a service using tenant credentials,
+// or nil for other services
+func (h *HubProvider) tenantPool(serviceKey string) *tenantSessions {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.tenantSessions[serviceKey]
+}
+
+// tenantPools returns the session pools of all services using tenant credentials
+func (h *HubProvider) tenantPools() map[string]*tenantSessions {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return maps.Clone(h.tenantSessions)
+}
+
+// routeTenantResources registers a resource template matching every resource
+// of a service using tenant credentials, which reads it through the calling
+// tenant's session. The route is hidden from resources/templates/list.
+func (h *HubProvider) routeTenantResources(serviceKey string) {
+	h.mu.RLock()
+	srv := h.mcpServer
+	h.mu.RUnlock()
+
+	if srv == nil {
+		h.logger.Errorf("Hub service '%s': MCP server not set, cannot register resources", serviceKey)
+		return
+	}
+
+	route, err := ConvertDownstreamResourceTemplate(serviceKey,
+		mcp.NewResourceTemplate(tenantResourceRoute, "resources"), h.resourceReader(serviceKey, nil))
+	if err != nil {
+		h.logger.Errorf("Hub service '%s': failed to route resources: %v", serviceKey, err)
+		return
+	}
+	srv.AddResourceTemplates(route)
+}
+
+// forEachTenantSession calls fn with the calling tenant's session of each
+// service using tenant credentials, in service order. Services the tenant
+// cannot use, for example because it has no credentials, are skipped.
+func (h *HubProvider) forEachTenantSession(ctx context.Context, pools map[string]*tenantSessions,
+	fn func(serviceKey string, session *MCPClientManager) error) {
+
+	for _, serviceKey := range slices.Sorted(maps.Keys(pools)) {
+		session, release, err := h.sessionFor(ctx, serviceKey, nil)
+		if err != nil {
+			h.logger.Debugf("Hub service '%s': skipped in list: %v", serviceKey, err)
+			continue
+		}
+		err = fn(serviceKey, session)
+		release()
+		if err != nil {
+			h.logger.Warningf("Hub service '%s': %v", serviceKey, err)
+		}
+	}
+}
+
+// TenantResources replaces the resources of services using tenant credentials
+// in a resources/list result with those of the calling tenant's sessions.
+func (h *HubProvider) TenantResources(ctx context.Context, resources []mcp.Resource) []mcp.Resource {
+	pools := h.tenantPools()
+	if len(pools) == 0 {
+		return resources
+	}
+
+	result := make([]mcp.Resource, 0, len(resources))
+	for _, resource := range resources {
+		if serviceKey, ok := ServiceFromResourceURI(resource.URI); !ok || pools[serviceKey] == nil {
+			result = append(result, resource)
+		}
+	}
+	h.forEachTenantSession(ctx, pools, func(serviceKey string, session *MCPClientManager) error {
+		listed, err := session.ListResources(ctx)
+		if err != nil {
+			return err
+		}
+		for _, uri := range slices.Sorted(maps.Keys(listed)) {
+			result = append(result, ConvertDownstreamResource(serviceKey, listed[uri], nil).Resource)
+		}
+		return nil
+	})
+
+	slices.SortStableFunc(result, func(a, b mcp.Resource) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// TenantResourceTemplates replaces the resource templates of services using
+// tenant credentials, including their routes, in a resources/templates/list
+// result with those of the calling tenant's sessions.
+func (h *HubProvider) TenantResourceTemplates(ctx context.Context, templates []mcp.ResourceTemplate) []mcp.ResourceTemplate {
+	pools := h.tenantPools()
+	if len(pools) == 0 {
+		return templates
+	}
+
+	result := make([]mcp.ResourceTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template.URITemplate != nil && template.URITemplate.Template != nil {
+			if serviceKey, ok := ServiceFromResourceURI(template.URITemplate.Raw()); ok && pools[serviceKey] != nil {
+				continue
+			}
+		}
+		result = append(result, template)
+	}
+	h.forEachTenantSession(ctx, pools, func(serviceKey string, session *MCPClientManager) error {
+		listed, err := session.ListResourceTemplates(ctx)
+		if err != nil {
+			return err
+		}
+		for _, raw := range slices.Sorted(maps.Keys(listed)) {
+			serverTemplate, err := ConvertDownstreamResourceTemplate(serviceKey, listed[raw], nil)
+			if err != nil {
+				h.logger.Warningf("Hub service '%s': skipping resource template: %v", serviceKey, err)
+				continue
+			}
+			result = append(result, serverTemplate.Template)
+		}
+		return nil
+	})
+
+	slices.SortStableFunc(result, func(a, b mcp.ResourceTemplate) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// TenantPrompts replaces the prompts of services using tenant credentials in a
+// prompts/list result with those of the calling tenant's sessions. It is a
+// prompt filter. mcp-go only gets prompts registered with the server, so each
+// listed prompt is also registered, once, with a handler that gets it through
+// the calling tenant's session.
+func (h *HubProvider) TenantPrompts(ctx context.Context, prompts []mcp.Prompt) []mcp.Prompt {
+	pools := h.tenantPools()
+	if len(pools) == 0 {
+		return prompts
+	}
+
+	routed := func(name string) bool {
+		for _, pool := range pools {
+			if pool.routesPrompt(name) {
+				return true
+			}
+		}
+		return false
+	}
+	result := make([]mcp.Prompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		if !routed(prompt.Name) {
+			result = append(result, prompt)
+		}
+	}
+	h.forEachTenantSession(ctx, pools, func(serviceKey string, session *MCPClientManager) error {
+		listed, err := session.ListPrompts(ctx)
+		if err != nil {
+			return err
+		}
+		serverPrompts := make(map[string]server.ServerPrompt, len(listed))
+		for _, name := range slices.Sorted(maps.Keys(listed)) {
+			serverPrompt := ConvertDownstreamPrompt(serviceKey, listed[name], h.promptGetter(serviceKey, nil))
+			serverPrompts[serverPrompt.Prompt.Name] = serverPrompt
+			result = append(result, serverPrompt.Prompt)
+		}
+		h.routeTenantPrompts(serviceKey, pools[serviceKey], serverPrompts)
+		return nil
+	})
+
+	slices.SortStableFunc(result, func(a, b mcp.Prompt) int { return cmp.Compare(a.Name, b.Name) })
+	return result
+}
+
+// routeTenantPrompts registers the prompts of a service using tenant
+// credentials that are not registered yet
+func (h *HubProvider) routeTenantPrompts(serviceKey string, pool *tenantSessions, serverPrompts map[string]server.ServerPrompt) {
+	h.mu.RLock()
+	srv := h.mcpServer
+	h.mu.RUnlock()
+
+	if srv == nil {
+		return
+	}
+
+	var added []server.ServerPrompt
+	for _, name := range pool.routePrompts(slices.Sorted(maps.Keys(serverPrompts))) {
+		added = append(added, serverPrompts[name])
+	}
+	if len(added) > 0 {
+		srv.AddPrompts(added...)
+		h.logger.Debugf("Hub service '%s': registered %d prompts", serviceKey, len(added))
+	}
+}
+
+// SubscribeResource subscribes the calling tenant's session to a resource of a
+// service using tenant credentials, so that the tenant receives updates from
+// its own session. The resources of other services are subscribed to by their
+// shared session when they are discovered, and nothing is done for them.
+func (h *HubProvider) SubscribeResource(ctx context.Context, uri string) error {
+	serviceKey, ok := ServiceFromResourceURI(uri)
+	if !ok {
+		return nil
+	}
+	pool := h.tenantPool(serviceKey)
+	if pool == nil {
+		return nil
+	}
+
+	session, release, err := h.sessionFor(ctx, serviceKey, nil)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	downstreamURI, _ := UnprefixResourceURI(serviceKey, uri)
+	if err := session.SubscribeResources(ctx, []string{downstreamURI}); err != nil {
+		return err
+	}
+
+	// sessionFor only succeeds for an authenticated tenant
+	tenantContext := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
+	pool.subscribe(tenantContext.TenantHash, downstreamURI)
+	return nil
+}
@@ -0,0 +1,142 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTenantCatalogServer starts a downstream MCP server that lists a different
+// resource, resource template and prompt to each tenant, identified by the
+// bearer token of fakeTenantAuth
+func newTenantCatalogServer(t *testing.T) string {
+	t.Helper()
+	tenantOf := func(ctx context.Context) string {
+		authorization, _ := ctx.Value(authorizationKey{}).(string)
+		return strings.TrimPrefix(authorization, "Bearer token-")
+	}
+
+	hooks := &server.Hooks{}
+	hooks.AddAfterListResources(func(ctx context.Context, _ any, _ *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+		tenant := tenantOf(ctx)
+		result.Resources = []mcp.Resource{mcp.NewResource("file:///"+tenant+".txt", tenant+"-notes")}
+	})
+	hooks.AddAfterListResourceTemplates(func(ctx context.Context, _ any, _ *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+		tenant := tenantOf(ctx)
+		result.ResourceTemplates = []mcp.ResourceTemplate{mcp.NewResourceTemplate("file:///"+tenant+"/{name}", tenant+"-files")}
+	})
+	downstream := server.NewMCPServer("downstream", "1.0",
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		server.WithHooks(hooks),
+		server.WithPromptFilter(func(ctx context.Context, _ []mcp.Prompt) []mcp.Prompt {
+			return []mcp.Prompt{mcp.NewPrompt(tenantOf(ctx) + "_greeting")}
+		}))
+
+	downstream.AddResourceTemplate(mcp.NewResourceTemplate("file:///{name}", "file"),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: req.Params.URI, Text: "contents for " + tenantOf(ctx)}}, nil
+		})
+	for _, tenant := range []string{"alice", "bob"} {
+		downstream.AddPrompt(mcp.NewPrompt(tenant+"_greeting"), func(ctx context.Context, _ mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("Greeting", []mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Hello "+tenantOf(ctx))),
+			}), nil
+		})
+	}
+
+	streamable := server.NewStreamableHTTPServer(downstream,
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return context.WithValue(ctx, authorizationKey{}, r.Header.Get("Authorization"))
+		}))
+	ts := httptest.NewServer(streamable)
+	t.Cleanup(ts.Close)
+	return ts.URL + "/mcp"
+}
+
+func TestHubProvider_TenantCatalog(t *testing.T) {
+	configs := map[string]*fusion.ServiceConfig{
+		"svc": {
+			ServiceKey: "svc",
+			Transport:  fusion.TransportTypeMCPHTTP,
+			BaseURL:    newTenantCatalogServer(t),
+			Auth:       fusion.AuthConfig{Type: fusion.AuthTypeOAuth2External, Config: map[string]interface{}{}},
+		},
+	}
+	h := NewHubProvider(configs, newTestLogger(t), WithTenantAuth(&fakeTenantAuth{tenants: []string{"alice", "bob"}}))
+
+	hooks := &server.Hooks{}
+	hooks.AddAfterListResources(func(ctx context.Context, _ any, _ *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+		result.Resources = h.TenantResources(ctx, result.Resources)
+	})
+	hooks.AddAfterListResourceTemplates(func(ctx context.Context, _ any, _ *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+		result.ResourceTemplates = h.TenantResourceTemplates(ctx, result.ResourceTemplates)
+	})
+	upstream := server.NewMCPServer("upstream", "1.0",
+		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
+		server.WithHooks(hooks),
+		server.WithPromptFilter(h.TenantPrompts))
+	h.SetMCPServer(upstream)
+	h.Start(context.Background())
+	t.Cleanup(h.Shutdown)
+
+	// request sends a request to the upstream server as a tenant and returns the
+	// JSON result
+	request := func(tenant, method, params string) map[string]any {
+		t.Helper()
+		ctx := context.WithValue(context.Background(), global.TenantContextKey, &fusion.TenantContext{TenantHash: tenant})
+		message := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+		response, ok := upstream.HandleMessage(ctx, json.RawMessage(message)).(mcp.JSONRPCResponse)
+		require.True(t, ok, "%s failed", method)
+		data, err := json.Marshal(response.Result)
+		require.NoError(t, err)
+		var result map[string]any
+		require.NoError(t, json.Unmarshal(data, &result))
+		return result
+	}
+	field := func(result map[string]any, list, key string) []string {
+		var values []string
+		for _, item := range result[list].([]any) {
+			values = append(values, item.(map[string]any)[key].(string))
+		}
+		return values
+	}
+
+	// Each tenant lists only its own resources, templates and prompts
+	for _, tenant := range []string{"alice", "bob"} {
+		assert.Equal(t, []string{"hub://svc/file:///" + tenant + ".txt"},
+			field(request(tenant, "resources/list", `{}`), "resources", "uri"))
+		assert.Equal(t, []string{"hub://svc/file:///" + tenant + "/{name}"},
+			field(request(tenant, "resources/templates/list", `{}`), "resourceTemplates", "uriTemplate"))
+		assert.Equal(t, []string{"svc_" + tenant + "_greeting"},
+			field(request(tenant, "prompts/list", `{}`), "prompts", "name"))
+	}
+	assert.Empty(t, upstream.ListResources(), "tenant resources must not be registered for every tenant")
+
+	// Reads and prompts go through the calling tenant's session
+	contents := request("bob", "resources/read", `{"uri":"hub://svc/file:///bob.txt"}`)["contents"].([]any)
+	assert.Equal(t, "contents for bob", contents[0].(map[string]any)["text"])
+	assert.Equal(t, "hub://svc/file:///bob.txt", contents[0].(map[string]any)["uri"])
+
+	messages := request("alice", "prompts/get", `{"name":"svc_alice_greeting"}`)["messages"].([]any)
+	assert.Equal(t, "Hello alice", messages[0].(map[string]any)["content"].(map[string]any)["text"])
+
+	// Without a tenant nothing of the service is listed
+	assert.Empty(t, request("", "resources/list", `{}`)["resources"])
+}
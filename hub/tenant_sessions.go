@@ -0,0 +1,413 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// tenantConnectTimeout bounds how long establishing a tenant's session may take
+const tenantConnectTimeout = 30 * time.Second
+
+// TenantAuthenticator applies a tenant's credentials for a service to an HTTP
+// request. It is implemented by fusion.MultiTenantAuthManager.
+type TenantAuthenticator interface {
+	ApplyAuthentication(ctx context.Context, req *http.Request, tenantContext *fusion.TenantContext, authConfig fusion.AuthConfig) error
+	ListTenants() ([]string, error)
+}
+
+// tenantClient is the part of a hub client used for a tenant's own session
+type tenantClient interface {
+	Manager() *MCPClientManager
+	Connect(ctx context.Context) error
+	Close() error
+}
+
+// tenantSession is one tenant's connection to a downstream MCP server
+type tenantSession struct {
+	client   tenantClient
+	ready    chan struct{} // closed once the connection attempt has finished
+	err      error         // set before ready is closed if the connection failed
+	active   int           // requests currently using the session
+	lastUsed time.Time
+}
+
+// done returns true once the connection attempt has finished
+func (s *tenantSession) done() bool {
+	select {
+	case <-s.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// usable returns true if the session is connected or still connecting
+func (s *tenantSession) usable() bool {
+	if !s.done() {
+		return true
+	}
+	return s.err == nil && s.client.Manager().IsConnected()
+}
+
+// tenantSessions holds the per-tenant sessions of a hub service whose auth type
+// uses each tenant's own credentials (user_credentials, oauth2_external or
+// session_jwt). Sessions are opened on a tenant's first request and closed after
+// they have been idle for the service's session idle timeout.
+type tenantSessions struct {
+	mu              sync.Mutex
+	ctx             context.Context
+	config          *fusion.ServiceConfig
+	auth            TenantAuthenticator
+	shared          *MCPClientManager // the service's shared session, used for discovery
+	idleTimeout     time.Duration
+	logger          global.Logger
+	sessions        map[string]*tenantSession  // keyed by tenant hash
+	discoveryTenant string                     // tenant whose credentials the shared session uses
+	subscriptions   map[string]map[string]bool // downstream URIs each tenant subscribed to
+	prompts         map[string]bool            // routing prompts registered upstream
+	onUpdated       func(tenantHash, uri string)
+	newClient       func(headerFunc transport.HTTPHeaderFunc) tenantClient
+}
+
+// newTenantSessions creates the session pool of a hub service. shared is the
+// manager of the service's shared session; tenant sessions relay progress
+// notifications through its forwarders. onUpdated receives the resource
+// updates of each tenant's session.
+func newTenantSessions(ctx context.Context, config *fusion.ServiceConfig, auth TenantAuthenticator,
+	shared *MCPClientManager, onUpdated func(tenantHash, uri string), logger global.Logger) *tenantSessions {
+
+	idleTimeout := config.SessionIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = global.HubDefaultSessionIdleTimeout
+	}
+
+	p := &tenantSessions{
+		ctx:           ctx,
+		config:        config,
+		auth:          auth,
+		shared:        shared,
+		idleTimeout:   idleTimeout,
+		logger:        logger,
+		sessions:      make(map[string]*tenantSession),
+		subscriptions: make(map[string]map[string]bool),
+		prompts:       make(map[string]bool),
+		onUpdated:     onUpdated,
+	}
+	p.newClient = func(headerFunc transport.HTTPHeaderFunc) tenantClient {
+		if config.Transport == fusion.TransportTypeSSE {
+			c := NewSSEClient(config, logger)
+			c.SetHeaderFunc(headerFunc)
+			return c
+		}
+		c := NewHTTPClient(config, logger)
+		c.SetHeaderFunc(headerFunc)
+		return c
+	}
+	return p
+}
+
+// acquire returns the tenant's session, connecting it on first use or after it
+// was lost. The returned release function must be called when the request is
+// complete so the session's idle time is measured from its last use.
+func (p *tenantSessions) acquire(ctx context.Context, tenantHash string) (*MCPClientManager, func(), error) {
+	var stale *tenantSession
+
+	p.mu.Lock()
+	session := p.sessions[tenantHash]
+	if session != nil && !session.usable() {
+		stale = session
+		session = nil
+	}
+	if session == nil {
+		session = &tenantSession{ready: make(chan struct{}), lastUsed: time.Now()}
+		p.sessions[tenantHash] = session
+		go p.connect(tenantHash, session)
+	}
+	session.active++
+	p.mu.Unlock()
+
+	if stale != nil && stale.client != nil {
+		_ = stale.client.Close()
+	}
+
+	release := func() { p.release(session) }
+
+	select {
+	case <-session.ready:
+	case <-ctx.Done():
+		release()
+		return nil, nil, ctx.Err()
+	}
+	if session.err != nil {
+		release()
+		return nil, nil, session.err
+	}
+	return session.client.Manager(), release, nil
+}
+
+// release marks the end of a request on a session
+func (p *tenantSessions) release(session *tenantSession) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	session.active--
+	session.lastUsed = time.Now()
+}
+
+// connect opens a tenant's session. The tenant's credentials are resolved first
+// so a tenant without them gets a clear error instead of a failed handshake;
+// afterwards they are applied to every request so refreshed tokens are used.
+func (p *tenantSessions) connect(tenantHash string, session *tenantSession) {
+	defer close(session.ready)
+
+	ctx, cancel := context.WithTimeout(p.ctx, tenantConnectTimeout)
+	defer cancel()
+
+	if _, err := p.headers(ctx, tenantHash); err != nil {
+		session.err = p.credentialsError(err)
+		return
+	}
+
+	c := p.newClient(func(ctx context.Context) map[string]string {
+		headers, err := p.headers(ctx, tenantHash)
+		if err != nil {
+			p.logger.Warningf("Hub service '%s': failed to apply credentials of tenant %s: %v",
+				p.config.ServiceKey, shortHash(tenantHash), err)
+		}
+		return headers
+	})
+	c.Manager().ShareProgressForwarders(p.shared)
+	c.Manager().SetOnResourceUpdated(func(_, uri string) {
+		if p.onUpdated != nil {
+			p.onUpdated(tenantHash, uri)
+		}
+	})
+
+	if err := c.Connect(ctx); err != nil {
+		session.err = fmt.Errorf("hub service '%s' is unavailable for this tenant: %w", p.config.ServiceKey, err)
+		return
+	}
+	session.client = c
+	p.logger.Infof("Hub service '%s': opened session for tenant %s", p.config.ServiceKey, shortHash(tenantHash))
+
+	// A new session starts without subscriptions; renew the tenant's earlier ones
+	if uris := p.subscribed(tenantHash); len(uris) > 0 {
+		if err := c.Manager().SubscribeResources(ctx, uris); err != nil {
+			p.logger.Warningf("Hub service '%s': tenant %s: %v", p.config.ServiceKey, shortHash(tenantHash), err)
+		}
+	}
+}
+
+// subscribe records a tenant's subscription to a downstream resource so that it
+// is renewed when the tenant's session is reopened. Subscriptions are kept for
+// the life of the process because unsubscribing is not forwarded downstream.
+func (p *tenantSessions) subscribe(tenantHash, uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.subscriptions[tenantHash] == nil {
+		p.subscriptions[tenantHash] = make(map[string]bool)
+	}
+	p.subscriptions[tenantHash][uri] = true
+}
+
+// subscribed returns the downstream resources a tenant subscribed to
+func (p *tenantSessions) subscribed(tenantHash string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Sorted(maps.Keys(p.subscriptions[tenantHash]))
+}
+
+// routePrompts returns the prompts not yet registered upstream and marks them
+// as registered
+func (p *tenantSessions) routePrompts(names []string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var added []string
+	for _, name := range names {
+		if !p.prompts[name] {
+			p.prompts[name] = true
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+// routesPrompt returns true if name is a prompt registered upstream to route
+// requests to the tenants' sessions
+func (p *tenantSessions) routesPrompt(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.prompts[name]
+}
+
+// credentialsError wraps a failure to resolve a tenant's credentials, telling
+// the caller how to provide them where a setup tool exists
+func (p *tenantSessions) credentialsError(err error) error {
+	switch p.config.Auth.Type {
+	case fusion.AuthTypeOAuth2External, fusion.AuthTypeUserCredentials:
+		return fmt.Errorf("no valid credentials for hub service '%s', run the %s_auth_setup tool to provide them: %w",
+			p.config.ServiceKey, p.config.ServiceKey, err)
+	}
+	return fmt.Errorf("no valid credentials for hub service '%s': %w", p.config.ServiceKey, err)
+}
+
+// headers returns the auth headers of a tenant's requests. The credentials are
+// applied to a scratch request by the tenant authenticator, which refreshes
+// expired tokens, and its headers (including cookies) are returned.
+func (p *tenantSessions) headers(ctx context.Context, tenantHash string) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	tenantContext := &fusion.TenantContext{TenantHash: tenantHash, ServiceName: p.config.ServiceKey}
+	if err := p.auth.ApplyAuthentication(ctx, req, tenantContext, p.authConfig()); err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(req.Header))
+	for name, values := range req.Header {
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers, nil
+}
+
+// authConfig returns a copy of the service's auth config with baseURL set to the
+// origin of the MCP endpoint, against which relative login and refresh URLs of
+// session_jwt are resolved
+func (p *tenantSessions) authConfig() fusion.AuthConfig {
+	authConfig := p.config.Auth
+	authConfig.Config = make(map[string]interface{}, len(p.config.Auth.Config)+1)
+	for k, v := range p.config.Auth.Config {
+		authConfig.Config[k] = v
+	}
+	if endpoint, err := url.Parse(p.config.BaseURL); err == nil {
+		authConfig.Config["baseURL"] = endpoint.Scheme + "://" + endpoint.Host
+	}
+	return authConfig
+}
+
+// discoveryHeaders supplies the auth headers of the service's shared session,
+// which only discovers tools. Resources and prompts are listed through each
+// tenant's own session instead, since they may differ between tenants. It uses the credentials of
+// the first tenant that has them and keeps that tenant while they work. Without
+// any, the shared session fails to connect and retries with backoff, so tools
+// appear once a tenant has authenticated.
+func (p *tenantSessions) discoveryHeaders(ctx context.Context) map[string]string {
+	p.mu.Lock()
+	current := p.discoveryTenant
+	p.mu.Unlock()
+
+	if current != "" {
+		if headers, err := p.headers(ctx, current); err == nil {
+			return headers
+		}
+	}
+
+	tenants, err := p.auth.ListTenants()
+	if err != nil {
+		p.logger.Warningf("Hub service '%s': failed to list tenants for discovery: %v", p.config.ServiceKey, err)
+		return nil
+	}
+	for _, tenantHash := range tenants {
+		if tenantHash == current {
+			continue
+		}
+		if headers, err := p.headers(ctx, tenantHash); err == nil {
+			p.mu.Lock()
+			p.discoveryTenant = tenantHash
+			p.mu.Unlock()
+			p.logger.Infof("Hub service '%s': discovering tools with the credentials of tenant %s",
+				p.config.ServiceKey, shortHash(tenantHash))
+			return headers
+		}
+	}
+
+	p.logger.Warningf("Hub service '%s': no tenant has credentials yet, tools will be discovered once one authenticates",
+		p.config.ServiceKey)
+	return nil
+}
+
+// run closes idle sessions until ctx is cancelled, then closes all sessions
+func (p *tenantSessions) run(ctx context.Context) {
+	ticker := time.NewTicker(min(p.idleTimeout, time.Minute))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.closeAll()
+			return
+		case now := <-ticker.C:
+			p.evictIdle(now)
+		}
+	}
+}
+
+// evictIdle closes sessions that have not been used for the idle timeout, and
+// drops sessions whose connection failed or was lost
+func (p *tenantSessions) evictIdle(now time.Time) {
+	var evicted []*tenantSession
+
+	p.mu.Lock()
+	for tenantHash, session := range p.sessions {
+		if session.active > 0 || !session.done() {
+			continue
+		}
+		if session.usable() && now.Sub(session.lastUsed) < p.idleTimeout {
+			continue
+		}
+		delete(p.sessions, tenantHash)
+		evicted = append(evicted, session)
+		p.logger.Debugf("Hub service '%s': closing idle session of tenant %s", p.config.ServiceKey, shortHash(tenantHash))
+	}
+	p.mu.Unlock()
+
+	for _, session := range evicted {
+		if session.client != nil {
+			_ = session.client.Close()
+		}
+	}
+}
+
+// closeAll closes every tenant session
+func (p *tenantSessions) closeAll() {
+	p.mu.Lock()
+	sessions := p.sessions
+	p.sessions = make(map[string]*tenantSession)
+	p.mu.Unlock()
+
+	for _, session := range sessions {
+		<-session.ready
+		if session.client != nil {
+			_ = session.client.Close()
+		}
+	}
+}
+
+// count returns the number of open or connecting sessions
+func (p *tenantSessions) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sessions)
+}
+
+// shortHash abbreviates a tenant hash for logging
+func shortHash(tenantHash string) string {
+	return (&fusion.TenantContext{TenantHash: tenantHash}).ShortHash()
+}
@@ -0,0 +1,176 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTenantAuth authenticates every tenant except those without credentials
+// with a bearer token derived from the tenant hash
+type fakeTenantAuth struct {
+	mu             sync.Mutex
+	tenants        []string
+	noCredentials  map[string]bool
+	lastAuthConfig fusion.AuthConfig
+}
+
+func (f *fakeTenantAuth) ApplyAuthentication(_ context.Context, req *http.Request, tenantContext *fusion.TenantContext, authConfig fusion.AuthConfig) error {
+	f.mu.Lock()
+	f.lastAuthConfig = authConfig
+	f.mu.Unlock()
+	if f.noCredentials[tenantContext.TenantHash] {
+		return fmt.Errorf("no token found for tenant")
+	}
+	req.Header.Set("Authorization", "Bearer token-"+tenantContext.TenantHash)
+	return nil
+}
+
+func (f *fakeTenantAuth) ListTenants() ([]string, error) {
+	return f.tenants, nil
+}
+
+type authorizationKey struct{}
+
+// newIdentityServer starts a downstream MCP server whose whoami tool returns the
+// Authorization header of the request
+func newIdentityServer(t *testing.T) string {
+	t.Helper()
+	downstream := server.NewMCPServer("downstream", "1.0")
+	downstream.AddTool(mcp.NewTool("whoami"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		authorization, _ := ctx.Value(authorizationKey{}).(string)
+		return mcp.NewToolResultText(authorization), nil
+	})
+	streamable := server.NewStreamableHTTPServer(downstream,
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return context.WithValue(ctx, authorizationKey{}, r.Header.Get("Authorization"))
+		}))
+	ts := httptest.NewServer(streamable)
+	t.Cleanup(ts.Close)
+	return ts.URL + "/mcp"
+}
+
+// newTestTenantSessions returns a session pool for an oauth2_external hub service
+func newTestTenantSessions(t *testing.T, baseURL string, auth TenantAuthenticator) *tenantSessions {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	config := &fusion.ServiceConfig{
+		ServiceKey: "svc",
+		Transport:  fusion.TransportTypeMCPHTTP,
+		BaseURL:    baseURL,
+		Auth:       fusion.AuthConfig{Type: fusion.AuthTypeOAuth2External, Config: map[string]interface{}{}},
+	}
+	logger := newTestLogger(t)
+	pool := newTenantSessions(ctx, config, auth, NewMCPClientManager("svc", logger), nil, logger)
+	t.Cleanup(func() {
+		cancel()
+		pool.closeAll()
+	})
+	return pool
+}
+
+// whoami calls the identity tool through a tenant's session
+func whoami(t *testing.T, pool *tenantSessions, tenantHash string) string {
+	t.Helper()
+	manager, release, err := pool.acquire(context.Background(), tenantHash)
+	require.NoError(t, err)
+	defer release()
+
+	result, err := manager.CallTool(context.Background(), "whoami", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestTenantSessions_PerTenantCredentials(t *testing.T) {
+	auth := &fakeTenantAuth{noCredentials: map[string]bool{"carol": true}}
+	pool := newTestTenantSessions(t, newIdentityServer(t), auth)
+
+	assert.Equal(t, "Bearer token-alice", whoami(t, pool, "alice"))
+	assert.Equal(t, "Bearer token-bob", whoami(t, pool, "bob"))
+	assert.Equal(t, "Bearer token-alice", whoami(t, pool, "alice"), "a tenant's session is reused")
+	assert.Equal(t, 2, pool.count())
+
+	// Relative session_jwt URLs resolve against the origin of the MCP endpoint
+	assert.NotContains(t, auth.lastAuthConfig.Config["baseURL"], "/mcp")
+
+	// A tenant without credentials is told how to provide them
+	_, _, err := pool.acquire(context.Background(), "carol")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "svc_auth_setup")
+}
+
+func TestTenantSessions_EvictIdle(t *testing.T) {
+	pool := newTestTenantSessions(t, newIdentityServer(t), &fakeTenantAuth{})
+	assert.Equal(t, "Bearer token-alice", whoami(t, pool, "alice"))
+
+	// A session in use is never evicted
+	manager, release, err := pool.acquire(context.Background(), "bob")
+	require.NoError(t, err)
+	pool.evictIdle(time.Now().Add(2 * global.HubDefaultSessionIdleTimeout))
+	assert.Equal(t, 1, pool.count(), "only the idle session should be closed")
+	assert.True(t, manager.IsConnected())
+	release()
+
+	pool.evictIdle(time.Now().Add(2 * global.HubDefaultSessionIdleTimeout))
+	assert.Equal(t, 0, pool.count())
+	assert.False(t, manager.IsConnected())
+
+	// An evicted tenant gets a new session on its next request
+	assert.Equal(t, "Bearer token-bob", whoami(t, pool, "bob"))
+}
+
+func TestTenantSessions_DiscoveryHeaders(t *testing.T) {
+	auth := &fakeTenantAuth{
+		tenants:       []string{"nobody", "alice", "bob"},
+		noCredentials: map[string]bool{"nobody": true},
+	}
+	pool := newTestTenantSessions(t, "http://localhost/mcp", auth)
+
+	headers := pool.discoveryHeaders(context.Background())
+	assert.Equal(t, "Bearer token-alice", headers["Authorization"])
+
+	// The discovery tenant changes only when its credentials stop working
+	auth.noCredentials["alice"] = true
+	headers = pool.discoveryHeaders(context.Background())
+	assert.Equal(t, "Bearer token-bob", headers["Authorization"])
+
+	auth.noCredentials["bob"] = true
+	assert.Empty(t, pool.discoveryHeaders(context.Background()))
+}
+
+func TestHubProvider_SessionFor(t *testing.T) {
+	h := NewHubProvider(nil, newTestLogger(t))
+	shared := NewMCPClientManager("plain", newTestLogger(t))
+
+	manager, release, err := h.sessionFor(context.Background(), "plain", shared)
+	require.NoError(t, err)
+	release()
+	assert.Same(t, shared, manager, "services without tenant credentials use the shared session")
+
+	h.tenantSessions["svc"] = newTestTenantSessions(t, newIdentityServer(t), &fakeTenantAuth{})
+	_, _, err = h.sessionFor(context.Background(), "svc", shared)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires an authenticated tenant")
+
+	ctx := context.WithValue(context.Background(), global.TenantContextKey, &fusion.TenantContext{TenantHash: "alice"})
+	result, err := h.toolCaller("svc", shared)(ctx, "whoami", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-alice", result.Content[0].(mcp.TextContent).Text)
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -23,10 +24,12 @@ import (
 )
 
 // ToolDiff represents the difference between two tool sets, tracking which
-// tools were added and which were removed.
+// tools were added, which were removed, and which kept their name but changed
+// definition (description, schema or annotations).
 type ToolDiff struct {
 	Added   []string
 	Removed []string
+	Changed []string
 }
 
 // FormatOptions controls optional post-processing of tool results.
@@ -218,7 +221,7 @@ func extensionFromMimeType(mimeType string) string {
 	}
 }
 
-// DiffTools compares two tool maps and returns which tools were added and removed.
+// DiffTools compares two tool maps and returns which tools were added, removed and changed.
 // Both maps are keyed by tool name. The returned slices are sorted for deterministic output.
 func DiffTools(oldTools, newTools map[string]mcp.Tool) ToolDiff {
 	var diff ToolDiff
@@ -230,16 +233,21 @@ func DiffTools(oldTools, newTools map[string]mcp.Tool) ToolDiff {
 		}
 	}
 
-	// Find added tools (present in new but not in old)
-	for name := range newTools {
-		if _, exists := oldTools[name]; !exists {
+	// Find added tools (present in new but not in old) and changed tools
+	// (present in both with a different definition)
+	for name, tool := range newTools {
+		oldTool, exists := oldTools[name]
+		if !exists {
 			diff.Added = append(diff.Added, name)
+		} else if !reflect.DeepEqual(oldTool, tool) {
+			diff.Changed = append(diff.Changed, name)
 		}
 	}
 
 	// Sort for deterministic output
 	sort.Strings(diff.Added)
 	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
 
 	return diff
 }
@@ -333,3 +341,30 @@ func convertAnnotations(annotations mcp.ToolAnnotation) *global.ToolHints {
 
 	return hints
 }
+
+// withHints sets the hint annotations of a proxied tool to the hints the
+// downstream server declared. mcp.NewTool fills in a default for every hint,
+// which would make the policy treat undeclared hints as declared.
+func withHints(hints *global.ToolHints) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		tool.Annotations.ReadOnlyHint = nil
+		tool.Annotations.DestructiveHint = nil
+		tool.Annotations.IdempotentHint = nil
+		tool.Annotations.OpenWorldHint = nil
+		if hints == nil {
+			return
+		}
+		if hints.ReadOnly != nil {
+			tool.Annotations.ReadOnlyHint = mcp.ToBoolPtr(*hints.ReadOnly)
+		}
+		if hints.Destructive != nil {
+			tool.Annotations.DestructiveHint = mcp.ToBoolPtr(*hints.Destructive)
+		}
+		if hints.Idempotent != nil {
+			tool.Annotations.IdempotentHint = mcp.ToBoolPtr(*hints.Idempotent)
+		}
+		if hints.OpenWorld != nil {
+			tool.Annotations.OpenWorldHint = mcp.ToBoolPtr(*hints.OpenWorld)
+		}
+	}
+}
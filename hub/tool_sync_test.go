@@ -181,5 +181,22 @@ func TestDiffTools(t *testing.T) {
 		diff := DiffTools(oldTools, newTools)
 		assert.Equal(t, []string{"add"}, diff.Added)
 		assert.Equal(t, []string{"remove"}, diff.Removed)
+		assert.Empty(t, diff.Changed)
+	})
+
+	t.Run("changed definition", func(t *testing.T) {
+		oldTools := map[string]mcp.Tool{
+			"alpha": makeTool("alpha"),
+			"beta":  makeTool("beta"),
+		}
+		newTools := map[string]mcp.Tool{
+			"alpha": mcp.NewTool("alpha", mcp.WithDescription("alpha tool"), mcp.WithString("query")),
+			"beta":  makeTool("beta"),
+		}
+
+		diff := DiffTools(oldTools, newTools)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Equal(t, []string{"alpha"}, diff.Changed)
 	})
 }
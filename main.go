@@ -14,6 +14,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -27,6 +29,7 @@ import (
 	"github.com/PivotLLM/MCPFusion/hub"
 	"github.com/PivotLLM/MCPFusion/mcpserver"
 	"github.com/PivotLLM/MCPFusion/metrics"
+	"github.com/PivotLLM/MCPFusion/tracing"
 	"github.com/tenebris-tech/mlogger"
 	"github.com/PivotLLM/MCPFusion/providers/health"
 	"github.com/PivotLLM/MCPFusion/providers/knowledge"
@@ -50,6 +53,7 @@ func main() {
 	tokenListFlag := flag.Bool("token-list", false, "List all API tokens")
 	tokenDeleteFlag := flag.String("token-del", "", "Delete API token by prefix or hash")
 	tokenUserFlag := flag.String("token-user", "", "User ID to link token to (use with -token-add)")
+	tokenAdminFlag := flag.Bool("token-admin", false, "Give the new token the admin role for the admin API (use with -token-add)")
 
 	// User management subcommands
 	userAddFlag := flag.String("user-add", "", "Add new user with description")
@@ -59,11 +63,43 @@ func main() {
 	userLinkFlag := flag.String("user-link", "", "Link API key to user (format: user_id:key_hash)")
 	userUnlinkFlag := flag.String("user-unlink", "", "Unlink API key from user by key hash")
 
+	// Audit log
+	auditListFlag := flag.Bool("audit-list", false, "List audit log entries, newest first")
+	auditUserFlag := flag.String("audit-user", "", "Only list entries of this user ID (use with -audit-list)")
+	auditTenantFlag := flag.String("audit-tenant", "", "Only list entries of this API token prefix or hash (use with -audit-list)")
+	auditToolFlag := flag.String("audit-tool", "", "Only list calls of this tool (use with -audit-list)")
+	auditSinceFlag := flag.String("audit-since", "", "Only list entries at or after this time: RFC 3339, YYYY-MM-DD, or an age such as 24h or 7d")
+	auditUntilFlag := flag.String("audit-until", "", "Only list entries before this time (same formats as -audit-since)")
+	auditLimitFlag := flag.Int("audit-limit", 100, "Maximum number of entries to list")
+
+	// OpenAPI import
+	openAPIImportFlag := flag.String("openapi-import", "", "Generate a service configuration from an OpenAPI 3 or Swagger 2 document (JSON or YAML)")
+	openAPIServiceFlag := flag.String("openapi-service", "", "Service key of the generated configuration (default: document file name)")
+	openAPITagsFlag := flag.String("openapi-tags", "", "Comma-separated tags; only import operations with one of them")
+	openAPIOperationsFlag := flag.String("openapi-operations", "", "Comma-separated operation ID patterns (e.g. list*,getUser); only import matching operations")
+	openAPIExcludeFlag := flag.String("openapi-exclude", "", "Comma-separated operation ID patterns to skip")
+	openAPIBaseURLFlag := flag.String("openapi-base-url", "", "Base URL of the service (default: first server of the document)")
+	openAPIOutFlag := flag.String("openapi-out", "", "Write the generated configuration to this file (default: standard output)")
+
 	// Auth code generation
 	authCodeFlag := flag.String("auth-code", "", "Generate auth code for a service (e.g., google)")
 	authURLFlag := flag.String("auth-url", "", "External URL of this server (required with -auth-code)")
 	authTokenFlag := flag.String("auth-token", "", "API token prefix/hash to identify tenant (for multi-token setups)")
 
+	// Encryption at rest
+	dbEncryptFlag := flag.Bool("db-encrypt", false, "Encrypt existing OAuth tokens and credentials with the master key")
+	dbRotateKeyFlag := flag.String("db-rotate-key", "", "Re-wrap encrypted records with the master key in this file")
+
+	// TLS
+	tlsCertFlag := flag.String("tls-cert", "", "TLS certificate file (PEM); enables HTTPS")
+	tlsKeyFlag := flag.String("tls-key", "", "TLS private key file (PEM)")
+	tlsClientCAFlag := flag.String("tls-client-ca", "", "CA bundle (PEM) for verifying client certificates")
+	tlsClientMapFlag := flag.String("tls-client-map", "", "JSON file mapping client certificate subjects to API tokens")
+	tlsClientRequiredFlag := flag.Bool("tls-client-required", false, "Require a valid client certificate on every connection")
+	acmeDomainsFlag := flag.String("acme-domains", "", "Comma-separated host names to obtain certificates for via ACME; enables HTTPS")
+	acmeEmailFlag := flag.String("acme-email", "", "Contact email for the ACME account")
+	acmeHTTPFlag := flag.String("acme-http", "", "Listen address for ACME HTTP-01 challenges (e.g. :80); TLS-ALPN-01 is always available")
+
 	// Perf provider flag (never use in production)
 	perfFlag := flag.Bool("perf", false, "Enable perf/stress testing tools (never use in production)")
 
@@ -86,11 +122,30 @@ func main() {
 		fmt.Printf("        Port to listen on (default 8888)\n")
 		fmt.Printf("  -version\n")
 		fmt.Printf("        Show version information\n\n")
+		fmt.Printf("TLS Options:\n")
+		fmt.Printf("  -tls-cert string\n")
+		fmt.Printf("        TLS certificate file (PEM); enables HTTPS. Reloaded when the file changes\n")
+		fmt.Printf("  -tls-key string\n")
+		fmt.Printf("        TLS private key file (PEM)\n")
+		fmt.Printf("  -acme-domains string\n")
+		fmt.Printf("        Comma-separated host names to obtain certificates for via ACME; enables HTTPS\n")
+		fmt.Printf("  -acme-email string\n")
+		fmt.Printf("        Contact email for the ACME account\n")
+		fmt.Printf("  -acme-http string\n")
+		fmt.Printf("        Listen address for ACME HTTP-01 challenges (e.g. :80); TLS-ALPN-01 is always available\n")
+		fmt.Printf("  -tls-client-ca string\n")
+		fmt.Printf("        CA bundle (PEM) for verifying client certificates\n")
+		fmt.Printf("  -tls-client-map string\n")
+		fmt.Printf("        JSON file mapping client certificate subjects to API tokens\n")
+		fmt.Printf("  -tls-client-required\n")
+		fmt.Printf("        Require a valid client certificate on every connection\n\n")
 		fmt.Printf("Token Management Commands:\n")
 		fmt.Printf("  -token-add string\n")
 		fmt.Printf("        Add new API token with description\n")
 		fmt.Printf("  -token-user string\n")
 		fmt.Printf("        User ID to link token to (use with -token-add)\n")
+		fmt.Printf("  -token-admin\n")
+		fmt.Printf("        Give the new token the admin role for the admin API (use with -token-add)\n")
 		fmt.Printf("  -token-list\n")
 		fmt.Printf("        List all API tokens\n")
 		fmt.Printf("  -token-del string\n")
@@ -108,6 +163,36 @@ func main() {
 		fmt.Printf("        Link API key to user (format: user_id:key_hash)\n")
 		fmt.Printf("  -user-unlink string\n")
 		fmt.Printf("        Unlink API key from user by key hash\n\n")
+		fmt.Printf("Audit Log Commands:\n")
+		fmt.Printf("  -audit-list\n")
+		fmt.Printf("        List audit log entries, newest first\n")
+		fmt.Printf("  -audit-user string\n")
+		fmt.Printf("        Only list entries of this user ID\n")
+		fmt.Printf("  -audit-tenant string\n")
+		fmt.Printf("        Only list entries of this API token prefix or hash\n")
+		fmt.Printf("  -audit-tool string\n")
+		fmt.Printf("        Only list calls of this tool\n")
+		fmt.Printf("  -audit-since string\n")
+		fmt.Printf("        Only list entries at or after this time: RFC 3339, YYYY-MM-DD, or an age such as 24h or 7d\n")
+		fmt.Printf("  -audit-until string\n")
+		fmt.Printf("        Only list entries before this time (same formats as -audit-since)\n")
+		fmt.Printf("  -audit-limit int\n")
+		fmt.Printf("        Maximum number of entries to list (default 100)\n\n")
+		fmt.Printf("OpenAPI Import Commands:\n")
+		fmt.Printf("  -openapi-import string\n")
+		fmt.Printf("        Generate a service configuration from an OpenAPI 3 or Swagger 2 document (JSON or YAML)\n")
+		fmt.Printf("  -openapi-service string\n")
+		fmt.Printf("        Service key of the generated configuration (default: document file name)\n")
+		fmt.Printf("  -openapi-tags string\n")
+		fmt.Printf("        Comma-separated tags; only import operations with one of them\n")
+		fmt.Printf("  -openapi-operations string\n")
+		fmt.Printf("        Comma-separated operation ID patterns (e.g. list*,getUser); only import matching operations\n")
+		fmt.Printf("  -openapi-exclude string\n")
+		fmt.Printf("        Comma-separated operation ID patterns to skip\n")
+		fmt.Printf("  -openapi-base-url string\n")
+		fmt.Printf("        Base URL of the service (default: first server of the document)\n")
+		fmt.Printf("  -openapi-out string\n")
+		fmt.Printf("        Write the generated configuration to this file (default: standard output)\n\n")
 		fmt.Printf("Auth Code Commands:\n")
 		fmt.Printf("  -auth-code string\n")
 		fmt.Printf("        Generate auth code for a service (e.g., google)\n")
@@ -115,21 +200,43 @@ func main() {
 		fmt.Printf("        External URL of this server (required with -auth-code)\n")
 		fmt.Printf("  -auth-token string\n")
 		fmt.Printf("        API token prefix/hash to identify tenant (for multi-token setups)\n\n")
+		fmt.Printf("Encryption Commands:\n")
+		fmt.Printf("  -db-encrypt\n")
+		fmt.Printf("        Encrypt existing OAuth tokens and credentials with the master key\n")
+		fmt.Printf("  -db-rotate-key string\n")
+		fmt.Printf("        Re-wrap encrypted records with the master key in this file\n\n")
 		fmt.Printf("Environment Variables:\n")
-		fmt.Printf("  MCP_FUSION_DB_DIR   Custom database directory (default: /opt/mcpfusion or ~/.mcpfusion)\n")
-		fmt.Printf("  MCP_FUSION_DL_DIR   Directory for saving binary downloads (e.g. generated reports)\n\n")
+		fmt.Printf("  MCP_FUSION_ACME_CA          Additional root CA (PEM) trusted for the ACME directory, e.g. a test CA\n")
+		fmt.Printf("  MCP_FUSION_ACME_DIRECTORY   ACME directory URL (default: Let's Encrypt production)\n")
+		fmt.Printf("  MCP_FUSION_AUDIT            Set to false to disable the audit log of tool calls\n")
+		fmt.Printf("  MCP_FUSION_AUDIT_RETENTION  Keep audit log entries this long, e.g. 2160h or 90d (default 90d; 0 keeps all)\n")
+		fmt.Printf("  MCP_FUSION_AUDIT_MAX_ENTRIES  Keep at most this many audit log entries (default: no limit)\n")
+		fmt.Printf("  MCP_FUSION_CONFIG_WATCH     Poll configuration files at this interval (e.g. 5s) and reload on change\n")
+		fmt.Printf("  MCP_FUSION_DB_DIR           Custom database directory (default: /opt/mcpfusion or ~/.mcpfusion)\n")
+		fmt.Printf("  MCP_FUSION_DL_DIR           Directory for saving binary downloads (e.g. generated reports)\n")
+		fmt.Printf("  MCP_FUSION_JOBS_DIR         Directory for asynchronous command job output (default: <db dir>/jobs)\n")
+		fmt.Printf("  MCP_FUSION_JOBS_RETENTION   Keep finished command jobs this long, e.g. 72h or 7d (default 7d; 0 keeps all)\n")
+		fmt.Printf("  MCP_FUSION_JOBS_MAX_PER_TENANT  Keep at most this many finished command jobs per tenant (default: no limit)\n")
+		fmt.Printf("  MCP_FUSION_JOBS_MAX_RUNNING  Maximum command jobs a tenant may run at once (default 10; 0 for no limit)\n")
+		fmt.Printf("  MCP_FUSION_MASTER_KEY       Base64 or hex 32-byte key for encrypting secrets at rest\n")
+		fmt.Printf("  MCP_FUSION_MASTER_KEY_FILE  File holding the master key (alternative to MCP_FUSION_MASTER_KEY)\n\n")
 		fmt.Printf("Examples:\n")
 		fmt.Printf("  # Start server with configuration\n")
 		fmt.Printf("  %s -config configs/microsoft365.json -port 8888\n\n", os.Args[0])
 		fmt.Printf("  # Token management examples\n")
 		fmt.Printf("  %s -token-add \"Production token\"\n", os.Args[0])
 		fmt.Printf("  %s -token-add \"Production token\" -token-user <user-uuid>\n", os.Args[0])
+		fmt.Printf("  %s -token-add \"Automation\" -token-admin\n", os.Args[0])
 		fmt.Printf("  %s -token-list\n", os.Args[0])
 		fmt.Printf("  %s -token-del abc12345\n\n", os.Args[0])
 		fmt.Printf("  # Create user with API token in one step\n")
 		fmt.Printf("  %s -user-add \"Alice\" -user-token \"Alice laptop\"\n\n", os.Args[0])
+		fmt.Printf("  # Audit log of one user's calls in the last day\n")
+		fmt.Printf("  %s -audit-list -audit-user <user-uuid> -audit-since 24h\n\n", os.Args[0])
 		fmt.Printf("  # Generate auth code for fusion-auth\n")
 		fmt.Printf("  %s -auth-code google -auth-url http://10.0.0.1:8888\n\n", os.Args[0])
+		fmt.Printf("  # Generate a service configuration from an OpenAPI document\n")
+		fmt.Printf("  %s -openapi-import petstore.yaml -openapi-tags pets -openapi-out configs/petstore.json\n\n", os.Args[0])
 	}
 
 	// Parse command line flags
@@ -147,6 +254,16 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Generate a configuration from an OpenAPI document; this needs no database or logger
+	if *openAPIImportFlag != "" {
+		if err := handleOpenAPIImport(*openAPIImportFlag, *openAPIServiceFlag, *openAPITagsFlag, *openAPIOperationsFlag,
+			*openAPIExcludeFlag, *openAPIBaseURLFlag, *openAPIOutFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "OpenAPI import failed: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Use the flag values
 	debug := *debugFlag
 	noAuth := *noAuthFlag
@@ -195,6 +312,24 @@ func main() {
 		perfEnabled = true
 	}
 
+	// The /metrics endpoint is served unless MCP_FUSION_METRICS=false, 0, or no.
+	// MCP_FUSION_METRICS_AUTH=true, 1, or yes requires an API token to read it.
+	metricsEnabled := true
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_FUSION_METRICS"))); v == "false" || v == "0" || v == "no" {
+		metricsEnabled = false
+	}
+	metricsAuth := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_FUSION_METRICS_AUTH"))); v == "true" || v == "1" || v == "yes" {
+		metricsAuth = true
+	}
+
+	// Every tool call is recorded in the audit log unless MCP_FUSION_AUDIT=false, 0, or no.
+	// Retention is parsed once the logger is available.
+	auditEnabled := true
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_FUSION_AUDIT"))); v == "false" || v == "0" || v == "no" {
+		auditEnabled = false
+	}
+
 	// My default log in the current directory
 	logfile := "mcpfusion.log"
 
@@ -274,6 +409,22 @@ func main() {
 		dbOpts = append(dbOpts, db.WithDataDir(dbDataDir))
 	}
 
+	// Audit log retention (default 90 days)
+	auditRetention, auditMaxEntries, err := getAuditRetention()
+	if err != nil {
+		logger.Fatalf("Invalid audit log retention: %v", err)
+	}
+	dbOpts = append(dbOpts, db.WithAuditRetention(auditRetention, auditMaxEntries))
+
+	// Master key for encrypting OAuth tokens and credentials at rest (optional)
+	masterKey, err := db.LoadMasterKey()
+	if err != nil {
+		logger.Fatalf("Failed to load master key: %v", err)
+	}
+	if masterKey != nil {
+		dbOpts = append(dbOpts, db.WithMasterKey(masterKey))
+	}
+
 	// Initialize database (required)
 	database, err := db.New(dbOpts...)
 	if err != nil {
@@ -283,7 +434,7 @@ func main() {
 
 	// Handle token management commands if specified
 	if *tokenAddFlag != "" || *tokenListFlag || *tokenDeleteFlag != "" {
-		if err := handleTokenCommands(database, *tokenAddFlag, *tokenListFlag, *tokenDeleteFlag, *tokenUserFlag, logger); err != nil {
+		if err := handleTokenCommands(database, *tokenAddFlag, *tokenListFlag, *tokenDeleteFlag, *tokenUserFlag, *tokenAdminFlag, logger); err != nil {
 			logger.Fatalf("Token management failed: %v", err)
 		}
 		// Exit after token management - don't start server
@@ -298,6 +449,22 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle encryption commands if specified
+	if *dbEncryptFlag || *dbRotateKeyFlag != "" {
+		if err := handleEncryptionCommands(database.(*db.DB), *dbEncryptFlag, *dbRotateKeyFlag); err != nil {
+			logger.Fatalf("Encryption command failed: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle audit log query if specified
+	if *auditListFlag {
+		if err := handleAuditList(database, *auditUserFlag, *auditTenantFlag, *auditToolFlag, *auditSinceFlag, *auditUntilFlag, *auditLimitFlag); err != nil {
+			logger.Fatalf("Audit log query failed: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	// Handle auth code generation if specified
 	if *authCodeFlag != "" {
 		if err := handleAuthCode(database, *authCodeFlag, *authURLFlag, *authTokenFlag, logger); err != nil {
@@ -306,6 +473,38 @@ func main() {
 		os.Exit(0)
 	}
 
+	// HTTPS is enabled by certificate files or ACME domains
+	var tlsConfig *mcpserver.TLSConfig
+	if *tlsCertFlag != "" || *tlsKeyFlag != "" || *acmeDomainsFlag != "" {
+		tlsConfig = &mcpserver.TLSConfig{
+			CertFile:          *tlsCertFlag,
+			KeyFile:           *tlsKeyFlag,
+			ACMEEmail:         *acmeEmailFlag,
+			ACMEDirectoryURL:  os.Getenv("MCP_FUSION_ACME_DIRECTORY"),
+			ACMECAFile:        os.Getenv("MCP_FUSION_ACME_CA"),
+			ACMEHTTPListen:    *acmeHTTPFlag,
+			ClientCAFile:      *tlsClientCAFlag,
+			RequireClientCert: *tlsClientRequiredFlag,
+		}
+		if *acmeDomainsFlag != "" {
+			for _, domain := range strings.Split(*acmeDomainsFlag, ",") {
+				if domain = strings.TrimSpace(domain); domain != "" {
+					tlsConfig.ACMEDomains = append(tlsConfig.ACMEDomains, domain)
+				}
+			}
+			tlsConfig.ACMECacheDir = filepath.Join(database.DataDir(), "acme")
+			logger.Infof("TLS certificates for %v will be obtained via ACME (cache: %s)", tlsConfig.ACMEDomains, tlsConfig.ACMECacheDir)
+		}
+		if err := tlsConfig.Validate(); err != nil {
+			logger.Fatalf("Invalid TLS configuration: %v", err)
+		}
+	} else if *tlsClientCAFlag != "" || *tlsClientMapFlag != "" || *tlsClientRequiredFlag {
+		logger.Fatal("Client certificate options require -tls-cert/-tls-key or -acme-domains")
+	}
+	if *tlsClientMapFlag != "" && *tlsClientCAFlag == "" {
+		logger.Fatal("-tls-client-map requires -tls-client-ca")
+	}
+
 	// Auto-migrate unlinked API keys to user accounts on startup
 	if err := database.AutoMigrateKeys(); err != nil {
 		logger.Warningf("API key auto-migration had issues: %v", err)
@@ -376,6 +575,7 @@ func main() {
 
 	// Create shared metrics collector for cross-package health reporting
 	sharedCollector := metrics.New()
+	multiTenantAuth.SetCollector(sharedCollector)
 
 	// Create a slice (list) of tool providers
 	var providers []global.ToolProvider
@@ -394,12 +594,12 @@ func main() {
 		}
 
 		// Set external URL for auth setup tools
-		if externalURL := os.Getenv("MCP_FUSION_EXTERNAL_URL"); externalURL != "" {
+		if externalURL, configured := getExternalURL(listen, tlsConfig); configured {
 			fusionOpts = append(fusionOpts, fusion.WithExternalURL(externalURL))
 			logger.Infof("External URL for auth setup: %s", externalURL)
 		} else {
-			fusionOpts = append(fusionOpts, fusion.WithExternalURL("http://"+listen))
-			logger.Warningf("MCP_FUSION_EXTERNAL_URL not set, using http://%s (may not be reachable externally)", listen)
+			fusionOpts = append(fusionOpts, fusion.WithExternalURL(externalURL))
+			logger.Warningf("MCP_FUSION_EXTERNAL_URL not set, using %s (may not be reachable externally)", externalURL)
 		}
 
 		// Set download directory for binary responses
@@ -408,6 +608,19 @@ func main() {
 			logger.Infof("Download directory: %s", dlDir)
 		}
 
+		// Set directory for asynchronous command job output
+		if jobsDir := os.Getenv("MCP_FUSION_JOBS_DIR"); jobsDir != "" {
+			fusionOpts = append(fusionOpts, fusion.WithJobsDir(jobsDir))
+			logger.Infof("Jobs directory: %s", jobsDir)
+		}
+
+		// Bound the asynchronous command jobs kept on disk and run at once
+		jobLimits, err := getJobLimits()
+		if err != nil {
+			logger.Fatalf("Invalid job limits: %v", err)
+		}
+		fusionOpts = append(fusionOpts, fusion.WithJobLimits(jobLimits))
+
 		// Add multi-tenant support if available
 		if multiTenantAuth != nil {
 			fusionOpts = append(fusionOpts, fusion.WithMultiTenantAuth(multiTenantAuth))
@@ -481,6 +694,7 @@ func main() {
 		logger.Infof("Found %d hub service(s) to connect", len(hubConfigs))
 		hubOpts := []hub.HubOption{
 			hub.WithSharedCollector(sharedCollector),
+			hub.WithTenantAuth(multiTenantAuth),
 		}
 		if dlDir := os.Getenv("MCP_FUSION_DL_DIR"); dlDir != "" {
 			hubOpts = append(hubOpts, hub.WithDownloadDir(dlDir))
@@ -502,6 +716,11 @@ func main() {
 		mcpserver.WithToolProviders(providers),
 	}
 
+	// List the resources and prompts of hub services using tenant credentials per tenant
+	if hubProvider != nil {
+		mcpOpts = append(mcpOpts, mcpserver.WithTenantCatalog(hubProvider))
+	}
+
 	// Setup resource and prompt providers (only if fusionProvider is initialized)
 	if fusionProvider != nil {
 		mcpOpts = append(mcpOpts,
@@ -515,12 +734,107 @@ func main() {
 	mcpOpts = append(mcpOpts, mcpserver.WithAuthManager(multiTenantAuth))
 	mcpOpts = append(mcpOpts, mcpserver.WithConfigManager(configManager))
 
+	// Enforce the tool access policy. The authorizer is always installed so that a
+	// policy added by a configuration reload takes effect; without one it allows all tools.
+	policyAuthorizer := fusion.NewPolicyAuthorizer(configManager.GetPolicy(), logger)
+	mcpOpts = append(mcpOpts, mcpserver.WithAuthorizer(policyAuthorizer))
+	if policy := configManager.GetPolicy(); policy != nil {
+		logger.Infof("Tool access policy enabled (%d rules, default: %s)", len(policy.Rules), policy.GetDefaultEffect())
+	}
+
+	// Apply rate and concurrency limits. Like the authorizer, the limiter is always
+	// installed so that limits added by a configuration reload take effect.
+	rateLimiter := fusion.NewRateLimiter(configManager.GetRateLimits(), sharedCollector, logger)
+	mcpOpts = append(mcpOpts, mcpserver.WithRateLimiter(rateLimiter))
+	if limits := configManager.GetRateLimits(); limits != nil {
+		logger.Infof("Rate limits enabled (%d limits)", len(limits.Limits))
+	}
+
+	// Record tool metrics and serve them in the OpenMetrics format at /metrics
+	mcpOpts = append(mcpOpts, mcpserver.WithCollector(sharedCollector))
+	if metricsEnabled {
+		var exporterOpts []metrics.ExporterOption
+		if fusionProvider != nil {
+			exporterOpts = append(exporterOpts, metrics.WithCircuitBreakerSource(fusionProvider))
+		}
+		mcpOpts = append(mcpOpts, mcpserver.WithMetricsHandler(metrics.NewExporter(sharedCollector, exporterOpts...)))
+		if metricsAuth {
+			logger.Info("Metrics endpoint enabled at /metrics (API token required)")
+		} else {
+			logger.Info("Metrics endpoint enabled at /metrics")
+		}
+	}
+
+	// Record every tool call in the audit log
+	if auditEnabled {
+		mcpOpts = append(mcpOpts, mcpserver.WithAuditLog(database))
+		logger.Infof("Audit log enabled (retention: %s)", describeAuditRetention(auditRetention, auditMaxEntries))
+	}
+
+	// Trace tool calls when an OTLP endpoint or trace file is configured
+	var tracer *tracing.Tracer
+	traceExporter, err := tracing.ExporterFromEnv()
+	if err != nil {
+		logger.Errorf("Invalid tracing configuration: %v", err)
+		os.Exit(1)
+	}
+	if traceExporter != nil {
+		tracerOpts := []tracing.Option{tracing.WithExporter(traceExporter), tracing.WithLogger(logger)}
+		if name := os.Getenv(tracing.EnvServiceName); name != "" {
+			tracerOpts = append(tracerOpts, tracing.WithServiceName(name))
+		}
+		tracer = tracing.New(tracerOpts...)
+		mcpOpts = append(mcpOpts, mcpserver.WithTracer(tracer))
+		logger.Info("Tracing enabled")
+	}
+
+	// Serve HTTPS when configured
+	if tlsConfig != nil {
+		mcpOpts = append(mcpOpts, mcpserver.WithTLS(tlsConfig))
+	}
+
 	// Add multi-tenant authentication middleware
-	authMiddleware := mcpserver.NewAuthMiddleware(multiTenantAuth, configManager,
+	authOpts := []mcpserver.AuthMiddlewareOption{
 		mcpserver.WithAuthLogger(logger),
 		mcpserver.WithRequireAuth(!noAuth),
-		mcpserver.WithSkipPaths("/health", "/metrics", "/status", "/capabilities"),
-	)
+	}
+	if metricsAuth {
+		authOpts = append(authOpts, mcpserver.WithSkipPaths("/health", "/status", "/capabilities"))
+	} else {
+		authOpts = append(authOpts, mcpserver.WithSkipPaths("/health", "/metrics", "/status", "/capabilities"))
+	}
+
+	// Map client certificate subjects to API tokens
+	var certMapper *mcpserver.ClientCertMapper
+	if *tlsClientMapFlag != "" {
+		resolveToken := func(identifier string) (string, error) {
+			token, err := findAPIToken(database, identifier)
+			if err != nil {
+				return "", err
+			}
+			return token.Hash, nil
+		}
+		certMapper, err = mcpserver.NewClientCertMapper(*tlsClientMapFlag, resolveToken, logger)
+		if err != nil {
+			logger.Fatalf("Failed to load client certificate map: %v", err)
+		}
+		authOpts = append(authOpts, mcpserver.WithClientCertMapper(certMapper))
+	}
+
+	// Built-in OAuth authorization server for MCP clients that use the spec's
+	// authorization flow (disabled by default because anyone can register
+	// clients; enable with MCP_FUSION_OAUTH_SERVER=true, 1, or yes)
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_FUSION_OAUTH_SERVER"))); !noAuth && (v == "true" || v == "1" || v == "yes") {
+		issuer, configured := getExternalURL(listen, tlsConfig)
+		if !configured {
+			logger.Warningf("MCP_FUSION_EXTERNAL_URL not set, OAuth authorization server issuer is %s (may not be reachable externally)", issuer)
+		}
+		oauthServer := mcpserver.NewOAuthServer(database.(*db.DB), issuer, logger)
+		mcpOpts = append(mcpOpts, mcpserver.WithOAuthServer(oauthServer))
+		authOpts = append(authOpts, mcpserver.WithResourceMetadata(oauthServer))
+	}
+
+	authMiddleware := mcpserver.NewAuthMiddleware(multiTenantAuth, configManager, authOpts...)
 	mcpOpts = append(mcpOpts, mcpserver.WithAuthMiddleware(authMiddleware))
 	if noAuth {
 		logger.Warning("Multi-tenant authentication middleware in NO-AUTH mode (insecure)")
@@ -538,6 +852,7 @@ func main() {
 	// Start hub provider after MCP server is created
 	if hubProvider != nil {
 		hubProvider.SetMCPServer(mcp.GetMCPServer())
+		hubProvider.SetResourceSubscribers(mcp.ResourceSubscriptions())
 		hubProvider.Start(context.Background())
 	}
 
@@ -546,12 +861,41 @@ func main() {
 		logger.Fatalf("MCP server failed to start: %v", err)
 	}
 
+	// Reload configuration on SIGHUP and, when MCP_FUSION_CONFIG_WATCH is set, on file changes
+	reloader := &configReloader{
+		logger:         logger,
+		configManager:  configManager,
+		fusionProvider: fusionProvider,
+		authorizer:     policyAuthorizer,
+		rateLimiter:    rateLimiter,
+		server:         mcp,
+		certMapper:     certMapper,
+	}
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if watch := os.Getenv("MCP_FUSION_CONFIG_WATCH"); watch != "" && len(configFiles) > 0 {
+		interval, err := time.ParseDuration(watch)
+		if err != nil || interval <= 0 {
+			logger.Warningf("Ignoring invalid MCP_FUSION_CONFIG_WATCH value %q (expected a duration such as 5s)", watch)
+		} else {
+			logger.Infof("Watching %d configuration files for changes every %v", len(configFiles), interval)
+			go configManager.Watch(watchCtx, interval, reloader.Reload)
+		}
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	// Wait for termination signal
-	<-sigChan
+	// Wait for termination signal, reloading configuration on SIGHUP
+	for sig := range sigChan {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		logger.Info("Received SIGHUP, reloading configuration")
+		reloader.Reload()
+	}
+	stopWatch()
 	logger.Infof("Shutting down...")
 
 	// Stop the MCP server
@@ -570,6 +914,15 @@ func main() {
 		fusionProvider.Shutdown()
 	}
 
+	// Export the remaining spans
+	if tracer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := tracer.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf("Error flushing traces: %v", err)
+		}
+		cancel()
+	}
+
 	// Close database connection if initialized
 	if database != nil {
 		if err := database.Close(); err != nil {
@@ -585,10 +938,26 @@ func main() {
 	os.Exit(0)
 }
 
+// getExternalURL returns the URL at which clients reach MCPFusion, from
+// MCP_FUSION_EXTERNAL_URL or else derived from the listen address and TLS
+// configuration. The second result reports whether it was configured.
+func getExternalURL(listen string, tlsConfig *mcpserver.TLSConfig) (string, bool) {
+	if externalURL := os.Getenv("MCP_FUSION_EXTERNAL_URL"); externalURL != "" {
+		return strings.TrimRight(externalURL, "/"), true
+	}
+	if tlsConfig != nil && len(tlsConfig.ACMEDomains) > 0 {
+		return "https://" + tlsConfig.ACMEDomains[0], false
+	}
+	if tlsConfig != nil {
+		return "https://" + listen, false
+	}
+	return "http://" + listen, false
+}
+
 // handleTokenCommands processes token management commands
-func handleTokenCommands(database db.Database, tokenAdd string, tokenList bool, tokenDelete string, tokenUser string, logger global.Logger) error {
+func handleTokenCommands(database db.Database, tokenAdd string, tokenList bool, tokenDelete string, tokenUser string, tokenAdmin bool, logger global.Logger) error {
 	if tokenAdd != "" {
-		return handleTokenAdd(database, tokenAdd, tokenUser, logger)
+		return handleTokenAdd(database, tokenAdd, tokenUser, tokenAdmin, logger)
 	}
 
 	if tokenList {
@@ -603,7 +972,7 @@ func handleTokenCommands(database db.Database, tokenAdd string, tokenList bool,
 }
 
 // handleTokenAdd creates a new API token
-func handleTokenAdd(database db.Database, description string, userID string, _ global.Logger) error {
+func handleTokenAdd(database db.Database, description string, userID string, admin bool, _ global.Logger) error {
 	if description == "" {
 		description = "API Token"
 	}
@@ -630,6 +999,12 @@ func handleTokenAdd(database db.Database, description string, userID string, _ g
 	fmt.Printf("Token:       %s\n", token)
 	fmt.Printf("Hash:        %s\n", hash[:12])
 	fmt.Printf("Description: %s\n", description)
+	if admin {
+		if err := database.SetAPITokenRole(hash, db.APITokenRoleAdmin); err != nil {
+			return fmt.Errorf("token created but failed to set admin role: %w", err)
+		}
+		fmt.Printf("Role:        %s\n", db.APITokenRoleAdmin)
+	}
 	fmt.Printf("\n")
 	fmt.Printf("Use this token in the Authorization header:\n")
 	fmt.Printf("  Authorization: Bearer %s\n", token)
@@ -661,8 +1036,8 @@ func handleTokenList(database db.Database, _ global.Logger) error {
 	}
 
 	fmt.Printf("API Tokens:\n")
-	fmt.Printf("%-10s %-20s %-20s %-20s %s\n", "PREFIX", "HASH", "CREATED", "LAST USED", "DESCRIPTION")
-	fmt.Printf("%-10s %-20s %-20s %-20s %s\n", "------", "----", "-------", "---------", "-----------")
+	fmt.Printf("%-10s %-20s %-20s %-20s %-6s %s\n", "PREFIX", "HASH", "CREATED", "LAST USED", "ROLE", "DESCRIPTION")
+	fmt.Printf("%-10s %-20s %-20s %-20s %-6s %s\n", "------", "----", "-------", "---------", "----", "-----------")
 
 	for _, token := range tokens {
 		prefix := token.Hash[:8]
@@ -680,37 +1055,51 @@ func handleTokenList(database db.Database, _ global.Logger) error {
 			description = description[:27] + "..."
 		}
 
-		fmt.Printf("%-10s %-20s %-20s %-20s %s\n", prefix, shortHash, createdAt, lastUsed, description)
+		role := db.APITokenRoleUser
+		if token.IsAdmin() {
+			role = db.APITokenRoleAdmin
+		}
+
+		fmt.Printf("%-10s %-20s %-20s %-20s %-6s %s\n", prefix, shortHash, createdAt, lastUsed, role, description)
 	}
 
 	fmt.Printf("\nTotal: %d tokens\n", len(tokens))
 	return nil
 }
 
-// handleTokenDelete removes an API token
-func handleTokenDelete(database db.Database, identifier string, _ global.Logger) error {
-	if identifier == "" {
-		return fmt.Errorf("token identifier is required")
-	}
-
-	// List tokens to find matching one
+// findAPIToken returns the API token whose hash is or starts with identifier,
+// as shown by -token-list
+func findAPIToken(database db.Database, identifier string) (*db.APITokenMetadata, error) {
 	tokens, err := database.ListAPITokens()
 	if err != nil {
-		return fmt.Errorf("failed to list API tokens: %w", err)
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
 	}
 
 	var matchedToken *db.APITokenMetadata
 	for _, token := range tokens {
 		if token.Hash == identifier || strings.HasPrefix(token.Hash, identifier) {
 			if matchedToken != nil {
-				return fmt.Errorf("multiple tokens match '%s'. Please use a longer prefix", identifier)
+				return nil, fmt.Errorf("multiple tokens match '%s'. Please use a longer prefix", identifier)
 			}
 			matchedToken = &token
 		}
 	}
 
 	if matchedToken == nil {
-		return fmt.Errorf("no API token found matching '%s'", identifier)
+		return nil, fmt.Errorf("no API token found matching '%s'", identifier)
+	}
+	return matchedToken, nil
+}
+
+// handleTokenDelete removes an API token
+func handleTokenDelete(database db.Database, identifier string, _ global.Logger) error {
+	if identifier == "" {
+		return fmt.Errorf("token identifier is required")
+	}
+
+	matchedToken, err := findAPIToken(database, identifier)
+	if err != nil {
+		return err
 	}
 
 	// Show token details and confirm deletion
@@ -965,6 +1354,249 @@ func handleUserUnlink(database db.Database, keyHash string, _ global.Logger) err
 	return nil
 }
 
+// getAuditRetention returns the audit log retention from MCP_FUSION_AUDIT_RETENTION
+// (a duration such as 2160h or a number of days such as 90d; 0 keeps entries
+// forever) and MCP_FUSION_AUDIT_MAX_ENTRIES
+func getAuditRetention() (time.Duration, int, error) {
+	retention, err := getRetentionEnv("MCP_FUSION_AUDIT_RETENTION", 90*24*time.Hour)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxEntries, err := getCountEnv("MCP_FUSION_AUDIT_MAX_ENTRIES", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	return retention, maxEntries, nil
+}
+
+// getJobLimits returns the asynchronous command job limits from
+// MCP_FUSION_JOBS_RETENTION (default 7 days), MCP_FUSION_JOBS_MAX_PER_TENANT
+// (default no limit) and MCP_FUSION_JOBS_MAX_RUNNING (default 10)
+func getJobLimits() (fusion.JobLimits, error) {
+	var limits fusion.JobLimits
+	var err error
+	if limits.Retention, err = getRetentionEnv("MCP_FUSION_JOBS_RETENTION", 7*24*time.Hour); err != nil {
+		return limits, err
+	}
+	if limits.MaxJobs, err = getCountEnv("MCP_FUSION_JOBS_MAX_PER_TENANT", 0); err != nil {
+		return limits, err
+	}
+	if limits.MaxRunningPerTenant, err = getCountEnv("MCP_FUSION_JOBS_MAX_RUNNING", 10); err != nil {
+		return limits, err
+	}
+	return limits, nil
+}
+
+// getRetentionEnv parses a retention period from the environment variable
+// name: a duration such as 2160h or a number of days such as 90d. 0 means
+// forever.
+func getRetentionEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return defaultValue, nil
+	}
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%s: invalid number of days %q", name, v)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	if v == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s: invalid duration %q", name, v)
+	}
+	return d, nil
+}
+
+// getCountEnv parses a non-negative count from the environment variable name.
+// 0 means no limit.
+func getCountEnv(name string, defaultValue int) (int, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s: invalid number %q", name, v)
+	}
+	return n, nil
+}
+
+// describeAuditRetention describes the audit log retention for the startup log
+func describeAuditRetention(retention time.Duration, maxEntries int) string {
+	description := "unlimited"
+	if retention > 0 {
+		description = retention.String()
+		if retention%(24*time.Hour) == 0 {
+			description = fmt.Sprintf("%d days", retention/(24*time.Hour))
+		}
+	}
+	if maxEntries > 0 {
+		description += fmt.Sprintf(", at most %d entries", maxEntries)
+	}
+	return description
+}
+
+// handleAuditList prints the audit log entries matching the filters
+func handleAuditList(database db.Database, userID, tenant, tool, since, until string, limit int) error {
+	filter := db.AuditFilter{UserID: userID, Tool: tool, Limit: limit}
+
+	// Tenants are identified by API token prefix or hash, as shown by -token-list
+	if tenant != "" {
+		token, err := findAPIToken(database, tenant)
+		if err != nil {
+			return err
+		}
+		filter.TenantHash = token.Hash
+	}
+
+	now := time.Now()
+	var err error
+	if filter.Since, err = db.ParseAuditTime(since, now); err != nil {
+		return fmt.Errorf("invalid -audit-since: %w", err)
+	}
+	if filter.Until, err = db.ParseAuditTime(until, now); err != nil {
+		return fmt.Errorf("invalid -audit-until: %w", err)
+	}
+
+	entries, err := database.QueryAuditLog(filter)
+	if err != nil {
+		return fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No audit log entries found.\n")
+		return nil
+	}
+
+	fmt.Printf("Audit Log:\n")
+	fmt.Printf("%-20s %-38s %-14s %-40s %-6s %9s %10s\n", "TIME", "USER ID", "TENANT", "TOOL", "STATUS", "DURATION", "BYTES")
+	fmt.Printf("%-20s %-38s %-14s %-40s %-6s %9s %10s\n", "----", "-------", "------", "----", "------", "--------", "-----")
+
+	for _, entry := range entries {
+		user := entry.UserID
+		if user == "" {
+			user = "-"
+		}
+		tenantHash := entry.TenantHash
+		if len(tenantHash) > 12 {
+			tenantHash = tenantHash[:12]
+		}
+		fmt.Printf("%-20s %-38s %-14s %-40s %-6s %7dms %10d\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05"), user, tenantHash, entry.Tool, entry.Status, entry.DurationMs, entry.Bytes)
+		if entry.Error != "" {
+			fmt.Printf("    error: %s\n", entry.Error)
+		}
+	}
+
+	fmt.Printf("\nTotal: %d entries\n", len(entries))
+	return nil
+}
+
+// handleOpenAPIImport generates a service configuration from an OpenAPI
+// document and writes it to outFile or standard output. Warnings are printed
+// to standard error so the output can be redirected to a file.
+func handleOpenAPIImport(specFile, serviceKey, tags, operations, exclude, baseURL, outFile string) error {
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", specFile, err)
+	}
+
+	if serviceKey == "" {
+		name := strings.TrimSuffix(filepath.Base(specFile), filepath.Ext(specFile))
+		serviceKey = strings.Trim(strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '_'
+		}, strings.ToLower(name)), "_")
+	}
+
+	imported, err := fusion.ImportOpenAPI(data, serviceKey, &fusion.OpenAPIConfig{
+		Tags:       splitCommaList(tags),
+		Operations: splitCommaList(operations),
+		Exclude:    splitCommaList(exclude),
+	})
+	if err != nil {
+		return err
+	}
+	service := imported.Service
+	if baseURL != "" {
+		service.BaseURL = baseURL
+	}
+
+	for _, warning := range imported.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if err := service.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the generated configuration needs editing before use: %v\n", err)
+	}
+
+	output, err := json.MarshalIndent(map[string]interface{}{
+		"services": map[string]*fusion.ServiceConfig{serviceKey: service},
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	output = append(output, '\n')
+
+	if outFile == "" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+	if err := os.WriteFile(outFile, output, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outFile, err)
+	}
+	fmt.Fprintf(os.Stderr, "Generated service '%s' with %d endpoints in %s\n", serviceKey, len(service.Endpoints), outFile)
+	return nil
+}
+
+// splitCommaList splits a comma-separated flag value, dropping empty items
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// handleEncryptionCommands processes the encryption-at-rest migration and key rotation commands
+func handleEncryptionCommands(database *db.DB, encrypt bool, rotateKeyFile string) error {
+	if !database.EncryptionEnabled() {
+		return fmt.Errorf("no master key configured; set %s or %s", db.MasterKeyEnvVar, db.MasterKeyFileEnvVar)
+	}
+
+	if encrypt {
+		count, err := database.EncryptExistingSecrets()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Encrypted %d OAuth token and credential records.\n", count)
+	}
+
+	if rotateKeyFile != "" {
+		newKey, err := db.ReadMasterKeyFile(rotateKeyFile)
+		if err != nil {
+			return err
+		}
+		count, err := database.RotateMasterKey(newKey)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Re-wrapped %d records with master key %s.\n", count, db.MasterKeyID(newKey))
+		fmt.Printf("Update %s or %s to the new key before restarting the server.\n",
+			db.MasterKeyEnvVar, db.MasterKeyFileEnvVar)
+	}
+
+	return nil
+}
+
 // getConfigFiles parses comma-separated config files from command line or environment
 func getConfigFiles(configFlag string, logger global.Logger) []string {
 	configPaths := configFlag
@@ -0,0 +1,772 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// adminMaxRequestBytes caps the size of admin API request bodies
+const adminMaxRequestBytes = 1024 * 1024
+
+// AdminAPIHandler provides HTTP endpoints under /api/v1/admin for managing
+// users, API tokens and the OAuth tokens and credentials stored per tenant,
+// and for querying the audit log. Every endpoint requires an API token with
+// the admin role.
+type AdminAPIHandler struct {
+	database *db.DB
+	logger   global.Logger
+}
+
+// NewAdminAPIHandler creates a new admin API handler
+func NewAdminAPIHandler(database *db.DB, logger global.Logger) *AdminAPIHandler {
+	return &AdminAPIHandler{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// AdminTokenInfo describes an API token. Token is only set when the token is created.
+type AdminTokenInfo struct {
+	Hash        string    `json:"hash"`
+	Prefix      string    `json:"prefix"`
+	Description string    `json:"description"`
+	Role        string    `json:"role"`
+	UserID      string    `json:"user_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsed    time.Time `json:"last_used"`
+	Token       string    `json:"token,omitempty"`
+}
+
+// AdminUserInfo describes a user and the hashes of its linked API keys
+type AdminUserInfo struct {
+	db.UserMetadata
+	APIKeys []string `json:"api_keys"`
+}
+
+// AdminOAuthTokenInfo describes a stored OAuth token without its secrets
+type AdminOAuthTokenInfo struct {
+	Service         string     `json:"service"`
+	TokenType       string     `json:"token_type"`
+	Scope           []string   `json:"scope,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Expired         bool       `json:"expired"`
+	HasRefreshToken bool       `json:"has_refresh_token"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// AdminCredentialInfo describes stored service credentials without their values
+type AdminCredentialInfo struct {
+	Service   string            `json:"service"`
+	Type      db.CredentialType `json:"type"`
+	Fields    []string          `json:"fields"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// AdminTenantInfo describes a tenant, which is identified by the hash of its API token
+type AdminTenantInfo struct {
+	AdminTokenInfo
+	OAuthTokens []AdminOAuthTokenInfo `json:"oauth_tokens"`
+	Credentials []AdminCredentialInfo `json:"credentials"`
+}
+
+// RegisterRoutes registers the admin API routes with the given mux
+func (h *AdminAPIHandler) RegisterRoutes(mux *http.ServeMux) {
+	routes := map[string]http.HandlerFunc{
+		"GET /api/v1/admin/users":                                   h.handleListUsers,
+		"POST /api/v1/admin/users":                                  h.handleCreateUser,
+		"GET /api/v1/admin/users/{id}":                              h.handleGetUser,
+		"DELETE /api/v1/admin/users/{id}":                           h.handleDeleteUser,
+		"POST /api/v1/admin/users/{id}/keys":                        h.handleLinkKey,
+		"DELETE /api/v1/admin/users/{id}/keys/{hash}":               h.handleUnlinkKey,
+		"GET /api/v1/admin/tokens":                                  h.handleListTokens,
+		"POST /api/v1/admin/tokens":                                 h.handleCreateToken,
+		"GET /api/v1/admin/tokens/{hash}":                           h.handleGetToken,
+		"PUT /api/v1/admin/tokens/{hash}/role":                      h.handleSetTokenRole,
+		"DELETE /api/v1/admin/tokens/{hash}":                        h.handleDeleteToken,
+		"GET /api/v1/admin/tenants":                                 h.handleListTenants,
+		"GET /api/v1/admin/tenants/{hash}":                          h.handleGetTenant,
+		"GET /api/v1/admin/tenants/{hash}/oauth":                    h.handleListOAuthTokens,
+		"PUT /api/v1/admin/tenants/{hash}/oauth/{service}":          h.handleStoreOAuthToken,
+		"DELETE /api/v1/admin/tenants/{hash}/oauth/{service}":       h.handleDeleteOAuthToken,
+		"GET /api/v1/admin/tenants/{hash}/credentials":              h.handleListCredentials,
+		"PUT /api/v1/admin/tenants/{hash}/credentials/{service}":    h.handleStoreCredentials,
+		"DELETE /api/v1/admin/tenants/{hash}/credentials/{service}": h.handleDeleteCredentials,
+		"GET /api/v1/admin/audit":                                   h.handleQueryAuditLog,
+	}
+	for pattern, handler := range routes {
+		mux.HandleFunc(pattern, h.requireAdmin(handler))
+	}
+}
+
+// requireAdmin only passes requests authenticated with an API token that has
+// the admin role. Tokens issued by the built-in OAuth authorization server are
+// meant for MCP clients and never grant admin access.
+func (h *AdminAPIHandler) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantContext, ok := r.Context().Value(global.TenantContextKey).(*fusion.TenantContext)
+		if !ok {
+			h.writeErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		if tenantContext.Metadata["oauth_client_id"] == "" {
+			if metadata, err := h.database.GetAPITokenMetadata(tenantContext.TenantHash); err == nil && metadata.IsAdmin() {
+				next(w, r)
+				return
+			}
+		}
+
+		h.logger.Warningf("Admin API request %s %s denied for tenant %s", r.Method, r.URL.Path, tenantContext.ShortHash())
+		h.writeErrorResponse(w, http.StatusForbidden, "Admin role required")
+	}
+}
+
+// handleListUsers handles GET /api/v1/admin/users
+func (h *AdminAPIHandler) handleListUsers(w http.ResponseWriter, _ *http.Request) {
+	users, err := h.database.ListUsers()
+	if err != nil {
+		h.writeDatabaseError(w, "list users", err)
+		return
+	}
+
+	result := make([]AdminUserInfo, 0, len(users))
+	for _, user := range users {
+		info, err := h.userInfo(&user)
+		if err != nil {
+			h.writeDatabaseError(w, "list users", err)
+			return
+		}
+		result = append(result, *info)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "users": result})
+}
+
+// handleCreateUser handles POST /api/v1/admin/users. A token_description in the
+// request also creates an API token linked to the user.
+func (h *AdminAPIHandler) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Description      string `json:"description"`
+		TokenDescription string `json:"token_description"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.database.CreateUser(req.Description)
+	if err != nil {
+		h.writeDatabaseError(w, "create user", err)
+		return
+	}
+
+	response := map[string]interface{}{"success": true, "user": AdminUserInfo{UserMetadata: *user, APIKeys: []string{}}}
+	if req.TokenDescription != "" {
+		token, err := h.createToken(req.TokenDescription, db.APITokenRoleUser, user.UserID)
+		if err != nil {
+			h.writeDatabaseError(w, "create token", err)
+			return
+		}
+		response["user"] = AdminUserInfo{UserMetadata: *user, APIKeys: []string{token.Hash}}
+		response["token"] = token
+	}
+
+	h.logger.Infof("Admin API: created user %s", user.UserID)
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// handleGetUser handles GET /api/v1/admin/users/{id}
+func (h *AdminAPIHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.database.GetUser(r.PathValue("id"))
+	if err != nil {
+		h.writeDatabaseError(w, "get user", err)
+		return
+	}
+
+	info, err := h.userInfo(user)
+	if err != nil {
+		h.writeDatabaseError(w, "get user", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "user": info})
+}
+
+// handleDeleteUser handles DELETE /api/v1/admin/users/{id}. The user's API keys
+// are unlinked but not deleted.
+func (h *AdminAPIHandler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	if err := h.database.DeleteUser(userID); err != nil {
+		h.writeDatabaseError(w, "delete user", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: deleted user %s", userID)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "User deleted"})
+}
+
+// handleLinkKey handles POST /api/v1/admin/users/{id}/keys
+func (h *AdminAPIHandler) handleLinkKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Hash string `json:"hash"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+
+	userID := r.PathValue("id")
+	if err := h.database.LinkAPIKey(userID, req.Hash); err != nil {
+		h.writeDatabaseError(w, "link API key", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: linked API key %s to user %s", safePrefix(req.Hash), userID)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "API key linked"})
+}
+
+// handleUnlinkKey handles DELETE /api/v1/admin/users/{id}/keys/{hash}
+func (h *AdminAPIHandler) handleUnlinkKey(w http.ResponseWriter, r *http.Request) {
+	userID, hash := r.PathValue("id"), r.PathValue("hash")
+	if linkedUser, err := h.database.GetUserByAPIKey(hash); err != nil || linkedUser != userID {
+		h.writeErrorResponse(w, http.StatusNotFound, "API key is not linked to this user")
+		return
+	}
+
+	if err := h.database.UnlinkAPIKey(hash); err != nil {
+		h.writeDatabaseError(w, "unlink API key", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: unlinked API key %s from user %s", safePrefix(hash), userID)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "API key unlinked"})
+}
+
+// handleListTokens handles GET /api/v1/admin/tokens
+func (h *AdminAPIHandler) handleListTokens(w http.ResponseWriter, _ *http.Request) {
+	tokens, err := h.database.ListAPITokens()
+	if err != nil {
+		h.writeDatabaseError(w, "list tokens", err)
+		return
+	}
+
+	result := make([]AdminTokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, h.tokenInfo(&token))
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "tokens": result})
+}
+
+// handleCreateToken handles POST /api/v1/admin/tokens. The token is returned
+// only in this response.
+func (h *AdminAPIHandler) handleCreateToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Description string `json:"description"`
+		Role        string `json:"role"`
+		UserID      string `json:"user_id"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Description == "" {
+		req.Description = "API Token"
+	}
+	if req.Role == "" {
+		req.Role = db.APITokenRoleUser
+	}
+	if req.Role != db.APITokenRoleUser && req.Role != db.APITokenRoleAdmin {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Role must be user or admin")
+		return
+	}
+	if req.UserID != "" {
+		if _, err := h.database.GetUser(req.UserID); err != nil {
+			h.writeDatabaseError(w, "create token", err)
+			return
+		}
+	}
+
+	token, err := h.createToken(req.Description, req.Role, req.UserID)
+	if err != nil {
+		h.writeDatabaseError(w, "create token", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: created API token %s with role %s", safePrefix(token.Hash), token.Role)
+	h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{"success": true, "token": token})
+}
+
+// handleGetToken handles GET /api/v1/admin/tokens/{hash}
+func (h *AdminAPIHandler) handleGetToken(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.database.GetAPITokenMetadata(r.PathValue("hash"))
+	if err != nil {
+		h.writeDatabaseError(w, "get token", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "token": h.tokenInfo(metadata)})
+}
+
+// handleSetTokenRole handles PUT /api/v1/admin/tokens/{hash}/role
+func (h *AdminAPIHandler) handleSetTokenRole(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Role string `json:"role"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+
+	hash := r.PathValue("hash")
+	if hash == h.callerHash(r) && req.Role != db.APITokenRoleAdmin {
+		h.writeErrorResponse(w, http.StatusConflict, "The token used for this request cannot remove its own admin role")
+		return
+	}
+
+	if err := h.database.SetAPITokenRole(hash, req.Role); err != nil {
+		h.writeDatabaseError(w, "set token role", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: set role of API token %s to %s", safePrefix(hash), req.Role)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "Role updated"})
+}
+
+// handleDeleteToken handles DELETE /api/v1/admin/tokens/{hash}. The tenant's
+// stored OAuth tokens and credentials are kept.
+func (h *AdminAPIHandler) handleDeleteToken(w http.ResponseWriter, r *http.Request) {
+	hash := r.PathValue("hash")
+	if hash == h.callerHash(r) {
+		h.writeErrorResponse(w, http.StatusConflict, "The token used for this request cannot delete itself")
+		return
+	}
+
+	if err := h.database.DeleteAPIToken(hash); err != nil {
+		h.writeDatabaseError(w, "delete token", err)
+		return
+	}
+
+	// Remove the user link as well; a token without a user is not an error
+	if err := h.database.UnlinkAPIKey(hash); err != nil && !errors.Is(err, db.ErrUserNotFound) {
+		h.logger.Warningf("Admin API: failed to unlink deleted API token %s: %v", safePrefix(hash), err)
+	}
+
+	h.logger.Infof("Admin API: deleted API token %s", safePrefix(hash))
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "Token deleted"})
+}
+
+// handleListTenants handles GET /api/v1/admin/tenants
+func (h *AdminAPIHandler) handleListTenants(w http.ResponseWriter, _ *http.Request) {
+	tokens, err := h.database.ListAPITokens()
+	if err != nil {
+		h.writeDatabaseError(w, "list tenants", err)
+		return
+	}
+
+	result := make([]AdminTenantInfo, 0, len(tokens))
+	for _, token := range tokens {
+		tenant, err := h.tenantInfo(&token)
+		if err != nil {
+			h.writeDatabaseError(w, "list tenants", err)
+			return
+		}
+		result = append(result, *tenant)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "tenants": result})
+}
+
+// handleGetTenant handles GET /api/v1/admin/tenants/{hash}
+func (h *AdminAPIHandler) handleGetTenant(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantInfo(metadata)
+	if err != nil {
+		h.writeDatabaseError(w, "get tenant", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "tenant": tenant})
+}
+
+// handleListOAuthTokens handles GET /api/v1/admin/tenants/{hash}/oauth
+func (h *AdminAPIHandler) handleListOAuthTokens(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.oauthTokenInfo(metadata.Hash)
+	if err != nil {
+		h.writeDatabaseError(w, "list OAuth tokens", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "oauth_tokens": tokens})
+}
+
+// handleStoreOAuthToken handles PUT /api/v1/admin/tenants/{hash}/oauth/{service}
+func (h *AdminAPIHandler) handleStoreOAuthToken(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		AccessToken  string            `json:"access_token"`
+		RefreshToken string            `json:"refresh_token"`
+		TokenType    string            `json:"token_type"`
+		ExpiresIn    int               `json:"expires_in"`
+		Scope        []string          `json:"scope"`
+		Metadata     map[string]string `json:"metadata"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+	if req.TokenType == "" {
+		req.TokenType = "Bearer"
+	}
+
+	tokenData := &db.OAuthTokenData{
+		AccessToken:  req.AccessToken,
+		RefreshToken: req.RefreshToken,
+		TokenType:    req.TokenType,
+		Scope:        req.Scope,
+		Metadata:     req.Metadata,
+	}
+	if req.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Second)
+		tokenData.ExpiresAt = &expiresAt
+	}
+
+	service := r.PathValue("service")
+	if err := h.database.StoreOAuthToken(metadata.Hash, service, tokenData); err != nil {
+		h.writeDatabaseError(w, "store OAuth token", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: stored OAuth token for tenant %s service %s", safePrefix(metadata.Hash), service)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "OAuth token stored"})
+}
+
+// handleDeleteOAuthToken handles DELETE /api/v1/admin/tenants/{hash}/oauth/{service}
+func (h *AdminAPIHandler) handleDeleteOAuthToken(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	service := r.PathValue("service")
+	if err := h.database.DeleteOAuthToken(metadata.Hash, service); err != nil {
+		h.writeDatabaseError(w, "delete OAuth token", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: deleted OAuth token for tenant %s service %s", safePrefix(metadata.Hash), service)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "OAuth token deleted"})
+}
+
+// handleListCredentials handles GET /api/v1/admin/tenants/{hash}/credentials
+func (h *AdminAPIHandler) handleListCredentials(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	credentials, err := h.credentialInfo(metadata.Hash)
+	if err != nil {
+		h.writeDatabaseError(w, "list credentials", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "credentials": credentials})
+}
+
+// handleStoreCredentials handles PUT /api/v1/admin/tenants/{hash}/credentials/{service}
+func (h *AdminAPIHandler) handleStoreCredentials(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Type db.CredentialType      `json:"type"`
+		Data map[string]interface{} `json:"data"`
+	}
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.Data) == 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Credential data is required")
+		return
+	}
+	if req.Type == "" {
+		req.Type = db.CredentialTypeCustom
+	}
+
+	service := r.PathValue("service")
+	credentials := &db.ServiceCredentials{Type: req.Type, Data: req.Data}
+	if err := h.database.StoreCredentials(metadata.Hash, service, credentials); err != nil {
+		h.writeDatabaseError(w, "store credentials", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: stored credentials for tenant %s service %s", safePrefix(metadata.Hash), service)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "Credentials stored"})
+}
+
+// handleDeleteCredentials handles DELETE /api/v1/admin/tenants/{hash}/credentials/{service}
+func (h *AdminAPIHandler) handleDeleteCredentials(w http.ResponseWriter, r *http.Request) {
+	metadata, ok := h.lookupTenant(w, r)
+	if !ok {
+		return
+	}
+
+	service := r.PathValue("service")
+	if err := h.database.DeleteCredentials(metadata.Hash, service); err != nil {
+		h.writeDatabaseError(w, "delete credentials", err)
+		return
+	}
+
+	h.logger.Infof("Admin API: deleted credentials for tenant %s service %s", safePrefix(metadata.Hash), service)
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "message": "Credentials deleted"})
+}
+
+// handleQueryAuditLog handles GET /api/v1/admin/audit. The user_id, tenant and
+// tool query parameters filter the entries; since and until bound their time.
+func (h *AdminAPIHandler) handleQueryAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := db.AuditFilter{
+		UserID:     query.Get("user_id"),
+		TenantHash: query.Get("tenant"),
+		Tool:       query.Get("tool"),
+	}
+
+	now := time.Now()
+	var err error
+	if filter.Since, err = db.ParseAuditTime(query.Get("since"), now); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid since: "+err.Error())
+		return
+	}
+	if filter.Until, err = db.ParseAuditTime(query.Get("until"), now); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid until: "+err.Error())
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	entries, err := h.database.QueryAuditLog(filter)
+	if err != nil {
+		h.writeDatabaseError(w, "query audit log", err)
+		return
+	}
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"success": true, "entries": entries, "count": len(entries)})
+}
+
+// createToken creates an API token with a role, optionally linked to a user
+func (h *AdminAPIHandler) createToken(description, role, userID string) (*AdminTokenInfo, error) {
+	token, hash, err := h.database.AddAPIToken(description)
+	if err != nil {
+		return nil, err
+	}
+	if role == db.APITokenRoleAdmin {
+		if err := h.database.SetAPITokenRole(hash, role); err != nil {
+			return nil, err
+		}
+	}
+	if userID != "" {
+		if err := h.database.LinkAPIKey(userID, hash); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata, err := h.database.GetAPITokenMetadata(hash)
+	if err != nil {
+		return nil, err
+	}
+	info := h.tokenInfo(metadata)
+	info.Token = token
+	return &info, nil
+}
+
+// tokenInfo describes an API token and the user it is linked to
+func (h *AdminAPIHandler) tokenInfo(metadata *db.APITokenMetadata) AdminTokenInfo {
+	info := AdminTokenInfo{
+		Hash:        metadata.Hash,
+		Prefix:      metadata.Prefix,
+		Description: metadata.Description,
+		Role:        db.APITokenRoleUser,
+		CreatedAt:   metadata.CreatedAt,
+		LastUsed:    metadata.LastUsed,
+	}
+	if metadata.IsAdmin() {
+		info.Role = db.APITokenRoleAdmin
+	}
+	if userID, err := h.database.GetUserByAPIKey(metadata.Hash); err == nil {
+		info.UserID = userID
+	}
+	return info
+}
+
+// userInfo describes a user and its linked API keys
+func (h *AdminAPIHandler) userInfo(user *db.UserMetadata) (*AdminUserInfo, error) {
+	keys, err := h.database.ListUserAPIKeys(user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminUserInfo{UserMetadata: *user, APIKeys: keys}, nil
+}
+
+// tenantInfo describes a tenant with its stored OAuth tokens and credentials
+func (h *AdminAPIHandler) tenantInfo(metadata *db.APITokenMetadata) (*AdminTenantInfo, error) {
+	oauthTokens, err := h.oauthTokenInfo(metadata.Hash)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := h.credentialInfo(metadata.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminTenantInfo{
+		AdminTokenInfo: h.tokenInfo(metadata),
+		OAuthTokens:    oauthTokens,
+		Credentials:    credentials,
+	}, nil
+}
+
+// oauthTokenInfo describes the OAuth tokens stored for a tenant, sorted by service
+func (h *AdminAPIHandler) oauthTokenInfo(tenantHash string) ([]AdminOAuthTokenInfo, error) {
+	tokens, err := h.database.ListOAuthTokens(tenantHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AdminOAuthTokenInfo, 0, len(tokens))
+	for service, token := range tokens {
+		result = append(result, AdminOAuthTokenInfo{
+			Service:         service,
+			TokenType:       token.TokenType,
+			Scope:           token.Scope,
+			ExpiresAt:       token.ExpiresAt,
+			Expired:         token.IsExpired(),
+			HasRefreshToken: token.HasRefreshToken(),
+			CreatedAt:       token.CreatedAt,
+			UpdatedAt:       token.UpdatedAt,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Service < result[j].Service })
+	return result, nil
+}
+
+// credentialInfo describes the credentials stored for a tenant, sorted by
+// service. Only the names of the credential fields are included.
+func (h *AdminAPIHandler) credentialInfo(tenantHash string) ([]AdminCredentialInfo, error) {
+	credentials, err := h.database.ListCredentials(tenantHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AdminCredentialInfo, 0, len(credentials))
+	for service, credential := range credentials {
+		fields := make([]string, 0, len(credential.Data))
+		for field := range credential.Data {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		result = append(result, AdminCredentialInfo{
+			Service:   service,
+			Type:      credential.Type,
+			Fields:    fields,
+			CreatedAt: credential.CreatedAt,
+			UpdatedAt: credential.UpdatedAt,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Service < result[j].Service })
+	return result, nil
+}
+
+// lookupTenant returns the API token of the tenant in the request path,
+// writing an error response if it does not exist
+func (h *AdminAPIHandler) lookupTenant(w http.ResponseWriter, r *http.Request) (*db.APITokenMetadata, bool) {
+	metadata, err := h.database.GetAPITokenMetadata(r.PathValue("hash"))
+	if err != nil {
+		h.writeDatabaseError(w, "get tenant", err)
+		return nil, false
+	}
+	return metadata, true
+}
+
+// callerHash returns the tenant hash of the request's API token
+func (h *AdminAPIHandler) callerHash(r *http.Request) string {
+	if tenantContext, ok := r.Context().Value(global.TenantContextKey).(*fusion.TenantContext); ok {
+		return tenantContext.TenantHash
+	}
+	return ""
+}
+
+// decodeRequest decodes a JSON request body, writing an error response on failure
+func (h *AdminAPIHandler) decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, adminMaxRequestBytes)).Decode(v); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+	return true
+}
+
+// writeDatabaseError maps a database error to a JSON error response
+func (h *AdminAPIHandler) writeDatabaseError(w http.ResponseWriter, action string, err error) {
+	switch {
+	case db.IsValidationError(err):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case db.IsNotFound(err):
+		h.writeErrorResponse(w, http.StatusNotFound, "Not found")
+	case errors.Is(err, db.ErrKeyAlreadyLinked):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.logger.Errorf("Admin API: failed to %s: %v", action, err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// writeJSONResponse writes a JSON response
+func (h *AdminAPIHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Errorf("Failed to encode JSON response: %v", err)
+	}
+}
+
+// writeErrorResponse writes a JSON error response
+func (h *AdminAPIHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	h.writeJSONResponse(w, statusCode, map[string]interface{}{
+		"success": false,
+		"error": map[string]interface{}{
+			"code":    statusCode,
+			"message": message,
+			"type":    "admin_error",
+		},
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
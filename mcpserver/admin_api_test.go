@@ -0,0 +1,211 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tenebris-tech/mlogger"
+
+	"github.com/PivotLLM/MCPFusion/db"
+)
+
+// adminTestEnv is the admin API behind the auth middleware, with an admin and a regular API token
+type adminTestEnv struct {
+	t          *testing.T
+	database   db.Database
+	handler    http.Handler
+	adminToken string
+	adminHash  string
+	userToken  string
+	userHash   string
+}
+
+func newAdminTestEnv(t *testing.T) *adminTestEnv {
+	t.Helper()
+	manager, database, tempDir := newTestAuthManagerWithDB(t)
+	t.Cleanup(func() {
+		_ = database.Close()
+		_ = os.RemoveAll(tempDir)
+	})
+
+	adminToken, adminHash, err := database.AddAPIToken("admin")
+	if err != nil {
+		t.Fatalf("failed to add admin token: %v", err)
+	}
+	if err := database.SetAPITokenRole(adminHash, db.APITokenRoleAdmin); err != nil {
+		t.Fatalf("failed to set admin role: %v", err)
+	}
+	userToken, userHash, err := database.AddAPIToken("user")
+	if err != nil {
+		t.Fatalf("failed to add user token: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	NewAdminAPIHandler(database.(*db.DB), mlogger.NewMemoryLogger()).RegisterRoutes(mux)
+	am := NewAuthMiddleware(manager, nil, WithRequireAuth(true))
+
+	return &adminTestEnv{
+		t:          t,
+		database:   database,
+		handler:    am.SimpleMiddleware(mux),
+		adminToken: adminToken,
+		adminHash:  adminHash,
+		userToken:  userToken,
+		userHash:   userHash,
+	}
+}
+
+// call sends a JSON request with the given token and decodes the response,
+// failing the test on an unexpected status
+func (e *adminTestEnv) call(token, method, path string, body interface{}, status int) map[string]interface{} {
+	e.t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	e.handler.ServeHTTP(rr, req)
+
+	if rr.Code != status {
+		e.t.Fatalf("%s %s: expected status %d, got %d: %s", method, path, status, rr.Code, rr.Body.String())
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		e.t.Fatalf("%s %s: invalid JSON response: %v", method, path, err)
+	}
+	return result
+}
+
+func TestAdminAPI_RequiresAdminRole(t *testing.T) {
+	env := newAdminTestEnv(t)
+
+	env.call(env.userToken, http.MethodGet, "/api/v1/admin/users", nil, http.StatusForbidden)
+	env.call(env.userToken, http.MethodPost, "/api/v1/admin/tokens", map[string]string{"role": "admin"}, http.StatusForbidden)
+	env.call(env.adminToken, http.MethodGet, "/api/v1/admin/users", nil, http.StatusOK)
+
+	// Promoting a token takes effect on its next request
+	env.call(env.adminToken, http.MethodPut, "/api/v1/admin/tokens/"+env.userHash+"/role", map[string]string{"role": "admin"}, http.StatusOK)
+	env.call(env.userToken, http.MethodGet, "/api/v1/admin/users", nil, http.StatusOK)
+
+	// The admin token cannot lock itself out
+	env.call(env.adminToken, http.MethodPut, "/api/v1/admin/tokens/"+env.adminHash+"/role", map[string]string{"role": "user"}, http.StatusConflict)
+	env.call(env.adminToken, http.MethodDelete, "/api/v1/admin/tokens/"+env.adminHash, nil, http.StatusConflict)
+	env.call(env.adminToken, http.MethodPut, "/api/v1/admin/tokens/"+env.userHash+"/role", map[string]string{"role": "owner"}, http.StatusBadRequest)
+}
+
+func TestAdminAPI_UsersAndTokens(t *testing.T) {
+	env := newAdminTestEnv(t)
+
+	created := env.call(env.adminToken, http.MethodPost, "/api/v1/admin/users",
+		map[string]string{"description": "Alice", "token_description": "laptop"}, http.StatusCreated)
+	userID := created["user"].(map[string]interface{})["user_id"].(string)
+	token := created["token"].(map[string]interface{})
+	if token["token"] == "" || token["user_id"] != userID || token["role"] != "user" {
+		t.Fatalf("unexpected token in response: %v", token)
+	}
+	laptopHash := token["hash"].(string)
+
+	// A second token is created separately and linked afterwards
+	created = env.call(env.adminToken, http.MethodPost, "/api/v1/admin/tokens",
+		map[string]string{"description": "phone"}, http.StatusCreated)
+	phoneHash := created["token"].(map[string]interface{})["hash"].(string)
+	env.call(env.adminToken, http.MethodPost, "/api/v1/admin/users/"+userID+"/keys",
+		map[string]string{"hash": phoneHash}, http.StatusOK)
+	env.call(env.adminToken, http.MethodPost, "/api/v1/admin/users/"+userID+"/keys",
+		map[string]string{"hash": phoneHash}, http.StatusConflict)
+
+	user := env.call(env.adminToken, http.MethodGet, "/api/v1/admin/users/"+userID, nil, http.StatusOK)["user"].(map[string]interface{})
+	if keys := user["api_keys"].([]interface{}); len(keys) != 2 {
+		t.Fatalf("expected 2 linked keys, got %v", keys)
+	}
+
+	// Unlinking checks the key belongs to the user
+	env.call(env.adminToken, http.MethodDelete, "/api/v1/admin/users/"+userID+"/keys/"+env.userHash, nil, http.StatusNotFound)
+	env.call(env.adminToken, http.MethodDelete, "/api/v1/admin/users/"+userID+"/keys/"+phoneHash, nil, http.StatusOK)
+
+	// Deleting a token removes its link
+	env.call(env.adminToken, http.MethodDelete, "/api/v1/admin/tokens/"+laptopHash, nil, http.StatusOK)
+	env.call(env.adminToken, http.MethodGet, "/api/v1/admin/tokens/"+laptopHash, nil, http.StatusNotFound)
+	user = env.call(env.adminToken, http.MethodGet, "/api/v1/admin/users/"+userID, nil, http.StatusOK)["user"].(map[string]interface{})
+	if keys := user["api_keys"].([]interface{}); len(keys) != 0 {
+		t.Fatalf("expected no linked keys, got %v", keys)
+	}
+
+	tokens := env.call(env.adminToken, http.MethodGet, "/api/v1/admin/tokens", nil, http.StatusOK)["tokens"].([]interface{})
+	if len(tokens) != 3 {
+		t.Fatalf("expected 3 tokens, got %d", len(tokens))
+	}
+	for _, item := range tokens {
+		if _, ok := item.(map[string]interface{})["token"]; ok {
+			t.Fatal("listed tokens must not include the token value")
+		}
+	}
+
+	env.call(env.adminToken, http.MethodDelete, "/api/v1/admin/users/"+userID, nil, http.StatusOK)
+	env.call(env.adminToken, http.MethodGet, "/api/v1/admin/users/"+userID, nil, http.StatusNotFound)
+}
+
+func TestAdminAPI_TenantCredentials(t *testing.T) {
+	env := newAdminTestEnv(t)
+	tenantPath := "/api/v1/admin/tenants/" + env.userHash
+
+	env.call(env.adminToken, http.MethodPut, tenantPath+"/credentials/github",
+		map[string]interface{}{"type": "api_key", "data": map[string]string{"api_key": "secret-key"}}, http.StatusOK)
+	env.call(env.adminToken, http.MethodPut, tenantPath+"/oauth/microsoft365",
+		map[string]interface{}{"access_token": "secret-access", "refresh_token": "secret-refresh", "expires_in": 3600}, http.StatusOK)
+	env.call(env.adminToken, http.MethodPut, tenantPath+"/credentials/github",
+		map[string]interface{}{"type": "api_key"}, http.StatusBadRequest)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, tenantPath, nil)
+	req.Header.Set("Authorization", "Bearer "+env.adminToken)
+	env.handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if strings.Contains(rr.Body.String(), "secret-") {
+		t.Fatalf("tenant details must not include secrets: %s", rr.Body.String())
+	}
+
+	var result struct {
+		Tenant AdminTenantInfo `json:"tenant"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(result.Tenant.Credentials) != 1 || result.Tenant.Credentials[0].Fields[0] != "api_key" {
+		t.Fatalf("unexpected credentials: %+v", result.Tenant.Credentials)
+	}
+	if len(result.Tenant.OAuthTokens) != 1 || !result.Tenant.OAuthTokens[0].HasRefreshToken || result.Tenant.OAuthTokens[0].Expired {
+		t.Fatalf("unexpected OAuth tokens: %+v", result.Tenant.OAuthTokens)
+	}
+
+	env.call(env.adminToken, http.MethodDelete, tenantPath+"/credentials/github", nil, http.StatusOK)
+	env.call(env.adminToken, http.MethodDelete, tenantPath+"/oauth/microsoft365", nil, http.StatusOK)
+	env.call(env.adminToken, http.MethodDelete, tenantPath+"/oauth/microsoft365", nil, http.StatusNotFound)
+
+	tenants := env.call(env.adminToken, http.MethodGet, "/api/v1/admin/tenants", nil, http.StatusOK)["tenants"].([]interface{})
+	if len(tenants) != 2 {
+		t.Fatalf("expected 2 tenants, got %d", len(tenants))
+	}
+
+	// Unknown tenants are not created implicitly
+	env.call(env.adminToken, http.MethodPut, "/api/v1/admin/tenants/"+strings.Repeat("0", 64)+"/credentials/github",
+		map[string]interface{}{"data": map[string]string{"api_key": "x"}}, http.StatusNotFound)
+}
@@ -0,0 +1,143 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
+)
+
+// auditMaxErrorLength caps the length of error messages stored in the audit log
+const auditMaxErrorLength = 512
+
+// auditRedacted replaces the values of sensitive arguments before hashing
+const auditRedacted = "[REDACTED]"
+
+// auditSensitiveNames are substrings of argument names whose values are redacted
+var auditSensitiveNames = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "authorization", "credential", "cookie", "private_key"}
+
+// WithToolAuditing records every tool call in the audit log, including calls
+// that are denied or throttled, with the caller, outcome, duration and size of
+// the result. Argument values are never stored: the entry holds the argument
+// names and a digest of the arguments with sensitive values redacted.
+func WithToolAuditing(database db.Database, logger global.Logger) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			entry := &db.AuditEntry{
+				Timestamp:  start,
+				Tool:       request.Params.Name,
+				Status:     "ok",
+				DurationMs: time.Since(start).Milliseconds(),
+				Bytes:      toolResultSize(result),
+			}
+			entry.ArgumentKeys, entry.ArgumentsDigest = auditArguments(request.GetArguments())
+			if service, serviceErr := global.ExtractServiceFromToolName(request.Params.Name); serviceErr == nil {
+				entry.Service = service
+			}
+			if tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext); ok {
+				entry.TenantHash = tenantContext.TenantHash
+				entry.UserID = tenantContext.UserID
+				entry.RequestID = tenantContext.RequestID
+			}
+			if record, ok := ctx.Value(global.RequestRecordKey).(*global.RequestRecord); ok {
+				entry.ClientIP = record.IP
+			}
+			if span := tracing.SpanFromContext(ctx); span != nil {
+				entry.TraceID = span.SpanContext().TraceID.String()
+			}
+			if err != nil || (result != nil && result.IsError) {
+				entry.Status = "error"
+			}
+			if err != nil {
+				entry.Error = err.Error()
+				if len(entry.Error) > auditMaxErrorLength {
+					entry.Error = entry.Error[:auditMaxErrorLength]
+				}
+			}
+
+			if auditErr := database.RecordAuditEntry(entry); auditErr != nil && logger != nil {
+				logger.Errorf("Failed to record audit entry for tool %s: %v", request.Params.Name, auditErr)
+			}
+			return result, err
+		}
+	})
+}
+
+// auditArguments returns the sorted names of the arguments and the SHA-256
+// digest of their JSON encoding with sensitive values redacted. The digest lets
+// an investigator match calls with identical arguments, or confirm suspected
+// arguments, without the audit log holding their values.
+func auditArguments(args map[string]any) ([]string, string) {
+	if len(args) == 0 {
+		return nil, ""
+	}
+
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// encoding/json sorts map keys, so equal arguments encode identically
+	encoded, err := json.Marshal(redactArguments(args))
+	if err != nil {
+		return keys, ""
+	}
+	digest := sha256.Sum256(encoded)
+	return keys, "sha256:" + hex.EncodeToString(digest[:])
+}
+
+// redactArguments returns a copy of a value with the values of sensitive
+// object members replaced, at any depth
+func redactArguments(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, member := range v {
+			if isSensitiveArgument(key) {
+				redacted[key] = auditRedacted
+			} else {
+				redacted[key] = redactArguments(member)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactArguments(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// isSensitiveArgument reports whether an argument name suggests a secret value
+func isSensitiveArgument(name string) bool {
+	lower := strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	for _, sensitive := range auditSensitiveNames {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tenebris-tech/mlogger"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// waitForAuditEntries polls the audit log until the background writer has
+// stored the expected number of entries
+func waitForAuditEntries(t *testing.T, database db.Database, want int) []db.AuditEntry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, err := database.QueryAuditLog(db.AuditFilter{})
+		if err != nil {
+			t.Fatalf("failed to query audit log: %v", err)
+		}
+		if len(entries) >= want || time.Now().After(deadline) {
+			if len(entries) != want {
+				t.Fatalf("expected %d audit entries, got %d", want, len(entries))
+			}
+			return entries
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestWithToolAuditing ensures tool calls are recorded with the caller and
+// outcome, and that argument values never reach the audit log
+func TestWithToolAuditing(t *testing.T) {
+	_, database, tempDir := newTestAuthManagerWithDB(t)
+	defer func() {
+		_ = database.Close()
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	srv := server.NewMCPServer("test", "1.0", WithToolAuditing(database, mlogger.NewMemoryLogger()))
+	srv.AddTool(mcp.NewTool("svc_ok"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("hello"), nil
+	})
+	srv.AddTool(mcp.NewTool("svc_fail"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, context.DeadlineExceeded
+	})
+
+	ctx := context.WithValue(context.Background(), global.TenantContextKey,
+		&fusion.TenantContext{TenantHash: "0123456789abcdef", UserID: "alice", RequestID: "req-1"})
+	ctx = context.WithValue(ctx, global.RequestRecordKey, &global.RequestRecord{IP: "192.0.2.1"})
+
+	call := func(tool, arguments string) {
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `","arguments":` + arguments + `}}`
+		srv.HandleMessage(ctx, json.RawMessage(message))
+	}
+	call("svc_ok", `{"query":"q","password":"hunter2"}`)
+	call("svc_ok", `{"query":"q","password":"other"}`)
+	call("svc_fail", `{}`)
+
+	entries := waitForAuditEntries(t, database, 3)
+
+	// Newest first
+	failed, second, first := entries[0], entries[1], entries[2]
+	if first.Tool != "svc_ok" || first.Service != "svc" || first.Status != "ok" || first.UserID != "alice" ||
+		first.TenantHash != "0123456789abcdef" || first.RequestID != "req-1" || first.ClientIP != "192.0.2.1" {
+		t.Errorf("unexpected entry %+v", first)
+	}
+	if first.Bytes != len("hello") {
+		t.Errorf("expected the result size, got %d", first.Bytes)
+	}
+	if strings.Join(first.ArgumentKeys, ",") != "password,query" || !strings.HasPrefix(first.ArgumentsDigest, "sha256:") {
+		t.Errorf("unexpected arguments %v %q", first.ArgumentKeys, first.ArgumentsDigest)
+	}
+	if first.ArgumentsDigest != second.ArgumentsDigest {
+		t.Error("sensitive values must be redacted before the arguments are hashed")
+	}
+	if failed.Status != "error" || !strings.Contains(failed.Error, "deadline") {
+		t.Errorf("expected an error entry, got %+v", failed)
+	}
+
+	data, _ := json.Marshal(entries)
+	if strings.Contains(string(data), "hunter2") {
+		t.Error("argument values must not be stored in the audit log")
+	}
+}
+
+func TestAdminAPI_AuditLog(t *testing.T) {
+	env := newAdminTestEnv(t)
+
+	for _, entry := range []*db.AuditEntry{
+		{Tool: "svc_a", UserID: "alice", Status: "ok"},
+		{Tool: "svc_b", UserID: "bob", Status: "error"},
+	} {
+		if err := env.database.RecordAuditEntry(entry); err != nil {
+			t.Fatalf("failed to record audit entry: %v", err)
+		}
+	}
+	waitForAuditEntries(t, env.database, 2)
+
+	result := env.call(env.adminToken, "GET", "/api/v1/admin/audit?user_id=bob&since=1h", nil, http.StatusOK)
+	entries, _ := result["entries"].([]interface{})
+	if len(entries) != 1 || entries[0].(map[string]interface{})["tool"] != "svc_b" {
+		t.Errorf("expected the entry of bob, got %v", result)
+	}
+
+	env.call(env.adminToken, "GET", "/api/v1/admin/audit?since=yesterday", nil, http.StatusBadRequest)
+	env.call(env.userToken, "GET", "/api/v1/admin/audit", nil, http.StatusForbidden)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/PivotLLM/MCPFusion/fusion"
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
 )
 
 // ServiceProvider interface for getting available services
@@ -35,6 +36,8 @@ type AuthMiddleware struct {
 	logger          global.Logger
 	skipPaths       []string // Paths that should skip authentication
 	requireAuth     bool     // Whether authentication is required for all requests
+	certMapper      *ClientCertMapper
+	oauthServer     *OAuthServer // advertised in 401 responses when set
 	requestCounter  atomic.Int64
 }
 
@@ -62,6 +65,23 @@ func WithAuthLogger(logger global.Logger) AuthMiddlewareOption {
 	}
 }
 
+// WithClientCertMapper enables authentication with TLS client certificates
+// mapped to API tokens
+func WithClientCertMapper(mapper *ClientCertMapper) AuthMiddlewareOption {
+	return func(am *AuthMiddleware) {
+		am.certMapper = mapper
+	}
+}
+
+// WithResourceMetadata advertises the protected resource metadata of the
+// built-in OAuth authorization server in 401 responses, so that MCP clients
+// can discover how to obtain a token
+func WithResourceMetadata(oauthServer *OAuthServer) AuthMiddlewareOption {
+	return func(am *AuthMiddleware) {
+		am.oauthServer = oauthServer
+	}
+}
+
 // NewAuthMiddleware creates a new authentication middleware
 func NewAuthMiddleware(authManager *fusion.MultiTenantAuthManager, serviceProvider ServiceProvider,
 	options ...AuthMiddlewareOption) *AuthMiddleware {
@@ -136,7 +156,7 @@ func (am *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 				if am.logger != nil {
 					am.logger.Warningf("Missing bearer token for authenticated request to %s", r.URL.Path)
 				}
-				am.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token")
+				am.writeUnauthorized(w, r)
 				return
 			} else {
 				// Auth not required, create NOAUTH tenant context
@@ -172,7 +192,7 @@ func (am *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 				if am.logger != nil {
 					am.logger.Errorf("Failed to extract tenant context from token: %v", err)
 				}
-				am.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token")
+				am.writeUnauthorized(w, r)
 				return
 			}
 			// In no-auth mode, fall back to NOAUTH tenant context
@@ -284,6 +304,37 @@ func (am *AuthMiddleware) extractBearerToken(r *http.Request) string {
 	return token
 }
 
+// extractClientCertTenant returns the tenant of the verified client
+// certificate presented on the connection, or nil if there is none or its
+// subject is not mapped to an API token
+func (am *AuthMiddleware) extractClientCertTenant(r *http.Request) *fusion.TenantContext {
+	if am.certMapper == nil || r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+	hash, ok := am.certMapper.TenantHash(cert)
+	if !ok {
+		if am.logger != nil {
+			am.logger.Debugf("Client certificate %q is not mapped to an API token", cert.Subject.String())
+		}
+		return nil
+	}
+
+	tenantContext, err := am.authManager.ExtractTenantFromTokenHash(hash)
+	if err != nil {
+		if am.logger != nil {
+			am.logger.Warningf("Client certificate %q is mapped to an unknown API token: %v", cert.Subject.String(), err)
+		}
+		return nil
+	}
+
+	if am.logger != nil {
+		am.logger.Debugf("Authenticated client certificate %q as tenant %s", cert.Subject.String(), tenantContext.ShortHash())
+	}
+	return tenantContext
+}
+
 // resolveServiceName attempts to resolve the service name from the request
 func (am *AuthMiddleware) resolveServiceName(r *http.Request, _ *fusion.TenantContext) (string, error) {
 	// Try multiple strategies to determine the service name
@@ -370,6 +421,19 @@ func (am *AuthMiddleware) generateRequestID(r *http.Request) string {
 	return fmt.Sprintf("req_%d_%04d", time.Now().Unix(), n)
 }
 
+// writeUnauthorized rejects a request without valid credentials. With the
+// built-in OAuth authorization server, the WWW-Authenticate header points the
+// client to the protected resource metadata (RFC 9728).
+func (am *AuthMiddleware) writeUnauthorized(w http.ResponseWriter, r *http.Request) {
+	if am.oauthServer != nil {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer resource_metadata="%s"`, am.oauthServer.ResourceMetadataURL(r.URL.Path)))
+	} else {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	am.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token")
+}
+
 // writeErrorResponse writes a JSON error response
 func (am *AuthMiddleware) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -471,12 +535,20 @@ func (am *AuthMiddleware) SimpleMiddleware(next http.Handler) http.Handler {
 
 		// Extract and validate bearer token
 		token := am.extractBearerToken(r)
+
+		// Without a bearer token, a verified client certificate mapped to an
+		// API token identifies the tenant
+		var tenantContext *fusion.TenantContext
 		if token == "" {
+			tenantContext = am.extractClientCertTenant(r)
+		}
+
+		if token == "" && tenantContext == nil {
 			if am.requireAuth {
 				if am.logger != nil {
 					am.logger.Warningf("Simple Auth: Missing bearer token for authenticated request to %s", r.URL.Path)
 				}
-				am.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token")
+				am.writeUnauthorized(w, r)
 				return
 			} else {
 				// Auth not required, create NOAUTH tenant context
@@ -506,33 +578,36 @@ func (am *AuthMiddleware) SimpleMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Extract tenant context from token
-		tenantContext, err := am.authManager.ExtractTenantFromToken(token)
-		if err != nil {
-			// Try auth code fallback before rejecting
-			tenantContext, err = am.authManager.ExtractTenantFromAuthCode(token)
+		if tenantContext == nil {
+			var err error
+			tenantContext, err = am.authManager.ExtractTenantFromToken(token)
 			if err != nil {
-				if am.requireAuth {
-					if am.logger != nil {
-						am.logger.Errorf("Simple Auth: Failed to extract tenant context from token or auth code: %v", err)
-					}
-					am.writeErrorResponse(w, http.StatusUnauthorized, "Invalid token")
-					return
-				}
-				// In no-auth mode, fall back to NOAUTH tenant context
-				if am.logger != nil {
-					am.logger.Warningf("Simple Auth: Invalid token provided, falling back to NOAUTH tenant context for %s", r.URL.Path)
-				}
-				tenantContext, err = am.authManager.ExtractTenantFromToken("")
+				// Try auth code fallback before rejecting
+				tenantContext, err = am.authManager.ExtractTenantFromAuthCode(token)
 				if err != nil {
+					if am.requireAuth {
+						if am.logger != nil {
+							am.logger.Errorf("Simple Auth: Failed to extract tenant context from token or auth code: %v", err)
+						}
+						am.writeUnauthorized(w, r)
+						return
+					}
+					// In no-auth mode, fall back to NOAUTH tenant context
 					if am.logger != nil {
-						am.logger.Errorf("Simple Auth: Failed to create NOAUTH tenant context: %v", err)
+						am.logger.Warningf("Simple Auth: Invalid token provided, falling back to NOAUTH tenant context for %s", r.URL.Path)
 					}
-					am.writeErrorResponse(w, http.StatusInternalServerError, "Internal error")
-					return
+					tenantContext, err = am.authManager.ExtractTenantFromToken("")
+					if err != nil {
+						if am.logger != nil {
+							am.logger.Errorf("Simple Auth: Failed to create NOAUTH tenant context: %v", err)
+						}
+						am.writeErrorResponse(w, http.StatusInternalServerError, "Internal error")
+						return
+					}
+				} else if am.logger != nil {
+					am.logger.Infof("Simple Auth: Authenticated via auth code for tenant %s",
+						tenantContext.ShortHash())
 				}
-			} else if am.logger != nil {
-				am.logger.Infof("Simple Auth: Authenticated via auth code for tenant %s",
-					tenantContext.ShortHash())
 			}
 		}
 
@@ -568,6 +643,7 @@ func (am *AuthMiddleware) SimpleMiddleware(next http.Handler) http.Handler {
 		// Store both tenant context and request record in context
 		ctx := context.WithValue(r.Context(), global.TenantContextKey, tenantContext)
 		ctx = context.WithValue(ctx, global.RequestRecordKey, record)
+		ctx = tracing.Extract(ctx, r.Header)
 		r = r.WithContext(ctx)
 
 		// Continue to next handler — MCP hooks will populate record fields
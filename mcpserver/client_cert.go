@@ -0,0 +1,91 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// TokenResolver resolves an API token identifier (hash or unique prefix) to
+// its full hash
+type TokenResolver func(identifier string) (string, error)
+
+// ClientCertMapper maps the subjects of verified TLS client certificates to
+// API tokens, so a client can authenticate with a certificate instead of a
+// bearer token and act as that token's tenant.
+//
+// The mapping file is a JSON object whose keys are certificate subjects in
+// the form produced by pkix.Name.String (e.g. "CN=alice,O=Example") and whose
+// values are API token hashes or unique hash prefixes as shown by -token-list.
+type ClientCertMapper struct {
+	file     string
+	resolve  TokenResolver
+	logger   global.Logger
+	mu       sync.RWMutex
+	subjects map[string]string // Certificate subject to API token hash
+}
+
+// NewClientCertMapper creates a mapper and loads its mapping file
+func NewClientCertMapper(file string, resolve TokenResolver, logger global.Logger) (*ClientCertMapper, error) {
+	m := &ClientCertMapper{
+		file:    file,
+		resolve: resolve,
+		logger:  logger,
+	}
+	if err := m.Load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Load re-reads the mapping file. If the file is invalid or names an unknown
+// token, the current mapping is kept.
+func (m *ClientCertMapper) Load() error {
+	data, err := os.ReadFile(m.file)
+	if err != nil {
+		return fmt.Errorf("failed to read client certificate map: %w", err)
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to parse client certificate map %s: %w", m.file, err)
+	}
+
+	subjects := make(map[string]string, len(entries))
+	for subject, token := range entries {
+		if subject == "" {
+			return fmt.Errorf("client certificate map %s: empty subject", m.file)
+		}
+		hash, err := m.resolve(token)
+		if err != nil {
+			return fmt.Errorf("client certificate map %s: subject %q: %w", m.file, subject, err)
+		}
+		subjects[subject] = hash
+	}
+
+	m.mu.Lock()
+	m.subjects = subjects
+	m.mu.Unlock()
+
+	if m.logger != nil {
+		m.logger.Infof("Loaded %d client certificate mappings from %s", len(subjects), m.file)
+	}
+	return nil
+}
+
+// TenantHash returns the API token hash mapped to the certificate's subject
+func (m *ClientCertMapper) TenantHash(cert *x509.Certificate) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	hash, ok := m.subjects[cert.Subject.String()]
+	return hash, ok
+}
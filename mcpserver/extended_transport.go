@@ -7,6 +7,7 @@ package mcpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 
 	"github.com/PivotLLM/MCPFusion/db"
@@ -26,7 +27,8 @@ type ExtendedTransport struct {
 // NewExtendedTransport creates a transport that combines both MCP transports with custom API endpoints
 func NewExtendedTransport(sseTransport, httpTransport MCPServerTransport, database *db.DB,
 	authManager *fusion.MultiTenantAuthManager, configManager ServiceProvider,
-	authMiddleware func(http.Handler) http.Handler, logger global.Logger) *ExtendedTransport {
+	authMiddleware func(http.Handler) http.Handler, oauthServer *OAuthServer, metricsHandler http.Handler,
+	logger global.Logger) *ExtendedTransport {
 
 	// Create OAuth API handler
 	oauthHandler := NewOAuthAPIHandler(database, authManager, configManager, logger)
@@ -37,6 +39,12 @@ func NewExtendedTransport(sseTransport, httpTransport MCPServerTransport, databa
 	// Register OAuth API routes with authentication middleware (if available)
 	tempMux := http.NewServeMux()
 	oauthHandler.RegisterRoutes(tempMux)
+
+	// Register the admin API, which requires an API token with the admin role
+	if database != nil {
+		NewAdminAPIHandler(database, logger).RegisterRoutes(tempMux)
+	}
+
 	if authMiddleware != nil {
 		mux.Handle("/api/", authMiddleware(tempMux))
 		mux.Handle("/ping", authMiddleware(tempMux))
@@ -45,6 +53,23 @@ func NewExtendedTransport(sseTransport, httpTransport MCPServerTransport, databa
 		mux.Handle("/ping", tempMux)
 	}
 
+	// Register the metrics endpoint. Whether it requires authentication is
+	// decided by the middleware's skip paths.
+	if metricsHandler != nil {
+		if authMiddleware != nil {
+			mux.Handle("/metrics", authMiddleware(metricsHandler))
+		} else {
+			mux.Handle("/metrics", metricsHandler)
+		}
+		logger.Info("Mounted metrics endpoint at /metrics")
+	}
+
+	// Register the built-in OAuth authorization server (if enabled) without
+	// authentication, as clients use it to obtain their tokens
+	if oauthServer != nil {
+		oauthServer.RegisterRoutes(mux)
+	}
+
 	// Mount SSE transport endpoints (/sse and /message)
 	// The SSEServer handles both internally when it gets requests to these paths
 	if sseHandler, ok := sseTransport.(http.Handler); ok {
@@ -85,6 +110,17 @@ func (et *ExtendedTransport) Start(addr string) error {
 	return et.server.ListenAndServe()
 }
 
+// StartTLS starts the extended transport serving HTTPS
+func (et *ExtendedTransport) StartTLS(addr string, config *tls.Config) error {
+	if et.logger != nil {
+		et.logger.Infof("Starting extended transport with both MCP transports and OAuth API on %s (TLS)", addr)
+	}
+
+	et.server.Addr = addr
+	et.server.TLSConfig = config
+	return et.server.ListenAndServeTLS("", "")
+}
+
 // Shutdown shuts down the extended transport
 func (et *ExtendedTransport) Shutdown(ctx context.Context) error {
 	if et.server != nil {
@@ -30,6 +30,26 @@ func (s *MCPServer) hookAfterListPrompts(ctx context.Context, id any, request *m
 	}
 }
 
+// hookTenantResources lists the calling tenant's own hub resources. The server
+// does not paginate, so the result holds every resource.
+//
+//goland:noinspection GoUnusedParameter
+func (s *MCPServer) hookTenantResources(ctx context.Context, id any, request *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+	if result != nil {
+		result.Resources = s.tenantCatalog.TenantResources(ctx, result.Resources)
+	}
+}
+
+// hookTenantResourceTemplates lists the calling tenant's own hub resource
+// templates
+//
+//goland:noinspection GoUnusedParameter
+func (s *MCPServer) hookTenantResourceTemplates(ctx context.Context, id any, request *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+	if result != nil {
+		result.ResourceTemplates = s.tenantCatalog.TenantResourceTemplates(ctx, result.ResourceTemplates)
+	}
+}
+
 //goland:noinspection GoUnusedParameter
 func (s *MCPServer) hookAfterListResources(ctx context.Context, id any, request *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
 	count := 0
@@ -90,7 +110,27 @@ func (s *MCPServer) hookAfterCallTool(ctx context.Context, id any, request *mcp.
 	// Type-assert result — mcp-go v0.45.0 changed the hook signature to any
 	result, _ := rawResult.(*mcp.CallToolResult)
 
-	// Calculate response size
+	responseSize := toolResultSize(result)
+
+	toolName := request.Params.Name
+	status := "ok"
+	if result != nil && result.IsError {
+		status = "error"
+	}
+
+	if rec, ok := ctx.Value(global.RequestRecordKey).(*global.RequestRecord); ok && rec != nil {
+		rec.MCPMethod = "tools/call"
+		rec.ToolName = toolName
+		rec.Status = status
+		rec.Bytes = responseSize
+	} else {
+		s.logger.Infof("tools/call: %s %s (%d bytes)", toolName, status, responseSize)
+	}
+}
+
+// toolResultSize returns the size in bytes of the text, image and embedded text
+// content of a tool result
+func toolResultSize(result *mcp.CallToolResult) int {
 	var responseSize int
 	if result != nil {
 		// Count content items and their sizes
@@ -118,19 +158,5 @@ func (s *MCPServer) hookAfterCallTool(ctx context.Context, id any, request *mcp.
 			}
 		}
 	}
-
-	toolName := request.Params.Name
-	status := "ok"
-	if result != nil && result.IsError {
-		status = "error"
-	}
-
-	if rec, ok := ctx.Value(global.RequestRecordKey).(*global.RequestRecord); ok && rec != nil {
-		rec.MCPMethod = "tools/call"
-		rec.ToolName = toolName
-		rec.Status = status
-		rec.Bytes = responseSize
-	} else {
-		s.logger.Infof("tools/call: %s %s (%d bytes)", toolName, status, responseSize)
-	}
+	return responseSize
 }
@@ -7,13 +7,16 @@ package mcpserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/PivotLLM/MCPFusion/fusion"
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/hub"
 )
 
 // MCPAuthConfiguration holds configuration for MCP-level authentication
@@ -21,6 +24,8 @@ type MCPAuthConfiguration struct {
 	authManager     *fusion.MultiTenantAuthManager
 	serviceProvider ServiceProvider
 	authorizer      global.Authorizer
+	rateLimiter     global.RateLimiter
+	toolHints       func(toolName string) *global.ToolHints
 	logger          global.Logger
 }
 
@@ -55,9 +60,23 @@ func WithMCPAuthorizer(authorizer global.Authorizer) MCPAuthOption {
 	}
 }
 
-// WithMCPAuthentication creates a server option that adds MCP-level authentication middleware
-// This middleware validates tenant access to specific tools and logs at the MCP protocol level
-func WithMCPAuthentication(options ...MCPAuthOption) server.ServerOption {
+// WithMCPRateLimiter sets the rate limiter applied to authorized tool calls
+func WithMCPRateLimiter(rateLimiter global.RateLimiter) MCPAuthOption {
+	return func(config *MCPAuthConfiguration) {
+		config.rateLimiter = rateLimiter
+	}
+}
+
+// WithMCPToolHints sets the function used to look up a tool's hint annotations
+// for authorization decisions
+func WithMCPToolHints(lookup func(toolName string) *global.ToolHints) MCPAuthOption {
+	return func(config *MCPAuthConfiguration) {
+		config.toolHints = lookup
+	}
+}
+
+// newMCPAuthConfiguration applies options and fills in defaults
+func newMCPAuthConfiguration(options ...MCPAuthOption) *MCPAuthConfiguration {
 	config := &MCPAuthConfiguration{}
 
 	// Apply options
@@ -70,26 +89,61 @@ func WithMCPAuthentication(options ...MCPAuthOption) server.ServerOption {
 		config.authorizer = &global.AllowAllAuthorizer{}
 	}
 
+	return config
+}
+
+// WithMCPToolFilter creates a server option that hides tools denied by the
+// authorizer from tools/list, so clients only see tools they may call
+func WithMCPToolFilter(options ...MCPAuthOption) server.ServerOption {
+	config := newMCPAuthConfiguration(options...)
+
+	return server.WithToolFilter(func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
+		if !ok || tenantContext == nil {
+			// Unauthenticated list requests are rejected by the HTTP middleware;
+			// there is no identity to evaluate the policy against here.
+			return tools
+		}
+
+		allowed := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			serviceName, err := global.ExtractServiceFromToolName(tool.Name)
+			if err != nil {
+				serviceName = ""
+			}
+			toolRequest := global.ToolRequest{
+				TenantHash:  tenantContext.TenantHash,
+				UserID:      tenantContext.UserID,
+				ServiceName: serviceName,
+				ToolName:    tool.Name,
+				Hints:       hintsFromAnnotations(tool.Annotations),
+			}
+			if err := config.authorizer.Authorize(ctx, toolRequest); err != nil {
+				continue
+			}
+			allowed = append(allowed, tool)
+		}
+
+		if config.logger != nil && len(allowed) != len(tools) {
+			config.logger.Debugf("MCP Auth: Hiding %d of %d tools from tenant %s",
+				len(tools)-len(allowed), len(tools), tenantContext.ShortHash())
+		}
+		return allowed
+	})
+}
+
+// WithMCPAuthentication creates a server option that adds MCP-level authentication middleware
+// This middleware validates tenant access to specific tools and logs at the MCP protocol level.
+// The same checks apply to reads of proxied hub resources and to prompts.
+func WithMCPAuthentication(options ...MCPAuthOption) server.ServerOption {
+	config := newMCPAuthConfiguration(options...)
+
 	if config.logger != nil {
 		config.logger.Info("Initialized MCP-level authentication middleware")
 	}
 
-	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	toolMiddleware := server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// Extract tenant context from the request context
-			tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
-			if !ok || tenantContext == nil {
-				if config.logger != nil {
-					config.logger.Errorf("MCP Auth: No tenant context found for tool call: %s", request.Params.Name)
-				}
-				return nil, fmt.Errorf("authentication required")
-			}
-
-			if config.logger != nil {
-				config.logger.Debugf("MCP Auth: Processing tool call %s for tenant %s",
-					request.Params.Name, tenantContext.ShortHash())
-			}
-
 			// Extract service name from tool name
 			serviceName, err := global.ExtractServiceFromToolName(request.Params.Name)
 			if err != nil {
@@ -100,54 +154,25 @@ func WithMCPAuthentication(options ...MCPAuthOption) server.ServerOption {
 				return nil, fmt.Errorf("invalid tool name: %s", request.Params.Name)
 			}
 
-			// Validate that this service exists in our configuration
-			if config.serviceProvider != nil {
-				availableServices := config.serviceProvider.GetAvailableServices()
-				serviceFound := false
-				for _, availableService := range availableServices {
-					if availableService == serviceName {
-						serviceFound = true
-						break
-					}
-				}
-				if !serviceFound {
-					if config.logger != nil {
-						config.logger.Errorf("MCP Auth: Service '%s' from tool '%s' not found in available services: %v",
-							serviceName, request.Params.Name, availableServices)
-					}
-					return nil, fmt.Errorf("service '%s' not configured", serviceName)
-				}
+			var hints *global.ToolHints
+			if config.toolHints != nil {
+				hints = config.toolHints(request.Params.Name)
 			}
-
-			// Validate tenant access to the service
-			if config.authManager != nil {
-				if err := config.authManager.ValidateTenantAccess(tenantContext, serviceName); err != nil {
-					if config.logger != nil {
-						config.logger.Errorf("MCP Auth: Tenant access validation failed for %s service %s: %v",
-							tenantContext.ShortHash(), serviceName, err)
-					}
-					return nil, fmt.Errorf("access denied to service: %s", serviceName)
-				}
+			toolRequest, err := config.authorize(ctx, "tool", request.Params.Name, serviceName, hints)
+			if err != nil {
+				return nil, err
 			}
 
-			// Run tool-level authorization
-			toolRequest := global.ToolRequest{
-				TenantHash:  tenantContext.TenantHash,
-				ServiceName: serviceName,
-				ToolName:    request.Params.Name,
-			}
-			if err := config.authorizer.Authorize(ctx, toolRequest); err != nil {
-				if config.logger != nil {
-					config.logger.Errorf("MCP Auth: Authorization denied for tenant %s tool %s: %v",
-						tenantContext.ShortHash(), request.Params.Name, err)
+			// Apply rate and concurrency limits
+			release, err := config.acquire(ctx, toolRequest)
+			if err != nil {
+				var throttle *global.ThrottleError
+				if errors.As(err, &throttle) {
+					return throttledResult(throttle), nil
 				}
-				return nil, fmt.Errorf("authorization denied: %v", err)
-			}
-
-			if config.logger != nil {
-				config.logger.Debugf("MCP Auth: Successfully validated tenant %s access to service %s for tool %s",
-					tenantContext.ShortHash(), serviceName, request.Params.Name)
+				return nil, err
 			}
+			defer release()
 
 			// Add service name to context for downstream handlers
 			enrichedCtx := context.WithValue(ctx, global.ServiceNameKey, serviceName)
@@ -156,4 +181,167 @@ func WithMCPAuthentication(options ...MCPAuthOption) server.ServerOption {
 			return next(enrichedCtx, request)
 		}
 	})
+
+	resourceMiddleware := server.WithResourceHandlerMiddleware(func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			// Only proxied hub resources belong to a service
+			serviceName, ok := hub.ServiceFromResourceURI(request.Params.URI)
+			if !ok {
+				return next(ctx, request)
+			}
+
+			toolRequest, err := config.authorize(ctx, "resource", request.Params.URI, serviceName, nil)
+			if err != nil {
+				return nil, err
+			}
+			release, err := config.acquire(ctx, toolRequest)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+
+			return next(context.WithValue(ctx, global.ServiceNameKey, serviceName), request)
+		}
+	})
+
+	promptMiddleware := server.WithPromptHandlerMiddleware(func(next server.PromptHandlerFunc) server.PromptHandlerFunc {
+		return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			serviceName, err := global.ExtractServiceFromToolName(request.Params.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid prompt name: %s", request.Params.Name)
+			}
+
+			toolRequest, err := config.authorize(ctx, "prompt", request.Params.Name, serviceName, nil)
+			if err != nil {
+				return nil, err
+			}
+			release, err := config.acquire(ctx, toolRequest)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+
+			return next(context.WithValue(ctx, global.ServiceNameKey, serviceName), request)
+		}
+	})
+
+	return func(s *server.MCPServer) {
+		toolMiddleware(s)
+		resourceMiddleware(s)
+		promptMiddleware(s)
+	}
+}
+
+// authorize checks that the request is authenticated, that the service exists
+// and the tenant may use it, and that the authorizer allows the tool, resource
+// or prompt (kind) called name. It returns the request passed to the authorizer.
+func (config *MCPAuthConfiguration) authorize(ctx context.Context, kind, name, serviceName string,
+	hints *global.ToolHints) (global.ToolRequest, error) {
+
+	// Extract tenant context from the request context
+	tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
+	if !ok || tenantContext == nil {
+		if config.logger != nil {
+			config.logger.Errorf("MCP Auth: No tenant context found for %s: %s", kind, name)
+		}
+		return global.ToolRequest{}, fmt.Errorf("authentication required")
+	}
+
+	if config.logger != nil {
+		config.logger.Debugf("MCP Auth: Processing %s %s for tenant %s", kind, name, tenantContext.ShortHash())
+	}
+
+	// Validate that this service exists in our configuration
+	if config.serviceProvider != nil {
+		availableServices := config.serviceProvider.GetAvailableServices()
+		serviceFound := false
+		for _, availableService := range availableServices {
+			if availableService == serviceName {
+				serviceFound = true
+				break
+			}
+		}
+		if !serviceFound {
+			if config.logger != nil {
+				config.logger.Errorf("MCP Auth: Service '%s' from %s '%s' not found in available services: %v",
+					serviceName, kind, name, availableServices)
+			}
+			return global.ToolRequest{}, fmt.Errorf("service '%s' not configured", serviceName)
+		}
+	}
+
+	// Validate tenant access to the service
+	if config.authManager != nil {
+		if err := config.authManager.ValidateTenantAccess(tenantContext, serviceName); err != nil {
+			if config.logger != nil {
+				config.logger.Errorf("MCP Auth: Tenant access validation failed for %s service %s: %v",
+					tenantContext.ShortHash(), serviceName, err)
+			}
+			return global.ToolRequest{}, fmt.Errorf("access denied to service: %s", serviceName)
+		}
+	}
+
+	// Run tool-level authorization; resources are matched by their URI
+	toolRequest := global.ToolRequest{
+		TenantHash:  tenantContext.TenantHash,
+		UserID:      tenantContext.UserID,
+		ServiceName: serviceName,
+		ToolName:    name,
+		Hints:       hints,
+	}
+	if err := config.authorizer.Authorize(ctx, toolRequest); err != nil {
+		if config.logger != nil {
+			config.logger.Errorf("MCP Auth: Authorization denied for tenant %s %s %s: %v",
+				tenantContext.ShortHash(), kind, name, err)
+		}
+		return global.ToolRequest{}, fmt.Errorf("authorization denied: %v", err)
+	}
+
+	if config.logger != nil {
+		config.logger.Debugf("MCP Auth: Successfully validated tenant %s access to service %s for %s %s",
+			tenantContext.ShortHash(), serviceName, kind, name)
+	}
+	return toolRequest, nil
+}
+
+// authorizeResource applies the checks of authorize to a proxied hub resource.
+// Other resources do not belong to a service and are allowed.
+func (config *MCPAuthConfiguration) authorizeResource(ctx context.Context, uri string) error {
+	serviceName, ok := hub.ServiceFromResourceURI(uri)
+	if !ok {
+		return nil
+	}
+	_, err := config.authorize(ctx, "resource", uri, serviceName, nil)
+	return err
+}
+
+// acquire applies rate and concurrency limits to an authorized request. A
+// throttled request fails with its ThrottleError.
+func (config *MCPAuthConfiguration) acquire(ctx context.Context, req global.ToolRequest) (func(), error) {
+	if config.rateLimiter == nil {
+		return func() {}, nil
+	}
+	release, err := config.rateLimiter.Acquire(ctx, req)
+	if err != nil {
+		var throttle *global.ThrottleError
+		if errors.As(err, &throttle) {
+			return nil, throttle
+		}
+		return nil, fmt.Errorf("rate limiter failed: %v", err)
+	}
+	return release, nil
+}
+
+// throttledResult reports a throttled tool call to the client as a tool error
+// carrying a machine-readable retry hint
+func throttledResult(throttle *global.ThrottleError) *mcp.CallToolResult {
+	retryAfter := int(math.Ceil(throttle.RetryAfter.Seconds()))
+	result := mcp.NewToolResultError(fmt.Sprintf("%v; retry after %d seconds", throttle, retryAfter))
+	result.StructuredContent = map[string]any{
+		"error":             "rate_limited",
+		"kind":              throttle.Kind,
+		"limit":             throttle.Limit,
+		"retryAfterSeconds": retryAfter,
+	}
+	return result
 }
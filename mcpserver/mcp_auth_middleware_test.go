@@ -0,0 +1,148 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// TestMCPAuthentication_RateLimited ensures a throttled tool call returns a tool
+// error with a retry hint instead of running the tool
+func TestMCPAuthentication_RateLimited(t *testing.T) {
+	limiter := fusion.NewRateLimiter(&fusion.RateLimitConfig{
+		Limits: []fusion.RateLimitRule{{Description: "one per minute", Per: []string{"tenant"}, RequestsPerMinute: 1}},
+	}, nil, nil)
+
+	calls := 0
+	srv := server.NewMCPServer("test", "1.0", WithMCPAuthentication(WithMCPRateLimiter(limiter)))
+	srv.AddTool(mcp.NewTool("svc_echo"), func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	ctx := context.WithValue(context.Background(), global.TenantContextKey, &fusion.TenantContext{TenantHash: "alice"})
+	call := func() mcp.CallToolResult {
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"svc_echo"}}`
+		response, ok := srv.HandleMessage(ctx, json.RawMessage(message)).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatal("expected a successful JSON-RPC response")
+		}
+		result, ok := response.Result.(*mcp.CallToolResult)
+		if !ok {
+			t.Fatalf("unexpected result type %T", response.Result)
+		}
+		return *result
+	}
+
+	if result := call(); result.IsError {
+		t.Fatalf("first call failed: %+v", result)
+	}
+
+	result := call()
+	if !result.IsError {
+		t.Fatal("expected the second call to be throttled")
+	}
+	if calls != 1 {
+		t.Errorf("expected the tool to run once, ran %d times", calls)
+	}
+	details, _ := result.StructuredContent.(map[string]any)
+	if details["error"] != "rate_limited" || details["kind"] != global.ThrottleRate || details["retryAfterSeconds"] != 60 {
+		t.Errorf("unexpected structured content %v", result.StructuredContent)
+	}
+}
+
+// TestMCPAuthentication_ResourcesAndPrompts ensures reads of proxied hub
+// resources and prompt requests are subject to the same checks as tool calls
+func TestMCPAuthentication_ResourcesAndPrompts(t *testing.T) {
+	authorizer := fusion.NewPolicyAuthorizer(&fusion.PolicyConfig{
+		Rules: []fusion.PolicyRule{{Effect: fusion.PolicyEffectDeny, Tenants: []string{"bob"}, Services: []string{"docs"}}},
+	}, nil)
+
+	srv := server.NewMCPServer("test", "1.0", WithMCPAuthentication(WithMCPAuthorizer(authorizer)))
+	srv.AddResource(mcp.NewResource("hub://docs/file:///a.txt", "docs_a"),
+		func(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "a"}}, nil
+		})
+	srv.AddPrompt(mcp.NewPrompt("docs_review"), func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("review", nil), nil
+	})
+
+	messages := map[string]string{
+		"resource": `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"hub://docs/file:///a.txt"}}`,
+		"prompt":   `{"jsonrpc":"2.0","id":1,"method":"prompts/get","params":{"name":"docs_review"}}`,
+	}
+	tenants := map[string]bool{"alice": true, "bob": false, "": false}
+
+	for kind, message := range messages {
+		for tenant, allowed := range tenants {
+			ctx := context.Background()
+			if tenant != "" {
+				ctx = context.WithValue(ctx, global.TenantContextKey, &fusion.TenantContext{TenantHash: tenant})
+			}
+			_, ok := srv.HandleMessage(ctx, json.RawMessage(message)).(mcp.JSONRPCResponse)
+			if ok != allowed {
+				t.Errorf("%s for tenant %q: allowed = %v, want %v", kind, tenant, ok, allowed)
+			}
+		}
+	}
+}
+
+// TestMCPAuthentication_UndeclaredHints ensures hint conditions only match
+// hints a tool declares, not the defaults mcp.NewTool fills in
+func TestMCPAuthentication_UndeclaredHints(t *testing.T) {
+	authorizer := fusion.NewPolicyAuthorizer(&fusion.PolicyConfig{
+		Rules: []fusion.PolicyRule{
+			{Effect: fusion.PolicyEffectDeny, Destructive: global.BoolPtr(true)},
+			{Effect: fusion.PolicyEffectDeny, ReadOnly: global.BoolPtr(false)},
+		},
+	}, nil)
+
+	var srv *server.MCPServer
+	lookup := func(name string) *global.ToolHints {
+		return hintsFromAnnotations(srv.GetTool(name).Tool.Annotations)
+	}
+	authOptions := []MCPAuthOption{WithMCPAuthorizer(authorizer), WithMCPToolHints(lookup)}
+	srv = server.NewMCPServer("test", "1.0", WithMCPAuthentication(authOptions...), WithMCPToolFilter(authOptions...))
+
+	handler := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	srv.AddTool(newProviderTool(global.ToolDefinition{Name: "svc_plain"}), handler)
+	srv.AddTool(newProviderTool(global.ToolDefinition{
+		Name:  "svc_delete",
+		Hints: &global.ToolHints{Destructive: global.BoolPtr(true)},
+	}), handler)
+
+	if annotations := srv.GetTool("svc_plain").Tool.Annotations; annotations.ReadOnlyHint != nil || annotations.DestructiveHint != nil {
+		t.Errorf("undeclared hints advertised: %+v", annotations)
+	}
+
+	ctx := context.WithValue(context.Background(), global.TenantContextKey, &fusion.TenantContext{TenantHash: "alice"})
+	for tool, allowed := range map[string]bool{"svc_plain": true, "svc_delete": false} {
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `"}}`
+		_, ok := srv.HandleMessage(ctx, json.RawMessage(message)).(mcp.JSONRPCResponse)
+		if ok != allowed {
+			t.Errorf("call %s: allowed = %v, want %v", tool, ok, allowed)
+		}
+	}
+
+	response, ok := srv.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatal("expected a successful JSON-RPC response")
+	}
+	tools := response.Result.(mcp.ListToolsResult).Tools
+	if len(tools) != 1 || tools[0].Name != "svc_plain" {
+		t.Errorf("expected only svc_plain to be listed, got %v", tools)
+	}
+}
@@ -7,6 +7,7 @@ package mcpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"sync"
@@ -18,6 +19,8 @@ import (
 	"github.com/PivotLLM/MCPFusion/db"
 	"github.com/PivotLLM/MCPFusion/fusion"
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/metrics"
+	"github.com/PivotLLM/MCPFusion/tracing"
 )
 
 // Option defines a function type for configuring the MCPServer.
@@ -31,6 +34,11 @@ type MCPServerTransport interface {
 	Shutdown(ctx context.Context) error
 }
 
+// TLSTransport is implemented by transports that can serve HTTPS
+type TLSTransport interface {
+	StartTLS(addr string, config *tls.Config) error
+}
+
 // AuthenticatedTransport wraps an underlying transport with authentication middleware
 type AuthenticatedTransport struct {
 	underlying MCPServerTransport
@@ -75,6 +83,24 @@ func (at *AuthenticatedTransport) Start(addr string) error {
 	return at.server.ListenAndServe()
 }
 
+// StartTLS starts the authenticated transport serving HTTPS
+func (at *AuthenticatedTransport) StartTLS(addr string, config *tls.Config) error {
+	if at.logger != nil {
+		at.logger.Infof("Starting authenticated transport with TLS on %s", addr)
+	}
+
+	at.server = &http.Server{
+		Addr:         addr,
+		Handler:      at.handler,
+		TLSConfig:    config,
+		ReadTimeout:  0,
+		WriteTimeout: 3600 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	return at.server.ListenAndServeTLS("", "")
+}
+
 // Shutdown shuts down the authenticated transport
 func (at *AuthenticatedTransport) Shutdown(ctx context.Context) error {
 	if at.server != nil {
@@ -114,6 +140,29 @@ type MCPServer struct {
 	authManager       *fusion.MultiTenantAuthManager
 	configManager     ServiceProvider
 	authorizer        global.Authorizer
+	rateLimiter       global.RateLimiter
+	toolsMutex        sync.RWMutex                     // Protects providerTools and providerToolDefs
+	providerTools     map[string]mcp.Tool              // Tools registered from tool providers
+	providerToolDefs  map[string]global.ToolDefinition // Provider definitions backing providerTools
+	tlsConfig         *TLSConfig                       // Serve HTTPS when set
+	tls               *tlsProvider
+	oauthServer       *OAuthServer // Built-in OAuth authorization server, if enabled
+	collector         *metrics.Collector
+	metricsHandler    http.Handler // Serves /metrics when set
+	tracer            *tracing.Tracer
+	auditLog          db.Database // Records every tool call when set
+	subscriptions     *ResourceSubscriptions
+	tenantCatalog     TenantCatalog // Lists per-tenant hub resources and prompts when set
+}
+
+// TenantCatalog supplies the resources, resource templates and prompts that
+// differ between tenants, replacing its own entries in list results with those
+// of the calling tenant. It is implemented by hub.HubProvider.
+type TenantCatalog interface {
+	TenantResources(ctx context.Context, resources []mcp.Resource) []mcp.Resource
+	TenantResourceTemplates(ctx context.Context, templates []mcp.ResourceTemplate) []mcp.ResourceTemplate
+	TenantPrompts(ctx context.Context, prompts []mcp.Prompt) []mcp.Prompt
+	SubscribeResource(ctx context.Context, uri string) error
 }
 
 func WithListen(listen string) Option {
@@ -194,6 +243,63 @@ func WithAuthorizer(authorizer global.Authorizer) Option {
 	}
 }
 
+// WithRateLimiter throttles tool calls that exceed the limiter's rate and concurrency limits
+func WithRateLimiter(rateLimiter global.RateLimiter) Option {
+	return func(m *MCPServer) {
+		m.rateLimiter = rateLimiter
+	}
+}
+
+// WithTLS serves the MCP server over HTTPS
+func WithTLS(config *TLSConfig) Option {
+	return func(m *MCPServer) {
+		m.tlsConfig = config
+	}
+}
+
+// WithOAuthServer serves the endpoints of the built-in OAuth authorization server
+func WithOAuthServer(oauthServer *OAuthServer) Option {
+	return func(m *MCPServer) {
+		m.oauthServer = oauthServer
+	}
+}
+
+// WithCollector records the calls, errors and latency of every tool in the collector
+func WithCollector(collector *metrics.Collector) Option {
+	return func(m *MCPServer) {
+		m.collector = collector
+	}
+}
+
+// WithMetricsHandler serves the handler at /metrics
+func WithMetricsHandler(handler http.Handler) Option {
+	return func(m *MCPServer) {
+		m.metricsHandler = handler
+	}
+}
+
+// WithTracer starts a trace span for every tool call
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(m *MCPServer) {
+		m.tracer = tracer
+	}
+}
+
+// WithAuditLog records every tool call in the audit log of the database
+func WithAuditLog(database db.Database) Option {
+	return func(m *MCPServer) {
+		m.auditLog = database
+	}
+}
+
+// WithTenantCatalog lists per-tenant resources and prompts of the catalog and
+// forwards subscriptions to them
+func WithTenantCatalog(catalog TenantCatalog) Option {
+	return func(m *MCPServer) {
+		m.tenantCatalog = catalog
+	}
+}
+
 // New creates a new MCPServer instance with the provided options.
 func New(options ...Option) (*MCPServer, error) {
 
@@ -224,13 +330,29 @@ func New(options ...Option) (*MCPServer, error) {
 		return nil, fmt.Errorf("logger not set")
 	}
 
-	// Create hooks
+	// Load the TLS certificate or set up ACME before anything listens
+	if m.tlsConfig != nil {
+		provider, err := newTLSProvider(m.tlsConfig, m.logger)
+		if err != nil {
+			return nil, fmt.Errorf("TLS configuration: %w", err)
+		}
+		m.tls = provider
+	}
+
+	// Create hooks. Per-tenant entries are listed first so the other hooks see them.
 	hooks := &server.Hooks{}
+	if m.tenantCatalog != nil {
+		hooks.AddAfterListResources(m.hookTenantResources)
+		hooks.AddAfterListResourceTemplates(m.hookTenantResourceTemplates)
+	}
 	hooks.AddAfterListPrompts(m.hookAfterListPrompts)
 	hooks.AddAfterListResources(m.hookAfterListResources)
 	hooks.AddAfterListResourceTemplates(m.hookAfterListResourceTemplates)
 	hooks.AddAfterListTools(m.hookAfterListTools)
 	hooks.AddAfterCallTool(m.hookAfterCallTool)
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		m.subscriptions.RemoveSession(session.SessionID())
+	})
 
 	// Create an MCP server using the mcp-go library with proper middleware ordering
 	// 1. Basic server capabilities (logging, recovery)
@@ -240,8 +362,23 @@ func New(options ...Option) (*MCPServer, error) {
 	serverOptions := []server.ServerOption{
 		server.WithLogging(),
 		server.WithRecovery(),
-		WithRequestLogging(m.logger),              // Our custom request logging middleware
-		server.WithToolCapabilities(true),          // Enable dynamic tool list change notifications
+		WithRequestLogging(m.logger),                // Our custom request logging middleware
+		server.WithToolCapabilities(true),           // Enable dynamic tool list change notifications
+		server.WithResourceCapabilities(true, true), // Resource subscriptions and list change notifications (hub resources)
+		server.WithPromptCapabilities(true),         // Prompt list change notifications (hub prompts)
+	}
+	if m.tenantCatalog != nil {
+		serverOptions = append(serverOptions, server.WithPromptFilter(m.tenantCatalog.TenantPrompts))
+	}
+
+	// Trace outside authentication, so that denied calls are traced too
+	if m.tracer != nil {
+		serverOptions = append(serverOptions, WithToolTracing(m.tracer))
+	}
+
+	// Audit outside authentication, so that denied and throttled calls are recorded
+	if m.auditLog != nil {
+		serverOptions = append(serverOptions, WithToolAuditing(m.auditLog, m.logger))
 	}
 
 	// Add MCP authentication middleware if configured
@@ -256,7 +393,30 @@ func New(options ...Option) (*MCPServer, error) {
 		if m.authorizer != nil {
 			authOptions = append(authOptions, WithMCPAuthorizer(m.authorizer))
 		}
+		if m.rateLimiter != nil {
+			authOptions = append(authOptions, WithMCPRateLimiter(m.rateLimiter))
+		}
+		authOptions = append(authOptions, WithMCPToolHints(m.lookupToolHints))
 		serverOptions = append(serverOptions, WithMCPAuthentication(authOptions...))
+
+		// Hide tools the authorizer denies from tools/list
+		if m.authorizer != nil {
+			serverOptions = append(serverOptions, WithMCPToolFilter(authOptions...))
+		}
+
+		// Apply the same checks to resource subscriptions as to resource reads
+		m.subscriptions = NewResourceSubscriptions(newMCPAuthConfiguration(authOptions...).authorizeResource, m.logger)
+	} else {
+		m.subscriptions = NewResourceSubscriptions(nil, m.logger)
+	}
+	if m.tenantCatalog != nil {
+		m.subscriptions.subscribe = m.tenantCatalog.SubscribeResource
+	}
+
+	// Record tool metrics inside authentication, so the service is known and
+	// only calls that reach the tool are counted
+	if m.collector != nil {
+		serverOptions = append(serverOptions, WithToolMetrics(m.collector))
 	}
 
 	// Add hooks last to ensure they see the fully processed requests
@@ -285,7 +445,11 @@ func (s *MCPServer) Start() error {
 		defer s.wg.Done()
 
 		// Log the start - both transports are always available
-		s.logger.Infof("MCP server listening on TCP port %s", s.listen)
+		if s.tls != nil {
+			s.logger.Infof("MCP server listening on TCP port %s (HTTPS)", s.listen)
+		} else {
+			s.logger.Infof("MCP server listening on TCP port %s", s.listen)
+		}
 		s.logger.Info("Available endpoints: /sse, /message (SSE mode), /mcp (Streamable HTTP mode)")
 
 		// Create both transports - clients can use either
@@ -299,23 +463,26 @@ func (s *MCPServer) Start() error {
 			server.WithDisableStreaming(true),
 		) // Handles /mcp
 
-		// Apply HTTP-level authentication to both transports
+		// Handle resource subscriptions, which mcp-go does not implement, inside
+		// the authentication middleware
 		var authenticatedSSE, authenticatedHTTP MCPServerTransport
-		authenticatedSSE = s.sseServer
-		authenticatedHTTP = s.httpServer
+		authenticatedSSE = NewAuthenticatedTransport(s.sseServer, s.subscriptions.Middleware, s.logger)
+		authenticatedHTTP = NewAuthenticatedTransport(s.httpServer, s.subscriptions.Middleware, s.logger)
+
+		// Apply HTTP-level authentication to both transports
 
 		if s.authMiddleware != nil {
 			s.logger.Info("Applying HTTP authentication middleware to both transports")
 
 			// Wrap SSE transport with auth
-			authenticatedSSE = NewAuthenticatedTransport(s.sseServer, s.authMiddleware.SimpleMiddleware, s.logger)
+			authenticatedSSE = NewAuthenticatedTransport(authenticatedSSE, s.authMiddleware.SimpleMiddleware, s.logger)
 			if authenticatedSSE == nil {
 				s.logger.Error("Failed to create authenticated SSE transport, using unauthenticated")
 				authenticatedSSE = s.sseServer
 			}
 
 			// Wrap HTTP transport with auth
-			authenticatedHTTP = NewAuthenticatedTransport(s.httpServer, s.authMiddleware.SimpleMiddleware, s.logger)
+			authenticatedHTTP = NewAuthenticatedTransport(authenticatedHTTP, s.authMiddleware.SimpleMiddleware, s.logger)
 			if authenticatedHTTP == nil {
 				s.logger.Error("Failed to create authenticated HTTP transport, using unauthenticated")
 				authenticatedHTTP = s.httpServer
@@ -332,7 +499,7 @@ func (s *MCPServer) Start() error {
 			}
 			// Wrap both transports with ExtendedTransport to add OAuth API endpoints
 			s.transport = NewExtendedTransport(authenticatedSSE, authenticatedHTTP, s.database, s.authManager,
-				s.configManager, oauthAuthMiddleware, s.logger)
+				s.configManager, oauthAuthMiddleware, s.oauthServer, s.metricsHandler, s.logger)
 			if s.transport == nil {
 				s.logger.Error("Failed to create extended transport, falling back to SSE transport only")
 				s.transport = authenticatedSSE
@@ -344,7 +511,18 @@ func (s *MCPServer) Start() error {
 		}
 
 		// Start the server
-		err := s.transport.Start(s.listen)
+		var err error
+		if s.tls != nil {
+			tlsTransport, ok := s.transport.(TLSTransport)
+			if !ok {
+				s.logger.Error("Transport does not support TLS, refusing to serve plain HTTP")
+				return
+			}
+			s.tls.start()
+			err = tlsTransport.StartTLS(s.listen, s.tls.config)
+		} else {
+			err = s.transport.Start(s.listen)
+		}
 		// We don't need to log anything here - if the server is shutting down,
 		// this is expected behavior and not an error condition
 		_ = err
@@ -370,6 +548,11 @@ func (s *MCPServer) Stop() error {
 		// This prevents both the ErrServerClosed and context deadline exceeded errors
 		_ = s.transport.Shutdown(ctx)
 	}
+	if s.tls != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = s.tls.Shutdown(ctx)
+	}
 
 	// Wait for the server goroutine to exit with a timeout
 	waitCh := make(chan struct{})
@@ -390,11 +573,25 @@ func (s *MCPServer) Stop() error {
 	}
 }
 
+// ReloadTLS re-reads the TLS certificate files. It does nothing when TLS is
+// disabled or certificates are managed by ACME.
+func (s *MCPServer) ReloadTLS() error {
+	if s.tls == nil {
+		return nil
+	}
+	return s.tls.Reload()
+}
+
 // GetMCPServer returns the underlying mcp-go server for dynamic tool management
 func (s *MCPServer) GetMCPServer() *server.MCPServer {
 	return s.srv
 }
 
+// ResourceSubscriptions returns the resource subscriptions of client sessions
+func (s *MCPServer) ResourceSubscriptions() *ResourceSubscriptions {
+	return s.subscriptions
+}
+
 // WithRequestLogging is a middleware function that logs request details.
 func WithRequestLogging(logger global.Logger) server.ServerOption {
 	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
@@ -408,3 +605,65 @@ func WithRequestLogging(logger global.Logger) server.ServerOption {
 		}
 	})
 }
+
+// WithToolMetrics records the outcome and duration of every tool call in the collector
+func WithToolMetrics(collector *metrics.Collector) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			service, _ := ctx.Value(global.ServiceNameKey).(string)
+			if service == "" {
+				service, _ = global.ExtractServiceFromToolName(request.Params.Name)
+			}
+			isError := err != nil || (result != nil && result.IsError)
+			collector.RecordToolCall(request.Params.Name, service, time.Since(start), isError)
+			return result, err
+		}
+	})
+}
+
+// WithToolTracing starts a server span for every tool call. The span continues
+// the trace of a traceparent in the request _meta, or else of the inbound HTTP
+// request, and its trace ID is added to the access log record.
+func WithToolTracing(tracer *tracing.Tracer) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if meta := request.Params.Meta; meta != nil {
+				if traceParent, ok := meta.AdditionalFields[tracing.TraceParentHeader].(string); ok {
+					ctx = tracing.ExtractTraceParent(ctx, traceParent)
+				}
+			}
+
+			ctx, span := tracer.Start(ctx, "tools/call "+request.Params.Name, tracing.SpanKindServer)
+			defer span.End()
+
+			span.SetAttribute("mcp.method.name", "tools/call")
+			span.SetAttribute("gen_ai.tool.name", request.Params.Name)
+			if service, err := global.ExtractServiceFromToolName(request.Params.Name); err == nil {
+				span.SetAttribute("mcpfusion.service", service)
+			}
+			if tenantContext, ok := ctx.Value(global.TenantContextKey).(*fusion.TenantContext); ok {
+				span.SetAttribute("mcpfusion.tenant", tenantContext.ShortHash())
+				if tenantContext.UserID != "" {
+					span.SetAttribute("enduser.id", tenantContext.UserID)
+				}
+			}
+			if record, ok := ctx.Value(global.RequestRecordKey).(*global.RequestRecord); ok {
+				record.TraceID = span.SpanContext().TraceID.String()
+			}
+
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+			case result != nil && result.IsError:
+				span.SetStatus(tracing.StatusError, "tool returned an error")
+			default:
+				span.SetStatus(tracing.StatusOK, "")
+			}
+			return result, err
+		}
+	})
+}
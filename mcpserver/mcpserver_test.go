@@ -0,0 +1,67 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/tracing"
+)
+
+// TestWithToolTracing ensures tool calls are traced with tenant, service and
+// tool attributes, continuing a trace passed in _meta
+func TestWithToolTracing(t *testing.T) {
+	exporter := tracing.NewMemoryExporter()
+	tracer := tracing.New(tracing.WithExporter(exporter))
+	defer func() { _ = tracer.Shutdown(context.Background()) }()
+
+	srv := server.NewMCPServer("test", "1.0", WithToolTracing(tracer))
+	srv.AddTool(mcp.NewTool("svc_fail"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if tracing.SpanFromContext(ctx) == nil {
+			t.Error("expected a span in the tool context")
+		}
+		return mcp.NewToolResultError("failed"), nil
+	})
+
+	record := &global.RequestRecord{}
+	ctx := context.WithValue(context.Background(), global.TenantContextKey,
+		&fusion.TenantContext{TenantHash: "0123456789abcdef", UserID: "alice"})
+	ctx = context.WithValue(ctx, global.RequestRecordKey, record)
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"svc_fail",` +
+		`"_meta":{"traceparent":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}}`
+	if _, ok := srv.HandleMessage(ctx, json.RawMessage(message)).(mcp.JSONRPCResponse); !ok {
+		t.Fatal("expected a successful JSON-RPC response")
+	}
+
+	if err := tracer.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	spans := exporter.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "tools/call svc_fail" || span.Kind != tracing.SpanKindServer || span.Status != tracing.StatusError {
+		t.Errorf("unexpected span %+v", span)
+	}
+	if span.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.String() != "00f067aa0ba902b7" {
+		t.Error("expected the span to continue the trace from _meta")
+	}
+	if span.Attribute("mcpfusion.service") != "svc" || span.Attribute("mcpfusion.tenant") != "0123456789ab..." ||
+		span.Attribute("enduser.id") != "alice" || span.Attribute("gen_ai.tool.name") != "svc_fail" {
+		t.Errorf("unexpected attributes %+v", span.Attributes)
+	}
+	if record.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the trace ID in the request record, got %q", record.TraceID)
+	}
+}
@@ -279,6 +279,13 @@ func (h *OAuthAPIHandler) handleServiceConfig(w http.ResponseWriter, r *http.Req
 		if authURL, ok := service.Auth.Config["authorizationURL"].(string); ok && authURL != "" {
 			oauthConfig["authorization_url"] = authURL
 		}
+		if tenantID, ok := service.Auth.Config["tenantId"].(string); ok && tenantID != "" {
+			oauthConfig["tenant_id"] = tenantID
+		}
+		// An OIDC issuer lets fusion-auth discover the endpoints of services it has no built-in provider for
+		if issuer, ok := service.Auth.Config["issuer"].(string); ok && issuer != "" {
+			oauthConfig["issuer"] = issuer
+		}
 	}
 
 	// Add auth type and user_credentials config details if available
@@ -0,0 +1,661 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// Paths served by the built-in OAuth authorization server
+const (
+	oauthProtectedResourcePath = "/.well-known/oauth-protected-resource"
+	oauthServerMetadataPath    = "/.well-known/oauth-authorization-server"
+	oauthAuthorizePath         = "/oauth/authorize"
+	oauthTokenPath             = "/oauth/token"
+	oauthRegisterPath          = "/oauth/register"
+	oauthRevokePath            = "/oauth/revoke"
+)
+
+// oauthCleanupInterval is the minimum time between sweeps of expired codes and tokens
+const oauthCleanupInterval = time.Hour
+
+// OAuthServer implements the MCP authorization flow for inbound clients: OAuth
+// 2.1 with protected resource metadata (RFC 9728), authorization server
+// metadata (RFC 8414), dynamic client registration (RFC 7591), the
+// authorization code grant with PKCE, refresh tokens and token revocation
+// (RFC 7009). Users sign in by entering an existing MCPFusion API token, so the
+// tokens issued resolve to the same tenant as that API token.
+type OAuthServer struct {
+	database    *db.DB
+	issuer      string
+	logger      global.Logger
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// NewOAuthServer creates an OAuth authorization server. issuer is the external
+// base URL of MCPFusion, e.g. https://mcp.example.com.
+func NewOAuthServer(database *db.DB, issuer string, logger global.Logger) *OAuthServer {
+	return &OAuthServer{
+		database: database,
+		issuer:   strings.TrimRight(issuer, "/"),
+		logger:   logger,
+	}
+}
+
+// ResourceMetadataURL returns the URL of the protected resource metadata for a
+// request path, as advertised in the WWW-Authenticate header of 401 responses
+func (s *OAuthServer) ResourceMetadataURL(path string) string {
+	if path == "/" {
+		path = ""
+	}
+	return s.issuer + oauthProtectedResourcePath + path
+}
+
+// RegisterRoutes registers the OAuth endpoints. They must be reachable without
+// authentication.
+func (s *OAuthServer) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(oauthProtectedResourcePath, s.handleProtectedResource)
+	mux.HandleFunc(oauthProtectedResourcePath+"/", s.handleProtectedResource)
+	mux.HandleFunc(oauthServerMetadataPath, s.handleServerMetadata)
+	mux.HandleFunc(oauthAuthorizePath, s.handleAuthorize)
+	mux.HandleFunc(oauthTokenPath, s.handleToken)
+	mux.HandleFunc(oauthRegisterPath, s.handleRegister)
+	mux.HandleFunc(oauthRevokePath, s.handleRevoke)
+	s.logger.Infof("OAuth authorization server enabled with issuer %s", s.issuer)
+}
+
+// handleProtectedResource handles GET /.well-known/oauth-protected-resource[/<path>]
+func (s *OAuthServer) handleProtectedResource(w http.ResponseWriter, r *http.Request) {
+	if !s.allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	resource := s.issuer + strings.TrimPrefix(r.URL.Path, oauthProtectedResourcePath)
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"resource":                 resource,
+		"authorization_servers":    []string{s.issuer},
+		"bearer_methods_supported": []string{"header"},
+		"resource_name":            global.AppName,
+	})
+}
+
+// handleServerMetadata handles GET /.well-known/oauth-authorization-server
+func (s *OAuthServer) handleServerMetadata(w http.ResponseWriter, r *http.Request) {
+	if !s.allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                         s.issuer,
+		"authorization_endpoint":                         s.issuer + oauthAuthorizePath,
+		"token_endpoint":                                 s.issuer + oauthTokenPath,
+		"registration_endpoint":                          s.issuer + oauthRegisterPath,
+		"revocation_endpoint":                            s.issuer + oauthRevokePath,
+		"response_types_supported":                       []string{"code"},
+		"grant_types_supported":                          []string{"authorization_code", "refresh_token"},
+		"code_challenge_methods_supported":               []string{"S256"},
+		"token_endpoint_auth_methods_supported":          []string{"none", "client_secret_post", "client_secret_basic"},
+		"revocation_endpoint_auth_methods_supported":     []string{"none", "client_secret_post", "client_secret_basic"},
+		"authorization_response_iss_parameter_supported": true,
+	})
+}
+
+// handleRegister handles POST /oauth/register (dynamic client registration)
+func (s *OAuthServer) handleRegister(w http.ResponseWriter, r *http.Request) {
+	if !s.allowMethods(w, r, http.MethodPost) {
+		return
+	}
+
+	var request struct {
+		ClientName              string   `json:"client_name"`
+		RedirectURIs            []string `json:"redirect_uris"`
+		GrantTypes              []string `json:"grant_types"`
+		ResponseTypes           []string `json:"response_types"`
+		TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(&request); err != nil {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "Invalid JSON request body")
+		return
+	}
+
+	if len(request.RedirectURIs) == 0 {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_redirect_uri", "At least one redirect URI is required")
+		return
+	}
+	for _, redirectURI := range request.RedirectURIs {
+		if err := validateRedirectURI(redirectURI); err != nil {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_redirect_uri", err.Error())
+			return
+		}
+	}
+
+	if len(request.GrantTypes) == 0 {
+		request.GrantTypes = []string{"authorization_code", "refresh_token"}
+	}
+	for _, grantType := range request.GrantTypes {
+		if grantType != "authorization_code" && grantType != "refresh_token" {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "Unsupported grant type: "+grantType)
+			return
+		}
+	}
+	for _, responseType := range request.ResponseTypes {
+		if responseType != "code" {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata", "Unsupported response type: "+responseType)
+			return
+		}
+	}
+
+	switch request.TokenEndpointAuthMethod {
+	case "":
+		request.TokenEndpointAuthMethod = "client_secret_basic"
+	case "none", "client_secret_post", "client_secret_basic":
+	default:
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_client_metadata",
+			"Unsupported token endpoint auth method: "+request.TokenEndpointAuthMethod)
+		return
+	}
+
+	client := &db.OAuthClientData{
+		ClientName:              request.ClientName,
+		RedirectURIs:            request.RedirectURIs,
+		GrantTypes:              request.GrantTypes,
+		TokenEndpointAuthMethod: request.TokenEndpointAuthMethod,
+	}
+	secret, err := s.database.CreateOAuthClient(client)
+	if err != nil {
+		s.logger.Errorf("Failed to register OAuth client: %v", err)
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error", "Failed to register client")
+		return
+	}
+
+	s.logger.Infof("Registered OAuth client %s (%s)", client.ClientID, client.ClientName)
+
+	response := map[string]interface{}{
+		"client_id":                  client.ClientID,
+		"client_id_issued_at":        client.CreatedAt.Unix(),
+		"client_name":                client.ClientName,
+		"redirect_uris":              client.RedirectURIs,
+		"grant_types":                client.GrantTypes,
+		"response_types":             []string{"code"},
+		"token_endpoint_auth_method": client.TokenEndpointAuthMethod,
+	}
+	if secret != "" {
+		response["client_secret"] = secret
+		response["client_secret_expires_at"] = 0
+	}
+	s.writeJSON(w, http.StatusCreated, response)
+}
+
+// authorizeRequest holds the parameters of an authorization request
+type authorizeRequest struct {
+	ClientID            string
+	ClientName          string
+	RedirectURI         string
+	RedirectURISupplied bool // false when the registered redirect URI was used by default
+	State               string
+	CodeChallenge       string
+	Scope               string
+	Resource            string
+	Error               string
+}
+
+// handleAuthorize handles GET /oauth/authorize, which shows the sign-in form,
+// and POST /oauth/authorize, which processes it
+func (s *OAuthServer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		s.writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "Method not allowed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.writeAuthorizePage(w, http.StatusBadRequest, nil, "The authorization request is malformed.")
+		return
+	}
+
+	// Until the client and redirect URI are validated, errors cannot be
+	// returned to the client and are shown to the user instead
+	client, err := s.database.GetOAuthClient(r.Form.Get("client_id"))
+	if err != nil {
+		s.writeAuthorizePage(w, http.StatusBadRequest, nil, "Unknown client. Please reconnect from your MCP client.")
+		return
+	}
+	redirectURI := r.Form.Get("redirect_uri")
+	redirectURISupplied := redirectURI != ""
+	if !redirectURISupplied && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if !slices.Contains(client.RedirectURIs, redirectURI) {
+		s.writeAuthorizePage(w, http.StatusBadRequest, nil, "The redirect URI is not registered for this client.")
+		return
+	}
+
+	request := &authorizeRequest{
+		ClientID:            client.ClientID,
+		ClientName:          client.ClientName,
+		RedirectURI:         redirectURI,
+		RedirectURISupplied: redirectURISupplied,
+		State:               r.Form.Get("state"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		Scope:               r.Form.Get("scope"),
+		Resource:            r.Form.Get("resource"),
+	}
+	if request.ClientName == "" {
+		request.ClientName = client.ClientID
+	}
+
+	switch {
+	case r.Form.Get("response_type") != "code":
+		s.redirectError(w, r, request, "unsupported_response_type", "Only the code response type is supported")
+		return
+	case request.CodeChallenge == "":
+		s.redirectError(w, r, request, "invalid_request", "PKCE code_challenge is required")
+		return
+	case r.Form.Get("code_challenge_method") != "S256":
+		s.redirectError(w, r, request, "invalid_request", "code_challenge_method must be S256")
+		return
+	case !s.validResource(request.Resource):
+		s.redirectError(w, r, request, "invalid_target", "The requested resource is not served by this server")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		s.writeAuthorizePage(w, http.StatusOK, request, "")
+		return
+	}
+
+	// The user signs in with an API token; the grant is for its tenant
+	valid, tenantHash, err := s.database.ValidateAPIToken(strings.TrimSpace(r.PostForm.Get("api_token")))
+	if err != nil || !valid {
+		s.logger.Warningf("OAuth authorization for client %s rejected: invalid API token", client.ClientID)
+		s.writeAuthorizePage(w, http.StatusUnauthorized, request, "The API token is not valid.")
+		return
+	}
+
+	// The token request must repeat the redirect URI only if the authorization
+	// request included it (RFC 6749 section 4.1.3)
+	grantRedirectURI := ""
+	if redirectURISupplied {
+		grantRedirectURI = redirectURI
+	}
+	code, err := s.database.CreateOAuthGrant(&db.OAuthGrantData{
+		TenantHash:    tenantHash,
+		ClientID:      client.ClientID,
+		RedirectURI:   grantRedirectURI,
+		CodeChallenge: request.CodeChallenge,
+		Scope:         request.Scope,
+		Resource:      request.Resource,
+		ExpiresAt:     time.Now().Add(global.OAuthServerCodeTTL),
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to create OAuth grant: %v", err)
+		s.redirectError(w, r, request, "server_error", "Failed to create authorization code")
+		return
+	}
+
+	s.logger.Infof("OAuth client %s authorized for tenant %s", client.ClientID, safePrefix(tenantHash))
+	s.redirect(w, r, request, url.Values{"code": {code}})
+}
+
+// handleToken handles POST /oauth/token
+func (s *OAuthServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if !s.allowMethods(w, r, http.MethodPost) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_request", "Malformed request body")
+		return
+	}
+	s.cleanupExpired()
+
+	client, ok := s.authenticateClient(w, r)
+	if !ok {
+		return
+	}
+
+	var tenantHash, scope, resource string
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		grant, err := s.database.ConsumeOAuthGrant(r.PostForm.Get("code"))
+		if err != nil {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Invalid or expired authorization code")
+			return
+		}
+		if grant.ClientID != client.ClientID || (grant.RedirectURI != "" && grant.RedirectURI != r.PostForm.Get("redirect_uri")) {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Authorization code was issued to another client or redirect URI")
+			return
+		}
+		if !verifyCodeChallenge(r.PostForm.Get("code_verifier"), grant.CodeChallenge) {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "PKCE verification failed")
+			return
+		}
+		tenantHash, scope, resource = grant.TenantHash, grant.Scope, grant.Resource
+
+	case "refresh_token":
+		previous, err := s.database.ConsumeOAuthRefreshToken(r.PostForm.Get("refresh_token"))
+		if err != nil {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Invalid or expired refresh token")
+			return
+		}
+		if previous.ClientID != client.ClientID {
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "Refresh token was issued to another client")
+			return
+		}
+		tenantHash, scope, resource = previous.TenantHash, previous.Scope, previous.Resource
+
+	default:
+		s.writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "Supported grant types are authorization_code and refresh_token")
+		return
+	}
+
+	// Tokens are only issued while the API token the user signed in with exists
+	if _, err := s.database.GetAPITokenMetadata(tenantHash); err != nil {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "The API token of this grant has been revoked")
+		return
+	}
+
+	now := time.Now()
+	accessToken, err := s.database.CreateOAuthAccessToken(&db.OAuthIssuedTokenData{
+		TenantHash: tenantHash,
+		ClientID:   client.ClientID,
+		Scope:      scope,
+		Resource:   resource,
+		ExpiresAt:  now.Add(global.OAuthServerAccessTokenTTL),
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to create OAuth access token: %v", err)
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error", "Failed to issue tokens")
+		return
+	}
+	refreshToken, err := s.database.CreateOAuthRefreshToken(&db.OAuthIssuedTokenData{
+		TenantHash: tenantHash,
+		ClientID:   client.ClientID,
+		Scope:      scope,
+		Resource:   resource,
+		ExpiresAt:  now.Add(global.OAuthServerRefreshTokenTTL),
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to create OAuth refresh token: %v", err)
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error", "Failed to issue tokens")
+		return
+	}
+
+	s.logger.Debugf("Issued OAuth tokens to client %s for tenant %s", client.ClientID, safePrefix(tenantHash))
+
+	response := map[string]interface{}{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(global.OAuthServerAccessTokenTTL.Seconds()),
+		"refresh_token": refreshToken,
+	}
+	if scope != "" {
+		response["scope"] = scope
+	}
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// handleRevoke handles POST /oauth/revoke
+func (s *OAuthServer) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if !s.allowMethods(w, r, http.MethodPost) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_request", "Malformed request body")
+		return
+	}
+	if _, ok := s.authenticateClient(w, r); !ok {
+		return
+	}
+
+	if err := s.database.RevokeOAuthToken(r.PostForm.Get("token")); err != nil {
+		s.logger.Errorf("Failed to revoke OAuth token: %v", err)
+		s.writeOAuthError(w, http.StatusServiceUnavailable, "server_error", "Failed to revoke token")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+// authenticateClient identifies the client of a token or revocation request.
+// Confidential clients must present their secret with HTTP basic auth or in
+// the request body; public clients only identify themselves.
+func (s *OAuthServer) authenticateClient(w http.ResponseWriter, r *http.Request) (*db.OAuthClientData, bool) {
+	clientID, secret, basic := r.BasicAuth()
+	if basic {
+		// Credentials in the basic auth header are form-encoded (RFC 6749 section 2.3.1)
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	client, err := s.database.GetOAuthClient(clientID)
+	if err != nil {
+		if basic {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+global.AppName+`"`)
+		}
+		s.writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "Unknown client")
+		return nil, false
+	}
+	if client.ClientSecretHash != "" && !client.VerifySecret(secret) {
+		if basic {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+global.AppName+`"`)
+		}
+		s.writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+		return nil, false
+	}
+	return client, true
+}
+
+// validResource returns true if a resource indicator (RFC 8707) is empty or
+// names this server
+func (s *OAuthServer) validResource(resource string) bool {
+	return resource == "" || resource == s.issuer || strings.HasPrefix(resource, s.issuer+"/")
+}
+
+// cleanupExpired deletes expired codes and tokens at most once per cleanup interval
+func (s *OAuthServer) cleanupExpired() {
+	s.mu.Lock()
+	if time.Since(s.lastCleanup) < oauthCleanupInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastCleanup = time.Now()
+	s.mu.Unlock()
+
+	go func() {
+		if err := s.database.CleanupExpiredOAuthServerData(); err != nil {
+			s.logger.Warningf("Failed to clean up expired OAuth codes and tokens: %v", err)
+		}
+	}()
+}
+
+// redirect sends the user back to the client with the given parameters
+func (s *OAuthServer) redirect(w http.ResponseWriter, r *http.Request, request *authorizeRequest, params url.Values) {
+	target, err := url.Parse(request.RedirectURI)
+	if err != nil {
+		s.writeAuthorizePage(w, http.StatusBadRequest, nil, "The redirect URI is invalid.")
+		return
+	}
+	query := target.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	if request.State != "" {
+		query.Set("state", request.State)
+	}
+	query.Set("iss", s.issuer)
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// redirectError returns an authorization error to the client
+func (s *OAuthServer) redirectError(w http.ResponseWriter, r *http.Request, request *authorizeRequest, code, description string) {
+	s.redirect(w, r, request, url.Values{"error": {code}, "error_description": {description}})
+}
+
+// allowMethods answers CORS preflight requests and rejects other methods than
+// those allowed, returning true if the request should be handled. The OAuth
+// endpoints other than authorize are called from browser-based clients.
+func (s *OAuthServer) allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(append(methods, http.MethodOptions), ", "))
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, MCP-Protocol-Version")
+		w.WriteHeader(http.StatusNoContent)
+		return false
+	}
+	if !slices.Contains(methods, r.Method) {
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+		s.writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "Method not allowed")
+		return false
+	}
+	return true
+}
+
+// writeJSON writes a JSON response that must not be cached
+func (s *OAuthServer) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.logger.Errorf("Failed to encode JSON response: %v", err)
+	}
+}
+
+// writeOAuthError writes an OAuth error response (RFC 6749 section 5.2)
+func (s *OAuthServer) writeOAuthError(w http.ResponseWriter, statusCode int, code, description string) {
+	s.writeJSON(w, statusCode, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// writeAuthorizePage renders the sign-in form, or only an error message when
+// request is nil
+func (s *OAuthServer) writeAuthorizePage(w http.ResponseWriter, statusCode int, request *authorizeRequest, message string) {
+	data := authorizeRequest{Error: message}
+	if request != nil {
+		data = *request
+		data.Error = message
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	// No form-action directive: browsers apply it to the redirect that follows
+	// the form submission, which goes to the client's redirect URI
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.WriteHeader(statusCode)
+
+	if err := authorizePageTemplate.Execute(w, map[string]interface{}{
+		"AppName": global.AppName,
+		"Request": data,
+		"Form":    request != nil,
+	}); err != nil {
+		s.logger.Errorf("Failed to render authorization page: %v", err)
+	}
+}
+
+// validateRedirectURI accepts https URLs, http URLs on a loopback host, and
+// private-use URI schemes of native apps, none of them with a fragment
+func validateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || u.Scheme == "" {
+		return errors.New("invalid redirect URI: " + redirectURI)
+	}
+	if u.Fragment != "" {
+		return errors.New("redirect URI must not contain a fragment: " + redirectURI)
+	}
+
+	switch u.Scheme {
+	case "https":
+		if u.Host == "" {
+			return errors.New("invalid redirect URI: " + redirectURI)
+		}
+	case "http":
+		if host := u.Hostname(); host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return errors.New("redirect URIs using http must be on a loopback host: " + redirectURI)
+		}
+	case "javascript", "data", "file":
+		return errors.New("unsupported redirect URI scheme: " + redirectURI)
+	}
+	return nil
+}
+
+// verifyCodeChallenge checks a PKCE code verifier against an S256 challenge
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// safePrefix abbreviates a tenant hash for logging
+func safePrefix(tenantHash string) string {
+	if len(tenantHash) > 12 {
+		return tenantHash[:12]
+	}
+	return tenantHash
+}
+
+var authorizePageTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.AppName}} - Authorize</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background: #f5f5f5; margin: 0; }
+main { max-width: 420px; margin: 60px auto; background: #fff; padding: 32px; border-radius: 8px; box-shadow: 0 1px 4px rgba(0,0,0,.1); }
+h1 { font-size: 1.4em; margin-top: 0; }
+label { display: block; margin: 16px 0 6px; font-weight: 600; }
+input[type=password] { width: 100%; box-sizing: border-box; padding: 8px; font-size: 1em; }
+button { margin-top: 20px; padding: 10px 20px; font-size: 1em; cursor: pointer; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.AppName}}</h1>
+{{if .Request.Error}}<p class="error">{{.Request.Error}}</p>{{end}}
+{{if .Form}}
+<p><strong>{{.Request.ClientName}}</strong> is requesting access to your {{.AppName}} tools.</p>
+<form method="post">
+<input type="hidden" name="response_type" value="code">
+<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+{{if .Request.RedirectURISupplied}}<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">{{end}}
+<input type="hidden" name="state" value="{{.Request.State}}">
+<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="S256">
+<input type="hidden" name="scope" value="{{.Request.Scope}}">
+<input type="hidden" name="resource" value="{{.Request.Resource}}">
+<label for="api_token">API token</label>
+<input type="password" id="api_token" name="api_token" autocomplete="off" required autofocus>
+<button type="submit">Authorize</button>
+</form>
+{{end}}
+</main>
+</body>
+</html>
+`))
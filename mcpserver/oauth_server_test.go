@@ -0,0 +1,362 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tenebris-tech/mlogger"
+
+	"github.com/PivotLLM/MCPFusion/db"
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+const testIssuer = "https://mcp.example.com"
+
+// oauthTestEnv is an OAuth server with an auth middleware protecting /mcp
+type oauthTestEnv struct {
+	t        *testing.T
+	database db.Database
+	mux      *http.ServeMux
+	apiToken string
+	apiHash  string
+}
+
+func newOAuthTestEnv(t *testing.T) *oauthTestEnv {
+	t.Helper()
+	manager, database, tempDir := newTestAuthManagerWithDB(t)
+	t.Cleanup(func() {
+		_ = database.Close()
+		_ = os.RemoveAll(tempDir)
+	})
+
+	apiToken, apiHash, err := database.AddAPIToken("oauth test")
+	if err != nil {
+		t.Fatalf("failed to add API token: %v", err)
+	}
+
+	oauthServer := NewOAuthServer(database.(*db.DB), testIssuer+"/", mlogger.NewMemoryLogger())
+	am := NewAuthMiddleware(manager, nil, WithRequireAuth(true), WithResourceMetadata(oauthServer))
+
+	mux := http.NewServeMux()
+	oauthServer.RegisterRoutes(mux)
+	mux.Handle("/mcp", am.SimpleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantContext := r.Context().Value(global.TenantContextKey).(*fusion.TenantContext)
+		_, _ = w.Write([]byte(tenantContext.TenantHash))
+	})))
+
+	return &oauthTestEnv{t: t, database: database, mux: mux, apiToken: apiToken, apiHash: apiHash}
+}
+
+func (e *oauthTestEnv) do(req *http.Request) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	e.mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func (e *oauthTestEnv) postForm(path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return e.do(req)
+}
+
+// decode unmarshals a JSON response, failing the test on an unexpected status
+func (e *oauthTestEnv) decode(rr *httptest.ResponseRecorder, status int) map[string]interface{} {
+	e.t.Helper()
+	if rr.Code != status {
+		e.t.Fatalf("expected status %d, got %d: %s", status, rr.Code, rr.Body.String())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		e.t.Fatalf("invalid JSON response: %v", err)
+	}
+	return body
+}
+
+// callMCP calls the protected endpoint with a bearer token
+func (e *oauthTestEnv) callMCP(token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return e.do(req)
+}
+
+// register registers a public client and returns its ID
+func (e *oauthTestEnv) register(redirectURI string) string {
+	e.t.Helper()
+	body := `{"client_name":"Test Client","redirect_uris":["` + redirectURI + `"],"token_endpoint_auth_method":"none"}`
+	response := e.decode(e.do(httptest.NewRequest(http.MethodPost, oauthRegisterPath, strings.NewReader(body))), http.StatusCreated)
+	if _, ok := response["client_secret"]; ok {
+		e.t.Error("a public client must not be given a secret")
+	}
+	return response["client_id"].(string)
+}
+
+// authorize signs in with the API token and returns the authorization code
+func (e *oauthTestEnv) authorize(clientID, redirectURI, verifier string) string {
+	e.t.Helper()
+	sum := sha256.Sum256([]byte(verifier))
+	form := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {"xyz"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+		"resource":              {testIssuer + "/mcp"},
+	}
+
+	rr := e.do(httptest.NewRequest(http.MethodGet, oauthAuthorizePath+"?"+form.Encode(), nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `name="api_token"`) {
+		e.t.Fatalf("expected sign-in form, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if csp := rr.Header().Get("Content-Security-Policy"); strings.Contains(csp, "form-action") {
+		e.t.Errorf("form-action would block the redirect to the client: %s", csp)
+	}
+
+	form.Set("api_token", "not-a-valid-token")
+	if rr := e.postForm(oauthAuthorizePath, form); rr.Code != http.StatusUnauthorized {
+		e.t.Fatalf("expected 401 for an invalid API token, got %d", rr.Code)
+	}
+
+	form.Set("api_token", e.apiToken)
+	rr = e.postForm(oauthAuthorizePath, form)
+	if rr.Code != http.StatusFound {
+		e.t.Fatalf("expected redirect, got %d: %s", rr.Code, rr.Body.String())
+	}
+	location, err := url.Parse(rr.Header().Get("Location"))
+	if err != nil || !strings.HasPrefix(location.String(), redirectURI+"?") {
+		e.t.Fatalf("unexpected redirect: %s", rr.Header().Get("Location"))
+	}
+	if location.Query().Get("state") != "xyz" || location.Query().Get("iss") != testIssuer {
+		e.t.Errorf("redirect is missing state or iss: %s", location)
+	}
+	return location.Query().Get("code")
+}
+
+func TestOAuthServer_Metadata(t *testing.T) {
+	env := newOAuthTestEnv(t)
+
+	// An unauthenticated request points to the protected resource metadata
+	rr := env.do(httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rr.Code)
+	}
+	expected := `Bearer resource_metadata="` + testIssuer + `/.well-known/oauth-protected-resource/mcp"`
+	if got := rr.Header().Get("WWW-Authenticate"); got != expected {
+		t.Errorf("expected WWW-Authenticate %q, got %q", expected, got)
+	}
+
+	resource := env.decode(env.do(httptest.NewRequest(http.MethodGet, oauthProtectedResourcePath+"/mcp", nil)), http.StatusOK)
+	if resource["resource"] != testIssuer+"/mcp" {
+		t.Errorf("unexpected resource %v", resource["resource"])
+	}
+	if servers := resource["authorization_servers"].([]interface{}); len(servers) != 1 || servers[0] != testIssuer {
+		t.Errorf("unexpected authorization servers %v", servers)
+	}
+
+	metadata := env.decode(env.do(httptest.NewRequest(http.MethodGet, oauthServerMetadataPath, nil)), http.StatusOK)
+	if metadata["issuer"] != testIssuer || metadata["token_endpoint"] != testIssuer+oauthTokenPath ||
+		metadata["registration_endpoint"] != testIssuer+oauthRegisterPath {
+		t.Errorf("unexpected authorization server metadata %v", metadata)
+	}
+}
+
+func TestOAuthServer_AuthorizationCodeFlow(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	redirectURI := "http://127.0.0.1:33418/callback"
+	verifier := strings.Repeat("v", 43)
+
+	clientID := env.register(redirectURI)
+	code := env.authorize(clientID, redirectURI, verifier)
+
+	exchange := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	}
+	tokens := env.decode(env.postForm(oauthTokenPath, exchange), http.StatusOK)
+	accessToken := tokens["access_token"].(string)
+	refreshToken := tokens["refresh_token"].(string)
+	if tokens["token_type"] != "Bearer" || !strings.HasPrefix(accessToken, db.OAuthAccessTokenPrefix) {
+		t.Fatalf("unexpected token response %v", tokens)
+	}
+
+	// The access token resolves to the tenant of the API token used to sign in
+	rr := env.callMCP(accessToken)
+	if rr.Code != http.StatusOK || rr.Body.String() != env.apiHash {
+		t.Fatalf("expected tenant %s, got %d: %s", env.apiHash, rr.Code, rr.Body.String())
+	}
+
+	// Codes are single use
+	response := env.decode(env.postForm(oauthTokenPath, exchange), http.StatusBadRequest)
+	if response["error"] != "invalid_grant" {
+		t.Errorf("expected invalid_grant for a reused code, got %v", response["error"])
+	}
+
+	// Refresh tokens are rotated
+	refresh := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "client_id": {clientID}}
+	refreshed := env.decode(env.postForm(oauthTokenPath, refresh), http.StatusOK)
+	if refreshed["refresh_token"] == refreshToken {
+		t.Error("expected a new refresh token")
+	}
+	env.decode(env.postForm(oauthTokenPath, refresh), http.StatusBadRequest)
+
+	// Revoked access tokens are rejected
+	newAccessToken := refreshed["access_token"].(string)
+	if rr := env.postForm(oauthRevokePath, url.Values{"token": {newAccessToken}, "client_id": {clientID}}); rr.Code != http.StatusOK {
+		t.Fatalf("expected revocation to succeed, got %d", rr.Code)
+	}
+	if rr := env.callMCP(newAccessToken); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a revoked token, got %d", rr.Code)
+	}
+
+	// Deleting the API token ends the grant
+	if err := env.database.DeleteAPIToken(env.apiHash); err != nil {
+		t.Fatalf("failed to delete API token: %v", err)
+	}
+	if rr := env.callMCP(accessToken); rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 once the API token is deleted, got %d", rr.Code)
+	}
+	refresh.Set("refresh_token", refreshed["refresh_token"].(string))
+	env.decode(env.postForm(oauthTokenPath, refresh), http.StatusBadRequest)
+}
+
+func TestOAuthServer_PKCEAndClientChecks(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	redirectURI := "https://client.example.com/callback"
+	verifier := strings.Repeat("a", 64)
+	clientID := env.register(redirectURI)
+
+	exchange := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {env.authorize(clientID, redirectURI, verifier)},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {strings.Repeat("b", 64)},
+	}
+	response := env.decode(env.postForm(oauthTokenPath, exchange), http.StatusBadRequest)
+	if response["error"] != "invalid_grant" {
+		t.Errorf("expected invalid_grant for a wrong code verifier, got %v", response["error"])
+	}
+
+	exchange.Set("client_id", "unknown")
+	response = env.decode(env.postForm(oauthTokenPath, exchange), http.StatusUnauthorized)
+	if response["error"] != "invalid_client" {
+		t.Errorf("expected invalid_client for an unknown client, got %v", response["error"])
+	}
+
+	// An unregistered redirect URI is never redirected to
+	query := url.Values{"response_type": {"code"}, "client_id": {clientID}, "redirect_uri": {"https://evil.example.com/"}}
+	rr := env.do(httptest.NewRequest(http.MethodGet, oauthAuthorizePath+"?"+query.Encode(), nil))
+	if rr.Code != http.StatusBadRequest || rr.Header().Get("Location") != "" {
+		t.Errorf("expected an error page for an unregistered redirect URI, got %d", rr.Code)
+	}
+
+	// Without PKCE the error is returned to the client
+	query.Set("redirect_uri", redirectURI)
+	rr = env.do(httptest.NewRequest(http.MethodGet, oauthAuthorizePath+"?"+query.Encode(), nil))
+	if rr.Code != http.StatusFound || !strings.Contains(rr.Header().Get("Location"), "error=invalid_request") {
+		t.Errorf("expected an invalid_request redirect without PKCE, got %d %s", rr.Code, rr.Header().Get("Location"))
+	}
+}
+
+func TestOAuthServer_RedirectURIOmitted(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	redirectURI := "https://client.example.com/callback"
+	verifier := strings.Repeat("c", 43)
+	clientID := env.register(redirectURI)
+
+	// A redirect URI sent to /authorize must be repeated at /token
+	exchange := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {env.authorize(clientID, redirectURI, verifier)},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	}
+	env.decode(env.postForm(oauthTokenPath, exchange), http.StatusBadRequest)
+
+	// Without one, the registered redirect URI is used and need not be repeated
+	sum := sha256.Sum256([]byte(verifier))
+	form := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	rr := env.do(httptest.NewRequest(http.MethodGet, oauthAuthorizePath+"?"+form.Encode(), nil))
+	if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), `name="redirect_uri"`) {
+		t.Fatalf("expected a sign-in form without a redirect URI, got %d: %s", rr.Code, rr.Body.String())
+	}
+	form.Set("api_token", env.apiToken)
+	rr = env.postForm(oauthAuthorizePath, form)
+	location, err := url.Parse(rr.Header().Get("Location"))
+	if rr.Code != http.StatusFound || err != nil || !strings.HasPrefix(location.String(), redirectURI+"?") {
+		t.Fatalf("expected a redirect to the registered URI, got %d %s", rr.Code, rr.Header().Get("Location"))
+	}
+	exchange.Set("code", location.Query().Get("code"))
+	env.decode(env.postForm(oauthTokenPath, exchange), http.StatusOK)
+}
+
+func TestOAuthServer_ConfidentialClient(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	body := `{"redirect_uris":["https://client.example.com/callback"]}`
+	response := env.decode(env.do(httptest.NewRequest(http.MethodPost, oauthRegisterPath, strings.NewReader(body))), http.StatusCreated)
+	clientID, _ := response["client_id"].(string)
+	secret, _ := response["client_secret"].(string)
+	if secret == "" {
+		t.Fatal("expected a client secret for a confidential client")
+	}
+
+	revoke := func(user, password string) int {
+		req := httptest.NewRequest(http.MethodPost, oauthRevokePath, strings.NewReader("token=unknown"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(user, password)
+		return env.do(req).Code
+	}
+	if code := revoke(clientID, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a wrong client secret, got %d", code)
+	}
+	if code := revoke(clientID, secret); code != http.StatusOK {
+		t.Errorf("expected 200 for revocation of an unknown token, got %d", code)
+	}
+}
+
+func TestValidateRedirectURI(t *testing.T) {
+	valid := []string{
+		"https://client.example.com/callback",
+		"http://localhost:8080/callback",
+		"http://127.0.0.1/callback",
+		"com.example.app:/oauth",
+	}
+	for _, uri := range valid {
+		if err := validateRedirectURI(uri); err != nil {
+			t.Errorf("expected %s to be valid: %v", uri, err)
+		}
+	}
+
+	invalid := []string{
+		"http://client.example.com/callback",
+		"https://client.example.com/callback#fragment",
+		"javascript:alert(1)",
+		"/relative",
+	}
+	for _, uri := range invalid {
+		if err := validateRedirectURI(uri); err == nil {
+			t.Errorf("expected %s to be rejected", uri)
+		}
+	}
+}
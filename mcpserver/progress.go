@@ -0,0 +1,44 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressNotifier returns a notifier that relays a tool's progress to the
+// client, or nil if the client did not ask for it. Progress is sent as
+// notifications/progress when the request carries a progress token, and
+// otherwise as info log messages if the client enabled that log level.
+func progressNotifier(ctx context.Context, req mcp.CallToolRequest, toolName string) global.ProgressNotifier {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	if req.Params.Meta != nil && req.Params.Meta.ProgressToken != nil {
+		token := req.Params.Meta.ProgressToken
+		return func(progress float64, message string) error {
+			return srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+				"progressToken": token,
+				"progress":      progress,
+				"message":       message,
+			})
+		}
+	}
+
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithLogging)
+	if !ok || !mcp.LoggingLevelInfo.ShouldSendTo(session.GetLogLevel()) {
+		return nil
+	}
+	return func(_ float64, message string) error {
+		return srv.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, toolName, message))
+	}
+}
@@ -0,0 +1,188 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// Resource subscription methods, which mcp-go does not define
+const (
+	methodResourcesSubscribe   mcp.MCPMethod = "resources/subscribe"
+	methodResourcesUnsubscribe mcp.MCPMethod = "resources/unsubscribe"
+)
+
+// maxSubscriptionRequestBytes bounds the request bodies inspected for
+// subscription requests
+const maxSubscriptionRequestBytes = 1 << 20
+
+// ResourceSubscriptions tracks which client sessions subscribed to which
+// resources, so that resource updates are only sent to those sessions.
+type ResourceSubscriptions struct {
+	mu        sync.RWMutex
+	sessions  map[string]map[string]string // URI -> subscribed session IDs -> tenant hash
+	authorize func(ctx context.Context, uri string) error
+	subscribe func(ctx context.Context, uri string) error // forwards a subscription downstream; nil = none
+	logger    global.Logger
+}
+
+// NewResourceSubscriptions creates an empty subscription registry. authorize,
+// if set, is called before a session subscribes to a resource.
+func NewResourceSubscriptions(authorize func(ctx context.Context, uri string) error, logger global.Logger) *ResourceSubscriptions {
+	return &ResourceSubscriptions{
+		sessions:  make(map[string]map[string]string),
+		authorize: authorize,
+		logger:    logger,
+	}
+}
+
+// Subscribe subscribes a session of a tenant to a resource. tenantHash is empty
+// without authentication.
+func (rs *ResourceSubscriptions) Subscribe(sessionID, tenantHash, uri string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.sessions[uri] == nil {
+		rs.sessions[uri] = make(map[string]string)
+	}
+	rs.sessions[uri][sessionID] = tenantHash
+}
+
+// Unsubscribe removes a session's subscription to a resource
+func (rs *ResourceSubscriptions) Unsubscribe(sessionID, uri string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	delete(rs.sessions[uri], sessionID)
+	if len(rs.sessions[uri]) == 0 {
+		delete(rs.sessions, uri)
+	}
+}
+
+// RemoveSession removes every subscription of a session that has ended
+func (rs *ResourceSubscriptions) RemoveSession(sessionID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for uri, sessions := range rs.sessions {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(rs.sessions, uri)
+		}
+	}
+}
+
+// Subscribers returns the IDs of the sessions of a tenant subscribed to a
+// resource, or of the sessions of every tenant if tenantHash is empty
+func (rs *ResourceSubscriptions) Subscribers(uri, tenantHash string) []string {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	sessionIDs := make([]string, 0, len(rs.sessions[uri]))
+	for sessionID, sessionTenant := range rs.sessions[uri] {
+		if tenantHash == "" || sessionTenant == tenantHash {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	sort.Strings(sessionIDs)
+	return sessionIDs
+}
+
+// Middleware handles resources/subscribe and resources/unsubscribe requests,
+// which mcp-go does not implement. The subscription is recorded for the
+// session (the sessionId query parameter of the SSE transport or the
+// Mcp-Session-Id header of the Streamable HTTP transport), and the request is
+// then passed on as a ping so that mcp-go returns the empty result through the
+// session's usual response path. Subscriptions to resources of hub services
+// using tenant credentials are forwarded to the tenant's own downstream
+// session. It must run inside the authentication middleware.
+func (rs *ResourceSubscriptions) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxSubscriptionRequestBytes+1))
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		if err != nil || len(data) > maxSubscriptionRequestBytes {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var request struct {
+			ID     any           `json:"id"`
+			Method mcp.MCPMethod `json:"method"`
+			Params struct {
+				URI string `json:"uri"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(data, &request) != nil || request.ID == nil ||
+			(request.Method != methodResourcesSubscribe && request.Method != methodResourcesUnsubscribe) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		sessionID := r.URL.Query().Get("sessionId")
+		if sessionID == "" {
+			sessionID = r.Header.Get(server.HeaderKeySessionID)
+		}
+		if sessionID == "" || request.Params.URI == "" {
+			rs.writeError(w, request.ID, mcp.INVALID_PARAMS, "a session and a resource URI are required")
+			return
+		}
+
+		if request.Method == methodResourcesSubscribe {
+			if rs.authorize != nil {
+				if err := rs.authorize(r.Context(), request.Params.URI); err != nil {
+					rs.writeError(w, request.ID, mcp.INVALID_REQUEST, err.Error())
+					return
+				}
+			}
+			if rs.subscribe != nil {
+				if err := rs.subscribe(r.Context(), request.Params.URI); err != nil {
+					rs.writeError(w, request.ID, mcp.INTERNAL_ERROR, err.Error())
+					return
+				}
+			}
+			var tenantHash string
+			if tenantContext, ok := r.Context().Value(global.TenantContextKey).(*fusion.TenantContext); ok && tenantContext != nil {
+				tenantHash = tenantContext.TenantHash
+			}
+			rs.Subscribe(sessionID, tenantHash, request.Params.URI)
+		} else {
+			rs.Unsubscribe(sessionID, request.Params.URI)
+		}
+		if rs.logger != nil {
+			rs.logger.Debugf("Session %s: %s %s", sessionID, request.Method, request.Params.URI)
+		}
+
+		ping, _ := json.Marshal(mcp.JSONRPCRequest{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			ID:      mcp.NewRequestId(request.ID),
+			Request: mcp.Request{Method: string(mcp.MethodPing)},
+		})
+		r.Body = io.NopCloser(bytes.NewReader(ping))
+		r.ContentLength = int64(len(ping))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError rejects a subscription request with a JSON-RPC error
+func (rs *ResourceSubscriptions) writeError(w http.ResponseWriter, id any, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(mcp.NewJSONRPCError(mcp.NewRequestId(id), code, message, nil))
+}
@@ -0,0 +1,130 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// TestResourceSubscriptions_Middleware ensures subscription requests are
+// recorded per session and answered through mcp-go as a ping
+func TestResourceSubscriptions_Middleware(t *testing.T) {
+	subscriptions := NewResourceSubscriptions(func(_ context.Context, uri string) error {
+		if strings.HasPrefix(uri, "hub://secret/") {
+			return errors.New("access denied")
+		}
+		return nil
+	}, nil)
+
+	var forwarded map[string]any
+	handler := subscriptions.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		forwarded = nil
+		_ = json.Unmarshal(data, &forwarded)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	send := func(path, method, uri string) int {
+		forwarded = nil
+		body := `{"jsonrpc":"2.0","id":7,"method":"` + method + `","params":{"uri":"` + uri + `"}}`
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return recorder.Code
+	}
+
+	if code := send("/message?sessionId=s1", "resources/subscribe", "hub://docs/a"); code != http.StatusAccepted {
+		t.Fatalf("subscribe returned %d", code)
+	}
+	if forwarded["method"] != "ping" || forwarded["id"] != float64(7) {
+		t.Errorf("expected a ping with the request ID, got %v", forwarded)
+	}
+	send("/message?sessionId=s2", "resources/subscribe", "hub://docs/a")
+	if got := subscriptions.Subscribers("hub://docs/a", ""); !reflect.DeepEqual(got, []string{"s1", "s2"}) {
+		t.Errorf("subscribers = %v", got)
+	}
+
+	// Denied subscriptions are rejected without reaching mcp-go
+	if code := send("/message?sessionId=s1", "resources/subscribe", "hub://secret/a"); code != http.StatusBadRequest {
+		t.Errorf("denied subscribe returned %d", code)
+	}
+	if forwarded != nil || len(subscriptions.Subscribers("hub://secret/a", "")) != 0 {
+		t.Error("a denied subscription must not be recorded")
+	}
+
+	// Other requests pass through unchanged
+	send("/message?sessionId=s1", "resources/read", "hub://docs/a")
+	if forwarded["method"] != "resources/read" {
+		t.Errorf("expected resources/read to pass through, got %v", forwarded)
+	}
+
+	send("/message?sessionId=s1", "resources/unsubscribe", "hub://docs/a")
+	if got := subscriptions.Subscribers("hub://docs/a", ""); !reflect.DeepEqual(got, []string{"s2"}) {
+		t.Errorf("subscribers after unsubscribe = %v", got)
+	}
+	subscriptions.RemoveSession("s2")
+	if got := subscriptions.Subscribers("hub://docs/a", ""); len(got) != 0 {
+		t.Errorf("subscribers after the session ended = %v", got)
+	}
+}
+
+// TestResourceSubscriptions_Tenants ensures subscriptions are forwarded with the
+// caller's tenant and updates of a tenant only reach that tenant's sessions
+func TestResourceSubscriptions_Tenants(t *testing.T) {
+	subscriptions := NewResourceSubscriptions(nil, nil)
+	var forwardedTenant string
+	subscriptions.subscribe = func(ctx context.Context, uri string) error {
+		tenantContext := ctx.Value(global.TenantContextKey).(*fusion.TenantContext)
+		if strings.HasPrefix(uri, "hub://down/") {
+			return errors.New("hub service 'down' is unavailable")
+		}
+		forwardedTenant = tenantContext.TenantHash
+		return nil
+	}
+	handler := subscriptions.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	subscribe := func(sessionID, tenantHash, uri string) int {
+		body := `{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"` + uri + `"}}`
+		r := httptest.NewRequest(http.MethodPost, "/message?sessionId="+sessionID, strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), global.TenantContextKey, &fusion.TenantContext{TenantHash: tenantHash}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		return recorder.Code
+	}
+
+	subscribe("s1", "alice", "hub://docs/a")
+	if forwardedTenant != "alice" {
+		t.Errorf("subscription forwarded for tenant %q", forwardedTenant)
+	}
+	subscribe("s2", "bob", "hub://docs/a")
+
+	if got := subscriptions.Subscribers("hub://docs/a", "alice"); !reflect.DeepEqual(got, []string{"s1"}) {
+		t.Errorf("subscribers of alice = %v", got)
+	}
+	if got := subscriptions.Subscribers("hub://docs/a", ""); !reflect.DeepEqual(got, []string{"s1", "s2"}) {
+		t.Errorf("subscribers of every tenant = %v", got)
+	}
+
+	// A subscription that cannot be forwarded is rejected
+	if code := subscribe("s1", "alice", "hub://down/a"); code != http.StatusBadRequest {
+		t.Errorf("failed subscribe returned %d", code)
+	}
+	if got := subscriptions.Subscribers("hub://down/a", ""); len(got) != 0 {
+		t.Errorf("a failed subscription must not be recorded, got %v", got)
+	}
+}
@@ -0,0 +1,293 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// certCheckInterval is how often the certificate files are checked for changes
+const certCheckInterval = 10 * time.Second
+
+// TLSConfig configures HTTPS for the MCP server. The certificate is either
+// read from files, which are reloaded when they change, or obtained and
+// renewed automatically from an ACME CA such as Let's Encrypt.
+type TLSConfig struct {
+	CertFile string // PEM certificate chain
+	KeyFile  string // PEM private key
+
+	ACMEDomains      []string // Obtain certificates for these host names via ACME instead of files
+	ACMEEmail        string   // Contact address for the ACME account (optional)
+	ACMEDirectoryURL string   // ACME directory URL (default: Let's Encrypt)
+	ACMECAFile       string   // Additional root CA trusted for the ACME directory, e.g. a local Pebble server
+	ACMECacheDir     string   // Directory holding the ACME account key and certificates
+	ACMEHTTPListen   string   // Address answering HTTP-01 challenges; empty allows TLS-ALPN-01 only
+
+	ClientCAFile      string // PEM CA bundle used to verify client certificates (mTLS)
+	RequireClientCert bool   // Refuse connections without a valid client certificate
+}
+
+// Validate checks the TLS configuration for consistency
+func (c *TLSConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("TLS certificate and key files must be set together")
+	}
+	if c.CertFile != "" && len(c.ACMEDomains) > 0 {
+		return fmt.Errorf("TLS certificate files and ACME cannot be used together")
+	}
+	if c.CertFile == "" && len(c.ACMEDomains) == 0 {
+		return fmt.Errorf("TLS requires either certificate files or ACME domains")
+	}
+	if len(c.ACMEDomains) > 0 && c.ACMECacheDir == "" {
+		return fmt.Errorf("ACME requires a cache directory")
+	}
+	if c.RequireClientCert && c.ClientCAFile == "" {
+		return fmt.Errorf("requiring client certificates needs a client CA file")
+	}
+	return nil
+}
+
+// tlsProvider supplies the server's tls.Config and keeps its certificate current
+type tlsProvider struct {
+	config    *tls.Config
+	certs     *certReloader     // Set when certificates come from files
+	acme      *autocert.Manager // Set when certificates come from ACME
+	challenge *http.Server      // HTTP-01 challenge listener, if enabled
+	logger    global.Logger
+}
+
+// newTLSProvider creates the TLS configuration described by cfg
+func newTLSProvider(cfg *TLSConfig, logger global.Logger) (*tlsProvider, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &tlsProvider{logger: logger}
+	if cfg.CertFile != "" {
+		certs, err := newCertReloader(cfg.CertFile, cfg.KeyFile, logger)
+		if err != nil {
+			return nil, err
+		}
+		p.certs = certs
+		p.config = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+	} else {
+		manager, err := newACMEManager(cfg)
+		if err != nil {
+			return nil, err
+		}
+		p.acme = manager
+		p.config = manager.TLSConfig()
+		p.config.MinVersion = tls.VersionTLS12
+		if cfg.ACMEHTTPListen != "" {
+			p.challenge = &http.Server{
+				Addr:              cfg.ACMEHTTPListen,
+				Handler:           manager.HTTPHandler(nil),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+		}
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+		p.config.ClientCAs = pool
+		p.config.ClientAuth = tls.VerifyClientCertIfGiven
+		if cfg.RequireClientCert {
+			p.config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		// TLS-ALPN-01 validation connections never present a client certificate
+		if p.acme != nil && cfg.RequireClientCert {
+			challengeConfig := p.config.Clone()
+			challengeConfig.ClientAuth = tls.NoClientCert
+			p.config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+				if slices.Equal(hello.SupportedProtos, []string{acme.ALPNProto}) {
+					return challengeConfig, nil
+				}
+				return nil, nil
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// newACMEManager creates an autocert manager for the configured domains
+func newACMEManager(cfg *TLSConfig) (*autocert.Manager, error) {
+	if err := os.MkdirAll(cfg.ACMECacheDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create ACME cache directory: %w", err)
+	}
+
+	client := &acme.Client{DirectoryURL: cfg.ACMEDirectoryURL}
+	if cfg.ACMECAFile != "" {
+		pem, err := os.ReadFile(cfg.ACMECAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ACME CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ACME CA file %s", cfg.ACMECAFile)
+		}
+		client.HTTPClient = &http.Client{
+			Timeout: 60 * time.Second,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+		}
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cfg.ACMECacheDir),
+		HostPolicy: autocert.HostWhitelist(cfg.ACMEDomains...),
+		Email:      cfg.ACMEEmail,
+		Client:     client,
+	}, nil
+}
+
+// start launches the HTTP-01 challenge listener, if configured
+func (p *tlsProvider) start() {
+	if p.challenge == nil {
+		return
+	}
+	go func() {
+		p.logger.Infof("ACME HTTP-01 challenge listener on %s", p.challenge.Addr)
+		if err := p.challenge.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Errorf("ACME HTTP-01 challenge listener failed: %v", err)
+		}
+	}()
+}
+
+// Reload re-reads the certificate files. ACME certificates are renewed
+// automatically and need no reload.
+func (p *tlsProvider) Reload() error {
+	if p.certs == nil {
+		return nil
+	}
+	return p.certs.load()
+}
+
+// Shutdown stops the HTTP-01 challenge listener
+func (p *tlsProvider) Shutdown(ctx context.Context) error {
+	if p.challenge != nil {
+		return p.challenge.Shutdown(ctx)
+	}
+	return nil
+}
+
+// certReloader serves a certificate loaded from files and reloads it when the
+// files change, so renewed certificates are picked up without a restart
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   global.Logger
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modTime  time.Time // Newest modification time of the files when last loaded
+	checked  time.Time // When the files were last checked for changes
+}
+
+// newCertReloader loads the certificate and key
+func newCertReloader(certFile, keyFile string, logger global.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load reads the certificate and key. On failure the current certificate is kept.
+func (r *certReloader) load() error {
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.checked = time.Now()
+	r.mu.Unlock()
+
+	if r.logger != nil {
+		if leaf := cert.Leaf; leaf != nil {
+			r.logger.Infof("Loaded TLS certificate for %v (expires %s)", leaf.DNSNames, leaf.NotAfter.Format(time.RFC3339))
+		} else {
+			r.logger.Infof("Loaded TLS certificate from %s", r.certFile)
+		}
+	}
+	return nil
+}
+
+// filesModTime returns the newest modification time of the certificate and key
+func (r *certReloader) filesModTime() (time.Time, error) {
+	var newest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read TLS certificate: %w", err)
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate, reloading the
+// certificate first if the files changed since it was loaded
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	due := time.Since(r.checked) >= certCheckInterval
+	if due {
+		r.checked = time.Now()
+	}
+	loaded := r.modTime
+	r.mu.Unlock()
+
+	if due {
+		if modTime, err := r.filesModTime(); err == nil && !modTime.Equal(loaded) {
+			if err := r.load(); err != nil && r.logger != nil {
+				r.logger.Errorf("Failed to reload TLS certificate, keeping the current one: %v", err)
+			}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, nil
+}
@@ -0,0 +1,278 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// testCert is a generated certificate with its key
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert creates a certificate for subject signed by parent, or
+// self-signed if parent is nil
+func newTestCert(t *testing.T, subject pkix.Name, isCA bool, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"localhost"},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeTestCert writes the certificate and key into dir and returns their paths
+func writeTestCert(t *testing.T, dir string, c *testCert) (string, string) {
+	t.Helper()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, c.certPEM, 0600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, c.keyPEM, 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// TestTLSConfig_Validate checks that inconsistent TLS settings are rejected
+func TestTLSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TLSConfig
+		wantErr bool
+	}{
+		{"files", TLSConfig{CertFile: "c", KeyFile: "k"}, false},
+		{"acme", TLSConfig{ACMEDomains: []string{"example.com"}, ACMECacheDir: "/tmp/acme"}, false},
+		{"cert without key", TLSConfig{CertFile: "c"}, true},
+		{"files and acme", TLSConfig{CertFile: "c", KeyFile: "k", ACMEDomains: []string{"example.com"}, ACMECacheDir: "d"}, true},
+		{"nothing", TLSConfig{}, true},
+		{"acme without cache", TLSConfig{ACMEDomains: []string{"example.com"}}, true},
+		{"required without CA", TLSConfig{CertFile: "c", KeyFile: "k", RequireClientCert: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCertReloader_ReloadsChangedFiles ensures a replaced certificate is served
+// without a restart, and a broken replacement keeps the current certificate
+func TestCertReloader_ReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := newTestCert(t, pkix.Name{CommonName: "first"}, false, nil)
+	certFile, keyFile := writeTestCert(t, dir, first)
+
+	reloader, err := newCertReloader(certFile, keyFile, nil)
+	if err != nil {
+		t.Fatalf("newCertReloader: %v", err)
+	}
+	cert, _ := reloader.GetCertificate(nil)
+	if cert.Leaf.Subject.CommonName != "first" {
+		t.Fatalf("expected first certificate, got %s", cert.Leaf.Subject.CommonName)
+	}
+
+	// Replace the files with a newer certificate and force the next check
+	second := newTestCert(t, pkix.Name{CommonName: "second"}, false, nil)
+	writeTestCert(t, dir, second)
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, future, future)
+	reloader.checked = time.Time{}
+
+	cert, _ = reloader.GetCertificate(nil)
+	if cert.Leaf.Subject.CommonName != "second" {
+		t.Fatalf("expected reloaded certificate, got %s", cert.Leaf.Subject.CommonName)
+	}
+
+	// A corrupt key is ignored and the current certificate stays in use
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloader.load(); err == nil {
+		t.Fatal("expected an error loading a corrupt key")
+	}
+	cert, _ = reloader.GetCertificate(nil)
+	if cert.Leaf.Subject.CommonName != "second" {
+		t.Errorf("expected current certificate to be kept, got %s", cert.Leaf.Subject.CommonName)
+	}
+}
+
+// TestTLSProvider_ClientCertificates runs a TLS server requiring client
+// certificates and checks that only clients with a certificate from the CA connect
+func TestTLSProvider_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, pkix.Name{CommonName: "Test CA"}, true, nil)
+	serverCert := newTestCert(t, pkix.Name{CommonName: "localhost"}, false, ca)
+	clientCert := newTestCert(t, pkix.Name{CommonName: "alice", Organization: []string{"Example"}}, false, ca)
+
+	certFile, keyFile := writeTestCert(t, dir, serverCert)
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, ca.certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := newTLSProvider(&TLSConfig{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("newTLSProvider: %v", err)
+	}
+
+	var subject string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = r.TLS.VerifiedChains[0][0].Subject.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = provider.config
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			ServerName:   "localhost",
+		}}}
+	}
+
+	// Without a client certificate the handshake fails
+	if resp, err := newClient().Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected connection without client certificate to fail")
+	}
+
+	pair, err := tls.X509KeyPair(clientCert.certPEM, clientCert.keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := newClient(pair).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with client certificate failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if subject != "CN=alice,O=Example" {
+		t.Errorf("unexpected client subject %q", subject)
+	}
+}
+
+// TestSimpleMiddleware_ClientCertificate ensures a verified client certificate
+// mapped to an API token authenticates as that token's tenant
+func TestSimpleMiddleware_ClientCertificate(t *testing.T) {
+	manager, database, tempDir := newTestAuthManagerWithDB(t)
+	defer func() {
+		_ = database.Close()
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	_, hash, err := database.AddAPIToken("alice")
+	if err != nil {
+		t.Fatalf("failed to add API token: %v", err)
+	}
+
+	mapFile := filepath.Join(t.TempDir(), "clients.json")
+	data, _ := json.Marshal(map[string]string{"CN=alice,O=Example": hash})
+	if err := os.WriteFile(mapFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	mapper, err := NewClientCertMapper(mapFile, database.ResolveAPIToken, nil)
+	if err != nil {
+		t.Fatalf("NewClientCertMapper: %v", err)
+	}
+
+	var capturedTenant *fusion.TenantContext
+	am := NewAuthMiddleware(manager, nil, WithRequireAuth(true), WithClientCertMapper(mapper))
+	handler := am.SimpleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedTenant, _ = r.Context().Value(global.TenantContextKey).(*fusion.TenantContext)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(subject pkix.Name) int {
+		cert := newTestCert(t, subject, false, nil)
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert.cert}}}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := request(pkix.Name{CommonName: "alice", Organization: []string{"Example"}}); code != http.StatusOK {
+		t.Fatalf("expected 200 for mapped certificate, got %d", code)
+	}
+	if capturedTenant == nil || capturedTenant.TenantHash != hash {
+		t.Fatalf("expected tenant %s, got %+v", hash, capturedTenant)
+	}
+
+	if code := request(pkix.Name{CommonName: "mallory"}); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for unmapped certificate, got %d", code)
+	}
+
+	// A mapping to a token that no longer exists is rejected
+	if err := database.DeleteAPIToken(hash); err != nil {
+		t.Fatal(err)
+	}
+	if code := request(pkix.Name{CommonName: "alice", Organization: []string{"Example"}}); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 after the token was deleted, got %d", code)
+	}
+}
@@ -7,117 +7,198 @@ package mcpserver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/hub"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+// AddTools registers the tools of every tool provider with the MCP server
 func (s *MCPServer) AddTools() {
+	tools, definitions := s.collectProviderTools()
 
-	// Iterate over tool providers and register their tools
+	s.toolsMutex.Lock()
+	defer s.toolsMutex.Unlock()
+
+	for name, tool := range tools {
+		s.srv.AddTool(tool, s.providerToolHandler(name))
+	}
+	s.providerTools = tools
+	s.providerToolDefs = definitions
+}
+
+// ReloadTools asks every tool provider for its tools again and applies the
+// difference to the MCP server. Added and changed tools are registered before
+// removed tools are deleted, and the server sends notifications/tools/list_changed
+// to connected clients whenever the set changes. Tools registered directly with
+// the underlying server (e.g. hub tools) are not affected.
+func (s *MCPServer) ReloadTools() hub.ToolDiff {
+	tools, definitions := s.collectProviderTools()
+
+	s.toolsMutex.Lock()
+	defer s.toolsMutex.Unlock()
+
+	diff := hub.DiffTools(s.providerTools, tools)
+
+	// Swap definitions first so unchanged tools immediately use the new handlers
+	s.providerTools = tools
+	s.providerToolDefs = definitions
+
+	var serverTools []server.ServerTool
+	for _, name := range append(append([]string{}, diff.Added...), diff.Changed...) {
+		serverTools = append(serverTools, server.ServerTool{
+			Tool:    tools[name],
+			Handler: s.providerToolHandler(name),
+		})
+	}
+	if len(serverTools) > 0 {
+		s.srv.AddTools(serverTools...)
+	}
+	if len(diff.Removed) > 0 {
+		s.srv.DeleteTools(diff.Removed...)
+	}
+
+	if s.logger != nil {
+		s.logger.Infof("Tools reloaded: %d added, %d changed, %d removed", len(diff.Added), len(diff.Changed), len(diff.Removed))
+	}
+
+	return diff
+}
+
+// collectProviderTools gathers tool definitions from every tool provider and
+// converts them to MCP tools, both keyed by tool name
+func (s *MCPServer) collectProviderTools() (map[string]mcp.Tool, map[string]global.ToolDefinition) {
+	tools := make(map[string]mcp.Tool)
+	definitions := make(map[string]global.ToolDefinition)
+
+	// Iterate over tool providers and collect their tools
 	for _, provider := range s.toolProviders {
+		for _, toolDef := range provider.RegisterTools() {
+			tools[toolDef.Name] = newProviderTool(toolDef)
+			definitions[toolDef.Name] = toolDef
+		}
+	}
+
+	return tools, definitions
+}
 
-		// Call the Register function of the provider to get tool definitions
-		toolDefinitions := provider.RegisterTools()
+// newProviderTool converts a provider tool definition to an MCP tool
+func newProviderTool(toolDef global.ToolDefinition) mcp.Tool {
+	// Combine description and parameters into a slice of options
+	toolOptions := []mcp.ToolOption{
+		mcp.WithDescription(toolDef.Description),
+	}
+	for _, param := range toolDef.Parameters {
+		options := []mcp.PropertyOption{mcp.Description(param.Description)}
+		if param.Required {
+			options = append(options, mcp.Required())
+		}
 
-		// Iterate over the tool definitions and register each tool
-		for _, toolDef := range toolDefinitions {
-			// Capture toolDef in closure to avoid loop variable issues
-			toolDef := toolDef
+		// A full JSON Schema for the parameter is emitted as is
+		if param.Schema != nil {
+			schemaOptions := append([]mcp.PropertyOption{withSchema(param.Schema)}, options...)
+			toolOptions = append(toolOptions, mcp.WithAny(param.Name, schemaOptions...))
+			continue
+		}
 
-			// Combine description and parameters into a slice of options
-			toolOptions := []mcp.ToolOption{
-				mcp.WithDescription(toolDef.Description),
+		// Use appropriate MCP parameter type based on param.Type
+		var toolOption mcp.ToolOption
+		switch param.Type {
+		case "string":
+			toolOption = mcp.WithString(param.Name, options...)
+		case "integer", "number":
+			if param.Minimum != nil {
+				options = append(options, mcp.Min(*param.Minimum))
 			}
-			for _, param := range toolDef.Parameters {
-				options := []mcp.PropertyOption{mcp.Description(param.Description)}
-				if param.Required {
-					options = append(options, mcp.Required())
-				}
-
-				// Use appropriate MCP parameter type based on param.Type
-				var toolOption mcp.ToolOption
-				switch param.Type {
-				case "string":
-					toolOption = mcp.WithString(param.Name, options...)
-				case "integer":
-					toolOption = mcp.WithNumber(param.Name, options...)
-				case "number":
-					toolOption = mcp.WithNumber(param.Name, options...)
-				case "boolean":
-					toolOption = mcp.WithBoolean(param.Name, options...)
-				case "array":
-					// Set the items schema based on the declared item type.
-					// JSON Schema requires arrays to have an items field, so we always set one.
-					if param.Items == "object" {
-						options = append(options, mcp.Items(map[string]any{"type": "object"}))
-					} else {
-						options = append(options, mcp.WithStringItems())
-					}
-					toolOption = mcp.WithArray(param.Name, options...)
-				case "object":
-					// Add additionalProperties for object parameters to satisfy strict JSON Schema validators
-					// Environment variables and similar objects typically accept string key-value pairs
-					options = append(options, mcp.AdditionalProperties(map[string]interface{}{
-						"type": "string",
-					}))
-					toolOption = mcp.WithObject(param.Name, options...)
-				default:
-					// Fallback to string for unknown types
-					toolOption = mcp.WithString(param.Name, options...)
-				}
-
-				toolOptions = append(toolOptions, toolOption)
+			if param.Maximum != nil {
+				options = append(options, mcp.Max(*param.Maximum))
 			}
-
-			// Add hint annotations if hints are set
-			if toolDef.Hints != nil {
-				if toolDef.Hints.ReadOnly != nil {
-					toolOptions = append(toolOptions, mcp.WithReadOnlyHintAnnotation(*toolDef.Hints.ReadOnly))
-				}
-				if toolDef.Hints.Destructive != nil {
-					toolOptions = append(toolOptions, mcp.WithDestructiveHintAnnotation(*toolDef.Hints.Destructive))
-				}
-				if toolDef.Hints.Idempotent != nil {
-					toolOptions = append(toolOptions, mcp.WithIdempotentHintAnnotation(*toolDef.Hints.Idempotent))
-				}
-				if toolDef.Hints.OpenWorld != nil {
-					toolOptions = append(toolOptions, mcp.WithOpenWorldHintAnnotation(*toolDef.Hints.OpenWorld))
-				}
+			toolOption = mcp.WithNumber(param.Name, options...)
+		case "boolean":
+			toolOption = mcp.WithBoolean(param.Name, options...)
+		case "array":
+			// Set the items schema based on the declared item type.
+			// JSON Schema requires arrays to have an items field, so we always set one.
+			if param.Items == "object" {
+				options = append(options, mcp.Items(map[string]any{"type": "object"}))
+			} else {
+				options = append(options, mcp.WithStringItems())
 			}
+			toolOption = mcp.WithArray(param.Name, options...)
+		case "object":
+			// Add additionalProperties for object parameters to satisfy strict JSON Schema validators
+			// Environment variables and similar objects typically accept string key-value pairs
+			options = append(options, mcp.AdditionalProperties(map[string]interface{}{
+				"type": "string",
+			}))
+			toolOption = mcp.WithObject(param.Name, options...)
+		default:
+			// Fallback to string for unknown types
+			toolOption = mcp.WithString(param.Name, options...)
+		}
+
+		toolOptions = append(toolOptions, toolOption)
+	}
+
+	// Advertise only the hints the tool declares
+	toolOptions = append(toolOptions, withHints(toolDef.Hints))
+
+	// Create the tool with all options
+	return mcp.NewTool(toolDef.Name, toolOptions...)
+}
+
+// withSchema copies a parameter's JSON Schema into its property schema
+func withSchema(schema map[string]interface{}) mcp.PropertyOption {
+	return func(property map[string]any) {
+		for key, value := range schema {
+			property[key] = value
+		}
+	}
+}
+
+// providerToolHandler returns an MCP handler for the named provider tool. The
+// definition is looked up on every call so that a reload takes effect for
+// tools whose MCP schema did not change.
+func (s *MCPServer) providerToolHandler(name string) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s.toolsMutex.RLock()
+		toolDef, ok := s.providerToolDefs[name]
+		s.toolsMutex.RUnlock()
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("tool %s is no longer available", name)), nil
+		}
+
+		// Add the tool name to the context for downstream middleware/handlers
+		ctx = context.WithValue(ctx, global.ToolNameKey, toolDef.Name)
+
+		// Let long-running tools report progress to the client
+		if notify := progressNotifier(ctx, req, toolDef.Name); notify != nil {
+			ctx = context.WithValue(ctx, global.ProgressNotifierKey, notify)
+		}
+
+		// Copy the MCP arguments to a map
+		options := req.GetArguments()
+
+		// Always pass context through options for fusion handlers to use
+		// This allows fusion providers to access tenant context
+		ctxOptions := make(map[string]any)
+		for k, v := range options {
+			ctxOptions[k] = v
+		}
+		// Store the context for fusion handlers to extract tenant context
+		ctxOptions["__mcp_context"] = ctx
+
+		// Debug: Log that we're passing context
+		if s.logger != nil {
+			s.logger.Debugf("MCP server passing context to tool %s", toolDef.Name)
+		}
 
-			// Create the tool with all options
-			tool := mcp.NewTool(toolDef.Name, toolOptions...)
-
-			// Register the tool with the MCP server, creating a handler compatible with the MCP server
-			// that wraps the tool's handler function with the provided options
-			s.srv.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-				// Add the tool name to the context for downstream middleware/handlers
-				ctx = context.WithValue(ctx, global.ToolNameKey, toolDef.Name)
-
-				// Copy the MCP arguments to a map
-				options := req.GetArguments()
-
-				// Always pass context through options for fusion handlers to use
-				// This allows fusion providers to access tenant context
-				ctxOptions := make(map[string]any)
-				for k, v := range options {
-					ctxOptions[k] = v
-				}
-				// Store the context for fusion handlers to extract tenant context
-				ctxOptions["__mcp_context"] = ctx
-
-				// Debug: Log that we're passing context
-				if s.logger != nil {
-					s.logger.Debugf("MCP server passing context to tool %s", toolDef.Name)
-				}
-
-				result, err := toolDef.Handler(ctxOptions)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), nil
-				}
-				return mcp.NewToolResultText(result), nil
-			})
+		result, err := toolDef.Handler(ctxOptions)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
+		return mcp.NewToolResultText(result), nil
 	}
 }
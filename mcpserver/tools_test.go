@@ -0,0 +1,129 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package mcpserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tenebris-tech/mlogger"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// mutableToolProvider returns whatever tools are currently assigned to it.
+type mutableToolProvider struct {
+	tools []global.ToolDefinition
+}
+
+func (p *mutableToolProvider) RegisterTools() []global.ToolDefinition {
+	return p.tools
+}
+
+func echoTool(name, description, reply string) global.ToolDefinition {
+	return global.ToolDefinition{
+		Name:        name,
+		Description: description,
+		Handler: func(map[string]any) (string, error) {
+			return reply, nil
+		},
+	}
+}
+
+func callTool(t *testing.T, s *MCPServer, name string) string {
+	t.Helper()
+	tool := s.srv.GetTool(name)
+	if tool == nil {
+		t.Fatalf("tool %s is not registered", name)
+	}
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("tool %s failed: %v", name, err)
+	}
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestReloadTools(t *testing.T) {
+	provider := &mutableToolProvider{tools: []global.ToolDefinition{
+		echoTool("svc_keep", "kept", "old keep"),
+		echoTool("svc_change", "before", "old change"),
+		echoTool("svc_remove", "removed", "old remove"),
+	}}
+	s, err := New(WithLogger(mlogger.NewMemoryLogger()), WithToolProviders([]global.ToolProvider{provider}))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	provider.tools = []global.ToolDefinition{
+		echoTool("svc_keep", "kept", "new keep"),
+		echoTool("svc_change", "after", "new change"),
+		echoTool("svc_add", "added", "new add"),
+	}
+	diff := s.ReloadTools()
+
+	if len(diff.Added) != 1 || diff.Added[0] != "svc_add" {
+		t.Errorf("expected svc_add to be added, got %v", diff.Added)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != "svc_change" {
+		t.Errorf("expected svc_change to be changed, got %v", diff.Changed)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "svc_remove" {
+		t.Errorf("expected svc_remove to be removed, got %v", diff.Removed)
+	}
+
+	if s.srv.GetTool("svc_remove") != nil {
+		t.Error("expected svc_remove to be deleted from the server")
+	}
+	if got := s.srv.GetTool("svc_change").Tool.Description; got != "after" {
+		t.Errorf("expected updated description, got %q", got)
+	}
+
+	// Unchanged tools keep their registration but dispatch to the new handler
+	if got := callTool(t, s, "svc_keep"); got != "new keep" {
+		t.Errorf("expected reloaded handler for svc_keep, got %q", got)
+	}
+	if got := callTool(t, s, "svc_add"); got != "new add" {
+		t.Errorf("expected handler for svc_add, got %q", got)
+	}
+}
+
+func TestNewProviderTool_Schema(t *testing.T) {
+	minimum := 1.0
+	tool := newProviderTool(global.ToolDefinition{
+		Name:        "svc_create",
+		Description: "create",
+		Parameters: []global.Parameter{
+			{
+				Name:        "attendees",
+				Description: "Meeting attendees",
+				Type:        "array",
+				Required:    true,
+				Schema: map[string]interface{}{
+					"type":  "array",
+					"items": map[string]interface{}{"type": "object", "required": []interface{}{"email"}},
+				},
+			},
+			{Name: "top", Type: "integer", Minimum: &minimum},
+		},
+	})
+
+	attendees, ok := tool.InputSchema.Properties["attendees"].(map[string]any)
+	if !ok {
+		t.Fatalf("attendees property missing: %v", tool.InputSchema.Properties)
+	}
+	if attendees["type"] != "array" || attendees["items"] == nil || attendees["description"] != "Meeting attendees" {
+		t.Errorf("schema not emitted: %v", attendees)
+	}
+	if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "attendees" {
+		t.Errorf("expected attendees to be required, got %v", tool.InputSchema.Required)
+	}
+
+	top := tool.InputSchema.Properties["top"].(map[string]any)
+	if top["minimum"] != 1.0 {
+		t.Errorf("minimum not emitted: %v", top)
+	}
+}
@@ -5,7 +5,12 @@
 
 package mcpserver
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/mark3labs/mcp-go/mcp"
+)
 
 // logInJSON logs data in JSON for debugging
 func (s *MCPServer) logInJSON(data any) {
@@ -16,3 +21,55 @@ func (s *MCPServer) logInJSON(data any) {
 	}
 	s.logger.Debugf("JSON DATA:\n%s", string(b))
 }
+
+// hintsFromAnnotations converts MCP tool annotations to ToolHints. Hints the
+// tool does not declare stay nil.
+func hintsFromAnnotations(annotations mcp.ToolAnnotation) *global.ToolHints {
+	return &global.ToolHints{
+		ReadOnly:    annotations.ReadOnlyHint,
+		Destructive: annotations.DestructiveHint,
+		Idempotent:  annotations.IdempotentHint,
+		OpenWorld:   annotations.OpenWorldHint,
+	}
+}
+
+// withHints sets the hint annotations of a tool to the declared hints.
+// mcp.NewTool fills in a default for every hint, so a tool without declared
+// hints would otherwise claim to be destructive and not read-only. Unset hints
+// are omitted and clients apply the same defaults from the MCP specification.
+func withHints(hints *global.ToolHints) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		tool.Annotations.ReadOnlyHint = nil
+		tool.Annotations.DestructiveHint = nil
+		tool.Annotations.IdempotentHint = nil
+		tool.Annotations.OpenWorldHint = nil
+		if hints == nil {
+			return
+		}
+		if hints.ReadOnly != nil {
+			tool.Annotations.ReadOnlyHint = mcp.ToBoolPtr(*hints.ReadOnly)
+		}
+		if hints.Destructive != nil {
+			tool.Annotations.DestructiveHint = mcp.ToBoolPtr(*hints.Destructive)
+		}
+		if hints.Idempotent != nil {
+			tool.Annotations.IdempotentHint = mcp.ToBoolPtr(*hints.Idempotent)
+		}
+		if hints.OpenWorld != nil {
+			tool.Annotations.OpenWorldHint = mcp.ToBoolPtr(*hints.OpenWorld)
+		}
+	}
+}
+
+// lookupToolHints returns the hint annotations of a registered tool, or nil if
+// the tool is not registered
+func (s *MCPServer) lookupToolHints(toolName string) *global.ToolHints {
+	if s.srv == nil {
+		return nil
+	}
+	tool := s.srv.GetTool(toolName)
+	if tool == nil {
+		return nil
+	}
+	return hintsFromAnnotations(tool.Tool.Annotations)
+}
@@ -16,29 +16,49 @@ import (
 	"github.com/PivotLLM/MCPFusion/global"
 )
 
-// Collector tracks request and error counts for registered services.
-// All methods are safe for concurrent use.
+// Collector tracks request and error counts for registered services, and
+// call counts and latencies for tools. All methods are safe for concurrent use.
 type Collector struct {
-	mu       sync.RWMutex
-	start    time.Time
-	services map[string]*ServiceStats
+	mu        sync.RWMutex
+	start     time.Time
+	services  map[string]*ServiceStats
+	latencies map[string]*histogram // Request latency by service
+	tools     map[string]*toolStats
 }
 
 // ServiceStats describes the operational state and request metrics for one service.
 type ServiceStats struct {
 	Name      string `json:"name"`
 	Transport string `json:"transport"`       // "api", "mcp_stdio", "mcp_sse", "mcp_http", "internal"
-	Status    string `json:"status"`           // "operational", "degraded", "disconnected"
-	Tools     *int   `json:"tools,omitempty"`  // nil for non-tool services
+	Status    string `json:"status"`          // "operational", "degraded", "disconnected"
+	Tools     *int   `json:"tools,omitempty"` // nil for non-tool services
 	Requests  int64  `json:"requests"`
 	Errors    int64  `json:"errors"`
+
+	CacheHits   int64 `json:"cache_hits,omitempty"`
+	CacheMisses int64 `json:"cache_misses,omitempty"`
+	Throttled   int64 `json:"throttled,omitempty"` // Requests rejected by rate or concurrency limits
+
+	ErrorCategories      map[string]int64 `json:"error_categories,omitempty"` // Errors by category, e.g. "network" or "timeout"
+	TokenRefreshes       int64            `json:"token_refreshes,omitempty"`
+	TokenRefreshFailures int64            `json:"token_refresh_failures,omitempty"`
+}
+
+// toolStats holds the call metrics for one tool.
+type toolStats struct {
+	service string
+	calls   int64
+	errors  int64
+	latency histogram
 }
 
 // New creates a new Collector and records the server start time.
 func New() *Collector {
 	return &Collector{
-		start:    time.Now(),
-		services: make(map[string]*ServiceStats),
+		start:     time.Now(),
+		services:  make(map[string]*ServiceStats),
+		latencies: make(map[string]*histogram),
+		tools:     make(map[string]*toolStats),
 	}
 }
 
@@ -80,6 +100,102 @@ func (c *Collector) RecordRequest(service string, isError bool) {
 	}
 }
 
+// RecordCacheLookup increments the response cache hit or miss counter for a service.
+// Calls for unregistered services are silently ignored.
+func (c *Collector) RecordCacheLookup(service string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.services[service]
+	if !ok {
+		return
+	}
+	if hit {
+		s.CacheHits++
+	} else {
+		s.CacheMisses++
+	}
+}
+
+// RecordThrottle increments the counter of requests rejected by a rate or
+// concurrency limit. Calls for unregistered services are silently ignored.
+func (c *Collector) RecordThrottle(service string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.services[service]; ok {
+		s.Throttled++
+	}
+}
+
+// RecordLatency records the duration of a request to a service.
+// Calls for unregistered services are silently ignored.
+func (c *Collector) RecordLatency(service string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.services[service]; !ok {
+		return
+	}
+	h, ok := c.latencies[service]
+	if !ok {
+		h = &histogram{}
+		c.latencies[service] = h
+	}
+	h.observe(d)
+}
+
+// RecordErrorCategory increments the counter for a category of failed requests
+// to a service. Calls for unregistered services are silently ignored.
+func (c *Collector) RecordErrorCategory(service, category string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.services[service]
+	if !ok || category == "" {
+		return
+	}
+	if s.ErrorCategories == nil {
+		s.ErrorCategories = make(map[string]int64)
+	}
+	s.ErrorCategories[category]++
+}
+
+// RecordTokenRefresh counts an attempt to refresh an upstream token for a service.
+// Calls for unregistered services are silently ignored.
+func (c *Collector) RecordTokenRefresh(service string, success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.services[service]
+	if !ok {
+		return
+	}
+	if success {
+		s.TokenRefreshes++
+	} else {
+		s.TokenRefreshFailures++
+	}
+}
+
+// RecordToolCall records a call to a tool, its duration and whether it failed.
+// The service is the one the tool belongs to, or empty if unknown.
+func (c *Collector) RecordToolCall(tool, service string, d time.Duration, isError bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, ok := c.tools[tool]
+	if !ok {
+		t = &toolStats{service: service}
+		c.tools[tool] = t
+	}
+	t.calls++
+	if isError {
+		t.errors++
+	}
+	t.latency.observe(d)
+}
+
 // SetStatus updates the status string for a service.
 // Calls for unregistered services are silently ignored.
 func (c *Collector) SetStatus(service, status string) {
@@ -129,13 +245,20 @@ func (c *Collector) GetAllServiceStats() []ServiceStats {
 	return result
 }
 
-// snapshot returns a deep copy of the ServiceStats, including the Tools pointer.
+// snapshot returns a deep copy of the ServiceStats, including the Tools pointer
+// and the error categories.
 func (s *ServiceStats) snapshot() *ServiceStats {
 	cpy := *s
 	if s.Tools != nil {
 		t := *s.Tools
 		cpy.Tools = &t
 	}
+	if s.ErrorCategories != nil {
+		cpy.ErrorCategories = make(map[string]int64, len(s.ErrorCategories))
+		for k, v := range s.ErrorCategories {
+			cpy.ErrorCategories[k] = v
+		}
+	}
 	return &cpy
 }
 
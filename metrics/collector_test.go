@@ -114,6 +114,81 @@ func TestRecordRequestUnregistered(t *testing.T) {
 	c.RecordRequest("unknown", true)
 }
 
+func TestRecordCacheLookup(t *testing.T) {
+	c := New()
+	tools := 1
+	c.RegisterService("svc", global.TransportAPI, &tools)
+
+	c.RecordCacheLookup("svc", false)
+	c.RecordCacheLookup("svc", true)
+	c.RecordCacheLookup("svc", true)
+
+	s := c.GetServiceStats("svc")
+	if s.CacheHits != 2 {
+		t.Errorf("expected 2 cache hits, got %d", s.CacheHits)
+	}
+	if s.CacheMisses != 1 {
+		t.Errorf("expected 1 cache miss, got %d", s.CacheMisses)
+	}
+	if s.Requests != 0 {
+		t.Errorf("cache lookups must not count as requests, got %d", s.Requests)
+	}
+
+	// Unregistered service should not panic
+	c.RecordCacheLookup("unknown", true)
+}
+
+func TestRecordThrottle(t *testing.T) {
+	c := New()
+	c.RegisterService("svc", global.TransportAPI, nil)
+
+	c.RecordThrottle("svc")
+	c.RecordThrottle("svc")
+
+	s := c.GetServiceStats("svc")
+	if s.Throttled != 2 {
+		t.Errorf("expected 2 throttled requests, got %d", s.Throttled)
+	}
+	if s.Requests != 0 {
+		t.Errorf("throttled requests must not count as requests, got %d", s.Requests)
+	}
+
+	// Unregistered service should not panic
+	c.RecordThrottle("unknown")
+}
+
+func TestRecordErrorCategoryAndTokenRefresh(t *testing.T) {
+	c := New()
+	c.RegisterService("svc", global.TransportAPI, nil)
+
+	c.RecordErrorCategory("svc", "network")
+	c.RecordErrorCategory("svc", "network")
+	c.RecordErrorCategory("svc", "timeout")
+	c.RecordErrorCategory("svc", "")
+	c.RecordTokenRefresh("svc", true)
+	c.RecordTokenRefresh("svc", false)
+	c.RecordTokenRefresh("svc", false)
+
+	s := c.GetServiceStats("svc")
+	if s.ErrorCategories["network"] != 2 || s.ErrorCategories["timeout"] != 1 || len(s.ErrorCategories) != 2 {
+		t.Errorf("unexpected error categories: %v", s.ErrorCategories)
+	}
+	if s.TokenRefreshes != 1 || s.TokenRefreshFailures != 2 {
+		t.Errorf("expected 1 refresh and 2 failures, got %d/%d", s.TokenRefreshes, s.TokenRefreshFailures)
+	}
+
+	// The snapshot must not share the category map
+	s.ErrorCategories["network"] = 100
+	if c.GetServiceStats("svc").ErrorCategories["network"] != 2 {
+		t.Error("modifying a snapshot changed the collector")
+	}
+
+	// Unregistered service should not panic
+	c.RecordErrorCategory("unknown", "network")
+	c.RecordTokenRefresh("unknown", true)
+	c.RecordLatency("unknown", time.Second)
+}
+
 func TestSetStatus(t *testing.T) {
 	c := New()
 	tools := 1
@@ -0,0 +1,299 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// ContentType is the media type of the OpenMetrics text format.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// latencyBuckets are the upper bounds, in seconds, of the latency histogram buckets.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// histogram counts observed durations in latencyBuckets.
+type histogram struct {
+	buckets [14]int64 // One per bucket plus +Inf; not cumulative
+	count   int64
+	sum     float64
+}
+
+// observe adds a duration to the histogram.
+func (h *histogram) observe(d time.Duration) {
+	seconds := d.Seconds()
+	i := sort.SearchFloat64s(latencyBuckets, seconds)
+	h.buckets[i]++
+	h.count++
+	h.sum += seconds
+}
+
+// CircuitBreakerSource reports the circuit breaker state of each service:
+// "closed", "open" or "half_open". It is defined here so that callers can
+// supply an adapter without the metrics package importing fusion.
+type CircuitBreakerSource interface {
+	CircuitBreakerStates() map[string]string
+}
+
+// circuitBreakerStates are the states exported for every circuit breaker.
+var circuitBreakerStates = []string{"closed", "open", "half_open"}
+
+// serviceStatuses are the statuses exported for every service.
+var serviceStatuses = []string{global.StatusOperational, global.StatusDegraded, global.StatusDisconnected}
+
+// Exporter serves the metrics of a Collector in the OpenMetrics text format.
+type Exporter struct {
+	collector *Collector
+	cbSource  CircuitBreakerSource
+}
+
+// ExporterOption is a functional option for configuring an Exporter.
+type ExporterOption func(*Exporter)
+
+// WithCircuitBreakerSource sets the source for circuit breaker state.
+func WithCircuitBreakerSource(s CircuitBreakerSource) ExporterOption {
+	return func(e *Exporter) { e.cbSource = s }
+}
+
+// NewExporter creates an Exporter for the given collector.
+func NewExporter(c *Collector, opts ...ExporterOption) *Exporter {
+	e := &Exporter{collector: c}
+	for _, o := range opts {
+		o(e)
+	}
+	return e
+}
+
+// ServeHTTP implements http.Handler.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var buf bytes.Buffer
+	e.write(&buf)
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method == http.MethodGet {
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+// WriteTo writes the current metrics to w.
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	e.write(&buf)
+	return buf.WriteTo(w)
+}
+
+// write renders all metric families. The collector lock is only held while
+// rendering into the buffer.
+func (e *Exporter) write(buf *bytes.Buffer) {
+	m := &metricWriter{buf: buf}
+
+	m.family("mcpfusion_build_info", "gauge", "", "Build information")
+	m.sample("mcpfusion_build_info", 1, "version", global.AppVersion)
+
+	m.family("mcpfusion_uptime_seconds", "gauge", "seconds", "Time since the server started")
+	m.sample("mcpfusion_uptime_seconds", e.collector.GetUptime().Seconds())
+
+	e.collector.mu.RLock()
+	services := make([]string, 0, len(e.collector.services))
+	for name := range e.collector.services {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+
+	m.family("mcpfusion_service_status", "gauge", "", "Current status of each service (1 for the current status)")
+	for _, name := range services {
+		s := e.collector.services[name]
+		for _, status := range serviceStatuses {
+			m.sample("mcpfusion_service_status", boolValue(s.Status == status), "service", name, "transport", s.Transport, "status", status)
+		}
+	}
+
+	m.family("mcpfusion_service_tools", "gauge", "", "Number of tools provided by each service")
+	for _, name := range services {
+		if s := e.collector.services[name]; s.Tools != nil {
+			m.sample("mcpfusion_service_tools", float64(*s.Tools), "service", name)
+		}
+	}
+
+	m.family("mcpfusion_service_requests", "counter", "", "Requests to each service")
+	for _, name := range services {
+		m.sample("mcpfusion_service_requests_total", float64(e.collector.services[name].Requests), "service", name)
+	}
+
+	m.family("mcpfusion_service_errors", "counter", "", "Failed requests to each service")
+	for _, name := range services {
+		m.sample("mcpfusion_service_errors_total", float64(e.collector.services[name].Errors), "service", name)
+	}
+
+	m.family("mcpfusion_service_error_categories", "counter", "", "Failed requests to each service by error category")
+	for _, name := range services {
+		categories := e.collector.services[name].ErrorCategories
+		for _, category := range sortedKeys(categories) {
+			m.sample("mcpfusion_service_error_categories_total", float64(categories[category]), "service", name, "category", category)
+		}
+	}
+
+	m.family("mcpfusion_service_request_duration_seconds", "histogram", "seconds", "Duration of requests to each service")
+	for _, name := range services {
+		if h, ok := e.collector.latencies[name]; ok {
+			m.histogram("mcpfusion_service_request_duration_seconds", h, "service", name)
+		}
+	}
+
+	m.family("mcpfusion_service_cache_lookups", "counter", "", "Response cache lookups for each service")
+	for _, name := range services {
+		s := e.collector.services[name]
+		m.sample("mcpfusion_service_cache_lookups_total", float64(s.CacheHits), "service", name, "result", "hit")
+		m.sample("mcpfusion_service_cache_lookups_total", float64(s.CacheMisses), "service", name, "result", "miss")
+	}
+
+	m.family("mcpfusion_service_throttled", "counter", "", "Requests to each service rejected by rate or concurrency limits")
+	for _, name := range services {
+		m.sample("mcpfusion_service_throttled_total", float64(e.collector.services[name].Throttled), "service", name)
+	}
+
+	m.family("mcpfusion_service_token_refreshes", "counter", "", "Upstream token refresh attempts for each service")
+	for _, name := range services {
+		s := e.collector.services[name]
+		m.sample("mcpfusion_service_token_refreshes_total", float64(s.TokenRefreshes), "service", name, "result", "success")
+		m.sample("mcpfusion_service_token_refreshes_total", float64(s.TokenRefreshFailures), "service", name, "result", "failure")
+	}
+
+	tools := make([]string, 0, len(e.collector.tools))
+	for name := range e.collector.tools {
+		tools = append(tools, name)
+	}
+	sort.Strings(tools)
+
+	m.family("mcpfusion_tool_calls", "counter", "", "Calls to each tool")
+	for _, name := range tools {
+		t := e.collector.tools[name]
+		m.sample("mcpfusion_tool_calls_total", float64(t.calls), "tool", name, "service", t.service)
+	}
+
+	m.family("mcpfusion_tool_errors", "counter", "", "Failed calls to each tool")
+	for _, name := range tools {
+		t := e.collector.tools[name]
+		m.sample("mcpfusion_tool_errors_total", float64(t.errors), "tool", name, "service", t.service)
+	}
+
+	m.family("mcpfusion_tool_call_duration_seconds", "histogram", "seconds", "Duration of calls to each tool")
+	for _, name := range tools {
+		t := e.collector.tools[name]
+		m.histogram("mcpfusion_tool_call_duration_seconds", &t.latency, "tool", name, "service", t.service)
+	}
+	e.collector.mu.RUnlock()
+
+	// Circuit breaker state comes from outside the collector
+	if e.cbSource != nil {
+		states := e.cbSource.CircuitBreakerStates()
+		m.family("mcpfusion_circuit_breaker_state", "gauge", "", "Circuit breaker state of each service (1 for the current state)")
+		for _, name := range sortedKeys(states) {
+			for _, state := range circuitBreakerStates {
+				m.sample("mcpfusion_circuit_breaker_state", boolValue(states[name] == state), "service", name, "state", state)
+			}
+		}
+	}
+
+	buf.WriteString("# EOF\n")
+}
+
+// metricWriter renders metric families and samples in the OpenMetrics text format.
+type metricWriter struct {
+	buf *bytes.Buffer
+}
+
+// family writes the metadata lines of a metric family.
+func (m *metricWriter) family(name, metricType, unit, help string) {
+	fmt.Fprintf(m.buf, "# TYPE %s %s\n", name, metricType)
+	if unit != "" {
+		fmt.Fprintf(m.buf, "# UNIT %s %s\n", name, unit)
+	}
+	fmt.Fprintf(m.buf, "# HELP %s %s\n", name, help)
+}
+
+// sample writes one sample. labels are alternating names and values.
+func (m *metricWriter) sample(name string, value float64, labels ...string) {
+	m.buf.WriteString(name)
+	if len(labels) > 0 {
+		m.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.buf.WriteByte(',')
+			}
+			m.buf.WriteString(labels[i])
+			m.buf.WriteString(`="`)
+			m.buf.WriteString(escapeLabelValue(labels[i+1]))
+			m.buf.WriteByte('"')
+		}
+		m.buf.WriteByte('}')
+	}
+	m.buf.WriteByte(' ')
+	m.buf.WriteString(formatValue(value))
+	m.buf.WriteByte('\n')
+}
+
+// histogram writes the cumulative buckets, count and sum of a histogram.
+func (m *metricWriter) histogram(name string, h *histogram, labels ...string) {
+	var cumulative int64
+	for i, bound := range latencyBuckets {
+		cumulative += h.buckets[i]
+		m.sample(name+"_bucket", float64(cumulative), append(labels, "le", formatValue(bound))...)
+	}
+	m.sample(name+"_bucket", float64(h.count), append(labels, "le", "+Inf")...)
+	m.sample(name+"_count", float64(h.count), labels...)
+	m.sample(name+"_sum", h.sum, labels...)
+}
+
+// labelEscaper escapes label values as required by the text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value.
+func escapeLabelValue(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+// formatValue formats a sample value, using integer notation where possible.
+func formatValue(v float64) string {
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// boolValue returns 1 for true and 0 for false.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
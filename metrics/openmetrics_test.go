@@ -0,0 +1,116 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+type fakeCircuitBreakers map[string]string
+
+func (f fakeCircuitBreakers) CircuitBreakerStates() map[string]string {
+	return f
+}
+
+// scrape returns the body served by an exporter for the collector
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("expected content type %q, got %q", ContentType, ct)
+	}
+	return rr.Body.String()
+}
+
+// expectLines fails the test for every line that is missing from body
+func expectLines(t *testing.T, body string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(body, "\n"+line+"\n") {
+			t.Errorf("missing line %q", line)
+		}
+	}
+}
+
+func TestExporterServiceMetrics(t *testing.T) {
+	c := New()
+	tools := 4
+	c.RegisterService("github", global.TransportAPI, &tools)
+	c.RegisterService("remote", global.TransportMCPHTTP, nil)
+	c.SetStatus("remote", global.StatusDisconnected)
+
+	c.RecordRequest("github", false)
+	c.RecordRequest("github", true)
+	c.RecordLatency("github", 20*time.Millisecond)
+	c.RecordLatency("github", 3*time.Second)
+	c.RecordErrorCategory("github", "ratelimit")
+	c.RecordCacheLookup("github", true)
+	c.RecordThrottle("github")
+	c.RecordTokenRefresh("github", false)
+
+	body := scrape(t, NewExporter(c))
+	expectLines(t, body,
+		`# TYPE mcpfusion_service_requests counter`,
+		`mcpfusion_service_requests_total{service="github"} 2`,
+		`mcpfusion_service_errors_total{service="github"} 1`,
+		`mcpfusion_service_error_categories_total{service="github",category="ratelimit"} 1`,
+		`mcpfusion_service_tools{service="github"} 4`,
+		`mcpfusion_service_status{service="remote",transport="mcp_http",status="disconnected"} 1`,
+		`mcpfusion_service_status{service="remote",transport="mcp_http",status="operational"} 0`,
+		`mcpfusion_service_cache_lookups_total{service="github",result="hit"} 1`,
+		`mcpfusion_service_throttled_total{service="github"} 1`,
+		`mcpfusion_service_token_refreshes_total{service="github",result="failure"} 1`,
+		`# UNIT mcpfusion_service_request_duration_seconds seconds`,
+		`mcpfusion_service_request_duration_seconds_bucket{service="github",le="0.01"} 0`,
+		`mcpfusion_service_request_duration_seconds_bucket{service="github",le="0.025"} 1`,
+		`mcpfusion_service_request_duration_seconds_bucket{service="github",le="5"} 2`,
+		`mcpfusion_service_request_duration_seconds_bucket{service="github",le="+Inf"} 2`,
+		`mcpfusion_service_request_duration_seconds_count{service="github"} 2`,
+		`mcpfusion_service_request_duration_seconds_sum{service="github"} 3.02`,
+	)
+	if !strings.HasSuffix(body, "\n# EOF\n") {
+		t.Error("exposition must end with # EOF")
+	}
+	if strings.Contains(body, "mcpfusion_circuit_breaker_state") {
+		t.Error("circuit breaker state must not be exported without a source")
+	}
+}
+
+func TestExporterToolAndCircuitBreakerMetrics(t *testing.T) {
+	c := New()
+	c.RecordToolCall("github_list_repos", "github", 40*time.Millisecond, false)
+	c.RecordToolCall("github_list_repos", "github", 2*time.Minute, true)
+	c.RecordToolCall(`odd"tool`, "", time.Millisecond, false)
+
+	body := scrape(t, NewExporter(c, WithCircuitBreakerSource(fakeCircuitBreakers{"GitHub": "open"})))
+	expectLines(t, body,
+		`mcpfusion_tool_calls_total{tool="github_list_repos",service="github"} 2`,
+		`mcpfusion_tool_errors_total{tool="github_list_repos",service="github"} 1`,
+		`mcpfusion_tool_call_duration_seconds_bucket{tool="github_list_repos",service="github",le="60"} 1`,
+		`mcpfusion_tool_call_duration_seconds_bucket{tool="github_list_repos",service="github",le="+Inf"} 2`,
+		`mcpfusion_tool_calls_total{tool="odd\"tool",service=""} 1`,
+		`mcpfusion_circuit_breaker_state{service="GitHub",state="open"} 1`,
+		`mcpfusion_circuit_breaker_state{service="GitHub",state="closed"} 0`,
+	)
+}
+
+func TestExporterMethodNotAllowed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewExporter(New()).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rr.Code)
+	}
+}
@@ -54,6 +54,9 @@ type healthService struct {
 	Tools          *int   `json:"tools,omitempty"`
 	Requests       int64  `json:"requests"`
 	Errors         int64  `json:"errors"`
+	CacheHits      int64  `json:"cache_hits,omitempty"`
+	CacheMisses    int64  `json:"cache_misses,omitempty"`
+	Throttled      int64  `json:"throttled,omitempty"`
 	CircuitBreaker string `json:"circuit_breaker,omitempty"`
 }
 
@@ -143,12 +146,15 @@ func (p *Provider) handleHealth(_ map[string]interface{}) (string, error) {
 	services := make([]healthService, 0, len(allStats))
 	for _, ss := range allStats {
 		hs := healthService{
-			Name:      ss.Name,
-			Transport: ss.Transport,
-			Status:    ss.Status,
-			Tools:     ss.Tools,
-			Requests:  ss.Requests,
-			Errors:    ss.Errors,
+			Name:        ss.Name,
+			Transport:   ss.Transport,
+			Status:      ss.Status,
+			Tools:       ss.Tools,
+			Requests:    ss.Requests,
+			Errors:      ss.Errors,
+			CacheHits:   ss.CacheHits,
+			CacheMisses: ss.CacheMisses,
+			Throttled:   ss.Throttled,
 		}
 
 		// Check base status from shared collector.
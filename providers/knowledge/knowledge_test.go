@@ -73,6 +73,7 @@ func (m *mockDB) GetAPITokenMetadata(_ string) (*db.APITokenMetadata, error) {
 	return nil, nil
 }
 func (m *mockDB) ResolveAPIToken(_ string) (string, error) { return "", nil }
+func (m *mockDB) SetAPITokenRole(_, _ string) error        { return nil }
 func (m *mockDB) StoreOAuthToken(_, _ string, _ *db.OAuthTokenData) error {
 	return nil
 }
@@ -101,9 +102,38 @@ func (m *mockDB) DeleteUser(_ string) error                                   {
 func (m *mockDB) LinkAPIKey(_, _ string) error                                { return nil }
 func (m *mockDB) UnlinkAPIKey(_ string) error                                 { return nil }
 func (m *mockDB) GetUserByAPIKey(_ string) (string, error)                    { return "", nil }
+func (m *mockDB) ListUserAPIKeys(_ string) ([]string, error)                  { return nil, nil }
 func (m *mockDB) AutoMigrateKeys() error                                      { return nil }
-func (m *mockDB) Close() error                                                { return nil }
-func (m *mockDB) Backup(_ string) error                                       { return nil }
+func (m *mockDB) SaveCommandJob(_ *db.CommandJob) error                       { return nil }
+func (m *mockDB) GetCommandJob(_ string) (*db.CommandJob, error)              { return nil, nil }
+func (m *mockDB) ListCommandJobs(_ string) ([]db.CommandJob, error)           { return nil, nil }
+func (m *mockDB) DeleteCommandJob(_ string) error                             { return nil }
+func (m *mockDB) CreateOAuthClient(_ *db.OAuthClientData) (string, error)     { return "", nil }
+func (m *mockDB) GetOAuthClient(_ string) (*db.OAuthClientData, error)        { return nil, nil }
+func (m *mockDB) CreateOAuthGrant(_ *db.OAuthGrantData) (string, error)       { return "", nil }
+func (m *mockDB) ConsumeOAuthGrant(_ string) (*db.OAuthGrantData, error)      { return nil, nil }
+func (m *mockDB) CreateOAuthAccessToken(_ *db.OAuthIssuedTokenData) (string, error) {
+	return "", nil
+}
+func (m *mockDB) ValidateOAuthAccessToken(_ string) (*db.OAuthIssuedTokenData, error) {
+	return nil, nil
+}
+func (m *mockDB) CreateOAuthRefreshToken(_ *db.OAuthIssuedTokenData) (string, error) {
+	return "", nil
+}
+func (m *mockDB) ConsumeOAuthRefreshToken(_ string) (*db.OAuthIssuedTokenData, error) {
+	return nil, nil
+}
+func (m *mockDB) RevokeOAuthToken(_ string) error         { return nil }
+func (m *mockDB) CleanupExpiredOAuthServerData() error    { return nil }
+func (m *mockDB) RecordAuditEntry(_ *db.AuditEntry) error { return nil }
+func (m *mockDB) QueryAuditLog(_ db.AuditFilter) ([]db.AuditEntry, error) {
+	return nil, nil
+}
+func (m *mockDB) PruneAuditLog(_ time.Time, _ int) (int, error) { return 0, nil }
+func (m *mockDB) DataDir() string                               { return "" }
+func (m *mockDB) Close() error                                  { return nil }
+func (m *mockDB) Backup(_ string) error                         { return nil }
 
 // Ensure mockDB satisfies the interface at compile time.
 var _ db.Database = (*mockDB)(nil)
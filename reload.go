@@ -0,0 +1,90 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package main
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/PivotLLM/MCPFusion/config"
+	"github.com/PivotLLM/MCPFusion/fusion"
+	"github.com/PivotLLM/MCPFusion/global"
+	"github.com/PivotLLM/MCPFusion/mcpserver"
+)
+
+// configReloader applies reloaded configuration files to the running server
+type configReloader struct {
+	logger         global.Logger
+	configManager  *config.Manager
+	fusionProvider *fusion.Fusion
+	authorizer     *fusion.PolicyAuthorizer
+	rateLimiter    *fusion.RateLimiter
+	server         *mcpserver.MCPServer
+	certMapper     *mcpserver.ClientCertMapper // Reloaded with the TLS certificate, if set
+	mu             sync.Mutex                  // Serialises reloads from SIGHUP and the file watcher
+}
+
+// Reload re-reads and validates every configuration file, then swaps in the new
+// services, commands, policy and rate limits and re-registers tools. If any file fails to
+// load, the running configuration is left untouched.
+func (r *configReloader) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadTLS()
+
+	hubServices := r.hubServiceNames()
+	if err := r.configManager.Reload(); err != nil {
+		r.logger.Errorf("Configuration reload failed, keeping current configuration: %v", err)
+		return
+	}
+
+	r.authorizer.SetPolicy(r.configManager.GetPolicy())
+	r.rateLimiter.SetConfig(r.configManager.GetRateLimits())
+
+	if r.fusionProvider != nil {
+		r.fusionProvider.ApplyConfig(r.configManager.GetConfig())
+	} else if r.configManager.ServiceCount() > 0 || r.configManager.CommandCount() > 0 {
+		r.logger.Warning("Configuration now defines services or commands, but no fusion provider was created at startup - restart to enable them")
+	}
+
+	if hubServices != r.hubServiceNames() {
+		r.logger.Warning("Hub service definitions changed - restart to connect or disconnect hub servers")
+	}
+
+	diff := r.server.ReloadTools()
+	if len(diff.Added) > 0 || len(diff.Changed) > 0 || len(diff.Removed) > 0 {
+		r.logger.Infof("Configuration reloaded: added %v, changed %v, removed %v", diff.Added, diff.Changed, diff.Removed)
+	} else {
+		r.logger.Info("Configuration reloaded: no tool changes")
+	}
+}
+
+// reloadTLS re-reads the TLS certificate files and the client certificate
+// map. Failures keep the current certificate and mapping.
+func (r *configReloader) reloadTLS() {
+	if err := r.server.ReloadTLS(); err != nil {
+		r.logger.Errorf("TLS certificate reload failed, keeping current certificate: %v", err)
+	}
+	if r.certMapper != nil {
+		if err := r.certMapper.Load(); err != nil {
+			r.logger.Errorf("Client certificate map reload failed, keeping current mapping: %v", err)
+		}
+	}
+}
+
+// hubServiceNames returns a stable representation of the configured hub services
+func (r *configReloader) hubServiceNames() string {
+	var names []string
+	for name, svc := range r.configManager.GetAllServices() {
+		if svc.IsHubService() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,401 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables read by ExporterFromEnv
+const (
+	EnvTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvHeaders        = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvServiceName    = "OTEL_SERVICE_NAME"
+	EnvTraceFile      = "MCP_FUSION_TRACE_FILE"
+)
+
+// Exporter receives batches of ended spans.
+type Exporter interface {
+	ExportSpans(ctx context.Context, resource Resource, spans []SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// ExporterFromEnv builds an exporter from the standard OpenTelemetry
+// environment variables and MCP_FUSION_TRACE_FILE. It returns nil if tracing
+// is not configured.
+func ExporterFromEnv() (Exporter, error) {
+	var exporters []Exporter
+
+	endpoint := os.Getenv(EnvTracesEndpoint)
+	if endpoint == "" {
+		if base := os.Getenv(EnvEndpoint); base != "" {
+			endpoint = strings.TrimRight(base, "/") + "/v1/traces"
+		}
+	}
+	if endpoint != "" {
+		headers, err := ParseHeaders(os.Getenv(EnvHeaders))
+		if err != nil {
+			return nil, err
+		}
+		otlp, err := NewOTLPExporter(endpoint, headers)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, otlp)
+	}
+
+	if path := os.Getenv(EnvTraceFile); path != "" {
+		file, err := NewFileExporter(path)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, file)
+	}
+
+	switch len(exporters) {
+	case 0:
+		return nil, nil
+	case 1:
+		return exporters[0], nil
+	default:
+		return multiExporter(exporters), nil
+	}
+}
+
+// ParseHeaders parses a comma-separated list of key=value pairs in the format
+// of OTEL_EXPORTER_OTLP_HEADERS. Values may be URL-encoded.
+func ParseHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q", pair)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid OTLP header value for %s: %w", key, err)
+		}
+		headers[key] = decoded
+	}
+	return headers, nil
+}
+
+// multiExporter sends spans to several exporters.
+type multiExporter []Exporter
+
+// ExportSpans implements Exporter.
+func (m multiExporter) ExportSpans(ctx context.Context, resource Resource, spans []SpanData) error {
+	var errs []error
+	for _, e := range m {
+		errs = append(errs, e.ExportSpans(ctx, resource, spans))
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown implements Exporter.
+func (m multiExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, e := range m {
+		errs = append(errs, e.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// MemoryExporter keeps exported spans in memory, for tests and debugging.
+type MemoryExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+// NewMemoryExporter creates an empty MemoryExporter.
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{}
+}
+
+// ExportSpans implements Exporter.
+func (m *MemoryExporter) ExportSpans(_ context.Context, _ Resource, spans []SpanData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = append(m.spans, spans...)
+	return nil
+}
+
+// Shutdown implements Exporter.
+func (m *MemoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// Spans returns a copy of the exported spans in export order.
+func (m *MemoryExporter) Spans() []SpanData {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]SpanData(nil), m.spans...)
+}
+
+// Reset discards the exported spans.
+func (m *MemoryExporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = nil
+}
+
+// FileExporter appends each batch to a file as one line of OTLP JSON, the
+// format of the OpenTelemetry Collector file exporter.
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileExporter opens path for appending, creating it if needed.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &FileExporter{file: file}, nil
+}
+
+// ExportSpans implements Exporter.
+func (f *FileExporter) ExportSpans(_ context.Context, resource Resource, spans []SpanData) error {
+	data, err := json.Marshal(newExportRequest(resource, spans))
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return errors.New("trace file is closed")
+	}
+	_, err = f.file.Write(append(data, '\n'))
+	return err
+}
+
+// Shutdown implements Exporter and closes the file.
+func (f *FileExporter) Shutdown(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// OTLPExporter sends spans to an OTLP/HTTP endpoint in JSON encoding.
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewOTLPExporter creates an exporter for the full traces URL of an OTLP
+// receiver, such as http://localhost:4318/v1/traces.
+func NewOTLPExporter(endpoint string, headers map[string]string) (*OTLPExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q", endpoint)
+	}
+	return &OTLPExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// ExportSpans implements Exporter.
+func (o *OTLPExporter) ExportSpans(ctx context.Context, resource Resource, spans []SpanData) error {
+	data, err := json.Marshal(newExportRequest(resource, spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range o.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown implements Exporter.
+func (o *OTLPExporter) Shutdown(context.Context) error {
+	o.client.CloseIdleConnections()
+	return nil
+}
+
+// The types below are the OTLP/JSON encoding of ExportTraceServiceRequest.
+// IDs are hex strings and 64-bit integers are decimal strings.
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// newExportRequest encodes a batch of spans.
+func newExportRequest(resource Resource, spans []SpanData) otlpExportRequest {
+	resourceAttrs := []otlpKeyValue{otlpAttribute("service.name", resource.ServiceName)}
+	if resource.ServiceVersion != "" {
+		resourceAttrs = append(resourceAttrs, otlpAttribute("service.version", resource.ServiceVersion))
+	}
+
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           s.SpanContext.TraceID.String(),
+			SpanID:            s.SpanContext.SpanID.String(),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: unixNano(s.Start),
+			EndTimeUnixNano:   unixNano(s.End),
+			Attributes:        otlpAttributes(s.Attributes),
+			Status:            otlpStatus{Code: s.Status, Message: s.StatusMessage},
+		}
+		if s.Parent.IsValid() {
+			span.ParentSpanID = s.Parent.String()
+		}
+		for _, e := range s.Events {
+			span.Events = append(span.Events, otlpEvent{
+				TimeUnixNano: unixNano(e.Time),
+				Name:         e.Name,
+				Attributes:   otlpAttributes(e.Attributes),
+			})
+		}
+		encoded = append(encoded, span)
+	}
+
+	return otlpExportRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: resourceAttrs},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/PivotLLM/MCPFusion/tracing", Version: resource.ServiceVersion},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+// otlpAttributes encodes a list of attributes.
+func otlpAttributes(attrs []Attribute) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	encoded := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		encoded = append(encoded, otlpAttribute(a.Key, a.Value))
+	}
+	return encoded
+}
+
+// otlpAttribute encodes one attribute. Unsupported types are encoded as strings.
+func otlpAttribute(key string, value any) otlpKeyValue {
+	var v otlpAnyValue
+	switch val := value.(type) {
+	case string:
+		v.StringValue = &val
+	case bool:
+		v.BoolValue = &val
+	case int:
+		s := strconv.Itoa(val)
+		v.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(val, 10)
+		v.IntValue = &s
+	case float64:
+		v.DoubleValue = &val
+	default:
+		s := fmt.Sprint(val)
+		v.StringValue = &s
+	}
+	return otlpKeyValue{Key: key, Value: v}
+}
+
+// unixNano formats a time as nanoseconds since the epoch.
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
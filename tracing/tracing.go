@@ -0,0 +1,561 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+// Package tracing provides lightweight distributed tracing compatible with
+// OpenTelemetry. Spans are propagated in W3C traceparent headers and exported
+// in the OTLP format. It imports only stdlib and global, so any package can
+// create spans without circular dependency risk.
+//
+// Only the tracer that starts the root span of a request needs to be passed
+// around: child spans are started with the package-level Start function, which
+// finds the tracer through the parent span in the context. Without a parent
+// span, Start returns a nil *Span, and all Span methods are no-ops on nil.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/MCPFusion/global"
+)
+
+// TraceParentHeader is the W3C trace context header and MCP _meta field.
+const TraceParentHeader = "traceparent"
+
+// Default batching parameters
+const (
+	defaultBatchSize    = 512
+	defaultBatchTimeout = 5 * time.Second
+	maxQueuedSpans      = 8192
+)
+
+// SpanKind describes the relationship of a span to its parent, using the OTLP values.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// StatusCode is the status of a span, using the OTLP values.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the trace ID as lowercase hex.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid returns true if the trace ID is not all zeros.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the span ID as lowercase hex.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid returns true if the span ID is not all zeros.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// SpanContext identifies a span and carries the sampling decision of its trace.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool // Received from another process
+}
+
+// IsValid returns true if both the trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// TraceParent returns the span context as a W3C traceparent value, or an
+// empty string if it is not valid.
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceParent parses a W3C traceparent value.
+func ParseTraceParent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, errors.New("invalid traceparent format")
+	}
+	// Version 00 has exactly four fields; later versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, errors.New("invalid traceparent format")
+	}
+
+	var sc SpanContext
+	if len(parts[1]) != 32 || !decodeHex(sc.TraceID[:], parts[1]) || !sc.TraceID.IsValid() {
+		return SpanContext{}, errors.New("invalid trace ID in traceparent")
+	}
+	if len(parts[2]) != 16 || !decodeHex(sc.SpanID[:], parts[2]) || !sc.SpanID.IsValid() {
+		return SpanContext{}, errors.New("invalid parent ID in traceparent")
+	}
+	var flags [1]byte
+	if len(parts[3]) != 2 || !decodeHex(flags[:], parts[3]) {
+		return SpanContext{}, errors.New("invalid flags in traceparent")
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+	sc.Remote = true
+	return sc, nil
+}
+
+// decodeHex decodes lowercase hex into dst, returning false on failure.
+func decodeHex(dst []byte, s string) bool {
+	if strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+// Attribute is a key-value pair describing a span or event.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Event is a timestamped annotation of a span.
+type Event struct {
+	Name       string
+	Time       time.Time
+	Attributes []Attribute
+}
+
+// SpanData is the record of an ended span passed to exporters.
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	Parent        SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	Events        []Event
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Attribute returns the value of an attribute, or nil if it is not set.
+func (d *SpanData) Attribute(key string) any {
+	for _, attr := range d.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// Span is an operation within a trace. All methods are safe for concurrent use
+// and do nothing on a nil *Span.
+type Span struct {
+	tracer *Tracer
+	mu     sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+// SpanContext returns the span's identity.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.data.SpanContext
+}
+
+// SetAttribute sets an attribute, replacing any previous value for the key.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Attributes {
+		if s.data.Attributes[i].Key == key {
+			s.data.Attributes[i].Value = value
+			return
+		}
+	}
+	s.data.Attributes = append(s.data.Attributes, Attribute{Key: key, Value: value})
+}
+
+// SetStatus sets the status of the span. The message is only kept for errors.
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Status = code
+	if code == StatusError {
+		s.data.StatusMessage = message
+	} else {
+		s.data.StatusMessage = ""
+	}
+}
+
+// RecordError records err as an exception event and sets the error status.
+// A nil error is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.data.Events = append(s.data.Events, Event{
+		Name:       "exception",
+		Time:       time.Now(),
+		Attributes: []Attribute{{Key: "exception.message", Value: err.Error()}},
+	})
+	s.mu.Unlock()
+	s.SetStatus(StatusError, err.Error())
+}
+
+// End completes the span and queues it for export. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	if data.SpanContext.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+// Resource describes the process that produces spans.
+type Resource struct {
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Tracer creates spans and exports them in batches.
+type Tracer struct {
+	exporter     Exporter
+	logger       global.Logger
+	resource     Resource
+	batchSize    int
+	batchTimeout time.Duration
+
+	mu      sync.Mutex
+	queue   []SpanData
+	dropped int64
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+	stopped bool
+}
+
+// Option is a functional option for configuring a Tracer.
+type Option func(*Tracer)
+
+// WithExporter sets the exporter that receives ended spans.
+func WithExporter(e Exporter) Option {
+	return func(t *Tracer) { t.exporter = e }
+}
+
+// WithLogger sets the logger used to report export failures.
+func WithLogger(l global.Logger) Option {
+	return func(t *Tracer) { t.logger = l }
+}
+
+// WithServiceName sets the service.name resource attribute.
+func WithServiceName(name string) Option {
+	return func(t *Tracer) { t.resource.ServiceName = name }
+}
+
+// WithBatchTimeout sets the maximum time an ended span waits before export.
+func WithBatchTimeout(d time.Duration) Option {
+	return func(t *Tracer) {
+		if d > 0 {
+			t.batchTimeout = d
+		}
+	}
+}
+
+// New creates a Tracer and starts its background exporter.
+func New(opts ...Option) *Tracer {
+	t := &Tracer{
+		resource:     Resource{ServiceName: strings.ToLower(global.AppName), ServiceVersion: global.AppVersion},
+		batchSize:    defaultBatchSize,
+		batchTimeout: defaultBatchTimeout,
+		flushCh:      make(chan struct{}, 1),
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	go t.run()
+	return t
+}
+
+// Start starts a span. Its parent is the span in ctx, or else a remote span
+// context extracted into ctx; without either the span starts a new trace.
+// A nil Tracer returns ctx unchanged and a nil *Span.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	var parent SpanContext
+	if span := SpanFromContext(ctx); span != nil {
+		parent = span.SpanContext()
+	} else if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		parent = remote
+	}
+
+	sc := SpanContext{SpanID: newSpanID(), Sampled: true}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+	}
+
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:        name,
+			Kind:        kind,
+			SpanContext: sc,
+			Parent:      parent.SpanID,
+			Start:       time.Now(),
+		},
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// enqueue adds an ended span to the export queue.
+func (t *Tracer) enqueue(data SpanData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped || t.exporter == nil {
+		return
+	}
+	if len(t.queue) >= maxQueuedSpans {
+		t.dropped++
+		return
+	}
+	t.queue = append(t.queue, data)
+	if len(t.queue) >= t.batchSize {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run exports queued spans when a batch is full, on every batch timeout, and on shutdown.
+func (t *Tracer) run() {
+	defer close(t.doneCh)
+	ticker := time.NewTicker(t.batchTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case <-ticker.C:
+		case <-t.flushCh:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), t.batchTimeout)
+		_ = t.export(ctx)
+		cancel()
+	}
+}
+
+// export sends all queued spans to the exporter.
+func (t *Tracer) export(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.queue
+	t.queue = nil
+	dropped := t.dropped
+	t.dropped = 0
+	t.mu.Unlock()
+
+	if dropped > 0 && t.logger != nil {
+		t.logger.Warningf("Tracing: dropped %d spans because the export queue was full", dropped)
+	}
+	if len(spans) == 0 || t.exporter == nil {
+		return nil
+	}
+
+	err := t.exporter.ExportSpans(ctx, t.resource, spans)
+	if err != nil && t.logger != nil {
+		t.logger.Warningf("Tracing: failed to export %d spans: %v", len(spans), err)
+	}
+	return err
+}
+
+// ForceFlush exports all queued spans immediately.
+func (t *Tracer) ForceFlush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.export(ctx)
+}
+
+// Shutdown stops the background exporter, exports the remaining spans and
+// shuts down the exporter. Spans ended afterwards are discarded.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return nil
+	}
+	t.mu.Unlock()
+
+	close(t.stopCh)
+	<-t.doneCh
+	err := t.export(ctx)
+
+	t.mu.Lock()
+	t.stopped = true
+	t.mu.Unlock()
+
+	if t.exporter != nil {
+		if shutdownErr := t.exporter.Shutdown(ctx); err == nil {
+			err = shutdownErr
+		}
+	}
+	return err
+}
+
+// spanKey is the context key for the current *Span.
+type spanKey struct{}
+
+// remoteKey is the context key for a remote SpanContext.
+type remoteKey struct{}
+
+// Start starts a child of the span in ctx using the same tracer. Without a
+// span in ctx it returns ctx unchanged and a nil *Span, so that code paths
+// outside a traced request cost nothing.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return parent.tracer.Start(ctx, name, kind)
+}
+
+// SpanFromContext returns the current span, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a context whose next root span continues
+// the trace of sc.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	sc.Remote = true
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// ExtractTraceParent continues the trace of a traceparent value. Invalid values
+// are ignored.
+func ExtractTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Extract continues the trace of the traceparent header of an inbound request.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return ExtractTraceParent(ctx, header.Get(TraceParentHeader))
+}
+
+// TraceParent returns the traceparent value for an outbound request made
+// within ctx, or an empty string if ctx is not traced.
+func TraceParent(ctx context.Context) string {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext().TraceParent()
+	}
+	if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		return remote.TraceParent()
+	}
+	return ""
+}
+
+// Inject sets the traceparent header of an outbound request made within ctx.
+func Inject(ctx context.Context, header http.Header) {
+	if traceParent := TraceParent(ctx); traceParent != "" {
+		header.Set(TraceParentHeader, traceParent)
+	}
+}
+
+// newTraceID returns a random trace ID.
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// newSpanID returns a random span ID.
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
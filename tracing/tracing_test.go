@@ -0,0 +1,214 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see LICENSE file for details.                                       *
+ ******************************************************************************/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestTracer returns a tracer that exports to memory
+func newTestTracer(t *testing.T) (*Tracer, *MemoryExporter) {
+	t.Helper()
+	exporter := NewMemoryExporter()
+	tracer := New(WithExporter(exporter), WithServiceName("test"))
+	t.Cleanup(func() { _ = tracer.Shutdown(context.Background()) })
+	return tracer, exporter
+}
+
+func TestParseTraceParent(t *testing.T) {
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sc.Sampled || !sc.Remote || sc.TraceParent() != value {
+		t.Errorf("unexpected span context: %+v", sc)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	} {
+		if _, err := ParseTraceParent(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSpanHierarchyAndPropagation(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+
+	ctx := ExtractTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, root := tracer.Start(ctx, "tools/call test", SpanKindServer)
+	root.SetAttribute("gen_ai.tool.name", "test")
+
+	childCtx, child := Start(ctx, "HTTP GET", SpanKindClient)
+	header := http.Header{}
+	Inject(childCtx, header)
+	child.RecordError(errors.New("boom"))
+	child.End()
+	root.End()
+	root.End()
+
+	if err := tracer.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	spans := exporter.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	childData, rootData := spans[0], spans[1]
+
+	if rootData.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("root span must continue the remote trace, got %s", rootData.SpanContext.TraceID)
+	}
+	if rootData.Parent.String() != "00f067aa0ba902b7" {
+		t.Errorf("root span must have the remote parent, got %s", rootData.Parent)
+	}
+	if childData.Parent != rootData.SpanContext.SpanID || childData.SpanContext.TraceID != rootData.SpanContext.TraceID {
+		t.Error("child span must be a child of the root span")
+	}
+	if got := header.Get(TraceParentHeader); got != childData.SpanContext.TraceParent() {
+		t.Errorf("expected injected traceparent %q, got %q", childData.SpanContext.TraceParent(), got)
+	}
+	if childData.Status != StatusError || childData.StatusMessage != "boom" || len(childData.Events) != 1 {
+		t.Errorf("expected error status and exception event, got %+v", childData)
+	}
+	if rootData.Attribute("gen_ai.tool.name") != "test" {
+		t.Errorf("missing root attribute: %+v", rootData.Attributes)
+	}
+}
+
+func TestUntracedContextIsNoop(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop", SpanKindInternal)
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Fatal("Start without a parent span must return a nil span")
+	}
+	// Methods on a nil span must not panic
+	span.SetAttribute("key", "value")
+	span.RecordError(errors.New("ignored"))
+	span.End()
+
+	var tracer *Tracer
+	if _, span := tracer.Start(context.Background(), "noop", SpanKindServer); span != nil {
+		t.Error("a nil tracer must return a nil span")
+	}
+	if TraceParent(ctx) != "" {
+		t.Error("an untraced context must not produce a traceparent")
+	}
+}
+
+func TestUnsampledSpansAreNotExported(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+
+	ctx := ExtractTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	ctx, span := tracer.Start(ctx, "unsampled", SpanKindServer)
+	if !strings.HasSuffix(TraceParent(ctx), "-00") {
+		t.Errorf("unsampled flag must be propagated, got %q", TraceParent(ctx))
+	}
+	span.End()
+
+	_ = tracer.ForceFlush(context.Background())
+	if len(exporter.Spans()) != 0 {
+		t.Error("unsampled spans must not be exported")
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var body map[string]interface{}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+	}))
+	defer server.Close()
+
+	headers, err := ParseHeaders("Authorization=Bearer%20secret, x-tenant = a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exporter, err := NewOTLPExporter(server.URL+"/v1/traces", headers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tracer := New(WithExporter(exporter), WithServiceName("fusion-test"))
+	_, span := tracer.Start(context.Background(), "root", SpanKindServer)
+	span.SetAttribute("mcpfusion.attempt", 2)
+	span.SetStatus(StatusOK, "")
+	span.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	if auth != "Bearer secret" {
+		t.Errorf("expected configured header, got %q", auth)
+	}
+	resourceSpans := body["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	resourceAttr := resourceSpans["resource"].(map[string]interface{})["attributes"].([]interface{})[0].(map[string]interface{})
+	if resourceAttr["value"].(map[string]interface{})["stringValue"] != "fusion-test" {
+		t.Errorf("unexpected resource attribute: %v", resourceAttr)
+	}
+	encoded := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})[0].(map[string]interface{})
+	if encoded["traceId"] != span.SpanContext().TraceID.String() || encoded["kind"] != float64(SpanKindServer) {
+		t.Errorf("unexpected span: %v", encoded)
+	}
+	attr := encoded["attributes"].([]interface{})[0].(map[string]interface{})
+	if attr["value"].(map[string]interface{})["intValue"] != "2" {
+		t.Errorf("integer attributes must be encoded as strings: %v", attr)
+	}
+
+	if _, err := NewOTLPExporter("localhost:4318", nil); err == nil {
+		t.Error("expected error for endpoint without scheme")
+	}
+	if _, err := ParseHeaders("novalue"); err == nil {
+		t.Error("expected error for header without value")
+	}
+}
+
+func TestExporterFromEnv(t *testing.T) {
+	t.Setenv(EnvTracesEndpoint, "")
+	t.Setenv(EnvEndpoint, "")
+	t.Setenv(EnvTraceFile, "")
+	if exporter, err := ExporterFromEnv(); err != nil || exporter != nil {
+		t.Fatalf("expected no exporter without configuration, got %v, %v", exporter, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	t.Setenv(EnvTraceFile, path)
+	exporter, err := ExporterFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tracer := New(WithExporter(exporter))
+	_, span := tracer.Start(context.Background(), "file", SpanKindInternal)
+	span.End()
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read trace file: %v", err)
+	}
+	if !strings.Contains(string(data), `"name":"file"`) || !strings.HasSuffix(string(data), "\n") {
+		t.Errorf("unexpected trace file contents: %s", data)
+	}
+}